@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// TargetAggregatorsPerCommittee is the target number of aggregators in each beacon committee
+const TargetAggregatorsPerCommittee = 16
+
+// IsAggregator returns true if the given slot signature (selection proof) selects the validator as an aggregator.
+//
+// Spec pseudocode definition:
+//
+//	def is_aggregator(state: BeaconState, slot: Slot, index: CommitteeIndex, slot_signature: BLSSignature) -> bool:
+//		committee = get_beacon_committee(state, slot, index)
+//		modulo = max(1, len(committee) // TARGET_AGGREGATORS_PER_COMMITTEE)
+//		return bytes_to_uint64(hash(slot_signature)[0:8]) % modulo == 0
+func IsAggregator(committeeLength uint64, slotSig []byte) bool {
+	modulo := committeeLength / TargetAggregatorsPerCommittee
+	if modulo < 1 {
+		modulo = 1
+	}
+	h := sha256.Sum256(slotSig)
+	return binary.LittleEndian.Uint64(h[:8])%modulo == 0
+}
+
+const (
+	// SyncCommitteeSize is the number of validators in a sync committee
+	SyncCommitteeSize = 512
+	// SyncCommitteeSubnetCount is the number of sync committee subnets (subcommittees)
+	SyncCommitteeSubnetCount = 4
+	// TargetAggregatorsPerSyncSubcommittee is the target number of aggregators in each sync subcommittee
+	TargetAggregatorsPerSyncSubcommittee = 16
+	// EpochsPerSyncCommitteePeriod is the number of epochs in which a sync committee is active
+	EpochsPerSyncCommitteePeriod = 256
+)
+
+// SyncSubcommitteeIndex returns the index of the subcommittee (subnet) of the given index in the sync committee
+func SyncSubcommitteeIndex(syncCommitteeIndex uint64) uint64 {
+	return syncCommitteeIndex / (SyncCommitteeSize / SyncCommitteeSubnetCount)
+}
+
+// IsSyncCommitteeAggregator returns true if the given selection proof selects the validator as a sync committee aggregator.
+//
+// Spec pseudocode definition:
+//
+//	def is_sync_committee_aggregator(signature: BLSSignature) -> bool:
+//		modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
+//		return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
+func IsSyncCommitteeAggregator(selectionProof []byte) bool {
+	modulo := uint64(SyncCommitteeSize / SyncCommitteeSubnetCount / TargetAggregatorsPerSyncSubcommittee)
+	if modulo < 1 {
+		modulo = 1
+	}
+	h := sha256.Sum256(selectionProof)
+	return binary.LittleEndian.Uint64(h[:8])%modulo == 0
+}
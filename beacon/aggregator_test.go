@@ -0,0 +1,34 @@
+package beacon
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsAggregator(t *testing.T) {
+	slotSig := []byte{1, 2, 3, 4}
+
+	t.Run("small committee", func(t *testing.T) {
+		// modulo is 1 for committees smaller than TargetAggregatorsPerCommittee * 2
+		require.True(t, IsAggregator(1, slotSig))
+		require.True(t, IsAggregator(TargetAggregatorsPerCommittee, slotSig))
+		require.True(t, IsAggregator(TargetAggregatorsPerCommittee*2-1, slotSig))
+	})
+
+	t.Run("large committee", func(t *testing.T) {
+		require.False(t, IsAggregator(TargetAggregatorsPerCommittee<<20, slotSig))
+	})
+}
+
+func TestIsSyncCommitteeAggregator(t *testing.T) {
+	// modulo is 8 (512 / 4 / 16)
+	require.True(t, IsSyncCommitteeAggregator([]byte{3}))
+	require.False(t, IsSyncCommitteeAggregator([]byte{1, 2, 3, 4}))
+}
+
+func TestSyncSubcommitteeIndex(t *testing.T) {
+	require.Equal(t, uint64(0), SyncSubcommitteeIndex(0))
+	require.Equal(t, uint64(0), SyncSubcommitteeIndex(127))
+	require.Equal(t, uint64(1), SyncSubcommitteeIndex(128))
+	require.Equal(t, uint64(3), SyncSubcommitteeIndex(511))
+}
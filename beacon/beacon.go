@@ -6,8 +6,11 @@ import (
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"go.uber.org/zap"
+	"time"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -15,10 +18,21 @@ import (
 type Options struct {
 	Context        context.Context
 	Logger         *zap.Logger
-	Network        string `yaml:"Network" env:"NETWORK" env-default:"prater"`
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
-	Graffiti       []byte
-	DB             basedb.IDb
+	Network        string `yaml:"Network" env:"NETWORK" env-default:"prater" env-description:"network profile (mainnet, prater or a custom profile)"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"beacon node address, several (comma separated) addresses can be provided for failover"`
+	// RequestTimeout is the timeout of requests to the beacon node
+	RequestTimeout time.Duration `yaml:"RequestTimeout" env:"BEACON_REQUEST_TIMEOUT" env-default:"5s" env-description:"timeout of beacon node requests"`
+	// BroadcastAttestations submits attestations to all the beacon nodes rather than only to the best one
+	BroadcastAttestations bool `yaml:"BroadcastAttestations" env:"BEACON_BROADCAST_ATTESTATIONS" env-description:"submit attestations to all the beacon nodes"`
+	// KeyManager selects where share keys are kept and used for signing
+	KeyManager       string `yaml:"KeyManager" env:"KEY_MANAGER" env-default:"local" env-description:"key manager of share keys: local (node db) or web3signer (remote signer, iBFT messages are not supported)"`
+	RemoteSignerAddr string `yaml:"RemoteSignerAddr" env:"REMOTE_SIGNER_ADDR" env-description:"address of the Web3Signer compatible signer, used by the web3signer key manager"`
+	Graffiti         []byte
+	DB               basedb.IDb
+	// ETHNetwork is the network of the selected profile
+	ETHNetwork Network
+	// EncryptionKey is the key that share secrets are encrypted with in DB, empty if encryption is disabled
+	EncryptionKey []byte
 }
 
 // Beacon represents the behavior of the beacon node connector
@@ -41,15 +55,58 @@ type Beacon interface {
 	// SubmitAttestation submit the attestation to the node
 	SubmitAttestation(attestation *spec.Attestation) error
 
+	// GetBeaconBlock returns beacon block (of the version of the given slot) by the given slot and randao reveal
+	GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*ethspec.VersionedBeaconBlock, error)
+
+	// SubmitBeaconBlock submit the signed block to the node
+	SubmitBeaconBlock(block *ethspec.VersionedSignedBeaconBlock) error
+
+	// GetAggregateAttestation returns the aggregate attestation for the given slot and committee index
+	GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Attestation, error)
+
+	// SubmitSignedAggregateSelectionProof submit the signed aggregate and proof to the node
+	SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error
+
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error
+
+	// GetSyncCommitteeDuties returns the sync committee duties (message and contribution) of the passed validators
+	// indices for each slot of the given epoch, no duties are returned for epochs before altair
+	GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error)
+
+	// GetSyncMessageBlockRoot returns the root of the head block that should be signed by sync committee members at the given slot
+	GetSyncMessageBlockRoot(slot spec.Slot) (spec.Root, error)
+
+	// SubmitSyncMessage submits the signed sync committee message to the node
+	SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error
+
+	// GetSyncCommitteeContribution returns the sync committee contribution of the given subcommittee and block root
+	GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error)
+
+	// SubmitSignedContributionAndProof submits the signed contribution and proof to the node
+	SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error
+
+	// SubscribeToSyncCommitteeSubnet subscribes sync committee members to the relevant subnets (p2p topics)
+	SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error
+
+	// GetValidatorsLiveness returns whether the given validators were live (seen by the node) in the given epoch
+	GetValidatorsLiveness(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) (map[spec.ValidatorIndex]bool, error)
 }
 
+const (
+	// KeyManagerLocal keeps share keys in a wallet inside the node's db
+	KeyManagerLocal = "local"
+	// KeyManagerWeb3Signer keeps share keys in a remote, Web3Signer compatible, signer
+	KeyManagerWeb3Signer = "web3signer"
+)
+
 // KeyManager is an interface responsible for all key manager functions
 type KeyManager interface {
 	Signer
 	// AddShare saves a share key
 	AddShare(shareKey *bls.SecretKey) error
+	// RemoveShare removes a share key
+	RemoveShare(pubKey string) error
 }
 
 // Signer is an interface responsible for all signing operations
@@ -58,10 +115,51 @@ type Signer interface {
 	SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error)
 	// SignAttestation signs the given attestation
 	SignAttestation(data *spec.AttestationData, duty *Duty, pk []byte) (*spec.Attestation, []byte, error)
+	// SignRandaoReveal signs the given epoch, the result is used as randao reveal of a proposed block
+	SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error)
+	// SignBeaconBlock signs the given beacon block
+	SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error)
+	// SignSlot signs the given slot, the result is used as the selection proof of an aggregator
+	SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error)
+	// SignAggregateAndProof signs the given aggregate and proof
+	SignAggregateAndProof(msg *spec.AggregateAndProof, duty *Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error)
+	// SignSyncCommitteeBlockRoot signs the given block root, the result is used as a sync committee message
+	SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error)
+	// SignSyncCommitteeSelectionProof signs the given slot and subcommittee, the result is used as the selection proof of a sync committee aggregator
+	SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error)
+	// SignContributionAndProof signs the given contribution and proof
+	SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error)
+}
+
+// SlashingProtector checks values against the slashing protection data of shares, before they are signed
+type SlashingProtector interface {
+	// IsAttestationSlashable returns an error if signing the given attestation data with the given share is slashable
+	IsAttestationSlashable(data *spec.AttestationData, pk []byte) error
+	// IsBeaconBlockSlashable returns an error if signing the given block with the given share is slashable
+	IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error
+}
+
+// HighestSigned is the slashing protection data of a share, the highest attestation and block it signed
+type HighestSigned struct {
+	SourceEpoch uint64 `json:"sourceEpoch"`
+	TargetEpoch uint64 `json:"targetEpoch"`
+	Slot        uint64 `json:"slot"`
+}
+
+// ShareHandover is implemented by key managers that hold the share keys locally,
+// it is needed for handing over a validator to a new committee (resharing)
+type ShareHandover interface {
+	// ExportShare returns the secret key of the given share
+	ExportShare(pk []byte) (*bls.SecretKey, error)
+	// HighestSigned returns the slashing protection data of the given share
+	HighestSigned(pk []byte) (*HighestSigned, error)
+	// RaiseHighestSigned raises the slashing protection data of the given share, existing values are never lowered
+	RaiseHighestSigned(pk []byte, highest *HighestSigned) error
 }
 
 // SigningUtil is an interface for beacon node signing specific methods
 type SigningUtil interface {
 	GetDomain(data *spec.AttestationData) ([]byte, error)
+	GetDomainByType(domainType DomainType, epoch spec.Epoch) ([]byte, error)
 	ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error)
 }
@@ -0,0 +1,93 @@
+package beacon
+
+import (
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// MarshalBeaconBlock encodes the given block as a consensus value,
+// the first byte is the version of the block and the rest is the ssz encoding of the block of that version
+func MarshalBeaconBlock(block *ethspec.VersionedBeaconBlock) ([]byte, error) {
+	var byts []byte
+	var err error
+	switch block.Version {
+	case ethspec.DataVersionPhase0:
+		if block.Phase0 == nil {
+			return nil, errors.New("no phase0 block")
+		}
+		byts, err = block.Phase0.MarshalSSZ()
+	case ethspec.DataVersionAltair:
+		if block.Altair == nil {
+			return nil, errors.New("no altair block")
+		}
+		byts, err = block.Altair.MarshalSSZ()
+	default:
+		return nil, errors.Errorf("unknown block version %d", block.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal beacon block")
+	}
+	return append([]byte{byte(block.Version)}, byts...), nil
+}
+
+// UnmarshalBeaconBlock decodes a block that was encoded with MarshalBeaconBlock
+func UnmarshalBeaconBlock(data []byte) (*ethspec.VersionedBeaconBlock, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty beacon block")
+	}
+	block := &ethspec.VersionedBeaconBlock{Version: ethspec.DataVersion(data[0])}
+	var err error
+	switch block.Version {
+	case ethspec.DataVersionPhase0:
+		block.Phase0 = &spec.BeaconBlock{}
+		err = block.Phase0.UnmarshalSSZ(data[1:])
+	case ethspec.DataVersionAltair:
+		block.Altair = &altair.BeaconBlock{}
+		err = block.Altair.UnmarshalSSZ(data[1:])
+	default:
+		return nil, errors.Errorf("unknown block version %d", data[0])
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal beacon block")
+	}
+	return block, nil
+}
+
+// NewSignedBeaconBlock returns the signed block of the given block and signature, in the version of the block
+func NewSignedBeaconBlock(block *ethspec.VersionedBeaconBlock, sig spec.BLSSignature) (*ethspec.VersionedSignedBeaconBlock, error) {
+	switch block.Version {
+	case ethspec.DataVersionPhase0:
+		if block.Phase0 == nil {
+			return nil, errors.New("no phase0 block")
+		}
+		return &ethspec.VersionedSignedBeaconBlock{
+			Version: block.Version,
+			Phase0:  &spec.SignedBeaconBlock{Message: block.Phase0, Signature: sig},
+		}, nil
+	case ethspec.DataVersionAltair:
+		if block.Altair == nil {
+			return nil, errors.New("no altair block")
+		}
+		return &ethspec.VersionedSignedBeaconBlock{
+			Version: block.Version,
+			Altair:  &altair.SignedBeaconBlock{Message: block.Altair, Signature: sig},
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown block version %d", block.Version)
+	}
+}
+
+// SetBeaconBlockSignature sets the signature of the given signed block
+func SetBeaconBlockSignature(block *ethspec.VersionedSignedBeaconBlock, sig spec.BLSSignature) error {
+	switch {
+	case block.Version == ethspec.DataVersionPhase0 && block.Phase0 != nil:
+		block.Phase0.Signature = sig
+	case block.Version == ethspec.DataVersionAltair && block.Altair != nil:
+		block.Altair.Signature = sig
+	default:
+		return errors.Errorf("no signed block of version %d", block.Version)
+	}
+	return nil
+}
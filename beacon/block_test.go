@@ -0,0 +1,70 @@
+package beacon
+
+import (
+	"testing"
+
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBeaconBlock(t *testing.T) {
+	phase0Block := &ethspec.VersionedBeaconBlock{
+		Version: ethspec.DataVersionPhase0,
+		Phase0: &spec.BeaconBlock{
+			Slot: 10,
+			Body: &spec.BeaconBlockBody{
+				ETH1Data: &spec.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti: make([]byte, 32),
+			},
+		},
+	}
+	altairBlock := &ethspec.VersionedBeaconBlock{
+		Version: ethspec.DataVersionAltair,
+		Altair: &altair.BeaconBlock{
+			Slot: 20,
+			Body: &altair.BeaconBlockBody{
+				ETH1Data:      &spec.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti:      make([]byte, 32),
+				SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+			},
+		},
+	}
+
+	for _, block := range []*ethspec.VersionedBeaconBlock{phase0Block, altairBlock} {
+		byts, err := MarshalBeaconBlock(block)
+		require.NoError(t, err)
+		require.Equal(t, byte(block.Version), byts[0])
+		decoded, err := UnmarshalBeaconBlock(byts)
+		require.NoError(t, err)
+		require.Equal(t, block.Version, decoded.Version)
+		expectedSlot, err := block.Slot()
+		require.NoError(t, err)
+		slot, err := decoded.Slot()
+		require.NoError(t, err)
+		require.Equal(t, expectedSlot, slot)
+
+		signed, err := NewSignedBeaconBlock(decoded, spec.BLSSignature{1})
+		require.NoError(t, err)
+		require.Equal(t, block.Version, signed.Version)
+		require.NoError(t, SetBeaconBlockSignature(signed, spec.BLSSignature{2}))
+		if block.Version == ethspec.DataVersionAltair {
+			require.Equal(t, spec.BLSSignature{2}, signed.Altair.Signature)
+		} else {
+			require.Equal(t, spec.BLSSignature{2}, signed.Phase0.Signature)
+		}
+	}
+
+	t.Run("invalid blocks", func(t *testing.T) {
+		_, err := MarshalBeaconBlock(&ethspec.VersionedBeaconBlock{Version: ethspec.DataVersionAltair})
+		require.EqualError(t, err, "no altair block")
+		_, err = UnmarshalBeaconBlock(nil)
+		require.EqualError(t, err, "empty beacon block")
+		_, err = UnmarshalBeaconBlock([]byte{5, 1, 2})
+		require.EqualError(t, err, "unknown block version 5")
+		_, err = UnmarshalBeaconBlock([]byte{byte(ethspec.DataVersionAltair), 1, 2})
+		require.Error(t, err)
+	})
+}
@@ -4,7 +4,7 @@ import spec "github.com/attestantio/go-eth2-client/spec/phase0"
 
 // Duty represent data regarding the duty type with the duty data
 type Duty struct {
-	// Type is the duty type (attest, propose)
+	// Type is the duty type (attest, propose, sync committee)
 	Type RoleType
 	// PubKey is the public key of the validator that should attest.
 	PubKey spec.BLSPubKey
@@ -20,4 +20,7 @@ type Duty struct {
 	CommitteesAtSlot uint64
 	// ValidatorCommitteeIndex is the index of the validator in the list of validators in the committee.
 	ValidatorCommitteeIndex uint64
+	// ValidatorSyncCommitteeIndices is the index of the validator in the list of validators in the sync committee,
+	// relevant only for sync committee duties
+	ValidatorSyncCommitteeIndices []spec.CommitteeIndex
 }
@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -13,8 +15,10 @@ type DutyData struct {
 	Data IsInputValueData `protobuf_oneof:"data"`
 	// Types that are valid to be assigned to SignedData:
 	//	*InputValueAttestation
-	//	*InputValue_Aggregation
-	//	*InputValue_Block
+	//	*InputValueSignedAggregateAndProof
+	//	*InputValueSignedBeaconBlock
+	//	*InputValueSyncCommitteeMessage
+	//	*InputValueSignedContributionAndProof
 	SignedData IsInputValueSignedData `protobuf_oneof:"signed_data"`
 }
 
@@ -75,3 +79,67 @@ func (m *DutyData) GetAttestation() *phase0.Attestation {
 	}
 	return nil
 }
+
+// InputValueSignedBeaconBlock implementing IsInputValueSignedData
+type InputValueSignedBeaconBlock struct {
+	SignedBeaconBlock *ethspec.VersionedSignedBeaconBlock
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedBeaconBlock) isInputValueSignedData() {}
+
+// GetSignedBeaconBlock return cast signed beacon block input data
+func (m *DutyData) GetSignedBeaconBlock() *ethspec.VersionedSignedBeaconBlock {
+	if x, ok := m.GetSignedData().(*InputValueSignedBeaconBlock); ok {
+		return x.SignedBeaconBlock
+	}
+	return nil
+}
+
+// InputValueSignedAggregateAndProof implementing IsInputValueSignedData
+type InputValueSignedAggregateAndProof struct {
+	SignedAggregateAndProof *phase0.SignedAggregateAndProof
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedAggregateAndProof) isInputValueSignedData() {}
+
+// GetSignedAggregateAndProof return cast signed aggregate and proof input data
+func (m *DutyData) GetSignedAggregateAndProof() *phase0.SignedAggregateAndProof {
+	if x, ok := m.GetSignedData().(*InputValueSignedAggregateAndProof); ok {
+		return x.SignedAggregateAndProof
+	}
+	return nil
+}
+
+// InputValueSyncCommitteeMessage implementing IsInputValueSignedData
+type InputValueSyncCommitteeMessage struct {
+	SyncCommitteeMessage *altair.SyncCommitteeMessage
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSyncCommitteeMessage) isInputValueSignedData() {}
+
+// GetSyncCommitteeMessage return cast sync committee message input data
+func (m *DutyData) GetSyncCommitteeMessage() *altair.SyncCommitteeMessage {
+	if x, ok := m.GetSignedData().(*InputValueSyncCommitteeMessage); ok {
+		return x.SyncCommitteeMessage
+	}
+	return nil
+}
+
+// InputValueSignedContributionAndProof implementing IsInputValueSignedData
+type InputValueSignedContributionAndProof struct {
+	SignedContributionAndProof *altair.SignedContributionAndProof
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedContributionAndProof) isInputValueSignedData() {}
+
+// GetSignedContributionAndProof return cast signed contribution and proof input data
+func (m *DutyData) GetSignedContributionAndProof() *altair.SignedContributionAndProof {
+	if x, ok := m.GetSignedData().(*InputValueSignedContributionAndProof); ok {
+		return x.SignedContributionAndProof
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package goclient
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"time"
+)
+
+// GetAggregateAttestation returns the aggregate attestation for the given slot and committee index
+func (gc *goClient) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Attestation, error) {
+	gc.waitToSlotTwoThirds(uint64(slot))
+
+	attData, err := gc.GetAttestationData(slot, committeeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attestation data")
+	}
+	root, err := attData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attestation data root")
+	}
+
+	if provider, isProvider := gc.client.(eth2client.AggregateAttestationProvider); isProvider {
+		aggregate, err := provider.AggregateAttestation(gc.ctx, slot, root)
+		if err != nil {
+			return nil, err
+		}
+		if aggregate == nil {
+			return nil, errors.New("received empty aggregate attestation")
+		}
+		return aggregate, nil
+	}
+	return nil, errors.New("client does not support AggregateAttestationProvider")
+}
+
+func (gc *goClient) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	return gc.keyManager.SignSlot(slot, pk)
+}
+
+func (gc *goClient) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return gc.keyManager.SignAggregateAndProof(msg, duty, pk)
+}
+
+// SubmitSignedAggregateSelectionProof implements Beacon interface
+func (gc *goClient) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	if provider, isProvider := gc.client.(eth2client.AggregateAttestationsSubmitter); isProvider {
+		return provider.SubmitAggregateAttestations(gc.ctx, []*spec.SignedAggregateAndProof{msg})
+	}
+	return errors.New("client does not support AggregateAttestationsSubmitter")
+}
+
+// waitToSlotTwoThirds waits until two-third of the slot has transpired (SECONDS_PER_SLOT * 2 / 3 seconds after the start of slot)
+func (gc *goClient) waitToSlotTwoThirds(slot uint64) {
+	oneThird := slots.DivideSlotBy(3 /* one third of slot duration */)
+	finalTime := gc.slotStartTime(slot).Add(2 * oneThird)
+	wait := prysmTime.Until(finalTime)
+	if wait <= 0 {
+		return
+	}
+	time.Sleep(wait)
+}
@@ -2,20 +2,27 @@ package ekm
 
 import (
 	"encoding/hex"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	eth2keymanager "github.com/bloxapp/eth2-key-manager"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/eth2-key-manager/signer"
 	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
 	"github.com/bloxapp/eth2-key-manager/wallets"
+	"github.com/bloxapp/eth2-key-manager/wallets/hd"
+	"github.com/bloxapp/eth2-key-manager/wallets/nd"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/go-bitfield"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmblock "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"sync"
 )
 
@@ -23,13 +30,22 @@ type ethKeyManagerSigner struct {
 	wallet       core.Wallet
 	walletLock   *sync.RWMutex
 	signer       signer.ValidatorSigner
+	protection   core.SlashingProtector
 	storage      *signerStorage
 	signingUtils beacon.SigningUtil
+	network      beacon.Network
 }
 
-// NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, network core.Network) (beacon.KeyManager, error) {
-	signerStore := newSignerStorage(db, network)
+// NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner,
+// accounts are encrypted with the given encryption key unless it is empty
+func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, network beacon.Network, encryptionKey []byte) (beacon.KeyManager, error) {
+	signerStore := newSignerStorage(db, network.Network)
+	if len(encryptionKey) > 0 {
+		signerStore.SetEncryptor(encryption.NewEncryptor(), []byte(hex.EncodeToString(encryptionKey)))
+		if err := signerStore.encryptAccounts(); err != nil {
+			return nil, errors.WithMessage(err, "failed to encrypt accounts")
+		}
+	}
 	options := &eth2keymanager.KeyVaultOptions{}
 	options.SetStorage(signerStore)
 	options.SetWalletType(core.NDWallet)
@@ -49,7 +65,8 @@ func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, netw
 		}
 	}
 
-	beaconSigner, err := newBeaconSigner(wallet, signerStore, network)
+	slashingProtection := slashingprotection.NewNormalProtection(signerStore)
+	beaconSigner, err := newBeaconSigner(wallet, slashingProtection, network.Network)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to create signer")
 	}
@@ -58,16 +75,54 @@ func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, netw
 		wallet:       wallet,
 		walletLock:   &sync.RWMutex{},
 		signer:       beaconSigner,
+		protection:   slashingProtection,
 		storage:      signerStore,
 		signingUtils: signingUtils,
+		network:      network,
 	}, nil
 }
 
-func newBeaconSigner(wallet core.Wallet, store core.SlashingStore, network core.Network) (signer.ValidatorSigner, error) {
-	slashingProtection := slashingprotection.NewNormalProtection(store)
+func newBeaconSigner(wallet core.Wallet, slashingProtection core.SlashingProtector, network core.Network) (signer.ValidatorSigner, error) {
 	return signer.NewSimpleSigner(wallet, slashingProtection, network), nil
 }
 
+// IsAttestationSlashable checks the given attestation data against the slashing protection data of the share
+func (km *ethKeyManagerSigner) IsAttestationSlashable(data *spec.AttestationData, pk []byte) error {
+	status, err := km.protection.IsSlashableAttestation(pk, specAttDataToPrysmAttData(data))
+	if err != nil {
+		return errors.Wrap(err, "could not check attestation slashing protection")
+	}
+	if status != nil {
+		return errors.Errorf("slashable attestation (%s)", status.Status)
+	}
+	return nil
+}
+
+// IsBeaconBlockSlashable checks the given block against the slashing protection data of the share
+func (km *ethKeyManagerSigner) IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error {
+	slot, err := block.Slot()
+	if err != nil {
+		return errors.Wrap(err, "invalid beacon block")
+	}
+	// shares that were added before proposals were supported have no highest proposal yet,
+	// which is treated as the zero slot block (as in SignBeaconBlock)
+	if km.storage.RetrieveHighestProposal(pk) == nil {
+		if uint64(slot) > uint64(zeroSlotBlock.Slot) {
+			return nil
+		}
+		return errors.Errorf("slashable proposal (%s)", core.HighestProposalVote)
+	}
+	// proposals are slashable by their slot only, which is the same in all block versions
+	status, err := km.protection.IsSlashableProposal(pk, &eth.BeaconBlock{Slot: types.Slot(slot)})
+	if err != nil {
+		return errors.Wrap(err, "could not check proposal slashing protection")
+	}
+	if status.Status != core.ValidProposal {
+		return errors.Errorf("slashable proposal (%s)", status.Status)
+	}
+	return nil
+}
+
 func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 	km.walletLock.Lock()
 	defer km.walletLock.Unlock()
@@ -77,8 +132,16 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 		return errors.Wrap(err, "could not check share existence")
 	}
 	if acc == nil {
-		if err := km.storage.SaveHighestAttestation(shareKey.GetPublicKey().Serialize(), zeroSlotAttestation); err != nil {
-			return errors.Wrap(err, "could not save zero highest attestation")
+		// a share that was removed and added again keeps its slashing protection data
+		if km.storage.RetrieveHighestAttestation(shareKey.GetPublicKey().Serialize()) == nil {
+			if err := km.storage.SaveHighestAttestation(shareKey.GetPublicKey().Serialize(), zeroSlotAttestation); err != nil {
+				return errors.Wrap(err, "could not save zero highest attestation")
+			}
+		}
+		if km.storage.RetrieveHighestProposal(shareKey.GetPublicKey().Serialize()) == nil {
+			if err := km.storage.SaveHighestProposal(shareKey.GetPublicKey().Serialize(), zeroSlotBlock); err != nil {
+				return errors.Wrap(err, "could not save zero highest proposal")
+			}
 		}
 		if err := km.saveShare(shareKey); err != nil {
 			return errors.Wrap(err, "could not save share")
@@ -87,6 +150,30 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+// RemoveShare removes the account of the given share public key (hex) from the wallet,
+// slashing protection data is kept in case the share will be added again
+func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
+	km.walletLock.Lock()
+	defer km.walletLock.Unlock()
+
+	acc, err := km.wallet.AccountByPublicKey(pubKey)
+	if err != nil && !isAccountNotFound(err) {
+		return errors.Wrap(err, "could not check share existence")
+	}
+	if acc != nil {
+		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
+			return errors.Wrap(err, "could not delete share")
+		}
+	}
+	return nil
+}
+
+// isAccountNotFound returns true if the given error is the not found error of the wallet,
+// the wallet is created as nd wallet while it is opened from storage as hd wallet
+func isAccountNotFound(err error) bool {
+	return err == nd.ErrAccountNotFound || err == hd.ErrAccountNotFound
+}
+
 func (km *ethKeyManagerSigner) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	km.walletLock.RLock()
 	defer km.walletLock.RUnlock()
@@ -137,6 +224,190 @@ func (km *ethKeyManagerSigner) SignAttestation(data *spec.AttestationData, duty
 	}, root[:], nil
 }
 
+func (km *ethKeyManagerSigner) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainRandao, epoch)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(types.Epoch(epoch), domain)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignEpoch(types.Epoch(epoch), domain, pk)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return blsSig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid beacon block")
+	}
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainBeaconProposer, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	object, prysmBlock, err := specBlockToPrysmBlock(block)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert beacon block")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(object, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	// shares that were added before proposals were supported have no highest proposal yet
+	if km.storage.RetrieveHighestProposal(pk) == nil {
+		if err := km.storage.SaveHighestProposal(pk, zeroSlotBlock); err != nil {
+			return nil, nil, errors.Wrap(err, "could not save zero highest proposal")
+		}
+	}
+	sig, err := km.signer.SignBeaconBlock(prysmBlock, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign beacon block")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	signed, err := beacon.NewSignedBeaconBlock(block, blsSig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signed, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainSelectionProof, spec.Epoch(epoch))
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(types.Slot(slot), domain)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignSlot(types.Slot(slot), domain, pk)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign slot")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return blsSig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(msg.Aggregate.Data.Slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainAggregateAndProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	prysmMsg, err := specAggregateAndProofToPrysm(msg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert aggregate and proof")
+	}
+	sig, err := km.signer.SignAggregateAndProof(prysmMsg, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &spec.SignedAggregateAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainSyncCommittee, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	blockRoot := types.SSZBytes(root[:])
+	signingRoot, err := km.signingUtils.ComputeSigningRoot(&blockRoot, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignSyncCommittee(root[:], domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee block root")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SyncCommitteeMessage{
+		Slot:            slot,
+		BeaconBlockRoot: root,
+		ValidatorIndex:  validatorIndex,
+		Signature:       blsSig,
+	}, signingRoot[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainSyncCommitteeSelectionProof, spec.Epoch(epoch))
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	data := &eth.SyncAggregatorSelectionData{
+		Slot:              types.Slot(slot),
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignSyncCommitteeSelectionData(data, domain, pk)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign sync committee selection data")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return blsSig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	if msg.Contribution == nil {
+		return nil, nil, errors.New("contribution is missing")
+	}
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(msg.Contribution.Slot))
+	domain, err := km.signingUtils.GetDomainByType(beacon.DomainContributionAndProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	prysmMsg, err := specContributionAndProofToPrysm(msg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert contribution and proof")
+	}
+	sig, err := km.signer.SignSyncCommitteeContributionAndProof(prysmMsg, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SignedContributionAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root[:], nil
+}
+
 func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
 	key, err := core.NewHDKeyFromPrivateKey(shareKey.Serialize(), "")
 	if err != nil {
@@ -164,6 +435,22 @@ var zeroSlotAttestation = &eth.AttestationData{
 	},
 }
 
+// zeroSlotBlock is a place holder beacon block representing all zero values
+var zeroSlotBlock = &eth.BeaconBlock{
+	Slot:          0,
+	ProposerIndex: 0,
+	ParentRoot:    make([]byte, 32),
+	StateRoot:     make([]byte, 32),
+	Body: &eth.BeaconBlockBody{
+		RandaoReveal: make([]byte, 96),
+		Eth1Data: &eth.Eth1Data{
+			DepositRoot: make([]byte, 32),
+			BlockHash:   make([]byte, 32),
+		},
+		Graffiti: make([]byte, 32),
+	},
+}
+
 // specAttDataToPrysmAttData a simple func converting between data types
 func specAttDataToPrysmAttData(data *spec.AttestationData) *eth.AttestationData {
 	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
@@ -181,3 +468,66 @@ func specAttDataToPrysmAttData(data *spec.AttestationData) *eth.AttestationData
 		},
 	}
 }
+
+// specBlockToPrysmBlock converts between block types, both types share the same ssz encoding
+// specBlockToPrysmBlock returns the block of the given version (the signed object) and its prysm equivalent,
+// both types share the same ssz encoding
+func specBlockToPrysmBlock(block *ethspec.VersionedBeaconBlock) (interface{}, prysmblock.BeaconBlock, error) {
+	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
+	switch {
+	case block.Version == ethspec.DataVersionPhase0 && block.Phase0 != nil:
+		byts, err := block.Phase0.MarshalSSZ()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to marshal beacon block")
+		}
+		ret := &eth.BeaconBlock{}
+		if err := ret.UnmarshalSSZ(byts); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to unmarshal beacon block")
+		}
+		return block.Phase0, wrapper.WrappedPhase0BeaconBlock(ret), nil
+	case block.Version == ethspec.DataVersionAltair && block.Altair != nil:
+		byts, err := block.Altair.MarshalSSZ()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to marshal altair beacon block")
+		}
+		ret := &eth.BeaconBlockAltair{}
+		if err := ret.UnmarshalSSZ(byts); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to unmarshal altair beacon block")
+		}
+		wrapped, err := wrapper.WrappedAltairBeaconBlock(ret)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to wrap altair beacon block")
+		}
+		return block.Altair, wrapped, nil
+	default:
+		return nil, nil, errors.Errorf("no block of version %d", block.Version)
+	}
+}
+
+// specAggregateAndProofToPrysm converts between aggregate and proof types, both types share the same ssz encoding
+func specAggregateAndProofToPrysm(msg *spec.AggregateAndProof) (*eth.AggregateAttestationAndProof, error) {
+	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
+	byts, err := msg.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal aggregate and proof")
+	}
+	ret := &eth.AggregateAttestationAndProof{}
+	if err := ret.UnmarshalSSZ(byts); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal aggregate and proof")
+	}
+	return ret, nil
+}
+
+// specContributionAndProofToPrysm converts between contribution and proof types, both types share the same ssz encoding
+func specContributionAndProofToPrysm(msg *altair.ContributionAndProof) (*eth.ContributionAndProof, error) {
+	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
+	byts, err := msg.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal contribution and proof")
+	}
+	ret := &eth.ContributionAndProof{}
+	if err := ret.UnmarshalSSZ(byts); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal contribution and proof")
+	}
+	return ret, nil
+}
@@ -0,0 +1,77 @@
+package ekm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// exportedAccount is the part of an encoded account that holds the validation key
+type exportedAccount struct {
+	ValidationKey struct {
+		PrivKey string `json:"privKey"`
+	} `json:"validationKey"`
+}
+
+// ExportShare returns the secret key of the given share, the key is read from the encoded account
+// as accounts don't expose their keys
+func (km *ethKeyManagerSigner) ExportShare(pk []byte) (*bls.SecretKey, error) {
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
+
+	account, err := km.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get share account")
+	}
+	raw, err := json.Marshal(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode share account")
+	}
+	exported := exportedAccount{}
+	if err := json.Unmarshal(raw, &exported); err != nil {
+		return nil, errors.Wrap(err, "could not decode share account")
+	}
+	rawKey, err := hex.DecodeString(exported.ValidationKey.PrivKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid share key")
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(rawKey); err != nil {
+		return nil, errors.Wrap(err, "invalid share key")
+	}
+	if !bytes.Equal(sk.GetPublicKey().Serialize(), pk) {
+		return nil, errors.New("share key does not match the public key")
+	}
+	return sk, nil
+}
+
+// HighestSigned returns the highest attestation and proposal that were signed by the given share
+func (km *ethKeyManagerSigner) HighestSigned(pk []byte) (*beacon.HighestSigned, error) {
+	highest := &beacon.HighestSigned{}
+	if att := km.storage.RetrieveHighestAttestation(pk); att != nil {
+		highest.SourceEpoch = uint64(att.Source.Epoch)
+		highest.TargetEpoch = uint64(att.Target.Epoch)
+	}
+	if block := km.storage.RetrieveHighestProposal(pk); block != nil {
+		highest.Slot = uint64(block.Slot)
+	}
+	return highest, nil
+}
+
+// RaiseHighestSigned raises the highest attestation and proposal of the given share,
+// it might be called before the share is added as added shares keep their existing data
+func (km *ethKeyManagerSigner) RaiseHighestSigned(pk []byte, highest *beacon.HighestSigned) error {
+	h := &highestData{
+		sourceEpoch:    highest.SourceEpoch,
+		targetEpoch:    highest.TargetEpoch,
+		slot:           highest.Slot,
+		hasAttestation: true,
+		hasBlock:       true,
+	}
+	_, err := importHighest(km.storage, pk, h, &ImportResult{}, false)
+	return err
+}
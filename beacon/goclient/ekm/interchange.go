@@ -0,0 +1,326 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// InterchangeFormatVersion is the supported version of the EIP-3076 interchange format
+const InterchangeFormatVersion = "5"
+
+// Interchange is the EIP-3076 slashing protection interchange format
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData  `json:"data"`
+}
+
+// InterchangeMetadata is the metadata of an interchange file
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData holds the slashing protection data of a single validator,
+// numbers are encoded as decimal strings
+type InterchangeData struct {
+	Pubkey             string               `json:"pubkey"`
+	SignedBlocks       []*SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []*SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock is a block that was signed by the validator
+type SignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is an attestation that was signed by the validator
+type SignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// ShareKeys maps validators public keys (hex, without 0x) to the public keys of the operator's shares,
+// slashing protection data is kept per share while the interchange format refers to validators
+type ShareKeys map[string][]byte
+
+// ImportStatus describes the outcome of importing the data of a validator
+type ImportStatus string
+
+const (
+	// ImportStatusUpdated means that the slashing protection data of the share was raised
+	ImportStatusUpdated ImportStatus = "updated"
+	// ImportStatusUnchanged means that the existing data is already as strict as the imported one
+	ImportStatusUnchanged ImportStatus = "unchanged"
+	// ImportStatusUnknownValidator means that the node has no share of the validator
+	ImportStatusUnknownValidator ImportStatus = "unknown validator"
+)
+
+// ImportResult is the outcome of importing the data of a validator,
+// the epochs and slot are the highest values after the import
+type ImportResult struct {
+	Pubkey      string
+	Status      ImportStatus
+	SourceEpoch uint64
+	TargetEpoch uint64
+	Slot        uint64
+}
+
+// highestData is the summary of the data of a validator, as kept by the key manager
+type highestData struct {
+	sourceEpoch, targetEpoch, slot uint64
+	hasAttestation, hasBlock       bool
+}
+
+// Validate checks the interchange against the genesis validators root of the network
+func (i *Interchange) Validate(genesisValidatorsRoot string) error {
+	if i.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return errors.Errorf("unsupported interchange format version %q", i.Metadata.InterchangeFormatVersion)
+	}
+	if !strings.EqualFold(trimHexPrefix(i.Metadata.GenesisValidatorsRoot), trimHexPrefix(genesisValidatorsRoot)) {
+		return errors.Errorf("genesis validators root %s does not match the network (%s)",
+			i.Metadata.GenesisValidatorsRoot, genesisValidatorsRoot)
+	}
+	for _, d := range i.Data {
+		if _, err := d.highest(); err != nil {
+			return errors.Wrapf(err, "invalid data of validator %s", d.Pubkey)
+		}
+	}
+	return nil
+}
+
+// highest validates the data and returns the highest epochs and slot that were signed
+func (d *InterchangeData) highest() (*highestData, error) {
+	pk, err := hex.DecodeString(trimHexPrefix(d.Pubkey))
+	if err != nil || len(pk) != 48 {
+		return nil, errors.New("invalid public key")
+	}
+	ret := &highestData{}
+	for _, b := range d.SignedBlocks {
+		slot, err := parseUint("slot", b.Slot)
+		if err != nil {
+			return nil, err
+		}
+		if !ret.hasBlock || slot > ret.slot {
+			ret.slot = slot
+		}
+		ret.hasBlock = true
+	}
+	for _, a := range d.SignedAttestations {
+		source, err := parseUint("source epoch", a.SourceEpoch)
+		if err != nil {
+			return nil, err
+		}
+		target, err := parseUint("target epoch", a.TargetEpoch)
+		if err != nil {
+			return nil, err
+		}
+		if source > target {
+			return nil, errors.Errorf("source epoch %d is greater than target epoch %d", source, target)
+		}
+		if !ret.hasAttestation || source > ret.sourceEpoch {
+			ret.sourceEpoch = source
+		}
+		if !ret.hasAttestation || target > ret.targetEpoch {
+			ret.targetEpoch = target
+		}
+		ret.hasAttestation = true
+	}
+	return ret, nil
+}
+
+// ExportInterchange exports the slashing protection data of the given shares, in the minimal form of EIP-3076
+// (only the highest attestation and block of each validator)
+func ExportInterchange(db basedb.IDb, network core.Network, genesisValidatorsRoot string, shares ShareKeys) (*Interchange, error) {
+	store := newSignerStorage(db, network)
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    "0x" + trimHexPrefix(genesisValidatorsRoot),
+		},
+		Data: []*InterchangeData{},
+	}
+	for _, pubKey := range sortedKeys(shares) {
+		data := &InterchangeData{
+			Pubkey:             "0x" + pubKey,
+			SignedBlocks:       []*SignedBlock{},
+			SignedAttestations: []*SignedAttestation{},
+		}
+		// zero values are the place holders that are saved when a share is added
+		if att := store.RetrieveHighestAttestation(shares[pubKey]); att != nil && att.Target.Epoch > 0 {
+			data.SignedAttestations = append(data.SignedAttestations, &SignedAttestation{
+				SourceEpoch: strconv.FormatUint(uint64(att.Source.Epoch), 10),
+				TargetEpoch: strconv.FormatUint(uint64(att.Target.Epoch), 10),
+			})
+		}
+		if block := store.RetrieveHighestProposal(shares[pubKey]); block != nil && block.Slot > 0 {
+			data.SignedBlocks = append(data.SignedBlocks, &SignedBlock{
+				Slot: strconv.FormatUint(uint64(block.Slot), 10),
+			})
+		}
+		if len(data.SignedAttestations) == 0 && len(data.SignedBlocks) == 0 {
+			continue
+		}
+		interchange.Data = append(interchange.Data, data)
+	}
+	return interchange, nil
+}
+
+// ImportInterchange merges the given interchange into the slashing protection data of the given shares,
+// the highest epochs and slot are only raised. nothing is saved in dry run
+func ImportInterchange(db basedb.IDb, network core.Network, genesisValidatorsRoot string, shares ShareKeys,
+	interchange *Interchange, dryRun bool) ([]*ImportResult, error) {
+	if err := interchange.Validate(genesisValidatorsRoot); err != nil {
+		return nil, err
+	}
+	store := newSignerStorage(db, network)
+
+	// a validator might appear several times
+	imported := make(map[string]*highestData)
+	var pubKeys []string
+	for _, d := range interchange.Data {
+		h, err := d.highest()
+		if err != nil {
+			return nil, err
+		}
+		pubKey := strings.ToLower(trimHexPrefix(d.Pubkey))
+		existing, found := imported[pubKey]
+		if !found {
+			imported[pubKey] = h
+			pubKeys = append(pubKeys, pubKey)
+			continue
+		}
+		existing.merge(h)
+	}
+
+	results := make([]*ImportResult, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		result := &ImportResult{Pubkey: "0x" + pubKey}
+		results = append(results, result)
+		shareKey, found := shares[pubKey]
+		if !found {
+			result.Status = ImportStatusUnknownValidator
+			continue
+		}
+		updated, err := importHighest(store, shareKey, imported[pubKey], result, dryRun)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not import data of validator %s", pubKey)
+		}
+		result.Status = ImportStatusUnchanged
+		if updated {
+			result.Status = ImportStatusUpdated
+		}
+	}
+	return results, nil
+}
+
+// importHighest raises the highest attestation and proposal of the share, returns true if any of them was changed
+func importHighest(store *signerStorage, shareKey []byte, h *highestData, result *ImportResult, dryRun bool) (bool, error) {
+	updated := false
+
+	att := store.RetrieveHighestAttestation(shareKey)
+	if h.hasAttestation {
+		if att == nil {
+			att = newHighestAttestation()
+		}
+		if uint64(att.Source.Epoch) < h.sourceEpoch {
+			att.Source.Epoch = types.Epoch(h.sourceEpoch)
+			updated = true
+		}
+		if uint64(att.Target.Epoch) < h.targetEpoch {
+			att.Target.Epoch = types.Epoch(h.targetEpoch)
+			updated = true
+		}
+		if updated && !dryRun {
+			if err := store.SaveHighestAttestation(shareKey, att); err != nil {
+				return false, err
+			}
+		}
+	}
+	if att != nil {
+		result.SourceEpoch = uint64(att.Source.Epoch)
+		result.TargetEpoch = uint64(att.Target.Epoch)
+	}
+
+	block := store.RetrieveHighestProposal(shareKey)
+	if h.hasBlock && (block == nil || uint64(block.Slot) < h.slot) {
+		block = newHighestProposal(h.slot)
+		updated = true
+		if !dryRun {
+			if err := store.SaveHighestProposal(shareKey, block); err != nil {
+				return false, err
+			}
+		}
+	}
+	if block != nil {
+		result.Slot = uint64(block.Slot)
+	}
+	return updated, nil
+}
+
+func (h *highestData) merge(other *highestData) {
+	if other.hasAttestation {
+		if !h.hasAttestation || other.sourceEpoch > h.sourceEpoch {
+			h.sourceEpoch = other.sourceEpoch
+		}
+		if !h.hasAttestation || other.targetEpoch > h.targetEpoch {
+			h.targetEpoch = other.targetEpoch
+		}
+		h.hasAttestation = true
+	}
+	if other.hasBlock {
+		if !h.hasBlock || other.slot > h.slot {
+			h.slot = other.slot
+		}
+		h.hasBlock = true
+	}
+}
+
+// newHighestAttestation returns a copy of the zero attestation
+func newHighestAttestation() *eth.AttestationData {
+	return &eth.AttestationData{
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &eth.Checkpoint{Root: make([]byte, 32)},
+		Target:          &eth.Checkpoint{Root: make([]byte, 32)},
+	}
+}
+
+// newHighestProposal returns a copy of the zero block with the given slot
+func newHighestProposal(slot uint64) *eth.BeaconBlock {
+	return &eth.BeaconBlock{
+		Slot:       types.Slot(slot),
+		ParentRoot: zeroSlotBlock.ParentRoot,
+		StateRoot:  zeroSlotBlock.StateRoot,
+		Body:       zeroSlotBlock.Body,
+	}
+}
+
+func parseUint(name, value string) (uint64, error) {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+func trimHexPrefix(s string) string {
+	return strings.TrimPrefix(s, "0x")
+}
+
+func sortedKeys(shares ShareKeys) []string {
+	keys := make([]string, 0, len(shares))
+	for k := range shares {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesisValidatorsRoot = "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eee6eb2bb"
+
+func testInterchange(data ...*InterchangeData) *Interchange {
+	return &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    testGenesisValidatorsRoot,
+		},
+		Data: data,
+	}
+}
+
+func testPubKey(b byte) string {
+	return strings.Repeat(hex.EncodeToString([]byte{b}), 48)
+}
+
+func TestInterchange_Validate(t *testing.T) {
+	valid := &InterchangeData{
+		Pubkey:             "0x" + testPubKey(1),
+		SignedBlocks:       []*SignedBlock{{Slot: "10"}},
+		SignedAttestations: []*SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+	}
+	require.NoError(t, testInterchange(valid).Validate(testGenesisValidatorsRoot))
+	require.NoError(t, testInterchange(valid).Validate(strings.ToUpper(strings.TrimPrefix(testGenesisValidatorsRoot, "0x"))))
+
+	tests := []struct {
+		name        string
+		interchange *Interchange
+		err         string
+	}{
+		{"unsupported version", &Interchange{Metadata: InterchangeMetadata{InterchangeFormatVersion: "4"}},
+			`unsupported interchange format version "4"`},
+		{"genesis validators root mismatch", &Interchange{Metadata: InterchangeMetadata{InterchangeFormatVersion: "5", GenesisValidatorsRoot: "0x01"}},
+			"genesis validators root 0x01 does not match the network (" + testGenesisValidatorsRoot + ")"},
+		{"invalid public key", testInterchange(&InterchangeData{Pubkey: "0x0102"}),
+			"invalid data of validator 0x0102: invalid public key"},
+		{"invalid slot", testInterchange(&InterchangeData{Pubkey: testPubKey(1), SignedBlocks: []*SignedBlock{{Slot: "-1"}}}),
+			"invalid data of validator " + testPubKey(1) + `: invalid slot "-1"`},
+		{"invalid epoch", testInterchange(&InterchangeData{Pubkey: testPubKey(1), SignedAttestations: []*SignedAttestation{{SourceEpoch: "0x1", TargetEpoch: "2"}}}),
+			"invalid data of validator " + testPubKey(1) + `: invalid source epoch "0x1"`},
+		{"source after target", testInterchange(&InterchangeData{Pubkey: testPubKey(1), SignedAttestations: []*SignedAttestation{{SourceEpoch: "3", TargetEpoch: "2"}}}),
+			"invalid data of validator " + testPubKey(1) + ": source epoch 3 is greater than target epoch 2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.EqualError(t, test.interchange.Validate(testGenesisValidatorsRoot), test.err)
+		})
+	}
+}
+
+func TestImportInterchange(t *testing.T) {
+	db := getStorage(t)
+	defer db.Close()
+	store := newSignerStorage(db, core.PraterNetwork)
+
+	shareKey := []byte("share-1")
+	shares := ShareKeys{testPubKey(1): shareKey}
+	// the existing data has a higher source epoch than the imported one
+	highest := newHighestAttestation()
+	highest.Source.Epoch = 5
+	highest.Target.Epoch = 6
+	require.NoError(t, store.SaveHighestAttestation(shareKey, highest))
+	require.NoError(t, store.SaveHighestProposal(shareKey, zeroSlotBlock))
+
+	interchange := testInterchange(
+		&InterchangeData{
+			Pubkey:             "0x" + testPubKey(1),
+			SignedBlocks:       []*SignedBlock{{Slot: "100"}, {Slot: "64"}},
+			SignedAttestations: []*SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+		},
+		// the same validator appears again
+		&InterchangeData{
+			Pubkey:             "0x" + testPubKey(1),
+			SignedAttestations: []*SignedAttestation{{SourceEpoch: "4", TargetEpoch: "10"}},
+		},
+		&InterchangeData{
+			Pubkey:             "0x" + testPubKey(2),
+			SignedAttestations: []*SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+		},
+	)
+
+	t.Run("dry run", func(t *testing.T) {
+		results, err := ImportInterchange(db, core.PraterNetwork, testGenesisValidatorsRoot, shares, interchange, true)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, &ImportResult{Pubkey: "0x" + testPubKey(1), Status: ImportStatusUpdated, SourceEpoch: 5, TargetEpoch: 10, Slot: 100}, results[0])
+		require.Equal(t, ImportStatusUnknownValidator, results[1].Status)
+
+		require.Equal(t, types.Epoch(6), store.RetrieveHighestAttestation(shareKey).Target.Epoch)
+		require.Equal(t, types.Slot(0), store.RetrieveHighestProposal(shareKey).Slot)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		results, err := ImportInterchange(db, core.PraterNetwork, testGenesisValidatorsRoot, shares, interchange, false)
+		require.NoError(t, err)
+		require.Equal(t, ImportStatusUpdated, results[0].Status)
+
+		att := store.RetrieveHighestAttestation(shareKey)
+		require.Equal(t, types.Epoch(5), att.Source.Epoch)
+		require.Equal(t, types.Epoch(10), att.Target.Epoch)
+		require.Equal(t, types.Slot(100), store.RetrieveHighestProposal(shareKey).Slot)
+
+		// importing again changes nothing
+		results, err = ImportInterchange(db, core.PraterNetwork, testGenesisValidatorsRoot, shares, interchange, false)
+		require.NoError(t, err)
+		require.Equal(t, ImportStatusUnchanged, results[0].Status)
+	})
+
+	t.Run("slashable attestations are rejected after import", func(t *testing.T) {
+		status, err := slashingprotection.NewNormalProtection(store).IsSlashableAttestation(shareKey, &eth.AttestationData{
+			Source: &eth.Checkpoint{Epoch: 5},
+			Target: &eth.Checkpoint{Epoch: 9},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, status)
+		require.Equal(t, core.HighestAttestationVote, status.Status)
+	})
+
+	t.Run("invalid interchange", func(t *testing.T) {
+		_, err := ImportInterchange(db, core.PraterNetwork, "0x01", shares, interchange, false)
+		require.Error(t, err)
+	})
+}
+
+func TestExportInterchange(t *testing.T) {
+	db := getStorage(t)
+	defer db.Close()
+	store := newSignerStorage(db, core.PraterNetwork)
+
+	shares := ShareKeys{
+		testPubKey(1): []byte("share-1"),
+		testPubKey(2): []byte("share-2"),
+	}
+	highest := newHighestAttestation()
+	highest.Source.Epoch = 7
+	highest.Target.Epoch = 8
+	require.NoError(t, store.SaveHighestAttestation([]byte("share-1"), highest))
+	require.NoError(t, store.SaveHighestProposal([]byte("share-1"), newHighestProposal(99)))
+	// zero values are not exported
+	require.NoError(t, store.SaveHighestAttestation([]byte("share-2"), zeroSlotAttestation))
+	require.NoError(t, store.SaveHighestProposal([]byte("share-2"), zeroSlotBlock))
+
+	interchange, err := ExportInterchange(db, core.PraterNetwork, testGenesisValidatorsRoot, shares)
+	require.NoError(t, err)
+	require.NoError(t, interchange.Validate(testGenesisValidatorsRoot))
+	require.Len(t, interchange.Data, 1)
+	require.Equal(t, &InterchangeData{
+		Pubkey:             "0x" + testPubKey(1),
+		SignedBlocks:       []*SignedBlock{{Slot: "99"}},
+		SignedAttestations: []*SignedAttestation{{SourceEpoch: "7", TargetEpoch: "8"}},
+	}, interchange.Data[0])
+}
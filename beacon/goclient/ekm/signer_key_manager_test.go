@@ -1,6 +1,8 @@
 package ekm
 
 import (
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
@@ -9,6 +11,7 @@ import (
 	fssz "github.com/ferranbt/fastssz"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -28,6 +31,10 @@ func (s *signingUtils) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	return make([]byte, 32), nil
 }
 
+func (s *signingUtils) GetDomainByType(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	return make([]byte, 32), nil
+}
+
 func (s *signingUtils) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
 	if object == nil {
 		return [32]byte{}, errors.New("cannot compute signing root of nil")
@@ -59,7 +66,7 @@ func (s *signingUtils) signingData(rootFunc func() ([32]byte, error), domain []b
 func testKeyManager(t *testing.T) beacon.KeyManager {
 	threshold.Init()
 
-	km, err := NewETHKeyManagerSigner(getStorage(t), nil, core.PraterNetwork)
+	km, err := NewETHKeyManagerSigner(getStorage(t), nil, beacon.NewNetwork(core.PraterNetwork, 0, nil), nil)
 	km.(*ethKeyManagerSigner).signingUtils = &signingUtils{}
 	require.NoError(t, err)
 
@@ -75,6 +82,78 @@ func testKeyManager(t *testing.T) beacon.KeyManager {
 	return km
 }
 
+func TestRemoveShare(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey()
+
+	require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+	_, _, err := km.SignRandaoReveal(1, pk.Serialize())
+	require.EqualError(t, err, "failed to sign randao reveal: account not found")
+
+	t.Run("remove non-existing share", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+	})
+
+	t.Run("remove non-existing share from an opened wallet", func(t *testing.T) {
+		opened, err := NewETHKeyManagerSigner(km.(*ethKeyManagerSigner).storage.db, nil, beacon.NewNetwork(core.PraterNetwork, 0, nil), nil)
+		require.NoError(t, err)
+		require.NoError(t, opened.RemoveShare(pk.SerializeToHexStr()))
+	})
+
+	t.Run("slashing protection data is kept", func(t *testing.T) {
+		require.NotNil(t, km.(*ethKeyManagerSigner).storage.RetrieveHighestAttestation(pk.Serialize()))
+		require.NoError(t, km.AddShare(sk1))
+		_, _, err := km.SignRandaoReveal(1, pk.Serialize())
+		require.NoError(t, err)
+	})
+}
+
+func TestShareHandover(t *testing.T) {
+	km := testKeyManager(t)
+	handover := km.(beacon.ShareHandover)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey().Serialize()
+
+	t.Run("export share", func(t *testing.T) {
+		sk, err := handover.ExportShare(pk)
+		require.NoError(t, err)
+		require.True(t, sk.IsEqual(sk1))
+
+		sk3 := &bls.SecretKey{}
+		sk3.SetByCSPRNG()
+		_, err = handover.ExportShare(sk3.GetPublicKey().Serialize())
+		require.EqualError(t, err, "could not get share account: account not found")
+	})
+
+	t.Run("raise highest signed", func(t *testing.T) {
+		highest, err := handover.HighestSigned(pk)
+		require.NoError(t, err)
+		require.Equal(t, &beacon.HighestSigned{}, highest)
+
+		require.NoError(t, handover.RaiseHighestSigned(pk, &beacon.HighestSigned{SourceEpoch: 9, TargetEpoch: 10, Slot: 300}))
+		require.NoError(t, handover.RaiseHighestSigned(pk, &beacon.HighestSigned{SourceEpoch: 8, TargetEpoch: 11, Slot: 100}))
+		highest, err = handover.HighestSigned(pk)
+		require.NoError(t, err)
+		require.Equal(t, &beacon.HighestSigned{SourceEpoch: 9, TargetEpoch: 11, Slot: 300}, highest)
+	})
+
+	t.Run("raise before the share is added", func(t *testing.T) {
+		sk3 := &bls.SecretKey{}
+		sk3.SetByCSPRNG()
+		pk3 := sk3.GetPublicKey().Serialize()
+		require.NoError(t, handover.RaiseHighestSigned(pk3, &beacon.HighestSigned{SourceEpoch: 9, TargetEpoch: 10, Slot: 300}))
+		require.NoError(t, km.AddShare(sk3))
+		highest, err := handover.HighestSigned(pk3)
+		require.NoError(t, err)
+		require.Equal(t, &beacon.HighestSigned{SourceEpoch: 9, TargetEpoch: 10, Slot: 300}, highest)
+	})
+}
+
 func TestSignAttestation(t *testing.T) {
 	km := testKeyManager(t)
 
@@ -106,6 +185,9 @@ func TestSignAttestation(t *testing.T) {
 		},
 	}
 
+	t.Run("not slashable before signing", func(t *testing.T) {
+		require.NoError(t, km.(*ethKeyManagerSigner).IsAttestationSlashable(attestationData, sk1.GetPublicKey().Serialize()))
+	})
 	t.Run("sign once", func(t *testing.T) {
 		_, sig, err := km.SignAttestation(attestationData, duty, sk1.GetPublicKey().Serialize())
 		require.NoError(t, err)
@@ -117,6 +199,201 @@ func TestSignAttestation(t *testing.T) {
 		require.EqualError(t, err, "failed to sign attestation: slashable attestation (HighestAttestationVote), not signing")
 		require.Nil(t, sig)
 	})
+	t.Run("slashable check", func(t *testing.T) {
+		err := km.(*ethKeyManagerSigner).IsAttestationSlashable(attestationData, sk1.GetPublicKey().Serialize())
+		require.EqualError(t, err, "slashable attestation (HighestAttestationVote)")
+	})
+}
+
+func TestSignBeaconBlock(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeProposer,
+		PubKey:         [48]byte{},
+		Slot:           30,
+		ValidatorIndex: 1,
+	}
+	phase0Block := &spec.BeaconBlock{
+		Slot:          30,
+		ProposerIndex: 1,
+		ParentRoot:    spec.Root{1, 2, 3, 4, 5, 6},
+		StateRoot:     spec.Root{1, 2, 3, 4, 5, 6},
+		Body: &spec.BeaconBlockBody{
+			ETH1Data: &spec.ETH1Data{
+				BlockHash: make([]byte, 32),
+			},
+			Graffiti:          make([]byte, 32),
+			ProposerSlashings: []*spec.ProposerSlashing{},
+			AttesterSlashings: []*spec.AttesterSlashing{},
+			Attestations:      []*spec.Attestation{},
+			Deposits:          []*spec.Deposit{},
+			VoluntaryExits:    []*spec.SignedVoluntaryExit{},
+		},
+	}
+	block := &ethspec.VersionedBeaconBlock{Version: ethspec.DataVersionPhase0, Phase0: phase0Block}
+
+	t.Run("not slashable before signing", func(t *testing.T) {
+		require.NoError(t, km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, sk1.GetPublicKey().Serialize()))
+	})
+	t.Run("sign once", func(t *testing.T) {
+		signed, root, err := km.SignBeaconBlock(block, duty, sk1.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.NotNil(t, root)
+
+		require.Equal(t, ethspec.DataVersionPhase0, signed.Version)
+		signature := signed.Phase0.Signature
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(signature[:]))
+		require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+	})
+	t.Run("slashable sign, fail", func(t *testing.T) {
+		phase0Block.StateRoot = spec.Root{2, 2, 3, 4, 5, 6}
+		signed, _, err := km.SignBeaconBlock(block, duty, sk1.GetPublicKey().Serialize())
+		require.EqualError(t, err, "failed to sign beacon block: slashable proposal (HighestProposalVote), not signing")
+		require.Nil(t, signed)
+	})
+	t.Run("slashable check", func(t *testing.T) {
+		err := km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, sk1.GetPublicKey().Serialize())
+		require.EqualError(t, err, "slashable proposal (HighestProposalVote)")
+	})
+	t.Run("no stored highest proposal", func(t *testing.T) {
+		// shares that were added before proposals were supported
+		pk := sk1.GetPublicKey().Serialize()
+		require.NoError(t, km.(*ethKeyManagerSigner).storage.db.Delete(km.(*ethKeyManagerSigner).storage.objPrefix(highestProposalPrefix), pk))
+		require.Nil(t, km.(*ethKeyManagerSigner).storage.RetrieveHighestProposal(pk))
+
+		require.NoError(t, km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, pk))
+		phase0Block.Slot = 0
+		err := km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, pk)
+		require.EqualError(t, err, "slashable proposal (HighestProposalVote)")
+	})
+}
+
+func TestSignBeaconBlock_Altair(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey().Serialize()
+
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeProposer,
+		PubKey:         [48]byte{},
+		Slot:           30,
+		ValidatorIndex: 1,
+	}
+	altairBlock := &altair.BeaconBlock{
+		Slot:          30,
+		ProposerIndex: 1,
+		ParentRoot:    spec.Root{1, 2, 3, 4, 5, 6},
+		StateRoot:     spec.Root{1, 2, 3, 4, 5, 6},
+		Body: &altair.BeaconBlockBody{
+			ETH1Data: &spec.ETH1Data{
+				BlockHash: make([]byte, 32),
+			},
+			Graffiti:          make([]byte, 32),
+			ProposerSlashings: []*spec.ProposerSlashing{},
+			AttesterSlashings: []*spec.AttesterSlashing{},
+			Attestations:      []*spec.Attestation{},
+			Deposits:          []*spec.Deposit{},
+			VoluntaryExits:    []*spec.SignedVoluntaryExit{},
+			SyncAggregate: &altair.SyncAggregate{
+				SyncCommitteeBits: bitfield.NewBitvector512(),
+			},
+		},
+	}
+	block := &ethspec.VersionedBeaconBlock{Version: ethspec.DataVersionAltair, Altair: altairBlock}
+
+	require.NoError(t, km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, pk))
+	signed, root, err := km.SignBeaconBlock(block, duty, pk)
+	require.NoError(t, err)
+	require.Equal(t, ethspec.DataVersionAltair, signed.Version)
+	require.Equal(t, altairBlock, signed.Altair.Message)
+
+	// the signing root is of the altair block
+	domain, err := km.(*ethKeyManagerSigner).signingUtils.GetDomainByType(beacon.DomainBeaconProposer, 0)
+	require.NoError(t, err)
+	expectedRoot, err := km.(*ethKeyManagerSigner).signingUtils.ComputeSigningRoot(altairBlock, domain)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot[:], root)
+	signature := signed.Altair.Signature
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(signature[:]))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+
+	// a second block of the same slot is slashable
+	altairBlock.StateRoot = spec.Root{2, 2, 3, 4, 5, 6}
+	require.EqualError(t, km.(*ethKeyManagerSigner).IsBeaconBlockSlashable(block, pk), "slashable proposal (HighestProposalVote)")
+	_, _, err = km.SignBeaconBlock(block, duty, pk)
+	require.EqualError(t, err, "failed to sign beacon block: slashable proposal (HighestProposalVote), not signing")
+}
+
+func TestSignRandaoReveal(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	sig, root, err := km.SignRandaoReveal(3, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	blsSig := &bls.Sign{}
+	require.NoError(t, blsSig.Deserialize(sig[:]))
+	require.True(t, blsSig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignSlot(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	sig, root, err := km.SignSlot(30, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	blsSig := &bls.Sign{}
+	require.NoError(t, blsSig.Deserialize(sig[:]))
+	require.True(t, blsSig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignAggregateAndProof(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeAggregator,
+		PubKey:         [48]byte{},
+		Slot:           30,
+		ValidatorIndex: 1,
+	}
+	msg := &spec.AggregateAndProof{
+		AggregatorIndex: 1,
+		Aggregate: &spec.Attestation{
+			AggregationBits: bitfield.NewBitlist(8),
+			Data: &spec.AttestationData{
+				Slot:   30,
+				Index:  1,
+				Source: &spec.Checkpoint{},
+				Target: &spec.Checkpoint{Epoch: 3},
+			},
+		},
+		SelectionProof: spec.BLSSignature{1, 2, 3},
+	}
+
+	signed, root, err := km.SignAggregateAndProof(msg, duty, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.Equal(t, msg, signed.Message)
+
+	signature := signed.Signature
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(signature[:]))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
 }
 
 func TestSignIBFTMessage(t *testing.T) {
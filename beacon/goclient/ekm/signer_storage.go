@@ -28,6 +28,15 @@ type signerStorage struct {
 	db      basedb.IDb
 	network core.Network
 	lock    sync.RWMutex
+
+	encryptor encryptor.Encryptor
+	password  []byte
+}
+
+// encryptedAccount is the stored form of an account when an encryptor is set
+type encryptedAccount struct {
+	Encryptor string                 `json:"encryptor"`
+	Crypto    map[string]interface{} `json:"crypto"`
 }
 
 func newSignerStorage(db basedb.IDb, network core.Network) *signerStorage {
@@ -115,9 +124,9 @@ func (s *signerStorage) SaveAccount(account core.ValidatorAccount) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	data, err := json.Marshal(account)
+	data, err := s.encodeAccount(account)
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal account")
+		return err
 	}
 
 	key := fmt.Sprintf(accountsPath, account.ID().String())
@@ -152,11 +161,46 @@ func (s *signerStorage) OpenAccount(accountID uuid.UUID) (core.ValidatorAccount,
 	return s.decodeAccount(obj.Value)
 }
 
+func (s *signerStorage) encodeAccount(account core.ValidatorAccount) ([]byte, error) {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal account")
+	}
+	if s.encryptor == nil {
+		return data, nil
+	}
+	crypto, err := s.encryptor.Encrypt(data, string(s.password))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt account")
+	}
+	data, err = json.Marshal(&encryptedAccount{Encryptor: s.encryptor.Name(), Crypto: crypto})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal encrypted account")
+	}
+	return data, nil
+}
+
 func (s *signerStorage) decodeAccount(byts []byte) (core.ValidatorAccount, error) {
 	if len(byts) == 0 {
 		return nil, errors.New("bytes are empty")
 	}
 
+	// decrypt, accounts that were saved before an encryptor was set are kept in plain form
+	var encrypted encryptedAccount
+	if err := json.Unmarshal(byts, &encrypted); err == nil && encrypted.Crypto != nil {
+		if s.encryptor == nil {
+			return nil, errors.New("account is encrypted, storage must be unlocked")
+		}
+		if encrypted.Encryptor != s.encryptor.Name() {
+			return nil, errors.Errorf("account was encrypted with unknown encryptor %s", encrypted.Encryptor)
+		}
+		decrypted, err := s.encryptor.Decrypt(encrypted.Crypto, string(s.password))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt account")
+		}
+		byts = decrypted
+	}
+
 	// decode
 	var ret *wallets.HDAccount
 	if err := json.Unmarshal(byts, &ret); err != nil {
@@ -169,7 +213,46 @@ func (s *signerStorage) decodeAccount(byts []byte) (core.ValidatorAccount, error
 
 // SetEncryptor sets the given encryptor to the wallet.
 func (s *signerStorage) SetEncryptor(encryptor encryptor.Encryptor, password []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.encryptor = encryptor
+	s.password = password
+}
+
+// encryptAccounts re-saves accounts that are kept in plain form with the current encryptor
+func (s *signerStorage) encryptAccounts() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
+	if s.encryptor == nil {
+		return nil
+	}
+	var plain []basedb.Obj
+	err := s.db.GetAll(s.objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+		var encrypted encryptedAccount
+		if err := json.Unmarshal(obj.Value, &encrypted); err != nil || encrypted.Crypto == nil {
+			plain = append(plain, obj)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list accounts")
+	}
+	for _, obj := range plain {
+		acc, err := s.decodeAccount(obj.Value)
+		if err != nil {
+			return err
+		}
+		data, err := s.encodeAccount(acc)
+		if err != nil {
+			return err
+		}
+		if err := s.db.Set(s.objPrefix(accountsPrefix), obj.Key, data); err != nil {
+			return errors.Wrap(err, "failed to save encrypted account")
+		}
+	}
+	return nil
 }
 
 func (s *signerStorage) SaveHighestAttestation(pubKey []byte, attestation *eth.AttestationData) error {
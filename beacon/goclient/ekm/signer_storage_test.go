@@ -10,6 +10,7 @@ import (
 	"github.com/bloxapp/eth2-key-manager/wallets/hd"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
 	"github.com/bloxapp/ssv/utils/threshold"
 	"github.com/google/uuid"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -101,6 +102,43 @@ func TestDeleteAccount(t *testing.T) {
 	require.Nil(t, acc)
 }
 
+func TestEncryptedAccounts(t *testing.T) {
+	_, storage := testWallet(t)
+	defer storage.db.Close()
+
+	accts, err := storage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accts, 1)
+	account := accts[0]
+
+	// the existing (plain) account is encrypted once an encryptor is set
+	password := []byte(hex.EncodeToString(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")))
+	storage.SetEncryptor(encryption.NewEncryptor(), password)
+	require.NoError(t, storage.encryptAccounts())
+	obj, found, err := storage.db.Get(storage.objPrefix(accountsPrefix), []byte(fmt.Sprintf(accountsPath, account.ID().String())))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NotContains(t, string(obj.Value), "validationKey")
+
+	fetched, err := storage.OpenAccount(account.ID())
+	require.NoError(t, err)
+	require.Equal(t, account.ValidatorPublicKey(), fetched.ValidatorPublicKey())
+	sig, err := account.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+	fetchedSig, err := fetched.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, sig, fetchedSig)
+
+	// a storage without the encryptor can't open the account
+	storage.SetEncryptor(nil, nil)
+	_, err = storage.OpenAccount(account.ID())
+	require.EqualError(t, err, "account is encrypted, storage must be unlocked")
+
+	storage.SetEncryptor(encryption.NewEncryptor(), []byte(hex.EncodeToString(make([]byte, encryption.KeyLength))))
+	_, err = storage.OpenAccount(account.ID())
+	require.Error(t, err)
+}
+
 func TestNonExistingWallet(t *testing.T) {
 	storage := getWalletStorage(t)
 	w, err := storage.OpenWallet()
@@ -0,0 +1,55 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+)
+
+// NewerSlashingData returns the public keys (hex) of the shares that have a higher attestation or proposal in db
+// than in other, which means that replacing db with other would lower their slashing protection
+func NewerSlashingData(db basedb.IDb, other basedb.IDb, network core.Network) ([]string, error) {
+	store := newSignerStorage(db, network)
+	otherStore := newSignerStorage(other, network)
+
+	newer := make(map[string]bool)
+	err := db.GetAll(store.objPrefix(highestAttPrefix), func(i int, obj basedb.Obj) error {
+		// zero values are the place holders that are saved when a share is added
+		att := store.RetrieveHighestAttestation(obj.Key)
+		if att == nil || att.Target.Epoch == 0 {
+			return nil
+		}
+		otherAtt := otherStore.RetrieveHighestAttestation(obj.Key)
+		if otherAtt == nil || att.Source.Epoch > otherAtt.Source.Epoch || att.Target.Epoch > otherAtt.Target.Epoch {
+			newer[hex.EncodeToString(obj.Key)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read highest attestations")
+	}
+	err = db.GetAll(store.objPrefix(highestProposalPrefix), func(i int, obj basedb.Obj) error {
+		block := store.RetrieveHighestProposal(obj.Key)
+		if block == nil || block.Slot == 0 {
+			return nil
+		}
+		otherBlock := otherStore.RetrieveHighestProposal(obj.Key)
+		if otherBlock == nil || block.Slot > otherBlock.Slot {
+			newer[hex.EncodeToString(obj.Key)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read highest proposals")
+	}
+
+	pubKeys := make([]string, 0, len(newer))
+	for pk := range newer {
+		pubKeys = append(pubKeys, pk)
+	}
+	sort.Strings(pubKeys)
+	return pubKeys, nil
+}
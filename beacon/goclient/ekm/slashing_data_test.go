@@ -0,0 +1,58 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewerSlashingData(t *testing.T) {
+	db := getStorage(t)
+	defer db.Close()
+	other := getStorage(t)
+	defer other.Close()
+
+	saveAttestation := func(s *signerStorage, pk string, source, target uint64) {
+		highest := newHighestAttestation()
+		highest.Source.Epoch = types.Epoch(source)
+		highest.Target.Epoch = types.Epoch(target)
+		require.NoError(t, s.SaveHighestAttestation([]byte(pk), highest))
+	}
+	store := newSignerStorage(db, core.PraterNetwork)
+	otherStore := newSignerStorage(other, core.PraterNetwork)
+
+	// equal data
+	saveAttestation(store, "share-1", 7, 8)
+	saveAttestation(otherStore, "share-1", 7, 8)
+	require.NoError(t, store.SaveHighestProposal([]byte("share-1"), newHighestProposal(99)))
+	require.NoError(t, otherStore.SaveHighestProposal([]byte("share-1"), newHighestProposal(99)))
+	// higher target epoch
+	saveAttestation(store, "share-2", 7, 9)
+	saveAttestation(otherStore, "share-2", 7, 8)
+	// higher proposal
+	require.NoError(t, store.SaveHighestProposal([]byte("share-3"), newHighestProposal(100)))
+	require.NoError(t, otherStore.SaveHighestProposal([]byte("share-3"), newHighestProposal(99)))
+	// missing in other
+	saveAttestation(store, "share-4", 1, 2)
+	// zero values are not considered
+	saveAttestation(store, "share-5", 0, 0)
+	require.NoError(t, store.SaveHighestProposal([]byte("share-5"), zeroSlotBlock))
+	// lower than other
+	saveAttestation(store, "share-6", 1, 2)
+	saveAttestation(otherStore, "share-6", 3, 4)
+
+	newer, err := NewerSlashingData(db, other, core.PraterNetwork)
+	require.NoError(t, err)
+	require.Equal(t, []string{hexString("share-2"), hexString("share-3"), hexString("share-4")}, newer)
+
+	newer, err = NewerSlashingData(other, db, core.PraterNetwork)
+	require.NoError(t, err)
+	require.Equal(t, []string{hexString("share-6")}, newer)
+}
+
+func hexString(s string) string {
+	return hex.EncodeToString([]byte(s))
+}
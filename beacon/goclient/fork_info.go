@@ -0,0 +1,62 @@
+package goclient
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon/goclient/web3signer"
+	"github.com/pkg/errors"
+)
+
+// ForkInfo returns the fork of the given epoch and the genesis validators root
+func (gc *goClient) ForkInfo(epoch spec.Epoch) (*web3signer.ForkInfo, error) {
+	schedule, genesisValidatorsRoot, err := gc.forkData()
+	if err != nil {
+		return nil, err
+	}
+	var fork *spec.Fork
+	for _, f := range schedule {
+		if f.Epoch <= epoch && (fork == nil || f.Epoch >= fork.Epoch) {
+			fork = f
+		}
+	}
+	if fork == nil {
+		return nil, errors.Errorf("no fork was found for epoch %d", epoch)
+	}
+	return &web3signer.ForkInfo{
+		Fork:                  fork,
+		GenesisValidatorsRoot: *genesisValidatorsRoot,
+	}, nil
+}
+
+// forkData returns the fork schedule and the genesis validators root, the values are fetched once from the node
+func (gc *goClient) forkData() ([]*spec.Fork, *spec.Root, error) {
+	gc.forkInfoLock.Lock()
+	defer gc.forkInfoLock.Unlock()
+
+	if gc.genesisValidatorsRoot != nil {
+		return gc.forkSchedule, gc.genesisValidatorsRoot, nil
+	}
+	scheduleProvider, isProvider := gc.client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return nil, nil, errors.New("client does not support ForkScheduleProvider")
+	}
+	genesisProvider, isProvider := gc.client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, nil, errors.New("client does not support GenesisProvider")
+	}
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.requestTimeout)
+	defer cancel()
+	schedule, err := scheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get fork schedule")
+	}
+	genesis, err := genesisProvider.Genesis(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get genesis")
+	}
+	gc.forkSchedule = schedule
+	gc.genesisValidatorsRoot = &genesis.GenesisValidatorsRoot
+	return gc.forkSchedule, gc.genesisValidatorsRoot, nil
+}
@@ -8,9 +8,9 @@ import (
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/http"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/beacon/goclient/web3signer"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,12 +20,14 @@ import (
 	"github.com/rs/zerolog"
 	"go.uber.org/zap"
 	"log"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
 	healthCheckTimeout = 10 * time.Second
+	requestTimeout     = 5 * time.Second
 )
 
 type beaconNodeStatus int32
@@ -35,6 +37,10 @@ var (
 		Name: "ssv:beacon:node_status",
 		Help: "Status of the connected beacon node",
 	})
+	metricsBeaconNodesHealthy = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:beacon:healthy_nodes",
+		Help: "Count of healthy (connected and synced) beacon nodes, when several nodes are used",
+	})
 	statusUnknown beaconNodeStatus = 0
 	statusSyncing beaconNodeStatus = 1
 	statusOK      beaconNodeStatus = 2
@@ -44,33 +50,97 @@ func init() {
 	if err := prometheus.Register(metricsBeaconNodeStatus); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsBeaconNodesHealthy); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // goClient implementing Beacon struct
 type goClient struct {
 	ctx            context.Context
 	logger         *zap.Logger
-	network        core.Network
+	network        beacon.Network
 	client         client.Service
+	beaconNodeAddr string
+	requestTimeout time.Duration
 	indicesMapLock sync.Mutex
 	graffiti       []byte
 	keyManager     beacon.KeyManager
+	// forkSchedule and genesisValidatorsRoot are cached once fetched, as they don't change
+	forkSchedule          []*spec.Fork
+	genesisValidatorsRoot *spec.Root
+	forkInfoLock          sync.Mutex
 }
 
 // verifies that the client implements HealthCheckAgent
 var _ metrics.HealthCheckAgent = &goClient{}
 
-// New init new client and go-client instance
+// New init new client and go-client instance,
+// a client with failover is created if several (comma separated) beacon node addresses were provided
 func New(opt beacon.Options) (beacon.Beacon, error) {
+	if addrs := beaconNodeAddrs(opt.BeaconNodeAddr); len(addrs) > 1 {
+		return newMultiClient(opt, addrs)
+	}
+
+	_client, err := newGoClient(opt, opt.BeaconNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	_client.keyManager, err = newKeyManager(opt, _client)
+	if err != nil {
+		return nil, err
+	}
+
+	return _client, nil
+}
+
+// signingClient is the beacon functionality that key managers depend on
+type signingClient interface {
+	beacon.SigningUtil
+	web3signer.ForkInfoProvider
+}
+
+// newKeyManager creates the key manager that was selected in the options
+func newKeyManager(opt beacon.Options, client signingClient) (beacon.KeyManager, error) {
+	switch opt.KeyManager {
+	case "", beacon.KeyManagerLocal:
+		km, err := ekm.NewETHKeyManagerSigner(opt.DB, client, opt.ETHNetwork, opt.EncryptionKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create new eth-key-manager signer")
+		}
+		return km, nil
+	case beacon.KeyManagerWeb3Signer:
+		km, err := web3signer.New(web3signer.Options{
+			Context: opt.Context,
+			Logger:  opt.Logger,
+			Addr:    opt.RemoteSignerAddr,
+			Timeout: opt.RequestTimeout,
+			Network: opt.ETHNetwork,
+		}, client, client)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create remote signer")
+		}
+		return km, nil
+	default:
+		return nil, errors.Errorf("unknown key manager %s", opt.KeyManager)
+	}
+}
+
+// newGoClient connects to the given beacon node, the key manager should be set by the caller
+func newGoClient(opt beacon.Options, addr string) (*goClient, error) {
 	logger := opt.Logger.With(zap.String("component", "goClient"), zap.String("network", opt.Network))
 	logger.Info("connecting to beacon client...")
 
+	timeout := opt.RequestTimeout
+	if timeout == 0 {
+		timeout = requestTimeout
+	}
 	httpClient, err := http.New(opt.Context,
 		// WithAddress supplies the address of the beacon node, in host:port format.
-		http.WithAddress(opt.BeaconNodeAddr),
+		http.WithAddress(addr),
 		// LogLevel supplies the level of logging to carry out.
 		http.WithLogLevel(zerolog.DebugLevel),
-		http.WithTimeout(time.Second*5),
+		http.WithTimeout(timeout),
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to create http client")
@@ -79,21 +149,16 @@ func New(opt beacon.Options) (beacon.Beacon, error) {
 	logger = logger.With(zap.String("name", httpClient.Name()), zap.String("address", httpClient.Address()))
 	logger.Info("successfully connected to beacon client")
 
-	_client := &goClient{
+	return &goClient{
 		ctx:            opt.Context,
 		logger:         logger,
-		network:        core.NetworkFromString(opt.Network),
+		network:        opt.ETHNetwork,
 		client:         httpClient,
+		beaconNodeAddr: addr,
+		requestTimeout: timeout,
 		indicesMapLock: sync.Mutex{},
 		graffiti:       opt.Graffiti,
-	}
-
-	_client.keyManager, err = ekm.NewETHKeyManagerSigner(opt.DB, _client, core.PraterNetwork) // TODO need to set dynemic network
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create new eth-key-manager signer")
-	}
-
-	return _client, nil
+	}, nil
 }
 
 // HealthCheck provides health status of beacon node
@@ -127,6 +192,32 @@ func (gc *goClient) ExtendIndexMap(index spec.ValidatorIndex, pubKey spec.BLSPub
 }
 
 func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	attesterDuties, err := gc.getAttesterDuties(epoch, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	proposerDuties, err := gc.getProposerDuties(epoch, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	duties := append(attesterDuties, toAggregatorDuties(attesterDuties)...)
+	return append(duties, proposerDuties...), nil
+}
+
+// toAggregatorDuties returns a potential aggregator duty for each of the given attester duties,
+// the selection proof that is computed during the duty execution determines whether the validator is an aggregator
+func toAggregatorDuties(attesterDuties []*beacon.Duty) []*beacon.Duty {
+	var duties []*beacon.Duty
+	for _, attesterDuty := range attesterDuties {
+		aggregatorDuty := *attesterDuty
+		aggregatorDuty.Type = beacon.RoleTypeAggregator
+		duties = append(duties, &aggregatorDuty)
+	}
+	return duties
+}
+
+// getAttesterDuties returns attester duties for the passed validators indices
+func (gc *goClient) getAttesterDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
 	if provider, isProvider := gc.client.(eth2client.AttesterDutiesProvider); isProvider {
 		attesterDuties, err := provider.AttesterDuties(gc.ctx, epoch, validatorIndices)
 		if err != nil {
@@ -150,6 +241,27 @@ func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.Validato
 	return nil, errors.New("client does not support AttesterDutiesProvider")
 }
 
+// getProposerDuties returns proposer duties for the passed validators indices
+func (gc *goClient) getProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	if provider, isProvider := gc.client.(eth2client.ProposerDutiesProvider); isProvider {
+		proposerDuties, err := provider.ProposerDuties(gc.ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		var duties []*beacon.Duty
+		for _, proposerDuty := range proposerDuties {
+			duties = append(duties, &beacon.Duty{
+				Type:           beacon.RoleTypeProposer,
+				PubKey:         proposerDuty.PubKey,
+				Slot:           proposerDuty.Slot,
+				ValidatorIndex: proposerDuty.ValidatorIndex,
+			})
+		}
+		return duties, nil
+	}
+	return nil, errors.New("client does not support ProposerDutiesProvider")
+}
+
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *goClient) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
 	if provider, isProvider := gc.client.(eth2client.ValidatorsProvider); isProvider {
@@ -186,3 +298,14 @@ func (gc *goClient) slotStartTime(slot uint64) time.Time {
 	startTime := time.Unix(int64(gc.network.MinGenesisTime()), 0).Add(duration)
 	return startTime
 }
+
+// beaconNodeAddrs splits the given (comma separated) beacon node addresses
+func beaconNodeAddrs(addr string) []string {
+	var addrs []string
+	for _, a := range strings.Split(addr, ",") {
+		if a = strings.TrimSpace(a); len(a) > 0 {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
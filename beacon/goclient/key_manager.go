@@ -1,14 +1,64 @@
 package goclient
 
 import (
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 )
 
+var errHandoverNotSupported = errors.New("key manager does not support share handover")
+
 func (gc *goClient) AddShare(shareKey *bls.SecretKey) error {
 	return gc.keyManager.AddShare(shareKey)
 }
 
+func (gc *goClient) RemoveShare(pubKey string) error {
+	return gc.keyManager.RemoveShare(pubKey)
+}
+
 func (gc *goClient) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	return gc.keyManager.SignIBFTMessage(message, pk)
 }
+
+// IsAttestationSlashable checks the attestation with the key manager, remote signers protect their keys by themselves
+func (gc *goClient) IsAttestationSlashable(data *spec.AttestationData, pk []byte) error {
+	if protector, ok := gc.keyManager.(beacon.SlashingProtector); ok {
+		return protector.IsAttestationSlashable(data, pk)
+	}
+	return nil
+}
+
+// IsBeaconBlockSlashable checks the block with the key manager, remote signers protect their keys by themselves
+func (gc *goClient) IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error {
+	if protector, ok := gc.keyManager.(beacon.SlashingProtector); ok {
+		return protector.IsBeaconBlockSlashable(block, pk)
+	}
+	return nil
+}
+
+// ExportShare returns the secret key of the given share, remote signers don't expose their keys
+func (gc *goClient) ExportShare(pk []byte) (*bls.SecretKey, error) {
+	if handover, ok := gc.keyManager.(beacon.ShareHandover); ok {
+		return handover.ExportShare(pk)
+	}
+	return nil, errHandoverNotSupported
+}
+
+// HighestSigned returns the slashing protection data of the given share
+func (gc *goClient) HighestSigned(pk []byte) (*beacon.HighestSigned, error) {
+	if handover, ok := gc.keyManager.(beacon.ShareHandover); ok {
+		return handover.HighestSigned(pk)
+	}
+	return nil, errHandoverNotSupported
+}
+
+// RaiseHighestSigned raises the slashing protection data of the given share
+func (gc *goClient) RaiseHighestSigned(pk []byte, highest *beacon.HighestSigned) error {
+	if handover, ok := gc.keyManager.(beacon.ShareHandover); ok {
+		return handover.RaiseHighestSigned(pk, highest)
+	}
+	return errHandoverNotSupported
+}
@@ -0,0 +1,84 @@
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// livenessResponse is the response of the beacon node liveness api
+type livenessResponse struct {
+	Data []struct {
+		Index  string `json:"index"`
+		Epoch  string `json:"epoch"`
+		IsLive bool   `json:"is_live"`
+	} `json:"data"`
+}
+
+// GetValidatorsLiveness returns whether the given validators were live in the given epoch,
+// it calls the standard liveness api (/eth/v1/validator/liveness/{epoch}) as go-eth2-client doesn't support it
+func (gc *goClient) GetValidatorsLiveness(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) (map[spec.ValidatorIndex]bool, error) {
+	indices := make([]string, len(validatorIndices))
+	for i, index := range validatorIndices {
+		indices[i] = strconv.FormatUint(uint64(index), 10)
+	}
+	body, err := json.Marshal(indices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal validator indices")
+	}
+
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.requestTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/eth/v1/validator/liveness/%d", gc.beaconNodeURL(), epoch)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create liveness request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call liveness api")
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read liveness response")
+	}
+	if res.StatusCode/100 != 2 {
+		return nil, errors.Errorf("liveness api failed with status %d: %s", res.StatusCode, string(data))
+	}
+
+	liveness := livenessResponse{}
+	if err := json.Unmarshal(data, &liveness); err != nil {
+		return nil, errors.Wrap(err, "failed to parse liveness response")
+	}
+	result := make(map[spec.ValidatorIndex]bool, len(liveness.Data))
+	for _, item := range liveness.Data {
+		index, err := strconv.ParseUint(item.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", item.Index)
+		}
+		result[spec.ValidatorIndex(index)] = item.IsLive
+	}
+	return result, nil
+}
+
+// beaconNodeURL returns the url of the beacon node, http is used if no scheme was provided
+func (gc *goClient) beaconNodeURL() string {
+	addr := strings.TrimSuffix(gc.beaconNodeAddr, "/")
+	if !strings.HasPrefix(addr, "http") {
+		addr = fmt.Sprintf("http://%s", addr)
+	}
+	return addr
+}
@@ -0,0 +1,43 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoClient_GetValidatorsLiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v1/validator/liveness/10" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		var indices []string
+		if err != nil || json.Unmarshal(body, &indices) != nil || len(indices) != 2 || indices[0] != "1" || indices[1] != "2" {
+			http.Error(w, "invalid indices", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","epoch":"10","is_live":true},{"index":"2","epoch":"10","is_live":false}]}`))
+	}))
+	defer server.Close()
+
+	gc := &goClient{ctx: context.Background(), beaconNodeAddr: server.URL, requestTimeout: requestTimeout}
+	liveness, err := gc.GetValidatorsLiveness(10, []spec.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[spec.ValidatorIndex]bool{1: true, 2: false}, liveness)
+
+	_, err = gc.GetValidatorsLiveness(11, []spec.ValidatorIndex{1})
+	require.Error(t, err)
+}
+
+func TestGoClient_beaconNodeURL(t *testing.T) {
+	require.Equal(t, "http://localhost:5052", (&goClient{beaconNodeAddr: "localhost:5052"}).beaconNodeURL())
+	require.Equal(t, "https://beacon.io", (&goClient{beaconNodeAddr: "https://beacon.io/"}).beaconNodeURL())
+}
@@ -0,0 +1,555 @@
+package goclient
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/beacon/goclient/web3signer"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// nodesHealthCheckInterval is the interval of beacon nodes health checks (and reconnection attempts)
+	nodesHealthCheckInterval = 6 * time.Second
+	// latencySmoothing is the weight of the last measured latency in the latency score
+	latencySmoothing = 0.3
+)
+
+// nodeClient is a client of a single beacon node
+type nodeClient interface {
+	beacon.Beacon
+	metrics.HealthCheckAgent
+	web3signer.ForkInfoProvider
+}
+
+// beaconNode holds the health of a beacon node
+type beaconNode struct {
+	addr   string
+	client nodeClient
+	synced bool
+	// latency is the smoothed response time of health checks
+	latency time.Duration
+	// failures is the count of consecutive failed calls
+	failures int
+}
+
+// better returns whether the node should be preferred over the given one,
+// synced nodes come first, then nodes with less consecutive failures and then faster nodes
+func (n *beaconNode) better(other *beaconNode) bool {
+	if n.synced != other.synced {
+		return n.synced
+	}
+	if n.failures != other.failures {
+		return n.failures < other.failures
+	}
+	return n.latency < other.latency
+}
+
+// nodeSnapshot is a connected node along with its client
+type nodeSnapshot struct {
+	node   *beaconNode
+	client nodeClient
+}
+
+// multiClient implements Beacon on top of several beacon nodes,
+// each call is routed to the best node and fails over to the other nodes on errors
+type multiClient struct {
+	ctx                   context.Context
+	logger                *zap.Logger
+	keyManager            beacon.KeyManager
+	connect               func(addr string) (nodeClient, error)
+	broadcastAttestations bool
+
+	nodes     []*beaconNode
+	indexMap  map[spec.ValidatorIndex]spec.BLSPubKey
+	nodesLock sync.RWMutex
+}
+
+// verifies that the client implements HealthCheckAgent
+var _ metrics.HealthCheckAgent = &multiClient{}
+
+// newMultiClient connects to the given beacon nodes, at least one node must be available.
+// nodes that are not available are reconnected in the background
+func newMultiClient(opt beacon.Options, addrs []string) (*multiClient, error) {
+	mc := &multiClient{
+		ctx:                   opt.Context,
+		logger:                opt.Logger.With(zap.String("component", "multiClient")),
+		broadcastAttestations: opt.BroadcastAttestations,
+		indexMap:              make(map[spec.ValidatorIndex]spec.BLSPubKey),
+	}
+	var err error
+	// the key manager is shared by all the nodes, domains are resolved by the best node
+	mc.keyManager, err = newKeyManager(opt, mc)
+	if err != nil {
+		return nil, err
+	}
+	mc.connect = func(addr string) (nodeClient, error) {
+		gc, err := newGoClient(opt, addr)
+		if err != nil {
+			return nil, err
+		}
+		gc.keyManager = mc.keyManager
+		return gc, nil
+	}
+	for _, addr := range addrs {
+		mc.nodes = append(mc.nodes, &beaconNode{addr: addr})
+	}
+
+	if err := mc.init(); err != nil {
+		return nil, err
+	}
+	go mc.monitor(nodesHealthCheckInterval)
+	return mc, nil
+}
+
+// init connects and checks the health of all the nodes
+func (mc *multiClient) init() error {
+	mc.checkNodes()
+	if len(mc.connectedNodes()) == 0 {
+		return errors.New("could not connect to any beacon node")
+	}
+	return nil
+}
+
+// monitor checks the health of the nodes until the context is done
+func (mc *multiClient) monitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mc.ctx.Done():
+			return
+		case <-ticker.C:
+			mc.checkNodes()
+		}
+	}
+}
+
+// checkNodes connects the disconnected nodes and updates the sync status and latency of the connected ones
+func (mc *multiClient) checkNodes() {
+	var wg sync.WaitGroup
+	for _, n := range mc.nodes {
+		wg.Add(1)
+		go func(n *beaconNode) {
+			defer wg.Done()
+			mc.checkNode(n)
+		}(n)
+	}
+	wg.Wait()
+
+	healthy := 0
+	mc.nodesLock.RLock()
+	for _, n := range mc.nodes {
+		if n.client != nil && n.synced {
+			healthy++
+		}
+	}
+	mc.nodesLock.RUnlock()
+	metricsBeaconNodesHealthy.Set(float64(healthy))
+}
+
+func (mc *multiClient) checkNode(n *beaconNode) {
+	logger := mc.logger.With(zap.String("addr", n.addr))
+
+	mc.nodesLock.RLock()
+	client := n.client
+	mc.nodesLock.RUnlock()
+	if client == nil {
+		c, err := mc.connect(n.addr)
+		if err != nil {
+			logger.Warn("could not connect to beacon node", zap.Error(err))
+			return
+		}
+		mc.nodesLock.Lock()
+		n.client = c
+		for index, pubKey := range mc.indexMap {
+			c.ExtendIndexMap(index, pubKey)
+		}
+		mc.nodesLock.Unlock()
+		client = c
+	}
+
+	start := time.Now()
+	issues := client.HealthCheck()
+	latency := time.Since(start)
+
+	mc.nodesLock.Lock()
+	defer mc.nodesLock.Unlock()
+	if n.latency == 0 {
+		n.latency = latency
+	} else {
+		n.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(n.latency))
+	}
+	n.synced = len(issues) == 0
+	if n.synced {
+		// failures decay with successful health checks, so a node can recover from transient errors
+		n.failures /= 2
+	} else {
+		logger.Warn("beacon node is not healthy", zap.Strings("issues", issues))
+	}
+}
+
+// connectedNodes returns the connected nodes, ordered from best to worst
+func (mc *multiClient) connectedNodes() []nodeSnapshot {
+	mc.nodesLock.RLock()
+	defer mc.nodesLock.RUnlock()
+
+	var nodes []*beaconNode
+	for _, n := range mc.nodes {
+		if n.client != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].better(nodes[j])
+	})
+	snapshots := make([]nodeSnapshot, len(nodes))
+	for i, n := range nodes {
+		snapshots[i] = nodeSnapshot{node: n, client: n.client}
+	}
+	return snapshots
+}
+
+// report updates the failures count of the given node by the result of a call
+func (mc *multiClient) report(n *beaconNode, err error) {
+	mc.nodesLock.Lock()
+	defer mc.nodesLock.Unlock()
+
+	if err != nil {
+		n.failures++
+	} else {
+		n.failures = 0
+	}
+}
+
+// call invokes the given function with the best node, other nodes are used (by order) if it fails
+func (mc *multiClient) call(method string, f func(client nodeClient) error) error {
+	nodes := mc.connectedNodes()
+	if len(nodes) == 0 {
+		return errors.New("no connected beacon node")
+	}
+	var err error
+	for _, n := range nodes {
+		err = f(n.client)
+		mc.report(n.node, err)
+		if err == nil {
+			return nil
+		}
+		mc.logger.Warn("beacon node call failed", zap.String("method", method),
+			zap.String("addr", n.node.addr), zap.Error(err))
+	}
+	return errors.Wrapf(err, "all beacon nodes failed to %s", method)
+}
+
+// broadcast invokes the given function with all the connected nodes concurrently, it succeeds if any of the calls succeeded
+func (mc *multiClient) broadcast(method string, f func(client nodeClient) error) error {
+	nodes := mc.connectedNodes()
+	if len(nodes) == 0 {
+		return errors.New("no connected beacon node")
+	}
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n nodeSnapshot) {
+			defer wg.Done()
+			errs[i] = f(n.client)
+			mc.report(n.node, errs[i])
+		}(i, n)
+	}
+	wg.Wait()
+
+	var err error
+	for i, e := range errs {
+		if e == nil {
+			return nil
+		}
+		mc.logger.Warn("beacon node call failed", zap.String("method", method),
+			zap.String("addr", nodes[i].node.addr), zap.Error(e))
+		err = e
+	}
+	return errors.Wrapf(err, "all beacon nodes failed to %s", method)
+}
+
+// HealthCheck provides health status of the beacon nodes, the client is healthy if any of the nodes is synced
+func (mc *multiClient) HealthCheck() []string {
+	mc.nodesLock.RLock()
+	defer mc.nodesLock.RUnlock()
+
+	for _, n := range mc.nodes {
+		if n.client != nil && n.synced {
+			return []string{}
+		}
+	}
+	return []string{"no synced beacon node"}
+}
+
+func (mc *multiClient) ExtendIndexMap(index spec.ValidatorIndex, pubKey spec.BLSPubKey) {
+	mc.nodesLock.Lock()
+	defer mc.nodesLock.Unlock()
+
+	mc.indexMap[index] = pubKey
+	for _, n := range mc.nodes {
+		if n.client != nil {
+			n.client.ExtendIndexMap(index, pubKey)
+		}
+	}
+}
+
+func (mc *multiClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	var duties []*beacon.Duty
+	err := mc.call("GetDuties", func(client nodeClient) (err error) {
+		duties, err = client.GetDuties(epoch, validatorIndices)
+		return err
+	})
+	return duties, err
+}
+
+func (mc *multiClient) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
+	var validators map[spec.ValidatorIndex]*api.Validator
+	err := mc.call("GetValidatorData", func(client nodeClient) (err error) {
+		validators, err = client.GetValidatorData(validatorPubKeys)
+		return err
+	})
+	return validators, err
+}
+
+func (mc *multiClient) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
+	var data *spec.AttestationData
+	err := mc.call("GetAttestationData", func(client nodeClient) (err error) {
+		data, err = client.GetAttestationData(slot, committeeIndex)
+		return err
+	})
+	return data, err
+}
+
+// SubmitAttestation submits the attestation to the best node, or to all the nodes if broadcast is enabled
+func (mc *multiClient) SubmitAttestation(attestation *spec.Attestation) error {
+	submit := func(client nodeClient) error {
+		return client.SubmitAttestation(attestation)
+	}
+	if mc.broadcastAttestations {
+		return mc.broadcast("SubmitAttestation", submit)
+	}
+	return mc.call("SubmitAttestation", submit)
+}
+
+func (mc *multiClient) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*ethspec.VersionedBeaconBlock, error) {
+	var block *ethspec.VersionedBeaconBlock
+	err := mc.call("GetBeaconBlock", func(client nodeClient) (err error) {
+		block, err = client.GetBeaconBlock(slot, randaoReveal)
+		return err
+	})
+	return block, err
+}
+
+func (mc *multiClient) SubmitBeaconBlock(block *ethspec.VersionedSignedBeaconBlock) error {
+	return mc.call("SubmitBeaconBlock", func(client nodeClient) error {
+		return client.SubmitBeaconBlock(block)
+	})
+}
+
+func (mc *multiClient) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Attestation, error) {
+	var aggregate *spec.Attestation
+	err := mc.call("GetAggregateAttestation", func(client nodeClient) (err error) {
+		aggregate, err = client.GetAggregateAttestation(slot, committeeIndex)
+		return err
+	})
+	return aggregate, err
+}
+
+func (mc *multiClient) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	return mc.call("SubmitSignedAggregateSelectionProof", func(client nodeClient) error {
+		return client.SubmitSignedAggregateSelectionProof(msg)
+	})
+}
+
+func (mc *multiClient) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
+	return mc.call("SubscribeToCommitteeSubnet", func(client nodeClient) error {
+		return client.SubscribeToCommitteeSubnet(subscription)
+	})
+}
+
+func (mc *multiClient) GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	var duties []*beacon.Duty
+	err := mc.call("GetSyncCommitteeDuties", func(client nodeClient) (err error) {
+		duties, err = client.GetSyncCommitteeDuties(epoch, validatorIndices)
+		return err
+	})
+	return duties, err
+}
+
+func (mc *multiClient) GetSyncMessageBlockRoot(slot spec.Slot) (spec.Root, error) {
+	var root spec.Root
+	err := mc.call("GetSyncMessageBlockRoot", func(client nodeClient) (err error) {
+		root, err = client.GetSyncMessageBlockRoot(slot)
+		return err
+	})
+	return root, err
+}
+
+func (mc *multiClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	return mc.call("SubmitSyncMessage", func(client nodeClient) error {
+		return client.SubmitSyncMessage(msg)
+	})
+}
+
+func (mc *multiClient) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	var contribution *altair.SyncCommitteeContribution
+	err := mc.call("GetSyncCommitteeContribution", func(client nodeClient) (err error) {
+		contribution, err = client.GetSyncCommitteeContribution(slot, subcommitteeIndex, blockRoot)
+		return err
+	})
+	return contribution, err
+}
+
+func (mc *multiClient) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	return mc.call("SubmitSignedContributionAndProof", func(client nodeClient) error {
+		return client.SubmitSignedContributionAndProof(msg)
+	})
+}
+
+func (mc *multiClient) SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error {
+	return mc.call("SubscribeToSyncCommitteeSubnet", func(client nodeClient) error {
+		return client.SubscribeToSyncCommitteeSubnet(subscriptions)
+	})
+}
+
+func (mc *multiClient) GetValidatorsLiveness(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) (map[spec.ValidatorIndex]bool, error) {
+	var liveness map[spec.ValidatorIndex]bool
+	err := mc.call("GetValidatorsLiveness", func(client nodeClient) (err error) {
+		liveness, err = client.GetValidatorsLiveness(epoch, validatorIndices)
+		return err
+	})
+	return liveness, err
+}
+
+// GetDomain returns the domain of the given attestation data
+func (mc *multiClient) GetDomain(data *spec.AttestationData) ([]byte, error) {
+	var domain []byte
+	err := mc.call("GetDomain", func(client nodeClient) (err error) {
+		domain, err = client.GetDomain(data)
+		return err
+	})
+	return domain, err
+}
+
+// GetDomainByType returns the domain of the given type at the given epoch
+func (mc *multiClient) GetDomainByType(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	var domain []byte
+	err := mc.call("GetDomainByType", func(client nodeClient) (err error) {
+		domain, err = client.GetDomainByType(domainType, epoch)
+		return err
+	})
+	return domain, err
+}
+
+// ForkInfo returns the fork of the given epoch and the genesis validators root
+func (mc *multiClient) ForkInfo(epoch spec.Epoch) (*web3signer.ForkInfo, error) {
+	var forkInfo *web3signer.ForkInfo
+	err := mc.call("ForkInfo", func(client nodeClient) (err error) {
+		forkInfo, err = client.ForkInfo(epoch)
+		return err
+	})
+	return forkInfo, err
+}
+
+// ComputeSigningRoot computes the signing root of the given object, no beacon node is needed
+func (mc *multiClient) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
+	return (&goClient{}).ComputeSigningRoot(object, domain)
+}
+
+func (mc *multiClient) AddShare(shareKey *bls.SecretKey) error {
+	return mc.keyManager.AddShare(shareKey)
+}
+
+func (mc *multiClient) RemoveShare(pubKey string) error {
+	return mc.keyManager.RemoveShare(pubKey)
+}
+
+func (mc *multiClient) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
+	return mc.keyManager.SignIBFTMessage(message, pk)
+}
+
+func (mc *multiClient) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	return mc.keyManager.SignAttestation(data, duty, pk)
+}
+
+func (mc *multiClient) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	return mc.keyManager.SignRandaoReveal(epoch, pk)
+}
+
+func (mc *multiClient) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	return mc.keyManager.SignBeaconBlock(block, duty, pk)
+}
+
+func (mc *multiClient) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	return mc.keyManager.SignSlot(slot, pk)
+}
+
+func (mc *multiClient) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return mc.keyManager.SignAggregateAndProof(msg, duty, pk)
+}
+
+func (mc *multiClient) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return mc.keyManager.SignSyncCommitteeBlockRoot(slot, root, validatorIndex, pk)
+}
+
+func (mc *multiClient) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	return mc.keyManager.SignSyncCommitteeSelectionProof(slot, subcommitteeIndex, pk)
+}
+
+func (mc *multiClient) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return mc.keyManager.SignContributionAndProof(msg, pk)
+}
+
+// IsAttestationSlashable checks the attestation with the key manager, remote signers protect their keys by themselves
+func (mc *multiClient) IsAttestationSlashable(data *spec.AttestationData, pk []byte) error {
+	if protector, ok := mc.keyManager.(beacon.SlashingProtector); ok {
+		return protector.IsAttestationSlashable(data, pk)
+	}
+	return nil
+}
+
+// IsBeaconBlockSlashable checks the block with the key manager, remote signers protect their keys by themselves
+func (mc *multiClient) IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error {
+	if protector, ok := mc.keyManager.(beacon.SlashingProtector); ok {
+		return protector.IsBeaconBlockSlashable(block, pk)
+	}
+	return nil
+}
+
+// ExportShare returns the secret key of the given share, remote signers don't expose their keys
+func (mc *multiClient) ExportShare(pk []byte) (*bls.SecretKey, error) {
+	if handover, ok := mc.keyManager.(beacon.ShareHandover); ok {
+		return handover.ExportShare(pk)
+	}
+	return nil, errHandoverNotSupported
+}
+
+// HighestSigned returns the slashing protection data of the given share
+func (mc *multiClient) HighestSigned(pk []byte) (*beacon.HighestSigned, error) {
+	if handover, ok := mc.keyManager.(beacon.ShareHandover); ok {
+		return handover.HighestSigned(pk)
+	}
+	return nil, errHandoverNotSupported
+}
+
+// RaiseHighestSigned raises the slashing protection data of the given share
+func (mc *multiClient) RaiseHighestSigned(pk []byte, highest *beacon.HighestSigned) error {
+	if handover, ok := mc.keyManager.(beacon.ShareHandover); ok {
+		return handover.RaiseHighestSigned(pk, highest)
+	}
+	return errHandoverNotSupported
+}
@@ -0,0 +1,184 @@
+package goclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/beacon/goclient/web3signer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testNode is a beacon node stand-in, methods that are not overridden panic
+type testNode struct {
+	beacon.Beacon
+	name        string
+	issues      []string
+	delay       time.Duration
+	err         error
+	submissions int
+	indexMap    map[spec.ValidatorIndex]spec.BLSPubKey
+	lock        sync.Mutex
+}
+
+func (n *testNode) HealthCheck() []string {
+	time.Sleep(n.delay)
+	return n.issues
+}
+
+func (n *testNode) ForkInfo(epoch spec.Epoch) (*web3signer.ForkInfo, error) {
+	return &web3signer.ForkInfo{Fork: &spec.Fork{Epoch: epoch}}, nil
+}
+
+func (n *testNode) ExtendIndexMap(index spec.ValidatorIndex, pubKey spec.BLSPubKey) {
+	n.indexMap[index] = pubKey
+}
+
+func (n *testNode) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	return &spec.AttestationData{Slot: slot, Index: committeeIndex, BeaconBlockRoot: spec.Root{byte(len(n.name))}}, nil
+}
+
+func (n *testNode) SubmitAttestation(attestation *spec.Attestation) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.submissions++
+	return n.err
+}
+
+func newTestMultiClient(nodes map[string]*testNode, broadcast bool) *multiClient {
+	mc := &multiClient{
+		ctx:                   context.Background(),
+		logger:                zap.L(),
+		broadcastAttestations: broadcast,
+		indexMap:              make(map[spec.ValidatorIndex]spec.BLSPubKey),
+		connect: func(addr string) (nodeClient, error) {
+			if n, ok := nodes[addr]; ok {
+				return n, nil
+			}
+			return nil, errors.New("connection refused")
+		},
+	}
+	for _, addr := range []string{"a", "bb", "ccc"} {
+		mc.nodes = append(mc.nodes, &beaconNode{addr: addr})
+	}
+	return mc
+}
+
+func TestMultiClient_HealthScore(t *testing.T) {
+	nodes := map[string]*testNode{
+		"a":   {name: "a", issues: []string{"beacon node is currently syncing"}},
+		"bb":  {name: "bb", delay: time.Millisecond * 50},
+		"ccc": {name: "ccc", delay: time.Millisecond},
+	}
+	mc := newTestMultiClient(nodes, false)
+	require.NoError(t, mc.init())
+	require.Empty(t, mc.HealthCheck())
+
+	// synced and faster node is preferred
+	data, err := mc.GetAttestationData(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, spec.Root{3}, data.BeaconBlockRoot)
+
+	// failed node is replaced by the next best node
+	nodes["ccc"].err = errors.New("internal error")
+	data, err = mc.GetAttestationData(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, spec.Root{2}, data.BeaconBlockRoot)
+	ordered := mc.connectedNodes()
+	require.Equal(t, "bb", ordered[0].node.addr)
+	require.Equal(t, "ccc", ordered[1].node.addr)
+	require.Equal(t, "a", ordered[2].node.addr)
+
+	// all nodes failed
+	nodes["a"].err = errors.New("internal error")
+	nodes["bb"].err = errors.New("internal error")
+	_, err = mc.GetAttestationData(1, 2)
+	require.EqualError(t, err, "all beacon nodes failed to GetAttestationData: internal error")
+}
+
+func TestMultiClient_Reconnect(t *testing.T) {
+	nodes := map[string]*testNode{
+		"a": {name: "a", indexMap: map[spec.ValidatorIndex]spec.BLSPubKey{}},
+	}
+	mc := newTestMultiClient(nodes, false)
+	require.NoError(t, mc.init())
+	require.Len(t, mc.connectedNodes(), 1)
+	mc.ExtendIndexMap(1, spec.BLSPubKey{1})
+
+	// node is connected by the next health check, and gets the index map
+	nodes["bb"] = &testNode{name: "bb", indexMap: map[spec.ValidatorIndex]spec.BLSPubKey{}}
+	mc.checkNodes()
+	require.Len(t, mc.connectedNodes(), 2)
+	require.Equal(t, map[spec.ValidatorIndex]spec.BLSPubKey{1: {1}}, nodes["bb"].indexMap)
+
+	t.Run("no node", func(t *testing.T) {
+		mc := newTestMultiClient(map[string]*testNode{}, false)
+		require.EqualError(t, mc.init(), "could not connect to any beacon node")
+		require.Equal(t, []string{"no synced beacon node"}, mc.HealthCheck())
+	})
+}
+
+func TestMultiClient_SubmitAttestation(t *testing.T) {
+	t.Run("best node", func(t *testing.T) {
+		nodes := map[string]*testNode{"a": {name: "a"}, "bb": {name: "bb"}, "ccc": {name: "ccc"}}
+		mc := newTestMultiClient(nodes, false)
+		require.NoError(t, mc.init())
+		require.NoError(t, mc.SubmitAttestation(&spec.Attestation{}))
+		require.Equal(t, 1, nodes["a"].submissions+nodes["bb"].submissions+nodes["ccc"].submissions)
+	})
+
+	t.Run("broadcast", func(t *testing.T) {
+		nodes := map[string]*testNode{"a": {name: "a"}, "bb": {name: "bb", err: errors.New("internal error")}, "ccc": {name: "ccc"}}
+		mc := newTestMultiClient(nodes, true)
+		require.NoError(t, mc.init())
+		require.NoError(t, mc.SubmitAttestation(&spec.Attestation{}))
+		require.Equal(t, 1, nodes["a"].submissions)
+		require.Equal(t, 1, nodes["bb"].submissions)
+		require.Equal(t, 1, nodes["ccc"].submissions)
+
+		nodes["a"].err = errors.New("internal error")
+		nodes["ccc"].err = errors.New("internal error")
+		require.EqualError(t, mc.SubmitAttestation(&spec.Attestation{}), "all beacon nodes failed to SubmitAttestation: internal error")
+	})
+}
+
+func TestMultiClient_HTTPFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","epoch":"10","is_live":true}]}`))
+	}))
+	defer up.Close()
+
+	mc := &multiClient{ctx: context.Background(), logger: zap.L()}
+	for _, addr := range []string{down.URL, up.URL} {
+		mc.nodes = append(mc.nodes, &beaconNode{
+			addr:   addr,
+			client: &goClient{ctx: context.Background(), beaconNodeAddr: addr, requestTimeout: requestTimeout},
+		})
+	}
+
+	liveness, err := mc.GetValidatorsLiveness(10, []spec.ValidatorIndex{1})
+	require.NoError(t, err)
+	require.Equal(t, map[spec.ValidatorIndex]bool{1: true}, liveness)
+	require.Equal(t, 1, mc.nodes[0].failures)
+	require.Equal(t, 0, mc.nodes[1].failures)
+	require.Equal(t, up.URL, mc.connectedNodes()[0].node.addr)
+}
+
+func TestBeaconNodeAddrs(t *testing.T) {
+	require.Equal(t, []string{"localhost:5052"}, beaconNodeAddrs("localhost:5052"))
+	require.Equal(t, []string{"localhost:5052", "http://10.0.0.1:5052"}, beaconNodeAddrs("localhost:5052, http://10.0.0.1:5052,"))
+}
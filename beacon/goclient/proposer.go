@@ -0,0 +1,52 @@
+package goclient
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+)
+
+// GetBeaconBlock returns a block proposal for the given slot and randao reveal, phase0 and altair blocks are supported
+func (gc *goClient) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*ethspec.VersionedBeaconBlock, error) {
+	if provider, isProvider := gc.client.(eth2client.BeaconBlockProposalProvider); isProvider {
+		block, err := provider.BeaconBlockProposal(gc.ctx, slot, randaoReveal, gc.graffiti)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, errors.New("received empty block proposal")
+		}
+		switch block.Version {
+		case ethspec.DataVersionPhase0:
+			if block.Phase0 == nil {
+				return nil, errors.New("received empty phase0 block proposal")
+			}
+		case ethspec.DataVersionAltair:
+			if block.Altair == nil {
+				return nil, errors.New("received empty altair block proposal")
+			}
+		default:
+			return nil, errors.Errorf("block version %d is not supported", block.Version)
+		}
+		return block, nil
+	}
+	return nil, errors.New("client does not support BeaconBlockProposalProvider")
+}
+
+func (gc *goClient) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	return gc.keyManager.SignRandaoReveal(epoch, pk)
+}
+
+func (gc *goClient) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	return gc.keyManager.SignBeaconBlock(block, duty, pk)
+}
+
+// SubmitBeaconBlock implements Beacon interface
+func (gc *goClient) SubmitBeaconBlock(block *ethspec.VersionedSignedBeaconBlock) error {
+	if provider, isProvider := gc.client.(eth2client.BeaconBlockSubmitter); isProvider {
+		return provider.SubmitBeaconBlock(gc.ctx, block)
+	}
+	return errors.New("client does not support BeaconBlockSubmitter")
+}
@@ -23,47 +23,43 @@ func (gc *goClient) getSigningRoot(data *phase0spec.AttestationData) ([32]byte,
 	return root, nil
 }
 
-// getSigningRoot returns signing root
+// GetDomain returns the domain of the given attestation data
 func (gc *goClient) GetDomain(data *phase0spec.AttestationData) ([]byte, error) {
 	epoch := gc.network.EstimatedEpochAtSlot(types.Slot(data.Slot))
-	domainType, err := gc.getDomainType(beacon.RoleTypeAttester)
+	return gc.GetDomainByType(beacon.DomainBeaconAttester, phase0spec.Epoch(epoch))
+}
+
+// GetDomainByType returns the domain of the given type at the given epoch
+func (gc *goClient) GetDomainByType(domainType beacon.DomainType, epoch phase0spec.Epoch) ([]byte, error) {
+	dt, err := gc.getDomainType(domainType)
 	if err != nil {
 		return nil, err
 	}
-	domain, err := gc.getDomainData(domainType, phase0spec.Epoch(epoch))
+	domain, err := gc.getDomainData(dt, epoch)
 	if err != nil {
 		return nil, err
 	}
 	return domain[:], nil
 }
 
-// getDomainType returns domain type by role type
-func (gc *goClient) getDomainType(roleType beacon.RoleType) (*phase0spec.DomainType, error) {
+// getDomainType returns the value of the given domain type from the node's spec
+func (gc *goClient) getDomainType(domainType beacon.DomainType) (*phase0spec.DomainType, error) {
 	if provider, isProvider := gc.client.(eth2client.SpecProvider); isProvider {
 		spec, err := provider.Spec(gc.ctx)
 		if err != nil {
 			return nil, err
 		}
-		var val interface{}
-		var exists bool
-		switch roleType {
-		case beacon.RoleTypeAttester:
-			val, exists = spec["DOMAIN_BEACON_ATTESTER"]
-		case beacon.RoleTypeAggregator:
-			val, exists = spec["DOMAIN_AGGREGATE_AND_PROOF"]
-		case beacon.RoleTypeProposer:
-			val, exists = spec["DOMAIN_BEACON_PROPOSER"]
-		default:
-			return nil, errors.New("role type domain is not implemented")
-		}
-
+		val, exists := spec[string(domainType)]
 		if !exists {
 			return nil, errors.New("spec type is missing")
 		}
-		res := val.(phase0spec.DomainType)
+		res, ok := val.(phase0spec.DomainType)
+		if !ok {
+			return nil, errors.Errorf("spec type %s is not a domain type", domainType)
+		}
 		return &res, nil
 	}
-	return nil, errors.New("client does not support BeaconAttesterDomainProvider")
+	return nil, errors.New("client does not support SpecProvider")
 }
 
 // getDomainData return domain data by domain type
@@ -0,0 +1,147 @@
+package goclient
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+)
+
+// farFutureEpoch is the max epoch value
+const farFutureEpoch = ^uint64(0)
+
+// GetSyncCommitteeDuties returns the sync committee duties of the passed validators indices for each slot of the given epoch
+func (gc *goClient) GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	altairEpoch, err := gc.altairForkEpoch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get altair fork epoch")
+	}
+	if epoch < altairEpoch {
+		return nil, nil
+	}
+	if provider, isProvider := gc.client.(eth2client.SyncCommitteeDutiesProvider); isProvider {
+		syncCommitteeDuties, err := provider.SyncCommitteeDuties(gc.ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		return toSyncCommitteeDuties(syncCommitteeDuties, epoch, gc.network.SlotsPerEpoch()), nil
+	}
+	return nil, errors.New("client does not support SyncCommitteeDutiesProvider")
+}
+
+// toSyncCommitteeDuties returns a sync committee message duty and a potential contribution duty
+// for each of the given sync committee members in each slot of the given epoch,
+// the selection proof that is computed during the duty execution determines whether the validator is an aggregator
+func toSyncCommitteeDuties(syncCommitteeDuties []*api.SyncCommitteeDuty, epoch spec.Epoch, slotsPerEpoch uint64) []*beacon.Duty {
+	var duties []*beacon.Duty
+	firstSlot := uint64(epoch) * slotsPerEpoch
+	for _, syncCommitteeDuty := range syncCommitteeDuties {
+		if len(syncCommitteeDuty.ValidatorSyncCommitteeIndices) == 0 {
+			continue
+		}
+		for slot := firstSlot; slot < firstSlot+slotsPerEpoch; slot++ {
+			for _, role := range []beacon.RoleType{beacon.RoleTypeSyncCommittee, beacon.RoleTypeSyncCommitteeContribution} {
+				duties = append(duties, &beacon.Duty{
+					Type:                          role,
+					PubKey:                        syncCommitteeDuty.PubKey,
+					Slot:                          spec.Slot(slot),
+					ValidatorIndex:                syncCommitteeDuty.ValidatorIndex,
+					ValidatorSyncCommitteeIndices: syncCommitteeDuty.ValidatorSyncCommitteeIndices,
+				})
+			}
+		}
+	}
+	return duties
+}
+
+// GetSyncMessageBlockRoot returns the root of the head block, once the block of the given slot arrived or a third of the slot has passed
+func (gc *goClient) GetSyncMessageBlockRoot(slot spec.Slot) (spec.Root, error) {
+	if provider, isProvider := gc.client.(eth2client.BeaconBlockRootProvider); isProvider {
+		gc.waitOneThirdOrValidBlock(uint64(slot))
+		root, err := provider.BeaconBlockRoot(gc.ctx, "head")
+		if err != nil {
+			return spec.Root{}, err
+		}
+		if root == nil {
+			return spec.Root{}, errors.New("received empty block root")
+		}
+		return *root, nil
+	}
+	return spec.Root{}, errors.New("client does not support BeaconBlockRootProvider")
+}
+
+func (gc *goClient) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return gc.keyManager.SignSyncCommitteeBlockRoot(slot, root, validatorIndex, pk)
+}
+
+// SubmitSyncMessage implements Beacon interface
+func (gc *goClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	if provider, isProvider := gc.client.(eth2client.SyncCommitteeMessagesSubmitter); isProvider {
+		return provider.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg})
+	}
+	return errors.New("client does not support SyncCommitteeMessagesSubmitter")
+}
+
+func (gc *goClient) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	return gc.keyManager.SignSyncCommitteeSelectionProof(slot, subcommitteeIndex, pk)
+}
+
+// GetSyncCommitteeContribution returns the sync committee contribution once two-thirds of the slot have passed
+func (gc *goClient) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	if provider, isProvider := gc.client.(eth2client.SyncCommitteeContributionProvider); isProvider {
+		gc.waitToSlotTwoThirds(uint64(slot))
+		contribution, err := provider.SyncCommitteeContribution(gc.ctx, slot, subcommitteeIndex, blockRoot)
+		if err != nil {
+			return nil, err
+		}
+		if contribution == nil {
+			return nil, errors.New("received empty sync committee contribution")
+		}
+		return contribution, nil
+	}
+	return nil, errors.New("client does not support SyncCommitteeContributionProvider")
+}
+
+func (gc *goClient) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return gc.keyManager.SignContributionAndProof(msg, pk)
+}
+
+// SubmitSignedContributionAndProof implements Beacon interface
+func (gc *goClient) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	if provider, isProvider := gc.client.(eth2client.SyncCommitteeContributionsSubmitter); isProvider {
+		return provider.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{msg})
+	}
+	return errors.New("client does not support SyncCommitteeContributionsSubmitter")
+}
+
+// SubscribeToSyncCommitteeSubnet is implementation for subscribing sync committee members to subnets (p2p topics)
+func (gc *goClient) SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error {
+	if provider, isProvider := gc.client.(eth2client.SyncCommitteeSubscriptionsSubmitter); isProvider {
+		return provider.SubmitSyncCommitteeSubscriptions(gc.ctx, subscriptions)
+	}
+	return errors.New("client does not support SyncCommitteeSubscriptionsSubmitter")
+}
+
+// altairForkEpoch returns the altair fork epoch from the node's spec,
+// nodes that are not aware of altair are treated as if the fork is never reached
+func (gc *goClient) altairForkEpoch() (spec.Epoch, error) {
+	if provider, isProvider := gc.client.(eth2client.SpecProvider); isProvider {
+		nodeSpec, err := provider.Spec(gc.ctx)
+		if err != nil {
+			return 0, err
+		}
+		val, exists := nodeSpec["ALTAIR_FORK_EPOCH"]
+		if !exists {
+			gc.logger.Debug("altair fork epoch is missing in node spec")
+			return spec.Epoch(farFutureEpoch), nil
+		}
+		epoch, ok := val.(uint64)
+		if !ok {
+			return 0, errors.New("altair fork epoch is not a number")
+		}
+		return spec.Epoch(epoch), nil
+	}
+	return 0, errors.New("client does not support SpecProvider")
+}
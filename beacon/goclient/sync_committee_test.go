@@ -0,0 +1,36 @@
+package goclient
+
+import (
+	"testing"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSyncCommitteeDuties(t *testing.T) {
+	syncCommitteeDuties := []*api.SyncCommitteeDuty{
+		{
+			PubKey:                        spec.BLSPubKey{1},
+			ValidatorIndex:                1,
+			ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{3, 200},
+		},
+		{
+			PubKey:         spec.BLSPubKey{2},
+			ValidatorIndex: 2,
+		},
+	}
+
+	duties := toSyncCommitteeDuties(syncCommitteeDuties, 2, 4)
+	// a message and a contribution duty for each slot of the epoch, validators w/o indices are ignored
+	require.Len(t, duties, 8)
+	for i, duty := range duties {
+		require.EqualValues(t, 8+i/2, duty.Slot)
+		require.EqualValues(t, 1, duty.ValidatorIndex)
+		require.Equal(t, spec.BLSPubKey{1}, duty.PubKey)
+		require.Equal(t, []spec.CommitteeIndex{3, 200}, duty.ValidatorSyncCommitteeIndices)
+	}
+	require.Equal(t, beacon.RoleTypeSyncCommittee, duties[0].Type)
+	require.Equal(t, beacon.RoleTypeSyncCommitteeContribution, duties[1].Type)
+}
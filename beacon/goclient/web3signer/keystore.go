@@ -0,0 +1,33 @@
+package web3signer
+
+import (
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// keystore is an EIP-2335 keystore, the format that remote signers import keys with
+type keystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	Pubkey      string                 `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        string                 `json:"uuid"`
+	Version     uint                   `json:"version"`
+}
+
+// encryptKeystore encrypts the given share key with the given password
+func encryptKeystore(sk *bls.SecretKey, password string) (*keystore, error) {
+	encryptor := keystorev4.New(keystorev4.WithCipher("pbkdf2"))
+	crypto, err := encryptor.Encrypt(sk.Serialize(), password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt share key")
+	}
+	return &keystore{
+		Crypto:  crypto,
+		Pubkey:  sk.GetPublicKey().SerializeToHexStr(),
+		UUID:    uuid.New().String(),
+		Version: encryptor.Version(),
+	}, nil
+}
@@ -0,0 +1,63 @@
+package web3signer
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// signRequest is the body of a signing request, only the object of the given type is set
+type signRequest struct {
+	Type                        string                              `json:"type"`
+	ForkInfo                    *ForkInfo                           `json:"fork_info,omitempty"`
+	SigningRoot                 string                              `json:"signingRoot"`
+	Attestation                 *spec.AttestationData               `json:"attestation,omitempty"`
+	Block                       *spec.BeaconBlock                   `json:"block,omitempty"`
+	BeaconBlock                 *beaconBlockV2                      `json:"beacon_block,omitempty"`
+	RandaoReveal                *randaoReveal                       `json:"randao_reveal,omitempty"`
+	AggregationSlot             *aggregationSlot                    `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           *spec.AggregateAndProof             `json:"aggregate_and_proof,omitempty"`
+	SyncCommitteeMessage        *syncCommitteeMessage               `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *altair.SyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof        `json:"contribution_and_proof,omitempty"`
+}
+
+// beaconBlockV2 is the versioned block of BLOCK_V2 signing requests, used for blocks after phase0
+type beaconBlockV2 struct {
+	Version string              `json:"version"`
+	Block   *altair.BeaconBlock `json:"block"`
+}
+
+type randaoReveal struct {
+	Epoch string `json:"epoch"`
+}
+
+type aggregationSlot struct {
+	Slot string `json:"slot"`
+}
+
+type syncCommitteeMessage struct {
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Slot            string `json:"slot"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type importKeystoresRequest struct {
+	Keystores []string `json:"keystores"`
+	Passwords []string `json:"passwords"`
+}
+
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type keystoresResponse struct {
+	Data []*keystoreStatus `json:"data"`
+}
+
+type keystoreStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
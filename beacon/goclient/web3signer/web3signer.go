@@ -0,0 +1,426 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	upcheckPath   = "/upcheck"
+	signPath      = "/api/v1/eth2/sign/"
+	keystoresPath = "/eth/v1/keystores"
+)
+
+// ErrIBFTNotSupported is returned when signing iBFT messages, the signer signs only beacon objects
+var ErrIBFTNotSupported = errors.New("iBFT messages can't be signed by the remote signer")
+
+// Options are the options of the remote signer
+type Options struct {
+	Context context.Context
+	Logger  *zap.Logger
+	// Addr is the base url of the Web3Signer compatible API
+	Addr    string
+	Timeout time.Duration
+	Network beacon.Network
+}
+
+// ForkInfo is the fork information that the signer needs in order to verify the signing root of beacon objects
+type ForkInfo struct {
+	Fork                  *spec.Fork
+	GenesisValidatorsRoot spec.Root
+}
+
+// MarshalJSON implements json.Marshaler
+func (fi *ForkInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Fork                  *spec.Fork `json:"fork"`
+		GenesisValidatorsRoot string     `json:"genesis_validators_root"`
+	}{
+		Fork:                  fi.Fork,
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", fi.GenesisValidatorsRoot),
+	})
+}
+
+// ForkInfoProvider provides the fork information of a given epoch
+type ForkInfoProvider interface {
+	// ForkInfo returns the fork of the given epoch and the genesis validators root
+	ForkInfo(epoch spec.Epoch) (*ForkInfo, error)
+}
+
+// remoteSigner implements beacon.KeyManager on top of a remote (Web3Signer compatible) signer,
+// share keys are kept and used only by the signer, which is also responsible for slashing protection
+type remoteSigner struct {
+	ctx              context.Context
+	logger           *zap.Logger
+	addr             string
+	httpClient       *http.Client
+	signingUtils     beacon.SigningUtil
+	forkInfoProvider ForkInfoProvider
+	network          beacon.Network
+}
+
+// New creates a new remote signer, the signer must be reachable
+func New(opts Options, signingUtils beacon.SigningUtil, forkInfoProvider ForkInfoProvider) (beacon.KeyManager, error) {
+	if len(opts.Addr) == 0 {
+		return nil, errors.New("missing remote signer address")
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rs := &remoteSigner{
+		ctx:              ctx,
+		logger:           opts.Logger.With(zap.String("component", "web3signer"), zap.String("address", opts.Addr)),
+		addr:             strings.TrimSuffix(opts.Addr, "/"),
+		httpClient:       &http.Client{Timeout: timeout},
+		signingUtils:     signingUtils,
+		forkInfoProvider: forkInfoProvider,
+		network:          opts.Network,
+	}
+	if _, err := rs.request(http.MethodGet, upcheckPath, nil); err != nil {
+		return nil, errors.Wrap(err, "remote signer is not available")
+	}
+	rs.logger.Info("connected to remote signer")
+	return rs, nil
+}
+
+// AddShare imports the given share key into the signer, shares that were already imported are skipped
+func (rs *remoteSigner) AddShare(shareKey *bls.SecretKey) error {
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return errors.Wrap(err, "could not generate keystore password")
+	}
+	ks, err := encryptKeystore(shareKey, hex.EncodeToString(password))
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt share")
+	}
+	ksJSON, err := json.Marshal(ks)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal keystore")
+	}
+	res, err := rs.keystoresRequest(http.MethodPost, &importKeystoresRequest{
+		Keystores: []string{string(ksJSON)},
+		Passwords: []string{hex.EncodeToString(password)},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not import share")
+	}
+	switch res.Status {
+	case "imported", "duplicate":
+		return nil
+	default:
+		return errors.Errorf("could not import share: %s %s", res.Status, res.Message)
+	}
+}
+
+// RemoveShare deletes the given share public key (hex) from the signer,
+// the signer keeps its slashing protection data in case the share will be added again
+func (rs *remoteSigner) RemoveShare(pubKey string) error {
+	res, err := rs.keystoresRequest(http.MethodDelete, &deleteKeystoresRequest{
+		Pubkeys: []string{"0x" + strings.TrimPrefix(pubKey, "0x")},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not delete share")
+	}
+	switch res.Status {
+	case "deleted", "not_found", "not_active":
+		return nil
+	default:
+		return errors.Errorf("could not delete share: %s %s", res.Status, res.Message)
+	}
+}
+
+// SignIBFTMessage always fails, iBFT messages are not beacon objects and the signer accepts only
+// signing requests of a known beacon type, share keys are never kept locally to sign them instead
+func (rs *remoteSigner) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
+	return nil, ErrIBFTNotSupported
+}
+
+func (rs *remoteSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	sig, root, err := rs.signObject(pk, data, beacon.DomainBeaconAttester, rs.epochAtSlot(data.Slot), &signRequest{
+		Type:        "ATTESTATION",
+		Attestation: data,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign attestation")
+	}
+
+	aggregationBitfield := bitfield.NewBitlist(duty.CommitteeLength)
+	aggregationBitfield.SetBitAt(duty.ValidatorCommitteeIndex, true)
+	return &spec.Attestation{
+		AggregationBits: aggregationBitfield,
+		Data:            data,
+		Signature:       sig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	sig, root, err := rs.signObject(pk, types.Epoch(epoch), beacon.DomainRandao, epoch, &signRequest{
+		Type:         "RANDAO_REVEAL",
+		RandaoReveal: &randaoReveal{Epoch: fmt.Sprintf("%d", epoch)},
+	})
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	var object interface{}
+	req := &signRequest{}
+	switch {
+	case block.Version == ethspec.DataVersionPhase0 && block.Phase0 != nil:
+		object = block.Phase0
+		req.Type = "BLOCK"
+		req.Block = block.Phase0
+	case block.Version == ethspec.DataVersionAltair && block.Altair != nil:
+		object = block.Altair
+		req.Type = "BLOCK_V2"
+		req.BeaconBlock = &beaconBlockV2{Version: block.Version.String(), Block: block.Altair}
+	default:
+		return nil, nil, errors.Errorf("no block of version %d", block.Version)
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid beacon block")
+	}
+	sig, root, err := rs.signObject(pk, object, beacon.DomainBeaconProposer, rs.epochAtSlot(slot), req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign beacon block")
+	}
+	signed, err := beacon.NewSignedBeaconBlock(block, sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signed, root, nil
+}
+
+func (rs *remoteSigner) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	sig, root, err := rs.signObject(pk, types.Slot(slot), beacon.DomainSelectionProof, rs.epochAtSlot(slot), &signRequest{
+		Type:            "AGGREGATION_SLOT",
+		AggregationSlot: &aggregationSlot{Slot: fmt.Sprintf("%d", slot)},
+	})
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign slot")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	sig, root, err := rs.signObject(pk, msg, beacon.DomainAggregateAndProof, rs.epochAtSlot(msg.Aggregate.Data.Slot), &signRequest{
+		Type:              "AGGREGATE_AND_PROOF",
+		AggregateAndProof: msg,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+	}
+	return &spec.SignedAggregateAndProof{
+		Message:   msg,
+		Signature: sig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	blockRoot := types.SSZBytes(root[:])
+	sig, signingRoot, err := rs.signObject(pk, &blockRoot, beacon.DomainSyncCommittee, rs.epochAtSlot(slot), &signRequest{
+		Type: "SYNC_COMMITTEE_MESSAGE",
+		SyncCommitteeMessage: &syncCommitteeMessage{
+			BeaconBlockRoot: fmt.Sprintf("%#x", root),
+			Slot:            fmt.Sprintf("%d", slot),
+		},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee block root")
+	}
+	return &altair.SyncCommitteeMessage{
+		Slot:            slot,
+		BeaconBlockRoot: root,
+		ValidatorIndex:  validatorIndex,
+		Signature:       sig,
+	}, signingRoot, nil
+}
+
+func (rs *remoteSigner) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	data := &eth.SyncAggregatorSelectionData{
+		Slot:              types.Slot(slot),
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	sig, root, err := rs.signObject(pk, data, beacon.DomainSyncCommitteeSelectionProof, rs.epochAtSlot(slot), &signRequest{
+		Type: "SYNC_COMMITTEE_SELECTION_PROOF",
+		SyncAggregatorSelectionData: &altair.SyncAggregatorSelectionData{
+			Slot:              slot,
+			SubcommitteeIndex: subcommitteeIndex,
+		},
+	})
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to sign sync committee selection data")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	if msg.Contribution == nil {
+		return nil, nil, errors.New("contribution is missing")
+	}
+	sig, root, err := rs.signObject(pk, msg, beacon.DomainContributionAndProof, rs.epochAtSlot(msg.Contribution.Slot), &signRequest{
+		Type:                 "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF",
+		ContributionAndProof: msg,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+	}
+	return &altair.SignedContributionAndProof{
+		Message:   msg,
+		Signature: sig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) epochAtSlot(slot spec.Slot) spec.Epoch {
+	return spec.Epoch(rs.network.EstimatedEpochAtSlot(types.Slot(slot)))
+}
+
+// signObject computes the signing root of the given object and requests its signature,
+// the signer verifies the signing root and applies slashing protection based on the object in the request
+func (rs *remoteSigner) signObject(pk []byte, object interface{}, domainType beacon.DomainType, epoch spec.Epoch, req *signRequest) (spec.BLSSignature, []byte, error) {
+	domain, err := rs.signingUtils.GetDomainByType(domainType, epoch)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := rs.signingUtils.ComputeSigningRoot(object, domain)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	req.ForkInfo, err = rs.forkInfoProvider.ForkInfo(epoch)
+	if err != nil {
+		return spec.BLSSignature{}, nil, errors.Wrap(err, "failed to get fork info")
+	}
+	sig, err := rs.sign(pk, root[:], req)
+	if err != nil {
+		return spec.BLSSignature{}, nil, err
+	}
+	return sig, root[:], nil
+}
+
+// sign requests the signature of the given root, the returned signature is verified against the share public key
+func (rs *remoteSigner) sign(pk []byte, root []byte, req *signRequest) (spec.BLSSignature, error) {
+	req.SigningRoot = fmt.Sprintf("%#x", root)
+	body, err := rs.request(http.MethodPost, signPath+fmt.Sprintf("%#x", pk), req)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+	sigBytes, err := parseSignature(body)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+
+	pubKey := &bls.PublicKey{}
+	if err := pubKey.Deserialize(pk); err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not deserialize public key")
+	}
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(sigBytes); err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not deserialize signature")
+	}
+	if !sig.VerifyByte(pubKey, root) {
+		return spec.BLSSignature{}, errors.New("remote signer returned an invalid signature")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sigBytes)
+	return blsSig, nil
+}
+
+// keystoresRequest sends a request to the keystores (key manager) api, and returns the status of the single key in the request
+func (rs *remoteSigner) keystoresRequest(method string, req interface{}) (*keystoreStatus, error) {
+	body, err := rs.request(method, keystoresPath, req)
+	if err != nil {
+		return nil, err
+	}
+	var res keystoresResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal response")
+	}
+	if len(res.Data) != 1 {
+		return nil, errors.Errorf("unexpected count of statuses: %d", len(res.Data))
+	}
+	return res.Data[0], nil
+}
+
+// request sends the given (json) request to the signer and returns the body of the response
+func (rs *remoteSigner) request(method, path string, req interface{}) ([]byte, error) {
+	var reqBody []byte
+	if req != nil {
+		var err error
+		if reqBody, err = json.Marshal(req); err != nil {
+			return nil, errors.Wrap(err, "could not marshal request")
+		}
+	}
+	httpReq, err := http.NewRequestWithContext(rs.ctx, method, rs.addr+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	res, err := rs.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "request to remote signer failed")
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("remote signer responded with status %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// parseSignature parses a signing response, which is either a json object or the (hex) signature as plain text
+func parseSignature(body []byte) ([]byte, error) {
+	sigHex := strings.TrimSpace(string(body))
+	if strings.HasPrefix(sigHex, "{") {
+		var res signResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal signature")
+		}
+		sigHex = res.Signature
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature")
+	}
+	if len(sig) != 96 {
+		return nil, errors.Errorf("invalid signature length %d", len(sig))
+	}
+	return sig, nil
+}
@@ -0,0 +1,335 @@
+package web3signer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// signRequestObjects returns the object of every supported signing type of the given request,
+// Web3Signer rejects requests of an unknown type or without the object of the given type
+func signRequestObjects(req *signRequest) map[string]bool {
+	return map[string]bool{
+		"ATTESTATION":                           req.Attestation != nil,
+		"BLOCK":                                 req.Block != nil,
+		"BLOCK_V2":                              req.BeaconBlock != nil && req.BeaconBlock.Block != nil,
+		"RANDAO_REVEAL":                         req.RandaoReveal != nil,
+		"AGGREGATION_SLOT":                      req.AggregationSlot != nil,
+		"AGGREGATE_AND_PROOF":                   req.AggregateAndProof != nil,
+		"SYNC_COMMITTEE_MESSAGE":                req.SyncCommitteeMessage != nil,
+		"SYNC_COMMITTEE_SELECTION_PROOF":        req.SyncAggregatorSelectionData != nil,
+		"SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF": req.ContributionAndProof != nil,
+	}
+}
+
+// fakeSigner is a minimal Web3Signer, attestations are protected against double votes (same target epoch)
+type fakeSigner struct {
+	keys         map[string]*bls.SecretKey
+	attestations map[string]string
+	requests     []*signRequest
+	lock         sync.Mutex
+}
+
+func newFakeSigner() *fakeSigner {
+	return &fakeSigner{
+		keys:         map[string]*bls.SecretKey{},
+		attestations: map[string]string{},
+	}
+}
+
+func (s *fakeSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch {
+	case r.URL.Path == upcheckPath:
+		_, _ = w.Write([]byte("OK"))
+	case r.URL.Path == keystoresPath && r.Method == http.MethodPost:
+		var req importKeystoresRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var ks keystore
+		_ = json.Unmarshal([]byte(req.Keystores[0]), &ks)
+		sk, err := decryptKeystore(&ks, req.Passwords[0])
+		status := &keystoreStatus{Status: "imported"}
+		if err != nil {
+			status = &keystoreStatus{Status: "error", Message: err.Error()}
+		} else if _, exist := s.keys["0x"+ks.Pubkey]; exist {
+			status.Status = "duplicate"
+		} else {
+			s.keys["0x"+ks.Pubkey] = sk
+		}
+		_ = json.NewEncoder(w).Encode(&keystoresResponse{Data: []*keystoreStatus{status}})
+	case r.URL.Path == keystoresPath && r.Method == http.MethodDelete:
+		var req deleteKeystoresRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		status := &keystoreStatus{Status: "not_found"}
+		if _, exist := s.keys[req.Pubkeys[0]]; exist {
+			delete(s.keys, req.Pubkeys[0])
+			status.Status = "deleted"
+		}
+		_ = json.NewEncoder(w).Encode(&keystoresResponse{Data: []*keystoreStatus{status}})
+	case strings.HasPrefix(r.URL.Path, signPath):
+		sk, exist := s.keys[strings.TrimPrefix(r.URL.Path, signPath)]
+		if !exist {
+			http.Error(w, "public key not found", http.StatusNotFound)
+			return
+		}
+		var req signRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.requests = append(s.requests, &req)
+		if hasObject, known := signRequestObjects(&req)[req.Type]; !known || !hasObject || req.ForkInfo == nil {
+			http.Error(w, "invalid signing request", http.StatusBadRequest)
+			return
+		}
+		if req.Attestation != nil {
+			key := fmt.Sprintf("%s_%d", r.URL.Path, req.Attestation.Target.Epoch)
+			if signed, exist := s.attestations[key]; exist && signed != req.SigningRoot {
+				http.Error(w, "signing operation failed due to slashing protection rules", http.StatusPreconditionFailed)
+				return
+			}
+			s.attestations[key] = req.SigningRoot
+		}
+		root, _ := hex.DecodeString(strings.TrimPrefix(req.SigningRoot, "0x"))
+		_ = json.NewEncoder(w).Encode(&signResponse{Signature: "0x" + hex.EncodeToString(sk.SignByte(root).Serialize())})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// decryptKeystore decrypts a keystore that was created by encryptKeystore
+func decryptKeystore(ks *keystore, password string) (*bls.SecretKey, error) {
+	secret, err := keystorev4.New().Decrypt(ks.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(secret); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// testSigningUtil computes (fake) signing roots without a beacon node
+type testSigningUtil struct{}
+
+func (u *testSigningUtil) GetDomain(data *spec.AttestationData) ([]byte, error) {
+	return u.GetDomainByType(beacon.DomainBeaconAttester, 0)
+}
+
+func (u *testSigningUtil) GetDomainByType(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	domain := sha256.Sum256([]byte(fmt.Sprintf("%s_%d", domainType, epoch)))
+	return domain[:], nil
+}
+
+func (u *testSigningUtil) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
+	byts, err := json.Marshal(object)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(append(byts, domain...)), nil
+}
+
+type testForkInfoProvider struct{}
+
+func (p *testForkInfoProvider) ForkInfo(epoch spec.Epoch) (*ForkInfo, error) {
+	return &ForkInfo{Fork: &spec.Fork{Epoch: 0}}, nil
+}
+
+func newTestRemoteSigner(t *testing.T, addr string) beacon.KeyManager {
+	km, err := New(Options{
+		Logger:  zap.L(),
+		Addr:    addr,
+		Network: beacon.NewNetwork(core.PraterNetwork, 0, nil),
+	}, &testSigningUtil{}, &testForkInfoProvider{})
+	require.NoError(t, err)
+	return km
+}
+
+func TestRemoteSigner_New(t *testing.T) {
+	_, err := New(Options{Logger: zap.L()}, &testSigningUtil{}, &testForkInfoProvider{})
+	require.EqualError(t, err, "missing remote signer address")
+
+	server := httptest.NewServer(newFakeSigner())
+	server.Close()
+	_, err = New(Options{Logger: zap.L(), Addr: server.URL}, &testSigningUtil{}, &testForkInfoProvider{})
+	require.Error(t, err)
+}
+
+func TestRemoteSigner_Shares(t *testing.T) {
+	threshold.Init()
+	signer := newFakeSigner()
+	server := httptest.NewServer(signer)
+	defer server.Close()
+	km := newTestRemoteSigner(t, server.URL)
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey()
+
+	require.NoError(t, km.AddShare(sk))
+	require.Len(t, signer.keys, 1)
+	require.Equal(t, sk.SerializeToHexStr(), signer.keys["0x"+pk.SerializeToHexStr()].SerializeToHexStr())
+	// adding the share again is a no-op
+	require.NoError(t, km.AddShare(sk))
+	require.Len(t, signer.keys, 1)
+
+	require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+	require.Len(t, signer.keys, 0)
+	require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+
+	_, _, err := km.SignRandaoReveal(1, pk.Serialize())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 404")
+}
+
+func TestRemoteSigner_Sign(t *testing.T) {
+	threshold.Init()
+	signer := newFakeSigner()
+	server := httptest.NewServer(signer)
+	defer server.Close()
+	km := newTestRemoteSigner(t, server.URL)
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey()
+	require.NoError(t, km.AddShare(sk))
+
+	t.Run("ibft message", func(t *testing.T) {
+		msg := &proto.Message{Type: proto.RoundState_Commit, Round: 1, Lambda: []byte("lambda"), Value: []byte("value")}
+		requests := len(signer.requests)
+		_, err := km.SignIBFTMessage(msg, pk.Serialize())
+		require.EqualError(t, err, ErrIBFTNotSupported.Error())
+		// iBFT messages are never sent to the signer
+		require.Len(t, signer.requests, requests)
+	})
+
+	t.Run("attestation", func(t *testing.T) {
+		data := &spec.AttestationData{
+			Slot:   32,
+			Index:  1,
+			Source: &spec.Checkpoint{Epoch: 0},
+			Target: &spec.Checkpoint{Epoch: 1},
+		}
+		duty := &beacon.Duty{CommitteeLength: 4, ValidatorCommitteeIndex: 2}
+		att, root, err := km.SignAttestation(data, duty, pk.Serialize())
+		require.NoError(t, err)
+		require.Equal(t, data, att.Data)
+		require.True(t, att.AggregationBits.BitAt(2))
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(append([]byte{}, att.Signature[:]...)))
+		require.True(t, sig.VerifyByte(pk, root))
+
+		req := signer.requests[len(signer.requests)-1]
+		require.Equal(t, "ATTESTATION", req.Type)
+		require.NotNil(t, req.ForkInfo)
+		require.Equal(t, "0x"+hex.EncodeToString(root), req.SigningRoot)
+
+		// the signer refuses to sign a slashable attestation
+		slashable := *data
+		slashable.BeaconBlockRoot = spec.Root{1}
+		_, _, err = km.SignAttestation(&slashable, duty, pk.Serialize())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status 412")
+	})
+
+	t.Run("randao reveal", func(t *testing.T) {
+		sig, root, err := km.SignRandaoReveal(3, pk.Serialize())
+		require.NoError(t, err)
+		blsSig := &bls.Sign{}
+		require.NoError(t, blsSig.Deserialize(sig[:]))
+		require.True(t, blsSig.VerifyByte(pk, root))
+		req := signer.requests[len(signer.requests)-1]
+		require.Equal(t, "RANDAO_REVEAL", req.Type)
+		require.Equal(t, "3", req.RandaoReveal.Epoch)
+	})
+
+	t.Run("altair block", func(t *testing.T) {
+		block := &ethspec.VersionedBeaconBlock{
+			Version: ethspec.DataVersionAltair,
+			Altair: &altair.BeaconBlock{
+				Slot: 64,
+				Body: &altair.BeaconBlockBody{
+					ETH1Data:          &spec.ETH1Data{BlockHash: make([]byte, 32)},
+					Graffiti:          make([]byte, 32),
+					ProposerSlashings: []*spec.ProposerSlashing{},
+					AttesterSlashings: []*spec.AttesterSlashing{},
+					Attestations:      []*spec.Attestation{},
+					Deposits:          []*spec.Deposit{},
+					VoluntaryExits:    []*spec.SignedVoluntaryExit{},
+					SyncAggregate:     &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+				},
+			},
+		}
+		signed, root, err := km.SignBeaconBlock(block, &beacon.Duty{}, pk.Serialize())
+		require.NoError(t, err)
+		require.Equal(t, ethspec.DataVersionAltair, signed.Version)
+		require.Equal(t, block.Altair, signed.Altair.Message)
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(append([]byte{}, signed.Altair.Signature[:]...)))
+		require.True(t, sig.VerifyByte(pk, root))
+
+		req := signer.requests[len(signer.requests)-1]
+		require.Equal(t, "BLOCK_V2", req.Type)
+		require.Equal(t, "ALTAIR", req.BeaconBlock.Version)
+		require.Equal(t, spec.Slot(64), req.BeaconBlock.Block.Slot)
+	})
+}
+
+func TestFakeSigner_InvalidRequest(t *testing.T) {
+	threshold.Init()
+	signer := newFakeSigner()
+	server := httptest.NewServer(signer)
+	defer server.Close()
+	km := newTestRemoteSigner(t, server.URL)
+	rs := km.(*remoteSigner)
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	require.NoError(t, km.AddShare(sk))
+	pk := sk.GetPublicKey().Serialize()
+	root := make([]byte, 32)
+
+	// raw roots (without type) are not supported
+	_, err := rs.sign(pk, root, &signRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 400")
+	// the object of the given type is required
+	_, err = rs.sign(pk, root, &signRequest{Type: "BLOCK", ForkInfo: &ForkInfo{Fork: &spec.Fork{}}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 400")
+	// fork info is required
+	_, err = rs.sign(pk, root, &signRequest{Type: "RANDAO_REVEAL", RandaoReveal: &randaoReveal{Epoch: "1"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 400")
+}
+
+func TestParseSignature(t *testing.T) {
+	sig := strings.Repeat("ab", 96)
+	byts, err := parseSignature([]byte("0x" + sig))
+	require.NoError(t, err)
+	require.Len(t, byts, 96)
+	byts, err = parseSignature([]byte(`{"signature": "0x` + sig + `"}`))
+	require.NoError(t, err)
+	require.Len(t, byts, 96)
+	_, err = parseSignature([]byte("0xabcd"))
+	require.EqualError(t, err, "invalid signature length 2")
+}
@@ -0,0 +1,61 @@
+package beacon
+
+import (
+	"github.com/bloxapp/eth2-key-manager/core"
+	types "github.com/prysmaticlabs/eth2-types"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+)
+
+// Network represents the eth2 network the node works with.
+// it wraps a known core.Network (used by the key manager) with the genesis of the actual network,
+// which allows to work with custom networks (e.g. devnets) that are derived from a known one
+type Network struct {
+	core.Network
+	minGenesisTime     uint64
+	genesisForkVersion []byte
+}
+
+// NewNetwork creates a new network based on the given core network,
+// a zero genesis time or an empty fork version fall back to the values of the core network
+func NewNetwork(network core.Network, minGenesisTime uint64, genesisForkVersion []byte) Network {
+	if minGenesisTime == 0 {
+		minGenesisTime = network.MinGenesisTime()
+	}
+	if len(genesisForkVersion) == 0 {
+		genesisForkVersion = network.ForkVersion()
+	}
+	return Network{
+		Network:            network,
+		minGenesisTime:     minGenesisTime,
+		genesisForkVersion: genesisForkVersion,
+	}
+}
+
+// ForkVersion returns the genesis fork version of the network
+func (n Network) ForkVersion() []byte {
+	return n.genesisForkVersion
+}
+
+// MinGenesisTime returns min genesis time value
+func (n Network) MinGenesisTime() uint64 {
+	return n.minGenesisTime
+}
+
+// EstimatedCurrentSlot returns the estimation of the current slot
+func (n Network) EstimatedCurrentSlot() types.Slot {
+	return n.EstimatedSlotAtTime(prysmTime.Now().Unix())
+}
+
+// EstimatedSlotAtTime estimates slot at the given time
+func (n Network) EstimatedSlotAtTime(time int64) types.Slot {
+	genesis := int64(n.MinGenesisTime())
+	if time < genesis {
+		return 0
+	}
+	return types.Slot(uint64(time-genesis) / uint64(n.SlotDurationSec().Seconds()))
+}
+
+// EstimatedCurrentEpoch estimates the current epoch
+func (n Network) EstimatedCurrentEpoch() types.Epoch {
+	return n.EstimatedEpochAtSlot(n.EstimatedCurrentSlot())
+}
@@ -0,0 +1,29 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetwork(t *testing.T) {
+	t.Run("known network", func(t *testing.T) {
+		n := NewNetwork(core.PraterNetwork, 0, nil)
+		require.Equal(t, core.PraterNetwork.MinGenesisTime(), n.MinGenesisTime())
+		require.Equal(t, core.PraterNetwork.ForkVersion(), n.ForkVersion())
+		require.Equal(t, core.PraterNetwork.EstimatedCurrentSlot(), n.EstimatedCurrentSlot())
+	})
+
+	t.Run("custom genesis", func(t *testing.T) {
+		genesis := core.PraterNetwork.MinGenesisTime() + 1000*12
+		n := NewNetwork(core.PraterNetwork, genesis, []byte{1, 2, 3, 4})
+		require.Equal(t, genesis, n.MinGenesisTime())
+		require.Equal(t, []byte{1, 2, 3, 4}, n.ForkVersion())
+		require.Equal(t, types.Slot(0), n.EstimatedSlotAtTime(int64(genesis)-1))
+		require.Equal(t, types.Slot(64), n.EstimatedSlotAtTime(int64(genesis)+64*12))
+		require.Equal(t, types.Epoch(2), n.EstimatedEpochAtSlot(n.EstimatedSlotAtTime(int64(genesis)+64*12)))
+		require.Equal(t, core.PraterNetwork.EstimatedCurrentSlot()-1000, n.EstimatedCurrentSlot())
+	})
+}
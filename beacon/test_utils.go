@@ -2,6 +2,8 @@ package beacon
 
 import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -62,14 +64,90 @@ func (m *mockBeacon) SubmitAttestation(attestation *spec.Attestation) error {
 	return nil
 }
 
+func (m *mockBeacon) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (m *mockBeacon) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*ethspec.VersionedBeaconBlock, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubmitBeaconBlock(block *ethspec.VersionedSignedBeaconBlock) error {
+	return nil
+}
+
+func (m *mockBeacon) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (m *mockBeacon) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Attestation, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	return nil
+}
+
 func (m *mockBeacon) SubscribeToCommitteeSubnet(subscription []*v1.BeaconCommitteeSubscription) error {
 	return nil
 }
 
+func (m *mockBeacon) GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) GetSyncMessageBlockRoot(slot spec.Slot) (spec.Root, error) {
+	return spec.Root{}, nil
+}
+
+func (m *mockBeacon) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	return nil
+}
+
+func (m *mockBeacon) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (m *mockBeacon) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	return nil
+}
+
+func (m *mockBeacon) SubscribeToSyncCommitteeSubnet(subscriptions []*v1.SyncCommitteeSubscription) error {
+	return nil
+}
+
+func (m *mockBeacon) GetValidatorsLiveness(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) (map[spec.ValidatorIndex]bool, error) {
+	return map[spec.ValidatorIndex]bool{}, nil
+}
+
 func (m *mockBeacon) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (m *mockBeacon) RemoveShare(pubKey string) error {
+	return nil
+}
+
 func (m *mockBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	return nil, nil
 }
@@ -77,6 +155,9 @@ func (m *mockBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte,
 func (m *mockBeacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	panic("implement")
 }
+func (m *mockBeacon) GetDomainByType(domainType DomainType, epoch spec.Epoch) ([]byte, error) {
+	panic("implement")
+}
 func (m *mockBeacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
 	panic("implement")
 }
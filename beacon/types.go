@@ -14,6 +14,10 @@ func (r RoleType) String() string {
 		return "AGGREGATOR"
 	case RoleTypeProposer:
 		return "PROPOSER"
+	case RoleTypeSyncCommittee:
+		return "SYNC_COMMITTEE"
+	case RoleTypeSyncCommitteeContribution:
+		return "SYNC_COMMITTEE_CONTRIBUTION"
 	default:
 		return "UNDEFINED"
 	}
@@ -25,4 +29,22 @@ const (
 	RoleTypeAttester
 	RoleTypeAggregator
 	RoleTypeProposer
+	RoleTypeSyncCommittee
+	RoleTypeSyncCommitteeContribution
+)
+
+// DomainType is the name of a signature domain as defined in the beacon chain spec
+type DomainType string
+
+// List of signature domains
+const (
+	DomainBeaconProposer    DomainType = "DOMAIN_BEACON_PROPOSER"
+	DomainBeaconAttester    DomainType = "DOMAIN_BEACON_ATTESTER"
+	DomainRandao            DomainType = "DOMAIN_RANDAO"
+	DomainSelectionProof    DomainType = "DOMAIN_SELECTION_PROOF"
+	DomainAggregateAndProof DomainType = "DOMAIN_AGGREGATE_AND_PROOF"
+	// sync committee domains were introduced in altair
+	DomainSyncCommittee               DomainType = "DOMAIN_SYNC_COMMITTEE"
+	DomainSyncCommitteeSelectionProof DomainType = "DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF"
+	DomainContributionAndProof        DomainType = "DOMAIN_CONTRIBUTION_AND_PROOF"
 )
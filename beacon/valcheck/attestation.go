@@ -2,11 +2,14 @@ package valcheck
 
 import (
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
 	"github.com/pkg/errors"
 )
 
 // AttestationValueCheck checks for an Attestation type value
 type AttestationValueCheck struct {
+	protector beacon.SlashingProtector
+	pk        []byte
 }
 
 // Check returns error if value is invalid
@@ -17,10 +20,18 @@ func (v *AttestationValueCheck) Check(value []byte) error {
 		return errors.Wrap(err, "could not parse input value storing attestation data")
 	}
 
-	if inputValue.Slot == 100 {
-		return errors.New("TEST - failed on slot 100")
+	if inputValue.Source == nil || inputValue.Target == nil {
+		return errors.New("attestation checkpoints are missing")
+	}
+	if inputValue.Source.Epoch > inputValue.Target.Epoch {
+		return errors.Errorf("source epoch %d is greater than target epoch %d", inputValue.Source.Epoch, inputValue.Target.Epoch)
 	}
 
-	// TODO - test for slashing protection
+	if v.protector == nil {
+		return nil
+	}
+	if err := v.protector.IsAttestationSlashable(inputValue, v.pk); err != nil {
+		return errors.Wrap(err, "attestation data failed slashing protection")
+	}
 	return nil
 }
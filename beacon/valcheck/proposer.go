@@ -1,27 +1,29 @@
 package valcheck
 
 import (
-	"encoding/json"
-	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/beacon"
 	"github.com/pkg/errors"
 )
 
-// ProposerValueCheck checks for an Aggregator type value
+// ProposerValueCheck checks for a Proposer type value
 type ProposerValueCheck struct {
+	protector beacon.SlashingProtector
+	pk        []byte
 }
 
 // Check returns error if value is invalid
 func (v *ProposerValueCheck) Check(value []byte) error {
-	// try and parse to attestation data
-	inputValue := &proto.InputValue_Block{}
-	if err := json.Unmarshal(value, &inputValue); err != nil {
-		return errors.Wrap(err, "could not parse input value storing attestation data")
+	// try and parse to beacon block
+	inputValue, err := beacon.UnmarshalBeaconBlock(value)
+	if err != nil {
+		return errors.Wrap(err, "could not parse input value storing beacon block")
 	}
 
-	//if inputValue.Block.Block.Slot == 0 {
-	//	return errors.New("this is an example test error")
-	//}
-
-	// TODO - test for slashing protection
+	if v.protector == nil {
+		return nil
+	}
+	if err := v.protector.IsBeaconBlockSlashable(inputValue, v.pk); err != nil {
+		return errors.Wrap(err, "beacon block failed slashing protection")
+	}
 	return nil
 }
@@ -1,25 +1,39 @@
 package valcheck
 
+import "github.com/bloxapp/ssv/beacon"
+
 // SlashingProtection is a controller for different types of ethereum value and slashing protection instances
 type SlashingProtection struct {
+	protector beacon.SlashingProtector
 }
 
-// New returns a new instance of slashing protection
-func New() *SlashingProtection {
-	return &SlashingProtection{}
+// New returns a new instance of slashing protection,
+// values are checked against the slashing protection data of the given protector (skipped if nil)
+func New(protector beacon.SlashingProtector) *SlashingProtection {
+	return &SlashingProtection{protector: protector}
 }
 
-// AttestationSlashingProtector returns an attestation slashing protection value check
-func (sp *SlashingProtection) AttestationSlashingProtector() *AttestationValueCheck {
-	return &AttestationValueCheck{}
+// AttestationSlashingProtector returns an attestation slashing protection value check for the given share
+func (sp *SlashingProtection) AttestationSlashingProtector(pk []byte) *AttestationValueCheck {
+	return &AttestationValueCheck{protector: sp.protector, pk: pk}
 }
 
-// ProposalSlashingProtector returns a proposal slashing protection value check
-func (sp *SlashingProtection) ProposalSlashingProtector() *ProposerValueCheck {
-	return &ProposerValueCheck{}
+// ProposalSlashingProtector returns a proposal slashing protection value check for the given share
+func (sp *SlashingProtection) ProposalSlashingProtector(pk []byte) *ProposerValueCheck {
+	return &ProposerValueCheck{protector: sp.protector, pk: pk}
 }
 
 // AggregationValidation returns an aggregation value check
 func (sp *SlashingProtection) AggregationValidation() *AggregatorValueCheck {
 	return &AggregatorValueCheck{}
 }
+
+// SyncCommitteeValidation returns a sync committee value check
+func (sp *SlashingProtection) SyncCommitteeValidation() *SyncCommitteeValueCheck {
+	return &SyncCommitteeValueCheck{}
+}
+
+// SyncCommitteeContributionValidation returns a sync committee contribution value check
+func (sp *SlashingProtection) SyncCommitteeContributionValidation() *SyncCommitteeContributionValueCheck {
+	return &SyncCommitteeContributionValueCheck{}
+}
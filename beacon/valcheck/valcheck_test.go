@@ -0,0 +1,117 @@
+package valcheck
+
+import (
+	"testing"
+
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+// testProtector protects attestations up to the given target epoch and blocks up to the given slot
+type testProtector struct {
+	highestTarget spec.Epoch
+	highestSlot   spec.Slot
+	pks           [][]byte
+}
+
+func (p *testProtector) IsAttestationSlashable(data *spec.AttestationData, pk []byte) error {
+	p.pks = append(p.pks, pk)
+	if data.Target.Epoch <= p.highestTarget {
+		return errors.New("slashable attestation")
+	}
+	return nil
+}
+
+func (p *testProtector) IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error {
+	p.pks = append(p.pks, pk)
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+	if slot <= p.highestSlot {
+		return errors.New("slashable proposal")
+	}
+	return nil
+}
+
+func attestationValue(t *testing.T, source, target spec.Epoch) []byte {
+	data := &spec.AttestationData{
+		Slot:   spec.Slot(target) * 32,
+		Source: &spec.Checkpoint{Epoch: source},
+		Target: &spec.Checkpoint{Epoch: target},
+	}
+	byts, err := data.MarshalSSZ()
+	require.NoError(t, err)
+	return byts
+}
+
+func blockValue(t *testing.T, slot spec.Slot) []byte {
+	byts, err := beacon.MarshalBeaconBlock(&ethspec.VersionedBeaconBlock{
+		Version: ethspec.DataVersionPhase0,
+		Phase0: &spec.BeaconBlock{
+			Slot: slot,
+			Body: &spec.BeaconBlockBody{
+				ETH1Data: &spec.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti: make([]byte, 32),
+			},
+		},
+	})
+	require.NoError(t, err)
+	return byts
+}
+
+func altairBlockValue(t *testing.T, slot spec.Slot) []byte {
+	byts, err := beacon.MarshalBeaconBlock(&ethspec.VersionedBeaconBlock{
+		Version: ethspec.DataVersionAltair,
+		Altair: &altair.BeaconBlock{
+			Slot: slot,
+			Body: &altair.BeaconBlockBody{
+				ETH1Data:      &spec.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti:      make([]byte, 32),
+				SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return byts
+}
+
+func TestAttestationValueCheck(t *testing.T) {
+	protector := &testProtector{highestTarget: 10}
+	check := New(protector).AttestationSlashingProtector([]byte("pk"))
+
+	require.NoError(t, check.Check(attestationValue(t, 10, 11)))
+	require.Equal(t, [][]byte{[]byte("pk")}, protector.pks)
+	require.EqualError(t, check.Check(attestationValue(t, 9, 10)), "attestation data failed slashing protection: slashable attestation")
+	require.EqualError(t, check.Check(attestationValue(t, 12, 11)), "source epoch 12 is greater than target epoch 11")
+	require.Error(t, check.Check([]byte("value")))
+
+	t.Run("no protector", func(t *testing.T) {
+		require.NoError(t, New(nil).AttestationSlashingProtector(nil).Check(attestationValue(t, 9, 10)))
+	})
+}
+
+func TestProposerValueCheck(t *testing.T) {
+	protector := &testProtector{highestSlot: 100}
+	check := New(protector).ProposalSlashingProtector([]byte("pk"))
+
+	require.NoError(t, check.Check(blockValue(t, 101)))
+	require.EqualError(t, check.Check(blockValue(t, 100)), "beacon block failed slashing protection: slashable proposal")
+	require.Error(t, check.Check([]byte("value")))
+	// the ssz of a phase0 block is not a valid altair block
+	require.Error(t, check.Check(append([]byte{byte(ethspec.DataVersionAltair)}, blockValue(t, 101)[1:]...)))
+
+	t.Run("altair block", func(t *testing.T) {
+		require.NoError(t, check.Check(altairBlockValue(t, 101)))
+		require.EqualError(t, check.Check(altairBlockValue(t, 100)), "beacon block failed slashing protection: slashable proposal")
+	})
+
+	t.Run("no protector", func(t *testing.T) {
+		require.NoError(t, New(nil).ProposalSlashingProtector(nil).Check(blockValue(t, 100)))
+	})
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"log"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+type changeEncryptionPasswordConfig struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options     `yaml:"db"`
+	EncryptionOptions          encryption.Options `yaml:"encryption"`
+}
+
+// changeEncryptionPasswordCmd is the command to re-encrypt the node storage with a new password,
+// the node must be stopped as the db is opened by this command
+var changeEncryptionPasswordCmd = &cobra.Command{
+	Use:   "change-encryption-password",
+	Short: "changes the password that the operator key and shares are encrypted with",
+	Run: func(cmd *cobra.Command, args []string) {
+		configPath, err := flags.GetConfigFlagValue(cmd)
+		if err != nil {
+			log.Fatal("failed to get config flag value", zap.Error(err))
+		}
+		var cfg changeEncryptionPasswordConfig
+		if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			log.Fatal(err)
+		}
+		loggerLevel, _ := logex.GetLoggerLevelValue(cfg.LogLevel)
+		logger := logex.Build(RootCmd.Short, loggerLevel, nil)
+
+		newPasswordFile, err := flags.GetNewPasswordFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get new password file flag value", zap.Error(err))
+		}
+		newPassword, err := encryption.ReadPasswordFile(newPasswordFile)
+		if err != nil {
+			logger.Fatal("failed to read new password", zap.Error(err))
+		}
+		password, err := cfg.EncryptionOptions.Password()
+		if err != nil {
+			logger.Fatal("failed to read current password", zap.Error(err))
+		}
+
+		cfg.DBOptions.Logger = logger
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("failed to open db", zap.Error(err))
+		}
+		defer db.Close()
+
+		if err := encryption.ChangePassword(db, password, newPassword); err != nil {
+			logger.Fatal("failed to change encryption password", zap.Error(err))
+		}
+		logger.Info("encryption password was changed, the node config should point to the new password file")
+	},
+}
+
+func init() {
+	flags.AddConfigFlag(changeEncryptionPasswordCmd)
+	flags.AddNewPasswordFileFlag(changeEncryptionPasswordCmd)
+
+	RootCmd.AddCommand(changeEncryptionPasswordCmd)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +17,8 @@ type GlobalConfig struct {
 	LogLevel       string `yaml:"LogLevel" env:"LOG_LEVEL" env-default:"info" env-description:"Defines logger's log level'"`
 	LogFormat      string `yaml:"LogFormat" env:"LOG_FORMAT" env-default:"console" env-description:"Defines logger's encoding, valid values are 'console' (default) and 'json''"`
 	LogLevelFormat string `yaml:"LogLevelFormat" env:"LOG_LEVEL_FORMAT" env-default:"capitalColor" env-description:"Defines logger's level format, valid values are 'capitalColor' (default), 'capital' or 'lowercase''"`
+	// NetworkProfiles are custom network profiles (e.g. devnets) that can be selected in addition to the built-in ones
+	NetworkProfiles []networkconfig.Profile `yaml:"NetworkProfiles"`
 }
 
 // ProcessArgs processes and handles CLI arguments
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/backup"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// dbCmd is the parent command of the db backup and restore commands
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "backs up and restores the node db",
+}
+
+var backupDBCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "writes a backup of the node db",
+	Long: "writes a consistent snapshot of the node db to the backup file. " +
+		"if --admin-api is given, the backup is taken from the running node, otherwise the node must be stopped " +
+		"and the db is opened according to the config",
+	Run: func(cmd *cobra.Command, args []string) {
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			log.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		var logger *zap.Logger
+		var write func(w io.Writer) error
+		if flags.IsAdminAPIFlagSet(cmd) {
+			logger = logex.Build(RootCmd.Short, zap.InfoLevel, nil)
+			adminAPI, err := flags.GetAdminAPIFlagValue(cmd)
+			if err != nil {
+				logger.Fatal("failed to get admin api flag value", zap.Error(err))
+			}
+			write = func(w io.Writer) error {
+				return downloadBackup(adminAPI, w)
+			}
+		} else {
+			var cfg *slashingProtectionConfig
+			cfg, logger = readSlashingProtectionConfig(cmd)
+			db := openNodeDB(cmd, cfg, logger)
+			defer db.Close()
+			write = func(w io.Writer) error {
+				return backup.Write(db, w)
+			}
+		}
+
+		// the backup is written to a temporary file, which replaces the backup file once it was verified
+		tmp := file + ".tmp"
+		f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			logger.Fatal("failed to create backup file", zap.Error(err))
+		}
+		err = write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = backup.Verify(tmp)
+		}
+		if err != nil {
+			_ = os.Remove(tmp)
+			logger.Fatal("failed to write backup", zap.Error(err))
+		}
+		if err := os.Rename(tmp, file); err != nil {
+			logger.Fatal("failed to move backup file", zap.Error(err))
+		}
+		logger.Info("db backup was written", zap.String("file", file))
+	},
+}
+
+var restoreDBCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "replaces the node db with a backup",
+	Long: "verifies the backup file and replaces the content of the node db with it, the node must be stopped. " +
+		"the restore is refused if the db has newer slashing protection data (highest attestation or proposal) than the backup, " +
+		"unless --force is given",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, logger := readSlashingProtectionConfig(cmd)
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		force, err := flags.GetForceRestoreFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get force flag value", zap.Error(err))
+		}
+		networkProfile, err := networkconfig.Load(cfg.ETH2Options.Network, cfg.NetworkProfiles)
+		if err != nil {
+			logger.Fatal("failed to load network profile", zap.Error(err))
+		}
+
+		// the backup is first loaded into memory, to compare its slashing protection data with the db
+		restored, err := storage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: logger, Ctx: cmd.Context()})
+		if err != nil {
+			logger.Fatal("failed to create in-memory db", zap.Error(err))
+		}
+		defer restored.Close()
+		if err := backup.Restore(restored, file); err != nil {
+			logger.Fatal("invalid backup", zap.Error(err))
+		}
+
+		db := openNodeDB(cmd, cfg, logger)
+		defer db.Close()
+		newer, err := ekm.NewerSlashingData(db, restored, networkProfile.BaseNetwork)
+		if err != nil {
+			logger.Fatal("failed to compare slashing protection data", zap.Error(err))
+		}
+		if len(newer) > 0 {
+			logger.Warn("db has newer slashing protection data than the backup",
+				zap.String("shares", strings.Join(newer, ",")), zap.Bool("force", force))
+			if !force {
+				logger.Fatal("restore was refused, as it would lower the slashing protection of the shares (use --force to restore anyway)")
+			}
+		}
+
+		if err := backup.Restore(db, file); err != nil {
+			logger.Fatal("failed to restore backup", zap.Error(err))
+		}
+		logger.Info("db was restored", zap.String("file", file))
+	},
+}
+
+// openNodeDB opens the node db according to the config, the node must be stopped
+func openNodeDB(cmd *cobra.Command, cfg *slashingProtectionConfig, logger *zap.Logger) basedb.IDb {
+	cfg.DBOptions.Logger = logger
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		logger.Fatal("failed to open db, make sure the node is stopped", zap.Error(err))
+	}
+	return db
+}
+
+// downloadBackup writes a backup of the db of the running node to the given writer
+func downloadBackup(adminAPI string, w io.Writer) error {
+	res, err := http.Get(strings.TrimSuffix(adminAPI, "/") + "/db/backup")
+	if err != nil {
+		return errors.Wrap(err, "could not request backup")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		raw, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("backup request failed (%d): %s", res.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return errors.Wrap(err, "could not download backup")
+	}
+	return nil
+}
+
+func init() {
+	flags.AddBackupFileFlag(backupDBCmd)
+	flags.AddConfigFlag(backupDBCmd)
+	flags.AddAdminAPIFlag(backupDBCmd)
+	dbCmd.AddCommand(backupDBCmd)
+
+	flags.AddBackupFileFlag(restoreDBCmd)
+	flags.AddConfigFlag(restoreDBCmd)
+	flags.AddForceRestoreFlag(restoreDBCmd)
+	dbCmd.AddCommand(restoreDBCmd)
+
+	RootCmd.AddCommand(dbCmd)
+}
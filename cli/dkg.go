@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// dkgPollInterval is the interval of polling the node for the state of a ceremony
+const dkgPollInterval = 2 * time.Second
+
+// dkgCmd is the parent command of the distributed key generation commands,
+// the commands work against the admin api of a running operator node which is one of the ceremony operators
+var dkgCmd = &cobra.Command{
+	Use:   "dkg",
+	Short: "runs distributed key generation ceremonies between operators",
+}
+
+var startDKGCmd = &cobra.Command{
+	Use:   "start",
+	Short: "starts a ceremony, waits for it to finish and writes its result",
+	Long: "starts a ceremony between the given operators, all of them must be running a node. " +
+		"once the ceremony is finished, the result (validator public key and the encrypted shares) is written to the output file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zap.InfoLevel, nil)
+		adminAPI, err := flags.GetAdminAPIFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get admin api flag value", zap.Error(err))
+		}
+		operators, err := flags.GetDKGOperatorsFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get operators flag value", zap.Error(err))
+		}
+		threshold, err := flags.GetDKGThresholdFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get threshold flag value", zap.Error(err))
+		}
+		output, err := flags.GetDKGOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+
+		id, err := startCeremony(adminAPI, "/dkg", map[string]interface{}{"operators": operators, "threshold": threshold})
+		if err != nil {
+			logger.Fatal("failed to start ceremony", zap.Error(err))
+		}
+		logger = logger.With(zap.String("ceremonyID", id))
+		logger.Info("started ceremony, waiting for it to finish", zap.Int("operators", len(operators)), zap.Uint64("threshold", threshold))
+		waitForCeremony(logger, adminAPI, id, output)
+	},
+}
+
+var reshareDKGCmd = &cobra.Command{
+	Use:   "reshare",
+	Short: "starts a ceremony that reshares a validator to a new committee, waits for it to finish and writes its result",
+	Long: "starts a ceremony in which the current operators of the validator deal new shares of the same validator key to the given operators. " +
+		"it should run against a node of the current committee, and all the current and new operators must be running a node. " +
+		"once the ceremony is finished, the result (including the new encrypted shares) is written to the output file, " +
+		"the new shares should then be registered for the validator",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zap.InfoLevel, nil)
+		adminAPI, err := flags.GetAdminAPIFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get admin api flag value", zap.Error(err))
+		}
+		validatorPubKey, err := flags.GetDKGValidatorFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get validator flag value", zap.Error(err))
+		}
+		operators, err := flags.GetDKGOperatorsFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get operators flag value", zap.Error(err))
+		}
+		threshold, err := flags.GetDKGThresholdFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get threshold flag value", zap.Error(err))
+		}
+		output, err := flags.GetDKGOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+
+		id, err := startCeremony(adminAPI, "/dkg/reshare", map[string]interface{}{
+			"validatorPubKey": validatorPubKey,
+			"operators":       operators,
+			"threshold":       threshold,
+		})
+		if err != nil {
+			logger.Fatal("failed to start reshare ceremony", zap.Error(err))
+		}
+		logger = logger.With(zap.String("ceremonyID", id))
+		logger.Info("started reshare ceremony, waiting for it to finish", zap.String("validatorPubKey", validatorPubKey),
+			zap.Int("operators", len(operators)), zap.Uint64("threshold", threshold))
+		waitForCeremony(logger, adminAPI, id, output)
+	},
+}
+
+var dkgResultCmd = &cobra.Command{
+	Use:   "result",
+	Short: "writes the result of a completed ceremony",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zap.InfoLevel, nil)
+		adminAPI, err := flags.GetAdminAPIFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get admin api flag value", zap.Error(err))
+		}
+		id, err := flags.GetDKGCeremonyFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get ceremony id flag value", zap.Error(err))
+		}
+		output, err := flags.GetDKGOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+		logger = logger.With(zap.String("ceremonyID", id))
+
+		state, err := getCeremony(adminAPI, id)
+		if err != nil {
+			logger.Fatal("failed to get ceremony", zap.Error(err))
+		}
+		if state.Status != dkg.StatusCompleted {
+			logger.Fatal("ceremony is not completed", zap.String("status", string(state.Status)),
+				zap.String("phase", state.Phase), zap.String("error", state.Error))
+		}
+		if err := writeCeremonyResult(output, state); err != nil {
+			logger.Fatal("failed to write ceremony result", zap.Error(err))
+		}
+		logger.Info("ceremony result was written", zap.String("validatorPubKey", state.Result.ValidatorPubKey), zap.String("file", output))
+	},
+}
+
+// startCeremony requests the node to start a ceremony with the given request body, it returns the id of the ceremony
+func startCeremony(adminAPI string, path string, req map[string]interface{}) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	res, err := http.Post(strings.TrimSuffix(adminAPI, "/")+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "could not reach admin api")
+	}
+	var started struct {
+		ID string `json:"id"`
+	}
+	if err := readAdminAPIResponse(res, &started); err != nil {
+		return "", err
+	}
+	return started.ID, nil
+}
+
+// waitForCeremony polls the node until the given ceremony is finished, then writes its result to the output file
+func waitForCeremony(logger *zap.Logger, adminAPI string, id string, output string) {
+	var state *dkg.CeremonyState
+	var err error
+	for {
+		time.Sleep(dkgPollInterval)
+		state, err = getCeremony(adminAPI, id)
+		if err != nil {
+			logger.Fatal("failed to get ceremony", zap.Error(err))
+		}
+		if state.Status != dkg.StatusRunning {
+			break
+		}
+		logger.Debug("ceremony is running", zap.String("phase", state.Phase))
+	}
+	if state.Status != dkg.StatusCompleted {
+		logger.Fatal("ceremony failed", zap.String("phase", state.Phase), zap.String("error", state.Error))
+	}
+	if err := writeCeremonyResult(output, state); err != nil {
+		logger.Fatal("failed to write ceremony result", zap.Error(err))
+	}
+	logger.Info("ceremony completed", zap.String("validatorPubKey", state.Result.ValidatorPubKey), zap.String("file", output))
+}
+
+// getCeremony requests the state of the given ceremony from the node
+func getCeremony(adminAPI string, id string) (*dkg.CeremonyState, error) {
+	res, err := http.Get(fmt.Sprintf("%s/dkg?id=%s", strings.TrimSuffix(adminAPI, "/"), url.QueryEscape(id)))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reach admin api")
+	}
+	state := &dkg.CeremonyState{}
+	if err := readAdminAPIResponse(res, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// readAdminAPIResponse decodes a json response of the admin api into the given object
+func readAdminAPIResponse(res *http.Response, obj interface{}) error {
+	defer res.Body.Close()
+	raw, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "could not read response")
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("admin api responded with %d: %s", res.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return errors.Wrap(json.Unmarshal(raw, obj), "could not decode response")
+}
+
+func writeCeremonyResult(file string, state *dkg.CeremonyState) error {
+	raw, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal result")
+	}
+	return ioutil.WriteFile(file, raw, 0600)
+}
+
+func init() {
+	flags.AddAdminAPIFlag(startDKGCmd)
+	flags.AddDKGOperatorsFlag(startDKGCmd)
+	flags.AddDKGThresholdFlag(startDKGCmd)
+	flags.AddDKGOutputFlag(startDKGCmd)
+	dkgCmd.AddCommand(startDKGCmd)
+
+	flags.AddAdminAPIFlag(reshareDKGCmd)
+	flags.AddDKGValidatorFlag(reshareDKGCmd)
+	flags.AddDKGOperatorsFlag(reshareDKGCmd)
+	flags.AddDKGThresholdFlag(reshareDKGCmd)
+	flags.AddDKGOutputFlag(reshareDKGCmd)
+	dkgCmd.AddCommand(reshareDKGCmd)
+
+	flags.AddAdminAPIFlag(dkgResultCmd)
+	flags.AddDKGCeremonyFlag(dkgResultCmd)
+	flags.AddDKGOutputFlag(dkgResultCmd)
+	dkgCmd.AddCommand(dkgResultCmd)
+
+	RootCmd.AddCommand(dkgCmd)
+}
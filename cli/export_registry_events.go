@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"crypto/rsa"
+	"log"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/goeth"
+	"github.com/bloxapp/ssv/eth1/replay"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+type exportRegistryEventsConfig struct {
+	global_config.GlobalConfig `yaml:"global"`
+	ETH1Options                eth1.Options `yaml:"eth1"`
+	// ETH2Options holds only the network, the beacon node is not used by this command
+	ETH2Options struct {
+		Network string `yaml:"Network" env:"NETWORK" env-default:"prater" env-description:"network profile (mainnet, prater or a custom profile)"`
+	} `yaml:"eth2"`
+}
+
+// exportRegistryEventsCmd is the command to export the registry contract logs into a file,
+// which could be used later on to sync the registry without an eth1 node (ETH1LogsFile)
+var exportRegistryEventsCmd = &cobra.Command{
+	Use:   "export-registry-events",
+	Short: "exports registry contract events into a file",
+	Run: func(cmd *cobra.Command, args []string) {
+		configPath, err := flags.GetConfigFlagValue(cmd)
+		if err != nil {
+			log.Fatal("failed to get config flag value", zap.Error(err))
+		}
+		var cfg exportRegistryEventsConfig
+		if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			log.Fatal(err)
+		}
+		loggerLevel, _ := logex.GetLoggerLevelValue(cfg.LogLevel)
+		logger := logex.Build(RootCmd.Short, loggerLevel, nil)
+
+		output, err := flags.GetOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+		if len(cfg.ETH1Options.ETH1Addr) == 0 {
+			logger.Fatal("eth1 node address is required")
+		}
+		networkProfile, err := networkconfig.Load(cfg.ETH2Options.Network, cfg.NetworkProfiles)
+		if err != nil {
+			logger.Fatal("failed to load network profile", zap.Error(err))
+		}
+		if err := networkProfile.Apply(nil, &cfg.ETH1Options, nil); err != nil {
+			logger.Fatal("failed to apply network profile", zap.Error(err))
+		}
+		abiVersion, err := cfg.ETH1Options.ContractVersion()
+		if err != nil {
+			logger.Fatal("invalid abi version", zap.Error(err))
+		}
+		if len(cfg.ETH1Options.RegistryContractABI) > 0 {
+			if err := eth1.LoadABI(cfg.ETH1Options.RegistryContractABI); err != nil {
+				logger.Fatal("failed to load ABI JSON", zap.Error(err))
+			}
+		}
+		eth1Client, err := goeth.NewEth1Client(goeth.ClientOptions{
+			Ctx:                  cmd.Context(),
+			Logger:               logger,
+			NodeAddr:             cfg.ETH1Options.ETH1Addr,
+			ContractABI:          eth1.ContractABI(abiVersion),
+			ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+			RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
+			FollowDistance:       cfg.ETH1Options.ETH1FollowDistance,
+			// shares are not decrypted as only the raw logs are exported
+			ShareEncryptionKeyProvider: func() (*rsa.PrivateKey, bool, error) {
+				return nil, true, nil
+			},
+			AbiVersion: abiVersion,
+		})
+		if err != nil {
+			logger.Fatal("failed to create eth1 client", zap.Error(err))
+		}
+
+		fromBlock := eth1.HexStringToSyncOffset(cfg.ETH1Options.ETH1SyncOffset)
+		if fromBlock == nil {
+			fromBlock = new(eth1.SyncOffset)
+		}
+		dump, err := replay.ExportLogs(eth1Client, cfg.ETH1Options.RegistryContractAddr, fromBlock)
+		if err != nil {
+			logger.Fatal("failed to export contract logs", zap.Error(err))
+		}
+		if err := replay.WriteLogsDump(output, dump); err != nil {
+			logger.Fatal("failed to write contract logs", zap.Error(err))
+		}
+		logger.Info("exported registry contract events", zap.String("file", output),
+			zap.Int("logs", len(dump.Logs)), zap.Uint64("fromBlock", dump.FromBlock), zap.Uint64("toBlock", dump.ToBlock))
+	},
+}
+
+func init() {
+	flags.AddConfigFlag(exportRegistryEventsCmd)
+	flags.AddOutputFlag(exportRegistryEventsCmd)
+
+	RootCmd.AddCommand(exportRegistryEventsCmd)
+}
@@ -9,12 +9,15 @@ import (
 	global_config "github.com/bloxapp/ssv/cli/config"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/goeth"
+	"github.com/bloxapp/ssv/eth1/replay"
 	"github.com/bloxapp/ssv/exporter"
 	"github.com/bloxapp/ssv/exporter/api"
+	exporterstorage "github.com/bloxapp/ssv/exporter/storage"
 	"github.com/bloxapp/ssv/migrations"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	networkForkV0 "github.com/bloxapp/ssv/network/forks/v0"
 	"github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils"
@@ -41,6 +44,7 @@ type config struct {
 	EnableProfile                   bool          `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	IbftSyncEnabled                 bool          `yaml:"IbftSyncEnabled" env:"IBFT_SYNC_ENABLED" env-default:"false" env-description:"enable ibft sync for all topics"`
 	ValidatorMetaDataUpdateInterval time.Duration `yaml:"ValidatorMetaDataUpdateInterval" env:"VALIDATOR_METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"set the interval at which validator metadata gets updated"`
+	DecidedRetention                uint64        `yaml:"DecidedRetention" env:"DECIDED_RETENTION" env-default:"0" env-description:"Number of the last decided sequences to keep per validator, 0 keeps the full history"`
 	NetworkPrivateKey               string        `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 
 	// TODO: change this after network refactoring
@@ -96,6 +100,19 @@ var StartExporterNodeCmd = &cobra.Command{
 			Logger.Fatal("failed to run migrations", zap.Error(err))
 		}
 
+		networkProfile, err := networkconfig.Load(cfg.ETH2Options.Network, cfg.NetworkProfiles)
+		if err != nil {
+			Logger.Fatal("failed to load network profile", zap.Error(err))
+		}
+		if err := networkProfile.Apply(&cfg.ETH2Options, &cfg.ETH1Options, &cfg.P2pNetworkConfig); err != nil {
+			Logger.Fatal("failed to apply network profile", zap.Error(err))
+		}
+		abiVersion, err := cfg.ETH1Options.ContractVersion()
+		if err != nil {
+			Logger.Fatal("invalid abi version", zap.Error(err))
+		}
+		Logger.Info("using network profile", zap.String("network", networkProfile.Name))
+
 		cfg.P2pNetworkConfig.NetworkPrivateKey, err = utils.ECDSAPrivateKey(Logger.With(zap.String("who", "p2pNetworkPrivateKey")), cfg.NetworkPrivateKey)
 		if err != nil {
 			log.Fatal("Failed to get p2p privateKey", zap.Error(err))
@@ -109,7 +126,7 @@ var StartExporterNodeCmd = &cobra.Command{
 			Logger.Fatal("failed to create network", zap.Error(err))
 		}
 
-		Logger.Info("using registry contract address", zap.String("addr", cfg.ETH1Options.RegistryContractAddr), zap.String("abiVersion", cfg.ETH1Options.AbiVersion.String()))
+		Logger.Info("using registry contract address", zap.String("addr", cfg.ETH1Options.RegistryContractAddr), zap.String("abiVersion", abiVersion.String()))
 
 		if len(cfg.ETH1Options.RegistryContractABI) > 0 {
 			Logger.Info("using registry contract abi", zap.String("abi", cfg.ETH1Options.RegistryContractABI))
@@ -117,20 +134,37 @@ var StartExporterNodeCmd = &cobra.Command{
 				Logger.Fatal("failed to load ABI JSON", zap.Error(err))
 			}
 		}
-		eth1Client, err := goeth.NewEth1Client(goeth.ClientOptions{
-			Ctx:                  cmd.Context(),
-			Logger:               Logger,
-			NodeAddr:             cfg.ETH1Options.ETH1Addr,
-			ContractABI:          eth1.ContractABI(cfg.ETH1Options.AbiVersion),
-			ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
-			RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
-			// using an empty private key provider
-			// because the exporter doesn't run in the context of an operator
-			ShareEncryptionKeyProvider: func() (*rsa.PrivateKey, bool, error) {
-				return nil, true, nil
-			},
-			AbiVersion: cfg.ETH1Options.AbiVersion,
-		})
+		// using an empty private key provider
+		// because the exporter doesn't run in the context of an operator
+		shareEncryptionKeyProvider := func() (*rsa.PrivateKey, bool, error) {
+			return nil, true, nil
+		}
+		var eth1Client eth1.Client
+		if len(cfg.ETH1Options.ETH1LogsFile) > 0 {
+			Logger.Info("using contract logs file", zap.String("file", cfg.ETH1Options.ETH1LogsFile))
+			eth1Client, err = replay.NewEth1Client(replay.ClientOptions{
+				Logger:                     Logger,
+				LogsFile:                   cfg.ETH1Options.ETH1LogsFile,
+				RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
+				ContractABI:                eth1.ContractABI(abiVersion),
+				ShareEncryptionKeyProvider: shareEncryptionKeyProvider,
+				AbiVersion:                 abiVersion,
+			})
+		} else {
+			eth1Client, err = goeth.NewEth1Client(goeth.ClientOptions{
+				Ctx:                        cmd.Context(),
+				Logger:                     Logger,
+				NodeAddr:                   cfg.ETH1Options.ETH1Addr,
+				ContractABI:                eth1.ContractABI(abiVersion),
+				ConnectionTimeout:          cfg.ETH1Options.ETH1ConnectionTimeout,
+				RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
+				FollowDistance:             cfg.ETH1Options.ETH1FollowDistance,
+				PollingInterval:            cfg.ETH1Options.ETH1PollingInterval,
+				ShareEncryptionKeyProvider: shareEncryptionKeyProvider,
+				SyncedBlocksStorage:        exporterstorage.NewExporterStorage(db, Logger),
+				AbiVersion:                 abiVersion,
+			})
+		}
 		if err != nil {
 			Logger.Fatal("failed to create eth1 client", zap.Error(err))
 		}
@@ -157,6 +191,7 @@ var StartExporterNodeCmd = &cobra.Command{
 		exporterOptions.IbftSyncEnabled = cfg.IbftSyncEnabled
 		exporterOptions.CleanRegistryData = cfg.ETH1Options.CleanRegistryData
 		exporterOptions.ValidatorMetaDataUpdateInterval = cfg.ValidatorMetaDataUpdateInterval
+		exporterOptions.DecidedRetention = cfg.DecidedRetention
 		exporterOptions.UseMainTopic = cfg.P2pNetworkConfig.UseMainTopic
 		exporterOptions.NumOfInstances = cfg.NumOfInstances
 		exporterOptions.InstanceID = cfg.InstanceID
@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	adminAPIFlag = "admin-api"
+)
+
+// AddAdminAPIFlag adds the admin api address flag to the command
+func AddAdminAPIFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, adminAPIFlag, "http://localhost:15001", "Address of the admin api of the node", false)
+}
+
+// GetAdminAPIFlagValue gets the admin api address flag from the command
+func GetAdminAPIFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(adminAPIFlag)
+}
+
+// IsAdminAPIFlagSet returns true if the admin api address was given explicitly
+func IsAdminAPIFlagSet(c *cobra.Command) bool {
+	return c.Flags().Changed(adminAPIFlag)
+}
@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	newPasswordFileFlag = "new-password-file"
+)
+
+// AddNewPasswordFileFlag adds the new password file flag to the command
+func AddNewPasswordFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, newPasswordFileFlag, "", "Path to a file containing the new encryption password (or key)", true)
+}
+
+// GetNewPasswordFileFlagValue gets the new password file flag from the command
+func GetNewPasswordFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(newPasswordFileFlag)
+}
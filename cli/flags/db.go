@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	backupFileFlag   = "file"
+	forceRestoreFlag = "force"
+)
+
+// AddBackupFileFlag adds the db backup file flag to the command
+func AddBackupFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupFileFlag, "./ssv-db.backup", "Path to db backup file", false)
+}
+
+// GetBackupFileFlagValue gets the db backup file flag from the command
+func GetBackupFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupFileFlag)
+}
+
+// AddForceRestoreFlag adds the force restore flag to the command
+func AddForceRestoreFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, forceRestoreFlag, false, "Restore even if the db has newer slashing protection data than the backup")
+}
+
+// GetForceRestoreFlagValue gets the force restore flag from the command
+func GetForceRestoreFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(forceRestoreFlag)
+}
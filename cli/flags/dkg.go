@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	dkgOperatorsFlag = "operators"
+	dkgThresholdFlag = "threshold"
+	dkgOutputFlag    = "output"
+	dkgCeremonyFlag  = "id"
+	dkgValidatorFlag = "validator"
+)
+
+// AddDKGOperatorsFlag adds the dkg operators flag to the command
+func AddDKGOperatorsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dkgOperatorsFlag, "", "Comma separated public keys (base64) of the ceremony operators, ordered by their share index", true)
+}
+
+// GetDKGOperatorsFlagValue gets the dkg operators flag from the command
+func GetDKGOperatorsFlagValue(c *cobra.Command) ([]string, error) {
+	val, err := c.Flags().GetString(dkgOperatorsFlag)
+	if err != nil {
+		return nil, err
+	}
+	var operators []string
+	for _, pk := range strings.Split(val, ",") {
+		if pk = strings.TrimSpace(pk); len(pk) > 0 {
+			operators = append(operators, pk)
+		}
+	}
+	return operators, nil
+}
+
+// AddDKGThresholdFlag adds the dkg threshold flag to the command
+func AddDKGThresholdFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, dkgThresholdFlag, 3, "Amount of shares that are needed to sign", false)
+}
+
+// GetDKGThresholdFlagValue gets the dkg threshold flag from the command
+func GetDKGThresholdFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(dkgThresholdFlag)
+}
+
+// AddDKGOutputFlag adds the dkg output file flag to the command
+func AddDKGOutputFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dkgOutputFlag, "./dkg-result.json", "Path to the file that the ceremony result is written to", false)
+}
+
+// GetDKGOutputFlagValue gets the dkg output file flag from the command
+func GetDKGOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dkgOutputFlag)
+}
+
+// AddDKGCeremonyFlag adds the dkg ceremony id flag to the command
+func AddDKGCeremonyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dkgCeremonyFlag, "", "Id of the ceremony", true)
+}
+
+// GetDKGCeremonyFlagValue gets the dkg ceremony id flag from the command
+func GetDKGCeremonyFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dkgCeremonyFlag)
+}
+
+// AddDKGValidatorFlag adds the dkg validator public key flag to the command
+func AddDKGValidatorFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dkgValidatorFlag, "", "Public key (hex) of the validator to reshare", true)
+}
+
+// GetDKGValidatorFlagValue gets the dkg validator public key flag from the command
+func GetDKGValidatorFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dkgValidatorFlag)
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	configFlag = "config"
+	outputFlag = "output"
+)
+
+// AddConfigFlag adds the config file flag to the command
+func AddConfigFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, configFlag, "./config/config.yaml", "Path to configuration file", false)
+}
+
+// GetConfigFlagValue gets the config file flag from the command
+func GetConfigFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(configFlag)
+}
+
+// AddOutputFlag adds the output file flag to the command
+func AddOutputFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputFlag, "./registry-events.json", "Path to output file", false)
+}
+
+// GetOutputFlagValue gets the output file flag from the command
+func GetOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(outputFlag)
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	interchangeFileFlag = "file"
+	dryRunFlag          = "dry-run"
+)
+
+// AddInterchangeFileFlag adds the slashing protection interchange file flag to the command
+func AddInterchangeFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, interchangeFileFlag, "./slashing-protection.json", "Path to EIP-3076 interchange file", false)
+}
+
+// GetInterchangeFileFlagValue gets the slashing protection interchange file flag from the command
+func GetInterchangeFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(interchangeFileFlag)
+}
+
+// AddDryRunFlag adds the dry run flag to the command
+func AddDryRunFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, dryRunFlag, false, "Print the changes without applying them")
+}
+
+// GetDryRunFlagValue gets the dry run flag from the command
+func GetDryRunFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(dryRunFlag)
+}
@@ -5,22 +5,27 @@ import (
 	"fmt"
 	ssv_identity "github.com/bloxapp/ssv/identity"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
 
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/goclient"
 	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/dkg"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/goeth"
+	"github.com/bloxapp/ssv/eth1/replay"
 	"github.com/bloxapp/ssv/migrations"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/operator"
 	"github.com/bloxapp/ssv/operator/duties"
 	v0 "github.com/bloxapp/ssv/operator/forks/v0"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
 	"github.com/bloxapp/ssv/utils/commons"
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
@@ -32,15 +37,19 @@ import (
 
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
-	DBOptions                  basedb.Options   `yaml:"db"`
-	SSVOptions                 operator.Options `yaml:"ssv"`
-	ETH1Options                eth1.Options     `yaml:"eth1"`
-	ETH2Options                beacon.Options   `yaml:"eth2"`
-	P2pNetworkConfig           p2p.Config       `yaml:"p2p"`
+	DBOptions                  basedb.Options     `yaml:"db"`
+	SSVOptions                 operator.Options   `yaml:"ssv"`
+	ETH1Options                eth1.Options       `yaml:"eth1"`
+	ETH2Options                beacon.Options     `yaml:"eth2"`
+	P2pNetworkConfig           p2p.Config         `yaml:"p2p"`
+	EncryptionOptions          encryption.Options `yaml:"encryption"`
 
 	OperatorPrivateKey         string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	GenerateOperatorPrivateKey bool   `yaml:"GenerateOperatorPrivateKey" env:"GENERATE_OPERATOR_KEY" env-description:"Whether to generate operator key if none is passed by config"`
 	MetricsAPIPort             int    `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"port of metrics api"`
+	AdminAPIPort               int    `yaml:"AdminAPIPort" env:"ADMIN_API_PORT" env-description:"port of admin api, used to query the status of validators"`
+	AdminAPIHost               string `yaml:"AdminAPIHost" env:"ADMIN_API_HOST" env-default:"127.0.0.1" env-description:"loopback host that the admin api listens on, the api is not authenticated"`
+	AdminAPIEnableBackup       bool   `yaml:"AdminAPIEnableBackup" env:"ADMIN_API_ENABLE_BACKUP" env-description:"whether the admin api serves db backups (/db/backup)"`
 	EnableProfile              bool   `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	NetworkPrivateKey          string `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 
@@ -97,13 +106,28 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Fatal("failed to run migrations", zap.Error(err))
 		}
 
-		eth2Network := core.NetworkFromString(cfg.ETH2Options.Network)
+		encryptionKey := unlockStorage(db, cfg.EncryptionOptions, Logger)
+
+		networkProfile, err := networkconfig.Load(cfg.ETH2Options.Network, cfg.NetworkProfiles)
+		if err != nil {
+			Logger.Fatal("failed to load network profile", zap.Error(err))
+		}
+		if err := networkProfile.Apply(&cfg.ETH2Options, &cfg.ETH1Options, &cfg.P2pNetworkConfig); err != nil {
+			Logger.Fatal("failed to apply network profile", zap.Error(err))
+		}
+		abiVersion, err := cfg.ETH1Options.ContractVersion()
+		if err != nil {
+			Logger.Fatal("invalid abi version", zap.Error(err))
+		}
+		eth2Network := cfg.ETH2Options.ETHNetwork
+		Logger.Info("using network profile", zap.String("network", networkProfile.Name))
 
 		// TODO Not refactored yet Start (refactor in exporter as well):
 		cfg.ETH2Options.Context = cmd.Context()
 		cfg.ETH2Options.Logger = Logger
 		cfg.ETH2Options.Graffiti = []byte("SSV.Network")
 		cfg.ETH2Options.DB = db
+		cfg.ETH2Options.EncryptionKey = encryptionKey
 		beaconClient, err := goclient.New(cfg.ETH2Options)
 		if err != nil {
 			Logger.Fatal("failed to create beacon go-client", zap.Error(err),
@@ -111,6 +135,11 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		nodeStorage := operator.NewNodeStorage(db, Logger)
+		if len(encryptionKey) > 0 {
+			if err := nodeStorage.SetEncryptionKey(encryptionKey); err != nil {
+				Logger.Fatal("failed to encrypt operator private key", zap.Error(err))
+			}
+		}
 		if err := nodeStorage.SetupPrivateKey(cfg.GenerateOperatorPrivateKey, cfg.OperatorPrivateKey); err != nil {
 			Logger.Fatal("failed to setup operator private key", zap.Error(err))
 		}
@@ -162,7 +191,7 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.OperatorPubKey = operatorPubKey
 		cfg.SSVOptions.ValidatorOptions.RegistryStorage = nodeStorage
 
-		Logger.Info("using registry contract address", zap.String("addr", cfg.ETH1Options.RegistryContractAddr), zap.String("abi version", cfg.ETH1Options.AbiVersion.String()))
+		Logger.Info("using registry contract address", zap.String("addr", cfg.ETH1Options.RegistryContractAddr), zap.String("abi version", abiVersion.String()))
 
 		// create new eth1 client
 		if len(cfg.ETH1Options.RegistryContractABI) > 0 {
@@ -171,17 +200,33 @@ var StartNodeCmd = &cobra.Command{
 				Logger.Fatal("failed to load ABI JSON", zap.Error(err))
 			}
 		}
-		cfg.SSVOptions.Eth1Client, err = goeth.NewEth1Client(goeth.ClientOptions{
-			Ctx:                        cmd.Context(),
-			Logger:                     Logger,
-			NodeAddr:                   cfg.ETH1Options.ETH1Addr,
-			ConnectionTimeout:          cfg.ETH1Options.ETH1ConnectionTimeout,
-			ContractABI:                eth1.ContractABI(cfg.ETH1Options.AbiVersion),
-			RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
-			ShareEncryptionKeyProvider: nodeStorage.GetPrivateKey,
-			OperatorPubKey:             operatorPubKey,
-			AbiVersion:                 cfg.ETH1Options.AbiVersion,
-		})
+		if len(cfg.ETH1Options.ETH1LogsFile) > 0 {
+			Logger.Info("using contract logs file", zap.String("file", cfg.ETH1Options.ETH1LogsFile))
+			cfg.SSVOptions.Eth1Client, err = replay.NewEth1Client(replay.ClientOptions{
+				Logger:                     Logger,
+				LogsFile:                   cfg.ETH1Options.ETH1LogsFile,
+				RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
+				ContractABI:                eth1.ContractABI(abiVersion),
+				ShareEncryptionKeyProvider: nodeStorage.GetPrivateKey,
+				OperatorPubKey:             operatorPubKey,
+				AbiVersion:                 abiVersion,
+			})
+		} else {
+			cfg.SSVOptions.Eth1Client, err = goeth.NewEth1Client(goeth.ClientOptions{
+				Ctx:                        cmd.Context(),
+				Logger:                     Logger,
+				NodeAddr:                   cfg.ETH1Options.ETH1Addr,
+				ConnectionTimeout:          cfg.ETH1Options.ETH1ConnectionTimeout,
+				ContractABI:                eth1.ContractABI(abiVersion),
+				RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
+				ShareEncryptionKeyProvider: nodeStorage.GetPrivateKey,
+				OperatorPubKey:             operatorPubKey,
+				FollowDistance:             cfg.ETH1Options.ETH1FollowDistance,
+				PollingInterval:            cfg.ETH1Options.ETH1PollingInterval,
+				SyncedBlocksStorage:        nodeStorage,
+				AbiVersion:                 abiVersion,
+			})
+		}
 		if err != nil {
 			Logger.Fatal("failed to create eth1 client", zap.Error(err))
 		}
@@ -190,12 +235,26 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorController = validatorCtrl
 		if cfg.ReadOnlyMode {
 			cfg.SSVOptions.DutyExec = duties.NewReadOnlyExecutor(Logger)
+		} else {
+			cfg.SSVOptions.DKGOptions.Context = ctx
+			cfg.SSVOptions.DKGOptions.Logger = Logger
+			cfg.SSVOptions.DKGOptions.DB = db
+			cfg.SSVOptions.DKGOptions.Network = p2pNet
+			cfg.SSVOptions.DKGOptions.OperatorPrivateKey = operatorPrivateKey
+			cfg.SSVOptions.DKGOptions.Shares = validatorCtrl
+			cfg.SSVOptions.DKGController, err = dkg.NewController(cfg.SSVOptions.DKGOptions)
+			if err != nil {
+				Logger.Fatal("failed to create dkg controller", zap.Error(err))
+			}
 		}
 		operatorNode = operator.New(cfg.SSVOptions)
 
 		if cfg.MetricsAPIPort > 0 {
 			go startMetricsHandler(cmd.Context(), Logger, cfg.MetricsAPIPort, cfg.EnableProfile)
 		}
+		if cfg.AdminAPIPort > 0 {
+			startAdminAPI(Logger, cfg.AdminAPIHost, cfg.AdminAPIPort, cfg.AdminAPIEnableBackup)
+		}
 
 		metrics.WaitUntilHealthy(Logger, cfg.SSVOptions.Eth1Client, "eth1 node")
 		metrics.WaitUntilHealthy(Logger, beaconClient, "beacon node")
@@ -222,3 +281,35 @@ func startMetricsHandler(ctx context.Context, logger *zap.Logger, port int, enab
 		logger.Error("failed to start metrics handler", zap.Error(err))
 	}
 }
+
+func startAdminAPI(logger *zap.Logger, host string, port int, enableBackup bool) {
+	adminAPI := operator.NewAdminAPI(logger, operatorNode, enableBackup)
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	if err := adminAPI.Start(http.NewServeMux(), addr); err != nil {
+		logger.Fatal("failed to start admin api", zap.Error(err))
+	}
+}
+
+// unlockStorage returns the key that sensitive data in db is encrypted with, or nil if encryption is disabled
+func unlockStorage(db basedb.IDb, opts encryption.Options, logger *zap.Logger) []byte {
+	if !opts.Enabled() {
+		encrypted, err := encryption.IsInitialized(db)
+		if err != nil {
+			logger.Fatal("failed to check storage encryption", zap.Error(err))
+		}
+		if encrypted {
+			logger.Fatal("storage is encrypted, encryption password file or key file must be provided")
+		}
+		return nil
+	}
+	password, err := opts.Password()
+	if err != nil {
+		logger.Fatal("failed to read encryption password", zap.Error(err))
+	}
+	key, err := encryption.Unlock(db, password)
+	if err != nil {
+		logger.Fatal("failed to unlock storage", zap.Error(err))
+	}
+	logger.Info("storage was unlocked")
+	return key
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+)
+
+type slashingProtectionConfig struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options `yaml:"db"`
+	// ETH2Options holds only the network, the beacon node is not used by this command
+	ETH2Options struct {
+		Network string `yaml:"Network" env:"NETWORK" env-default:"prater" env-description:"network profile (mainnet, prater or a custom profile)"`
+	} `yaml:"eth2"`
+}
+
+// slashingProtectionCmd is the parent command of the EIP-3076 slashing protection interchange commands,
+// the node must be stopped as the db is opened by these commands
+var slashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "imports and exports slashing protection data (EIP-3076 interchange format)",
+}
+
+var exportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export",
+	Short: "exports the slashing protection data of the node's validators into an interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, logger := readSlashingProtectionConfig(cmd)
+		file, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		dryRun, err := flags.GetDryRunFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get dry run flag value", zap.Error(err))
+		}
+		network, genesisValidatorsRoot := slashingProtectionNetwork(cfg, logger)
+		db, shareKeys := openSlashingProtectionDB(cmd, cfg, logger)
+		defer db.Close()
+
+		interchange, err := ekm.ExportInterchange(db, network.BaseNetwork, genesisValidatorsRoot, shareKeys)
+		if err != nil {
+			logger.Fatal("failed to export slashing protection data", zap.Error(err))
+		}
+		for _, d := range interchange.Data {
+			logger.Info("exporting validator", zap.String("pubKey", d.Pubkey),
+				zap.Any("attestations", d.SignedAttestations), zap.Any("blocks", d.SignedBlocks))
+		}
+		if dryRun {
+			logger.Info("dry run, interchange file was not written", zap.Int("validators", len(interchange.Data)))
+			return
+		}
+		raw, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal interchange", zap.Error(err))
+		}
+		if err := ioutil.WriteFile(file, raw, 0600); err != nil {
+			logger.Fatal("failed to write interchange file", zap.Error(err))
+		}
+		logger.Info("exported slashing protection data", zap.String("file", file), zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+var importSlashingProtectionCmd = &cobra.Command{
+	Use:   "import",
+	Short: "imports an interchange file into the slashing protection data of the node's validators",
+	Long: "imports an interchange file into the slashing protection data of the node's validators, " +
+		"existing data is only raised (highest epochs and slot). validators must be synced from the registry before the import",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, logger := readSlashingProtectionConfig(cmd)
+		file, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		dryRun, err := flags.GetDryRunFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get dry run flag value", zap.Error(err))
+		}
+		interchange, err := readInterchange(file)
+		if err != nil {
+			logger.Fatal("failed to read interchange file", zap.Error(err))
+		}
+		network, genesisValidatorsRoot := slashingProtectionNetwork(cfg, logger)
+		if err := interchange.Validate(genesisValidatorsRoot); err != nil {
+			logger.Fatal("invalid interchange file", zap.Error(err))
+		}
+		db, shareKeys := openSlashingProtectionDB(cmd, cfg, logger)
+		defer db.Close()
+
+		results, err := ekm.ImportInterchange(db, network.BaseNetwork, genesisValidatorsRoot, shareKeys, interchange, dryRun)
+		if err != nil {
+			logger.Fatal("failed to import slashing protection data", zap.Error(err))
+		}
+		updated := 0
+		for _, r := range results {
+			fields := []zap.Field{zap.String("pubKey", r.Pubkey), zap.String("status", string(r.Status))}
+			if r.Status != ekm.ImportStatusUnknownValidator {
+				fields = append(fields, zap.Uint64("sourceEpoch", r.SourceEpoch),
+					zap.Uint64("targetEpoch", r.TargetEpoch), zap.Uint64("slot", r.Slot))
+			}
+			if r.Status == ekm.ImportStatusUnknownValidator {
+				logger.Warn("skipping validator", fields...)
+				continue
+			}
+			if r.Status == ekm.ImportStatusUpdated {
+				updated++
+			}
+			logger.Info("importing validator", fields...)
+		}
+		if dryRun {
+			logger.Info("dry run, slashing protection data was not changed", zap.Int("validators", len(results)), zap.Int("updated", updated))
+			return
+		}
+		logger.Info("imported slashing protection data", zap.Int("validators", len(results)), zap.Int("updated", updated))
+	},
+}
+
+func readSlashingProtectionConfig(cmd *cobra.Command) (*slashingProtectionConfig, *zap.Logger) {
+	configPath, err := flags.GetConfigFlagValue(cmd)
+	if err != nil {
+		log.Fatal("failed to get config flag value", zap.Error(err))
+	}
+	var cfg slashingProtectionConfig
+	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+		log.Fatal(err)
+	}
+	loggerLevel, _ := logex.GetLoggerLevelValue(cfg.LogLevel)
+	return &cfg, logex.Build(RootCmd.Short, loggerLevel, nil)
+}
+
+// slashingProtectionNetwork returns the network profile and its genesis validators root
+func slashingProtectionNetwork(cfg *slashingProtectionConfig, logger *zap.Logger) (networkconfig.Profile, string) {
+	networkProfile, err := networkconfig.Load(cfg.ETH2Options.Network, cfg.NetworkProfiles)
+	if err != nil {
+		logger.Fatal("failed to load network profile", zap.Error(err))
+	}
+	if len(networkProfile.GenesisValidatorsRoot) == 0 {
+		logger.Fatal("network profile has no genesis validators root", zap.String("network", networkProfile.Name))
+	}
+	return networkProfile, networkProfile.GenesisValidatorsRoot
+}
+
+// openSlashingProtectionDB opens the node db and returns the share keys of the node's validators
+func openSlashingProtectionDB(cmd *cobra.Command, cfg *slashingProtectionConfig, logger *zap.Logger) (basedb.IDb, ekm.ShareKeys) {
+	cfg.DBOptions.Logger = logger
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		logger.Fatal("failed to open db", zap.Error(err))
+	}
+	threshold.Init()
+	shares, err := validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: logger}).GetAllValidatorShares()
+	if err != nil {
+		logger.Fatal("failed to get validator shares", zap.Error(err))
+	}
+	return db, shareKeys(shares)
+}
+
+// shareKeys maps the validators of the given shares to the public keys of the operator's shares
+func shareKeys(shares []*validatorstorage.Share) ekm.ShareKeys {
+	keys := make(ekm.ShareKeys)
+	for _, share := range shares {
+		node, found := share.Committee[share.NodeID]
+		if !found || share.PublicKey == nil {
+			continue
+		}
+		keys[share.PublicKey.SerializeToHexStr()] = node.Pk
+	}
+	return keys
+}
+
+func readInterchange(file string) (*ekm.Interchange, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	interchange := &ekm.Interchange{}
+	if err := json.Unmarshal(raw, interchange); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal interchange")
+	}
+	return interchange, nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{exportSlashingProtectionCmd, importSlashingProtectionCmd} {
+		flags.AddConfigFlag(c)
+		flags.AddInterchangeFileFlag(c)
+		flags.AddDryRunFlag(c)
+		slashingProtectionCmd.AddCommand(c)
+	}
+
+	RootCmd.AddCommand(slashingProtectionCmd)
+}
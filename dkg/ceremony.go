@@ -0,0 +1,707 @@
+package dkg
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// phase is a phase of the ceremony
+type phase int
+
+const (
+	// dealPhase - every operator deals shares of its own random polynomial
+	dealPhase phase = iota
+	// complaintPhase - every operator complains about dealers that dealt it an invalid share
+	complaintPhase
+	// justificationPhase - accused dealers reveal the shares that were complained about
+	justificationPhase
+	// outputPhase - operators exchange the resulting keys and verify that they agree
+	outputPhase
+	// donePhase - the ceremony completed
+	donePhase
+)
+
+var phaseNames = map[phase]string{
+	dealPhase:          "deal",
+	complaintPhase:     "complaint",
+	justificationPhase: "justification",
+	outputPhase:        "output",
+	donePhase:          "done",
+}
+
+func (p phase) String() string {
+	return phaseNames[p]
+}
+
+// ceremony is a single run of the Joint-Feldman DKG protocol, from the point of view of one of the operators.
+// each operator deals a random polynomial, the group secret is the sum of the secrets of the qualified dealers
+// and is never assembled. ceremony is not thread-safe, it is driven by the controller with messages and time.
+//
+// when resharing, the operators of the current committee deal polynomials whose secrets are their current shares,
+// and the new shares are the lagrange interpolation of the dealt shares, so the group secret doesn't change.
+type ceremony struct {
+	id             string
+	operators      []string
+	threshold      uint64
+	operatorKey    *rsa.PrivateKey
+	operatorPubKey string
+	timeout        time.Duration
+
+	// dealers are the operators that deal shares, the current committee when resharing
+	dealers []string
+	reshare *Reshare
+	// index is the index of the operator in operators, or 0 if it only deals
+	index uint64
+	// dealerIndex is the index of the operator in dealers, or 0 if it doesn't deal
+	dealerIndex uint64
+	// share is the current share of the operator, when resharing
+	share *OperatorShare
+
+	phase     phase
+	deadline  time.Time
+	startTime time.Time
+
+	// poly is the secret polynomial of this operator
+	poly []bls.SecretKey
+	// commitments are the commitments of the dealers, by index
+	commitments map[uint64][]bls.PublicKey
+	// shares are the valid shares that were dealt to this operator, by dealer
+	shares map[uint64]*bls.SecretKey
+	// invalid are the dealers that dealt an invalid share to this operator
+	invalid        map[uint64]bool
+	complaints     map[uint64][]uint64
+	justifications map[uint64]map[uint64]string
+	outputs        map[uint64]*Output
+	// handovers are the signing histories of the dealers, when resharing
+	handovers map[uint64]*Handover
+	// expected is the output that was computed by the operator, the outputs of all operators must match it
+	expected *Output
+
+	result *Result
+}
+
+// newCeremony creates a ceremony for the operator with the given key, which must be one of the participants.
+// when resharing, dealers must provide their current share
+func newCeremony(id string, init *Init, operatorKey *rsa.PrivateKey, operatorPubKey string, share *OperatorShare, timeout time.Duration) (*ceremony, error) {
+	if err := init.validate(); err != nil {
+		return nil, err
+	}
+	c := &ceremony{
+		id:             id,
+		operators:      init.Operators,
+		threshold:      init.Threshold,
+		operatorKey:    operatorKey,
+		operatorPubKey: operatorPubKey,
+		timeout:        timeout,
+		dealers:        init.Operators,
+		reshare:        init.Reshare,
+		commitments:    make(map[uint64][]bls.PublicKey),
+		shares:         make(map[uint64]*bls.SecretKey),
+		invalid:        make(map[uint64]bool),
+		complaints:     make(map[uint64][]uint64),
+		justifications: make(map[uint64]map[uint64]string),
+		outputs:        make(map[uint64]*Output),
+		handovers:      make(map[uint64]*Handover),
+	}
+	if init.Reshare != nil {
+		c.dealers = init.Reshare.Operators
+	}
+	c.index = indexOf(c.operators, operatorPubKey)
+	c.dealerIndex = indexOf(c.dealers, operatorPubKey)
+	if c.index == 0 && c.dealerIndex == 0 {
+		return nil, errors.New("operator is not a participant of the ceremony")
+	}
+	if c.reshare != nil && c.dealerIndex != 0 {
+		if share == nil {
+			return nil, errors.New("missing current share")
+		}
+		if err := matchSharePubKey(share.SecretKey, c.reshare.SharePubKeys[c.dealerIndex-1]); err != nil {
+			return nil, err
+		}
+		c.share = share
+	}
+	return c, nil
+}
+
+// senderIndex returns the index of the given operator in the role that sends the given message type,
+// deals and justifications are sent by dealers, complaints and outputs by operators. 0 means no such role
+func (c *ceremony) senderIndex(operatorPubKey string, msgType MsgType) uint64 {
+	if msgType == DealMsgType || msgType == JustificationMsgType {
+		return indexOf(c.dealers, operatorPubKey)
+	}
+	return indexOf(c.operators, operatorPubKey)
+}
+
+// indexOf returns the index (position + 1) of the given operator, or 0 if it is not in the list
+func indexOf(operators []string, operatorPubKey string) uint64 {
+	for i, op := range operators {
+		if op == operatorPubKey {
+			return uint64(i + 1)
+		}
+	}
+	return 0
+}
+
+// start generates the secret polynomial of the operator and returns its deal, or nil if it doesn't deal
+func (c *ceremony) start(now time.Time) (*Message, error) {
+	c.startTime = now
+	c.setPhase(dealPhase, now)
+	if c.dealerIndex == 0 {
+		return nil, nil
+	}
+	c.poly = make([]bls.SecretKey, c.threshold)
+	for i := range c.poly {
+		c.poly[i].SetByCSPRNG()
+	}
+	if c.share != nil {
+		c.poly[0] = *c.share.SecretKey
+	}
+	commitments := bls.GetMasterPublicKey(c.poly)
+	deal := &Deal{
+		Commitments: make([][]byte, len(commitments)),
+		Shares:      make(map[uint64]string),
+	}
+	if c.share != nil {
+		deal.Handover = c.share.Handover
+		c.handovers[c.dealerIndex] = c.share.Handover
+	}
+	for i := range commitments {
+		deal.Commitments[i] = commitments[i].Serialize()
+	}
+	for i, op := range c.operators {
+		index := uint64(i + 1)
+		share, err := evaluate(c.poly, index)
+		if err != nil {
+			return nil, err
+		}
+		if index == c.index {
+			c.shares[c.dealerIndex] = share
+			continue
+		}
+		pk, err := rsaencryption.ConvertEncodedPemToPublicKey(op)
+		if err != nil {
+			return nil, err
+		}
+		encrypted, err := rsaencryption.EncodeKey(pk, share.SerializeToHexStr())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encrypt share of operator %d", index)
+		}
+		deal.Shares[index] = encrypted
+	}
+	c.commitments[c.dealerIndex] = commitments
+	return &Message{Type: DealMsgType, CeremonyID: c.id, Deal: deal}, nil
+}
+
+// processMessage records the given message of the operator with the given index,
+// duplicated messages are ignored. phases are advanced by step
+func (c *ceremony) processMessage(from uint64, msg *Message) error {
+	switch msg.Type {
+	case DealMsgType:
+		return c.processDeal(from, msg.Deal)
+	case ComplaintMsgType:
+		if _, found := c.complaints[from]; found {
+			return nil
+		}
+		// late complaints are ignored as justifications might have been evaluated already
+		if c.phase > complaintPhase {
+			return errors.Errorf("complaint of operator %d was received in %s phase", from, c.phase)
+		}
+		c.complaints[from] = msg.Complaint.Dealers
+	case JustificationMsgType:
+		if _, found := c.justifications[from]; found {
+			return nil
+		}
+		if c.phase > justificationPhase {
+			return errors.Errorf("justification of operator %d was received in %s phase", from, c.phase)
+		}
+		c.justifications[from] = msg.Justification.Shares
+	case OutputMsgType:
+		if _, found := c.outputs[from]; !found {
+			c.outputs[from] = msg.Output
+		}
+	default:
+		return errors.Errorf("unexpected message type %d", msg.Type)
+	}
+	return nil
+}
+
+// processDeal verifies the share that was dealt to this operator against the commitments of the dealer
+func (c *ceremony) processDeal(from uint64, deal *Deal) error {
+	if _, found := c.commitments[from]; found {
+		return nil
+	}
+	if c.phase != dealPhase {
+		return errors.Errorf("deal of operator %d was received in %s phase", from, c.phase)
+	}
+	if uint64(len(deal.Commitments)) != c.threshold {
+		return errors.Errorf("deal of operator %d has %d commitments", from, len(deal.Commitments))
+	}
+	commitments := make([]bls.PublicKey, len(deal.Commitments))
+	for i, raw := range deal.Commitments {
+		if err := commitments[i].Deserialize(raw); err != nil {
+			return errors.Wrapf(err, "invalid commitment of operator %d", from)
+		}
+	}
+	// the secret of a resharing dealer must be its current share, otherwise the dealer is excluded
+	if c.reshare != nil && !bytes.Equal(commitments[0].Serialize(), c.reshare.SharePubKeys[from-1]) {
+		return errors.Errorf("deal of operator %d does not match its current share", from)
+	}
+	c.commitments[from] = commitments
+	c.handovers[from] = deal.Handover
+	if c.index == 0 {
+		return nil
+	}
+
+	share, err := c.decryptShare(deal.Shares[c.index])
+	if err == nil {
+		err = verifyShare(commitments, c.index, share)
+	}
+	if err != nil {
+		c.invalid[from] = true
+		return errors.Wrapf(err, "invalid share from operator %d", from)
+	}
+	c.shares[from] = share
+	return nil
+}
+
+// step advances the ceremony as far as possible (all messages of the phase were received or the phase timed out),
+// it returns the messages that should be broadcasted. an error means that the ceremony failed
+func (c *ceremony) step(now time.Time) ([]*Message, error) {
+	var msgs []*Message
+	n := len(c.operators)
+	for {
+		timedOut := !now.Before(c.deadline)
+		switch c.phase {
+		case dealPhase:
+			if len(c.commitments) < len(c.dealers) && !timedOut {
+				return msgs, nil
+			}
+			if msg := c.endDealPhase(now); msg != nil {
+				msgs = append(msgs, msg)
+			}
+		case complaintPhase:
+			if len(c.complaints) < n && !timedOut {
+				return msgs, nil
+			}
+			if msg := c.endComplaintPhase(now); msg != nil {
+				msgs = append(msgs, msg)
+			}
+		case justificationPhase:
+			if !c.allJustified() && !timedOut {
+				return msgs, nil
+			}
+			msg, err := c.endJustificationPhase(now)
+			if err != nil {
+				return msgs, err
+			}
+			if msg != nil {
+				msgs = append(msgs, msg)
+			}
+		case outputPhase:
+			if len(c.outputs) < n && !timedOut {
+				return msgs, nil
+			}
+			return msgs, c.endOutputPhase(now)
+		default:
+			return msgs, nil
+		}
+	}
+}
+
+// endDealPhase returns the complaint of this operator, dealers that didn't deal are excluded anyway
+func (c *ceremony) endDealPhase(now time.Time) *Message {
+	if c.index == 0 {
+		c.setPhase(complaintPhase, now)
+		return nil
+	}
+	complaint := &Complaint{Dealers: sortedIndexes(c.invalid)}
+	c.complaints[c.index] = complaint.Dealers
+	c.setPhase(complaintPhase, now)
+	return &Message{Type: ComplaintMsgType, CeremonyID: c.id, Complaint: complaint}
+}
+
+// endComplaintPhase returns a justification if this operator was complained about
+func (c *ceremony) endComplaintPhase(now time.Time) *Message {
+	c.setPhase(justificationPhase, now)
+	complainers := c.accused()[c.dealerIndex]
+	if c.dealerIndex == 0 || len(complainers) == 0 {
+		return nil
+	}
+	justification := &Justification{Shares: make(map[uint64]string)}
+	for _, complainer := range complainers {
+		share, err := evaluate(c.poly, complainer)
+		if err != nil {
+			continue
+		}
+		justification.Shares[complainer] = share.SerializeToHexStr()
+	}
+	c.justifications[c.dealerIndex] = justification.Shares
+	return &Message{Type: JustificationMsgType, CeremonyID: c.id, Justification: justification}
+}
+
+// endJustificationPhase disqualifies dealers that were not justified, computes the keys and returns the output
+func (c *ceremony) endJustificationPhase(now time.Time) (*Message, error) {
+	disqualified := make(map[uint64]bool)
+	for dealer, complainers := range c.accused() {
+		shares := c.justifications[dealer]
+		for _, complainer := range complainers {
+			share := &bls.SecretKey{}
+			if err := share.SetHexString(shares[complainer]); err != nil || verifyShare(c.commitments[dealer], complainer, share) != nil {
+				disqualified[dealer] = true
+				break
+			}
+			if complainer == c.index {
+				c.shares[dealer] = share
+			}
+		}
+	}
+	var qualified []uint64
+	for dealer := range c.commitments {
+		if !disqualified[dealer] {
+			qualified = append(qualified, dealer)
+		}
+	}
+	sort.Slice(qualified, func(i, j int) bool { return qualified[i] < qualified[j] })
+	minQualified := c.threshold
+	if c.reshare != nil {
+		minQualified = c.reshare.Threshold
+	}
+	if uint64(len(qualified)) < minQualified {
+		return nil, errors.Errorf("not enough qualified dealers: %v", qualified)
+	}
+
+	groupCommitments, err := c.groupCommitments(qualified)
+	if err != nil {
+		return nil, err
+	}
+	if c.reshare != nil && !bytes.Equal(groupCommitments[0].Serialize(), c.reshare.ValidatorPubKey) {
+		return nil, errors.New("reshared validator public key does not match")
+	}
+	c.expected = &Output{
+		ValidatorPubKey: groupCommitments[0].Serialize(),
+		SharePubKeys:    make([][]byte, len(c.operators)),
+		Qualified:       qualified,
+	}
+	for i := range c.operators {
+		pk, err := evaluateCommitments(groupCommitments, uint64(i+1))
+		if err != nil {
+			return nil, err
+		}
+		c.expected.SharePubKeys[i] = pk.Serialize()
+	}
+	c.setPhase(outputPhase, now)
+	if c.index == 0 {
+		return nil, nil
+	}
+
+	share, err := c.groupShare(qualified)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(c.expected.SharePubKeys[c.index-1], share.GetPublicKey().Serialize()) {
+		return nil, errors.New("share does not match the group commitments")
+	}
+	output := &Output{
+		ValidatorPubKey: c.expected.ValidatorPubKey,
+		SharePubKeys:    c.expected.SharePubKeys,
+		Qualified:       qualified,
+		Signature:       share.SignByte([]byte(c.id)).Serialize(),
+	}
+	pk, err := rsaencryption.ConvertEncodedPemToPublicKey(c.operators[c.index-1])
+	if err != nil {
+		return nil, err
+	}
+	output.EncryptedShare, err = rsaencryption.EncodeKey(pk, share.SerializeToHexStr())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt share")
+	}
+	c.outputs[c.index] = output
+	return &Message{Type: OutputMsgType, CeremonyID: c.id, Output: output}, nil
+}
+
+// groupCommitments returns the commitments of the group polynomial, the sum of the commitments of the qualified dealers.
+// when resharing, it is the lagrange interpolation of their commitments
+func (c *ceremony) groupCommitments(qualified []uint64) ([]bls.PublicKey, error) {
+	groupCommitments := make([]bls.PublicKey, c.threshold)
+	if c.reshare != nil {
+		ids, err := shareIDs(qualified)
+		if err != nil {
+			return nil, err
+		}
+		for k := range groupCommitments {
+			commitments := make([]bls.PublicKey, len(qualified))
+			for i, dealer := range qualified {
+				commitments[i] = c.commitments[dealer][k]
+			}
+			if err := groupCommitments[k].Recover(commitments, ids); err != nil {
+				return nil, errors.Wrap(err, "could not interpolate commitments")
+			}
+		}
+		return groupCommitments, nil
+	}
+	for i, dealer := range qualified {
+		if i == 0 {
+			copy(groupCommitments, c.commitments[dealer])
+			continue
+		}
+		for k := range groupCommitments {
+			groupCommitments[k].Add(&c.commitments[dealer][k])
+		}
+	}
+	return groupCommitments, nil
+}
+
+// groupShare returns the share of this operator, the sum of the shares that the qualified dealers dealt to it.
+// when resharing, it is the lagrange interpolation of these shares
+func (c *ceremony) groupShare(qualified []uint64) (*bls.SecretKey, error) {
+	shares := make([]bls.SecretKey, len(qualified))
+	for i, dealer := range qualified {
+		dealerShare, found := c.shares[dealer]
+		if !found {
+			return nil, errors.Errorf("missing share of dealer %d", dealer)
+		}
+		shares[i] = *dealerShare
+	}
+	share := &bls.SecretKey{}
+	if c.reshare != nil {
+		ids, err := shareIDs(qualified)
+		if err != nil {
+			return nil, err
+		}
+		if err := share.Recover(shares, ids); err != nil {
+			return nil, errors.Wrap(err, "could not interpolate shares")
+		}
+		return share, nil
+	}
+	*share = shares[0]
+	for i := 1; i < len(shares); i++ {
+		share.Add(&shares[i])
+	}
+	return share, nil
+}
+
+// endOutputPhase verifies that all operators computed the same keys and that their shares can sign together
+func (c *ceremony) endOutputPhase(now time.Time) error {
+	var missing []uint64
+	for i := range c.operators {
+		if _, found := c.outputs[uint64(i+1)]; !found {
+			missing = append(missing, uint64(i+1))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("missing outputs of operators %v", missing)
+	}
+	own := c.expected
+	validatorPk := &bls.PublicKey{}
+	if err := validatorPk.Deserialize(own.ValidatorPubKey); err != nil {
+		return errors.Wrap(err, "invalid validator public key")
+	}
+	result := &Result{
+		ValidatorPubKey: validatorPk.SerializeToHexStr(),
+		Qualified:       own.Qualified,
+	}
+	signatures := make(map[uint64][]byte)
+	for i, op := range c.operators {
+		index := uint64(i + 1)
+		output := c.outputs[index]
+		if err := matchOutputs(own, output); err != nil {
+			return errors.Wrapf(err, "output of operator %d does not match", index)
+		}
+		sharePk := &bls.PublicKey{}
+		if err := sharePk.Deserialize(own.SharePubKeys[i]); err != nil {
+			return errors.Wrapf(err, "invalid share public key of operator %d", index)
+		}
+		sig := &bls.Sign{}
+		if err := sig.Deserialize(append([]byte{}, output.Signature...)); err != nil || !sig.VerifyByte(sharePk, []byte(c.id)) {
+			return errors.Errorf("invalid signature of operator %d", index)
+		}
+		if uint64(len(signatures)) < c.threshold {
+			signatures[index] = output.Signature
+		}
+		result.Shares = append(result.Shares, &ShareResult{
+			Index:          index,
+			OperatorPubKey: op,
+			SharePubKey:    sharePk.SerializeToHexStr(),
+			EncryptedShare: output.EncryptedShare,
+		})
+	}
+	sig, err := threshold.ReconstructSignatures(signatures)
+	if err != nil {
+		return errors.Wrap(err, "could not reconstruct signature")
+	}
+	if !sig.VerifyByte(validatorPk, []byte(c.id)) {
+		return errors.New("reconstructed signature is invalid")
+	}
+	if c.reshare != nil {
+		handovers := make([]*Handover, 0, len(own.Qualified))
+		for _, dealer := range own.Qualified {
+			handovers = append(handovers, c.handovers[dealer])
+		}
+		result.Handover = mergeHandovers(c.reshare, handovers)
+	}
+	c.result = result
+	c.setPhase(donePhase, now)
+	return nil
+}
+
+// accused returns the complainers of each dealer, only dealers that dealt are considered
+func (c *ceremony) accused() map[uint64][]uint64 {
+	accused := make(map[uint64][]uint64)
+	for complainer, dealers := range c.complaints {
+		for _, dealer := range dealers {
+			if _, found := c.commitments[dealer]; !found {
+				continue
+			}
+			accused[dealer] = append(accused[dealer], complainer)
+		}
+	}
+	for _, complainers := range accused {
+		sort.Slice(complainers, func(i, j int) bool { return complainers[i] < complainers[j] })
+	}
+	return accused
+}
+
+// allJustified returns true if all accused dealers sent a justification
+func (c *ceremony) allJustified() bool {
+	for dealer := range c.accused() {
+		if _, found := c.justifications[dealer]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// decryptShare decrypts a share that was encrypted with the operator key of this operator
+func (c *ceremony) decryptShare(encrypted string) (*bls.SecretKey, error) {
+	if len(encrypted) == 0 {
+		return nil, errors.New("missing share")
+	}
+	decrypted, err := rsaencryption.DecodeKey(c.operatorKey, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	share := &bls.SecretKey{}
+	if err := share.SetHexString(decrypted); err != nil {
+		return nil, errors.Wrap(err, "invalid share")
+	}
+	return share, nil
+}
+
+// state returns the current state of the ceremony
+func (c *ceremony) state() *CeremonyState {
+	state := &CeremonyState{
+		ID:        c.id,
+		Operators: c.operators,
+		Threshold: c.threshold,
+		Reshare:   c.reshare,
+		Status:    StatusRunning,
+		Phase:     c.phase.String(),
+		Result:    c.result,
+		StartTime: c.startTime,
+	}
+	if c.result != nil {
+		state.Status = StatusCompleted
+	}
+	return state
+}
+
+func (c *ceremony) setPhase(p phase, now time.Time) {
+	c.phase = p
+	c.deadline = now.Add(c.timeout)
+}
+
+// matchOutputs returns an error if the given outputs do not agree on the keys
+func matchOutputs(a, b *Output) error {
+	if !bytes.Equal(a.ValidatorPubKey, b.ValidatorPubKey) {
+		return errors.New("different validator public key")
+	}
+	if len(a.SharePubKeys) != len(b.SharePubKeys) {
+		return errors.New("different share public keys")
+	}
+	for i := range a.SharePubKeys {
+		if !bytes.Equal(a.SharePubKeys[i], b.SharePubKeys[i]) {
+			return errors.New("different share public keys")
+		}
+	}
+	if fmt.Sprint(a.Qualified) != fmt.Sprint(b.Qualified) {
+		return errors.New("different qualified dealers")
+	}
+	return nil
+}
+
+// evaluate returns the share of the given index of the secret polynomial
+func evaluate(poly []bls.SecretKey, index uint64) (*bls.SecretKey, error) {
+	id, err := shareID(index)
+	if err != nil {
+		return nil, err
+	}
+	share := &bls.SecretKey{}
+	if err := share.Set(poly, id); err != nil {
+		return nil, errors.Wrap(err, "could not evaluate polynomial")
+	}
+	return share, nil
+}
+
+// evaluateCommitments returns the public key of the share of the given index
+func evaluateCommitments(commitments []bls.PublicKey, index uint64) (*bls.PublicKey, error) {
+	id, err := shareID(index)
+	if err != nil {
+		return nil, err
+	}
+	pk := &bls.PublicKey{}
+	if err := pk.Set(commitments, id); err != nil {
+		return nil, errors.Wrap(err, "could not evaluate commitments")
+	}
+	return pk, nil
+}
+
+// verifyShare checks that the given share of the given index matches the commitments of the dealer
+func verifyShare(commitments []bls.PublicKey, index uint64, share *bls.SecretKey) error {
+	expected, err := evaluateCommitments(commitments, index)
+	if err != nil {
+		return err
+	}
+	if !expected.IsEqual(share.GetPublicKey()) {
+		return errors.New("share does not match the commitments")
+	}
+	return nil
+}
+
+// shareIDs returns the bls ids of the given indexes
+func shareIDs(indexes []uint64) ([]bls.ID, error) {
+	ids := make([]bls.ID, len(indexes))
+	for i, index := range indexes {
+		id, err := shareID(index)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = *id
+	}
+	return ids, nil
+}
+
+// shareID returns the bls id of the given index, as in threshold.Create
+func shareID(index uint64) (*bls.ID, error) {
+	id := &bls.ID{}
+	if err := id.SetDecString(fmt.Sprintf("%d", index)); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func sortedIndexes(set map[uint64]bool) []uint64 {
+	indexes := make([]uint64, 0, len(set))
+	for index := range set {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
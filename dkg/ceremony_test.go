@@ -0,0 +1,269 @@
+package dkg
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 10 * time.Second
+
+type testOperator struct {
+	sk *rsa.PrivateKey
+	pk string
+}
+
+type testMsg struct {
+	// from is the index of the sender in the role that sends the message
+	from   uint64
+	sender *ceremony
+	msg    *Message
+}
+
+// testFilter can change or drop (returns nil) a message to the given operator
+type testFilter func(m *testMsg, to uint64) *Message
+
+func newTestOperators(t *testing.T, n int) []*testOperator {
+	ops := make([]*testOperator, n)
+	for i := range ops {
+		_, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		pk, err := rsaencryption.ExtractPublicKey(sk)
+		require.NoError(t, err)
+		ops[i] = &testOperator{sk: sk, pk: pk}
+	}
+	return ops
+}
+
+func testInit(ops []*testOperator, threshold uint64) *Init {
+	init := &Init{Threshold: threshold}
+	for _, op := range ops {
+		init.Operators = append(init.Operators, op.pk)
+	}
+	return init
+}
+
+// startTestCeremonies creates and starts the ceremonies of the given operators, it returns their deals
+func startTestCeremonies(t *testing.T, ops []*testOperator, now time.Time) ([]*ceremony, []*testMsg) {
+	return startTestInit(t, testInit(ops, uint64(len(ops)-1)), ops, nil, now)
+}
+
+// startTestInit creates and starts the ceremonies of the given participants, it returns their deals.
+// shares are the current shares of the operators (by public key) when resharing
+func startTestInit(t *testing.T, init *Init, ops []*testOperator, shares map[string]*OperatorShare, now time.Time) ([]*ceremony, []*testMsg) {
+	var cers []*ceremony
+	var deals []*testMsg
+	for _, op := range ops {
+		cer, err := newCeremony("test-ceremony", init, op.sk, op.pk, shares[op.pk], testTimeout)
+		require.NoError(t, err)
+		deal, err := cer.start(now)
+		require.NoError(t, err)
+		cers = append(cers, cer)
+		if deal != nil {
+			deals = append(deals, newTestMsg(cer, deal))
+		}
+	}
+	return cers, deals
+}
+
+func newTestMsg(sender *ceremony, msg *Message) *testMsg {
+	return &testMsg{from: sender.senderIndex(sender.operatorPubKey, msg.Type), sender: sender, msg: msg}
+}
+
+// runTestCeremonies delivers the queued messages to all other ceremonies and steps them at the given time,
+// until no new messages are produced. it returns the errors of the failed ceremonies
+func runTestCeremonies(cers []*ceremony, queue []*testMsg, now time.Time, filter testFilter) map[*ceremony]error {
+	errs := make(map[*ceremony]error)
+	stepAll := func() {
+		for _, cer := range cers {
+			if _, failed := errs[cer]; failed {
+				continue
+			}
+			msgs, err := cer.step(now)
+			if err != nil {
+				errs[cer] = err
+			}
+			for _, msg := range msgs {
+				queue = append(queue, newTestMsg(cer, msg))
+			}
+		}
+	}
+	stepAll()
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		for _, cer := range cers {
+			if cer == m.sender {
+				continue
+			}
+			msg := m.msg
+			if filter != nil {
+				msg = filter(m, cer.index)
+			}
+			if msg != nil {
+				_ = cer.processMessage(m.from, msg)
+			}
+		}
+		stepAll()
+	}
+	return errs
+}
+
+// recoverSecret recovers the group secret from the given shares of the result
+func recoverSecret(t *testing.T, ops []*testOperator, result *Result, indexes ...uint64) *bls.SecretKey {
+	var shares []bls.SecretKey
+	var ids []bls.ID
+	for _, index := range indexes {
+		share := result.Shares[index-1]
+		require.Equal(t, index, share.Index)
+		decrypted, err := rsaencryption.DecodeKey(ops[index-1].sk, share.EncryptedShare)
+		require.NoError(t, err)
+		sk := bls.SecretKey{}
+		require.NoError(t, sk.SetHexString(decrypted))
+		require.Equal(t, share.SharePubKey, sk.GetPublicKey().SerializeToHexStr())
+		id := bls.ID{}
+		require.NoError(t, id.SetDecString(fmt.Sprintf("%d", index)))
+		shares = append(shares, sk)
+		ids = append(ids, id)
+	}
+	secret := &bls.SecretKey{}
+	require.NoError(t, secret.Recover(shares, ids))
+	return secret
+}
+
+func TestCeremony(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 4)
+	now := time.Now()
+	cers, deals := startTestCeremonies(t, ops, now)
+
+	require.Empty(t, runTestCeremonies(cers, deals, now, nil))
+	for _, cer := range cers {
+		require.Equal(t, donePhase, cer.phase)
+		require.Equal(t, cers[0].result, cer.result)
+		require.Equal(t, StatusCompleted, cer.state().Status)
+	}
+	result := cers[0].result
+	require.Equal(t, []uint64{1, 2, 3, 4}, result.Qualified)
+	require.Len(t, result.Shares, 4)
+
+	// any threshold of shares recovers the same secret, which matches the validator public key
+	secret := recoverSecret(t, ops, result, 1, 2, 3)
+	require.Equal(t, result.ValidatorPubKey, secret.GetPublicKey().SerializeToHexStr())
+	require.True(t, secret.IsEqual(recoverSecret(t, ops, result, 2, 3, 4)))
+}
+
+func TestCeremony_Complaints(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 4)
+
+	// dealer 1 deals an invalid share to operator 2
+	invalidShare := func(m *testMsg, to uint64) *Message {
+		if m.from != 1 || m.msg.Type != DealMsgType || to != 2 {
+			return m.msg
+		}
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		encrypted, err := rsaencryption.EncodeKey(&ops[1].sk.PublicKey, sk.SerializeToHexStr())
+		require.NoError(t, err)
+		deal := &Deal{Commitments: m.msg.Deal.Commitments, Shares: map[uint64]string{2: encrypted}}
+		return &Message{Type: DealMsgType, CeremonyID: m.msg.CeremonyID, Deal: deal}
+	}
+
+	t.Run("justified", func(t *testing.T) {
+		now := time.Now()
+		cers, deals := startTestCeremonies(t, ops, now)
+		require.Empty(t, runTestCeremonies(cers, deals, now, invalidShare))
+		require.Equal(t, []uint64{1}, cers[1].complaints[2])
+		for _, cer := range cers {
+			require.Equal(t, donePhase, cer.phase)
+			require.Equal(t, []uint64{1, 2, 3, 4}, cer.result.Qualified)
+		}
+		secret := recoverSecret(t, ops, cers[0].result, 2, 3, 4)
+		require.Equal(t, cers[0].result.ValidatorPubKey, secret.GetPublicKey().SerializeToHexStr())
+	})
+
+	t.Run("not justified", func(t *testing.T) {
+		now := time.Now()
+		cers, deals := startTestCeremonies(t, ops, now)
+		noJustification := func(m *testMsg, to uint64) *Message {
+			if m.from == 1 && m.msg.Type == JustificationMsgType {
+				return nil
+			}
+			return invalidShare(m, to)
+		}
+		errs := runTestCeremonies(cers, deals, now, noJustification)
+		require.Empty(t, errs)
+		require.Equal(t, justificationPhase, cers[1].phase)
+
+		// dealer 1 is disqualified by the other operators once the justification phase is over
+		errs = runTestCeremonies(cers, nil, now.Add(testTimeout), noJustification)
+		for _, cer := range cers[1:] {
+			require.Equal(t, []uint64{2, 3, 4}, cer.outputs[cer.index].Qualified)
+			require.EqualError(t, errs[cer], "output of operator 1 does not match: different validator public key")
+		}
+	})
+}
+
+func TestCeremony_Timeout(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 4)
+	now := time.Now()
+	cers, deals := startTestCeremonies(t, ops, now)
+	// operator 4 is offline
+	offline := func(m *testMsg, to uint64) *Message {
+		if m.from == 4 || to == 4 {
+			return nil
+		}
+		return m.msg
+	}
+	cers, deals = cers[:3], deals[:3]
+	require.Empty(t, runTestCeremonies(cers, deals, now, offline))
+	require.Equal(t, dealPhase, cers[0].phase)
+
+	errs := runTestCeremonies(cers, nil, now.Add(testTimeout), offline)
+	require.Empty(t, errs)
+	require.Equal(t, complaintPhase, cers[0].phase)
+	errs = runTestCeremonies(cers, nil, now.Add(2*testTimeout), offline)
+	require.Empty(t, errs)
+	require.Equal(t, outputPhase, cers[0].phase)
+	require.Equal(t, []uint64{1, 2, 3}, cers[0].outputs[1].Qualified)
+
+	errs = runTestCeremonies(cers, nil, now.Add(3*testTimeout), offline)
+	for _, cer := range cers {
+		require.EqualError(t, errs[cer], "missing outputs of operators [4]")
+	}
+}
+
+func TestNewCeremony(t *testing.T) {
+	ops := newTestOperators(t, 3)
+	tests := []struct {
+		name string
+		init *Init
+		pk   string
+		err  string
+	}{
+		{"threshold too low", testInit(ops, 1), ops[0].pk, "invalid threshold 1 for 3 operators"},
+		{"threshold too high", testInit(ops, 4), ops[0].pk, "invalid threshold 4 for 3 operators"},
+		{"duplicated operator", testInit(append(ops, ops[0]), 2), ops[0].pk, fmt.Sprintf("operator %s appears more than once", ops[0].pk)},
+		{"not a participant", testInit(ops[1:], 2), ops[0].pk, "operator is not a participant of the ceremony"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newCeremony("id", test.init, ops[0].sk, test.pk, nil, testTimeout)
+			require.EqualError(t, err, test.err)
+		})
+	}
+
+	cer, err := newCeremony("id", testInit(ops, 2), ops[1].sk, ops[1].pk, nil, testTimeout)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), cer.index)
+}
@@ -0,0 +1,383 @@
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxRunningCeremonies is the max amount of ceremonies that can run at the same time
+	maxRunningCeremonies = 16
+	// maxPendingCeremonies is the max amount of unknown ceremonies whose messages are kept,
+	// messages might arrive before the init message of the ceremony
+	maxPendingCeremonies = 64
+	// maxPendingMessages is the max amount of messages that are kept for an unknown ceremony
+	maxPendingMessages = 64
+	// tickInterval is the interval of checking phases timeouts
+	tickInterval = time.Second
+)
+
+// ControllerOptions for creating a dkg controller
+type ControllerOptions struct {
+	Context            context.Context
+	Logger             *zap.Logger
+	DB                 basedb.IDb
+	Network            network.DKG
+	OperatorPrivateKey *rsa.PrivateKey
+	// Shares is optional, validators can't be reshared if it is nil
+	Shares       ShareStore
+	PhaseTimeout time.Duration `yaml:"PhaseTimeout" env:"DKG_PHASE_TIMEOUT" env-default:"30s" env-description:"Timeout of each phase of a dkg ceremony"`
+}
+
+// Controller runs distributed key generation ceremonies with other operators,
+// a ceremony produces the shares of a new validator key without any party learning the key
+type Controller interface {
+	// Start subscribes to dkg messages and starts to handle them
+	Start() error
+	// StartCeremony starts a ceremony between the given operators (public keys), the node must be one of them.
+	// it returns the id of the ceremony
+	StartCeremony(operators []string, threshold uint64) (string, error)
+	// StartReshare starts a ceremony that reshares the given validator (hex public key) to the given operators,
+	// the node must be an operator of the current committee. it returns the id of the ceremony
+	StartReshare(validatorPubKey string, operators []string, threshold uint64) (string, error)
+	// GetCeremony returns the state of the given ceremony
+	GetCeremony(id string) (*CeremonyState, bool, error)
+}
+
+// pendingMessage is a verified message of a ceremony that is not known yet
+type pendingMessage struct {
+	signer string
+	msg    *Message
+}
+
+// pendingMessages are the messages of a ceremony that is not known yet
+type pendingMessages struct {
+	received time.Time
+	msgs     []*pendingMessage
+}
+
+// controller implements Controller
+type controller struct {
+	ctx            context.Context
+	logger         *zap.Logger
+	storage        Storage
+	network        network.DKG
+	operatorKey    *rsa.PrivateKey
+	operatorPubKey string
+	shares         ShareStore
+	phaseTimeout   time.Duration
+
+	lock       sync.Mutex
+	ceremonies map[string]*ceremony
+	pending    map[string]*pendingMessages
+}
+
+// NewController creates a new dkg controller instance
+func NewController(opts ControllerOptions) (Controller, error) {
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(opts.OperatorPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	return &controller{
+		ctx:            opts.Context,
+		logger:         opts.Logger.With(zap.String("component", "dkgController")),
+		storage:        NewStorage(opts.DB),
+		network:        opts.Network,
+		operatorKey:    opts.OperatorPrivateKey,
+		operatorPubKey: operatorPubKey,
+		shares:         opts.Shares,
+		phaseTimeout:   opts.PhaseTimeout,
+		ceremonies:     make(map[string]*ceremony),
+		pending:        make(map[string]*pendingMessages),
+	}, nil
+}
+
+// Start subscribes to dkg messages and starts to handle them
+func (c *controller) Start() error {
+	if err := c.network.SubscribeToDKGTopic(); err != nil {
+		return errors.Wrap(err, "failed to subscribe to dkg topic")
+	}
+	msgs, done := c.network.ReceivedDKGMsgChan()
+	go func() {
+		defer done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case msg := <-msgs:
+				c.handleMessage(msg)
+			case <-ticker.C:
+				c.tick()
+			}
+		}
+	}()
+	return nil
+}
+
+// StartCeremony starts a ceremony between the given operators and broadcasts its init message
+func (c *controller) StartCeremony(operators []string, threshold uint64) (string, error) {
+	return c.start(&Init{Operators: operators, Threshold: threshold})
+}
+
+// StartReshare starts a ceremony that reshares the given validator from its current committee to the given operators
+func (c *controller) StartReshare(validatorPubKey string, operators []string, threshold uint64) (string, error) {
+	pk, err := hex.DecodeString(validatorPubKey)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid validator public key")
+	}
+	share, err := c.operatorShare(pk)
+	if err != nil {
+		return "", err
+	}
+	return c.start(&Init{Operators: operators, Threshold: threshold, Reshare: share.Committee})
+}
+
+// start starts a ceremony with a random id and broadcasts its init message
+func (c *controller) start(init *Init) (string, error) {
+	rawID := make([]byte, 16)
+	if _, err := rand.Read(rawID); err != nil {
+		return "", errors.Wrap(err, "could not generate ceremony id")
+	}
+	id := hex.EncodeToString(rawID)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.startCeremony(id, init); err != nil {
+		return "", err
+	}
+	c.broadcast(&Message{Type: InitMsgType, CeremonyID: id, Init: init})
+	return id, nil
+}
+
+// GetCeremony returns the state of a running ceremony, or of a finished one
+func (c *controller) GetCeremony(id string) (*CeremonyState, bool, error) {
+	c.lock.Lock()
+	cer, found := c.ceremonies[id]
+	var state *CeremonyState
+	if found {
+		state = cer.state()
+	}
+	c.lock.Unlock()
+
+	if found {
+		return state, true, nil
+	}
+	return c.storage.GetCeremony(id)
+}
+
+// handleMessage verifies the given message and passes it to its ceremony
+func (c *controller) handleMessage(netMsg *network.DKGMessage) {
+	if netMsg == nil {
+		return
+	}
+	msg, err := verifyMessage(netMsg)
+	if err != nil {
+		c.logger.Debug("invalid dkg message", zap.Error(err))
+		return
+	}
+	logger := c.logger.With(zap.String("ceremonyID", msg.CeremonyID))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if msg.Type == InitMsgType {
+		if _, found := c.ceremonies[msg.CeremonyID]; found {
+			return
+		}
+		if _, found, _ := c.storage.GetCeremony(msg.CeremonyID); found {
+			return
+		}
+		participants := msg.Init.participants()
+		if !contains(participants, c.operatorPubKey) {
+			return
+		}
+		if !contains(participants, netMsg.Signer) {
+			logger.Debug("init message was not sent by a participant")
+			return
+		}
+		if err := c.startCeremony(msg.CeremonyID, msg.Init); err != nil {
+			logger.Warn("could not start ceremony", zap.Error(err))
+		}
+		return
+	}
+
+	cer, found := c.ceremonies[msg.CeremonyID]
+	if !found {
+		c.addPending(netMsg.Signer, msg)
+		return
+	}
+	c.processMessage(cer, netMsg.Signer, msg)
+}
+
+// startCeremony creates a ceremony, broadcasts the deal of this operator and processes pending messages.
+// this method is not thread-safe - should be called after lock was acquired
+func (c *controller) startCeremony(id string, init *Init) error {
+	if _, found := c.ceremonies[id]; found {
+		return errors.New("ceremony already exist")
+	}
+	if len(c.ceremonies) >= maxRunningCeremonies {
+		return errors.New("too many running ceremonies")
+	}
+	var share *OperatorShare
+	if init.Reshare != nil && contains(init.Reshare.Operators, c.operatorPubKey) {
+		var err error
+		if share, err = c.operatorShare(init.Reshare.ValidatorPubKey); err != nil {
+			return err
+		}
+	}
+	cer, err := newCeremony(id, init, c.operatorKey, c.operatorPubKey, share, c.phaseTimeout)
+	if err != nil {
+		return err
+	}
+	deal, err := cer.start(time.Now())
+	if err != nil {
+		return errors.Wrap(err, "could not deal shares")
+	}
+	c.ceremonies[id] = cer
+	c.logger.Info("started dkg ceremony", zap.String("ceremonyID", id),
+		zap.Int("operators", len(init.Operators)), zap.Uint64("threshold", init.Threshold), zap.Bool("reshare", init.Reshare != nil))
+	if deal != nil {
+		c.broadcast(deal)
+	}
+
+	if pending, found := c.pending[id]; found {
+		delete(c.pending, id)
+		for _, p := range pending.msgs {
+			c.processMessage(cer, p.signer, p.msg)
+		}
+	}
+	return nil
+}
+
+// processMessage passes the message to the ceremony and advances it.
+// this method is not thread-safe - should be called after lock was acquired
+func (c *controller) processMessage(cer *ceremony, signer string, msg *Message) {
+	logger := c.logger.With(zap.String("ceremonyID", cer.id))
+	from := cer.senderIndex(signer, msg.Type)
+	if from == 0 {
+		logger.Debug("message was not sent by a participant")
+		return
+	}
+	if err := cer.processMessage(from, msg); err != nil {
+		logger.Warn("could not process dkg message", zap.Uint64("from", from), zap.Error(err))
+	}
+	c.step(cer)
+}
+
+// tick advances the ceremonies whose phase timed out and drops old pending messages
+func (c *controller) tick() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, cer := range c.ceremonies {
+		c.step(cer)
+	}
+	for id, pending := range c.pending {
+		if time.Since(pending.received) > c.phaseTimeout {
+			delete(c.pending, id)
+		}
+	}
+}
+
+// step advances the ceremony and broadcasts the resulting messages, finished ceremonies are saved.
+// this method is not thread-safe - should be called after lock was acquired
+func (c *controller) step(cer *ceremony) {
+	msgs, err := cer.step(time.Now())
+	for _, msg := range msgs {
+		c.broadcast(msg)
+	}
+	if err == nil && cer.phase != donePhase {
+		return
+	}
+	delete(c.ceremonies, cer.id)
+	state := cer.state()
+	state.EndTime = time.Now()
+	logger := c.logger.With(zap.String("ceremonyID", cer.id))
+	if err != nil {
+		state.Status = StatusFailed
+		state.Error = err.Error()
+		logger.Warn("dkg ceremony failed", zap.String("phase", state.Phase), zap.Error(err))
+	} else {
+		logger.Info("dkg ceremony completed", zap.String("validatorPubKey", state.Result.ValidatorPubKey))
+		c.saveHandover(cer)
+	}
+	if err := c.storage.SaveCeremony(state); err != nil {
+		logger.Error("could not save ceremony", zap.Error(err))
+	}
+}
+
+// saveHandover saves the signing history of a reshared validator for the new share of this operator
+func (c *controller) saveHandover(cer *ceremony) {
+	if cer.reshare == nil || cer.index == 0 || cer.result.Handover == nil || c.shares == nil {
+		return
+	}
+	sharePubKey := cer.expected.SharePubKeys[cer.index-1]
+	if err := c.shares.SaveHandover(cer.reshare.ValidatorPubKey, sharePubKey, cer.result.Handover); err != nil {
+		c.logger.Error("could not save handover of reshared validator", zap.String("ceremonyID", cer.id), zap.Error(err))
+	}
+}
+
+// operatorShare returns the operator's share of the given validator
+func (c *controller) operatorShare(validatorPubKey []byte) (*OperatorShare, error) {
+	if c.shares == nil {
+		return nil, errors.New("resharing is not supported")
+	}
+	share, found, err := c.shares.GetOperatorShare(validatorPubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator share")
+	}
+	if !found {
+		return nil, errors.New("validator share was not found")
+	}
+	return share, nil
+}
+
+// broadcast signs and broadcasts the given message
+func (c *controller) broadcast(msg *Message) {
+	netMsg, err := signMessage(c.operatorKey, c.operatorPubKey, msg)
+	if err == nil {
+		err = c.network.BroadcastDKGMessage(netMsg)
+	}
+	if err != nil {
+		c.logger.Error("could not broadcast dkg message", zap.String("ceremonyID", msg.CeremonyID),
+			zap.Int32("type", int32(msg.Type)), zap.Error(err))
+	}
+}
+
+// addPending keeps a message of an unknown ceremony, until its init message arrives.
+// this method is not thread-safe - should be called after lock was acquired
+func (c *controller) addPending(signer string, msg *Message) {
+	pending, found := c.pending[msg.CeremonyID]
+	if !found {
+		if len(c.pending) >= maxPendingCeremonies {
+			return
+		}
+		pending = &pendingMessages{received: time.Now()}
+		c.pending[msg.CeremonyID] = pending
+	}
+	if len(pending.msgs) < maxPendingMessages {
+		pending.msgs = append(pending.msgs, &pendingMessage{signer: signer, msg: msg})
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
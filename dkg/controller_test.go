@@ -0,0 +1,172 @@
+package dkg
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network/local"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testShareStore is an in memory ShareStore
+type testShareStore struct {
+	lock      sync.Mutex
+	shares    map[string]*OperatorShare
+	handovers map[string]*Handover
+}
+
+func (s *testShareStore) GetOperatorShare(validatorPubKey []byte) (*OperatorShare, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	share, found := s.shares[hex.EncodeToString(validatorPubKey)]
+	return share, found, nil
+}
+
+func (s *testShareStore) SaveHandover(validatorPubKey []byte, sharePubKey []byte, handover *Handover) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handovers[hex.EncodeToString(sharePubKey)] = handover
+	return nil
+}
+
+func newTestController(t *testing.T, ctx context.Context, net *local.Local, op *testOperator, shares ShareStore) Controller {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	ctrl, err := NewController(ControllerOptions{
+		Context:            ctx,
+		Logger:             logex.Build("test", zap.InfoLevel, nil),
+		DB:                 db,
+		Network:            net,
+		OperatorPrivateKey: op.sk,
+		PhaseTimeout:       testTimeout,
+		Shares:             shares,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ctrl.Start())
+	return ctrl
+}
+
+func TestController(t *testing.T) {
+	threshold.Init()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ops := newTestOperators(t, 4)
+	net := local.NewLocalNetwork()
+	var ctrls []Controller
+	for i, op := range ops {
+		ctrls = append(ctrls, newTestController(t, ctx, net.CopyWithLocalNodeID(peer.ID(fmt.Sprintf("%d", i))), op, nil))
+	}
+	// a node that doesn't participate
+	other := newTestController(t, ctx, net.CopyWithLocalNodeID("other"), newTestOperators(t, 1)[0], nil)
+
+	init := testInit(ops, 3)
+	_, err := ctrls[0].StartCeremony(init.Operators[1:], 2)
+	require.EqualError(t, err, "operator is not a participant of the ceremony")
+
+	id, err := ctrls[0].StartCeremony(init.Operators, init.Threshold)
+	require.NoError(t, err)
+
+	var states []*CeremonyState
+	for _, ctrl := range ctrls {
+		var state *CeremonyState
+		require.Eventually(t, func() bool {
+			s, found, err := ctrl.GetCeremony(id)
+			require.NoError(t, err)
+			state = s
+			return found && state.Status != StatusRunning
+		}, 10*time.Second, 50*time.Millisecond)
+		require.Equal(t, StatusCompleted, state.Status, state.Error)
+		states = append(states, state)
+	}
+	for _, state := range states {
+		require.Equal(t, init.Operators, state.Operators)
+		require.Equal(t, states[0].Result, state.Result)
+	}
+	secret := recoverSecret(t, ops, states[0].Result, 1, 3, 4)
+	require.Equal(t, states[0].Result.ValidatorPubKey, secret.GetPublicKey().SerializeToHexStr())
+
+	_, found, err := other.GetCeremony(id)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestController_Reshare(t *testing.T) {
+	threshold.Init()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ops := newTestOperators(t, 5)
+	committee := newTestCommittee(t, ops[:4], 3)
+	validatorPk := hex.EncodeToString(committee.reshare.ValidatorPubKey)
+	handover := &Handover{
+		Decided:       []*proto.SignedMessage{committee.decided(t, 3, 1, 2, 3)},
+		HighestSigned: &beacon.HighestSigned{SourceEpoch: 1, TargetEpoch: 2, Slot: 30},
+	}
+	shares := committee.operatorShares(map[uint64]*Handover{1: handover, 2: handover, 3: handover, 4: handover})
+
+	net := local.NewLocalNetwork()
+	var ctrls []Controller
+	var stores []*testShareStore
+	for i, op := range ops {
+		store := &testShareStore{shares: make(map[string]*OperatorShare), handovers: make(map[string]*Handover)}
+		if share, found := shares[op.pk]; found {
+			store.shares[validatorPk] = share
+		}
+		stores = append(stores, store)
+		ctrls = append(ctrls, newTestController(t, ctx, net.CopyWithLocalNodeID(peer.ID(fmt.Sprintf("%d", i))), op, store))
+	}
+
+	// the validator moves from the first 4 operators to the last 4
+	newOps := ops[1:]
+	init := testInit(newOps, 3)
+	_, err := ctrls[4].StartReshare(validatorPk, init.Operators, 3)
+	require.EqualError(t, err, "validator share was not found")
+
+	id, err := ctrls[0].StartReshare(validatorPk, init.Operators, 3)
+	require.NoError(t, err)
+	for _, ctrl := range ctrls {
+		var state *CeremonyState
+		require.Eventually(t, func() bool {
+			s, found, err := ctrl.GetCeremony(id)
+			require.NoError(t, err)
+			state = s
+			return found && state.Status != StatusRunning
+		}, 10*time.Second, 50*time.Millisecond)
+		require.Equal(t, StatusCompleted, state.Status, state.Error)
+		require.Equal(t, validatorPk, state.Result.ValidatorPubKey)
+	}
+	state, _, err := ctrls[0].GetCeremony(id)
+	require.NoError(t, err)
+	require.True(t, committee.sk.IsEqual(recoverSecret(t, newOps, state.Result, 1, 2, 4)))
+
+	// every operator of the new committee saved the handover for its new share
+	require.Empty(t, stores[0].handovers)
+	for i, share := range state.Result.Shares {
+		sharePk := &bls.PublicKey{}
+		require.NoError(t, sharePk.DeserializeHexStr(share.SharePubKey))
+		store := stores[i+1]
+		store.lock.Lock()
+		saved := store.handovers[hex.EncodeToString(sharePk.Serialize())]
+		store.lock.Unlock()
+		require.NotNil(t, saved)
+		require.Equal(t, handover.HighestSigned, saved.HighestSigned)
+		require.Len(t, saved.Decided, 1)
+	}
+}
@@ -0,0 +1,233 @@
+package dkg
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// MsgType is the type of a ceremony message
+type MsgType int32
+
+const (
+	// InitMsgType starts a ceremony between the listed operators
+	InitMsgType MsgType = iota
+	// DealMsgType holds the commitments of a dealer and the encrypted shares it dealt to the other operators
+	DealMsgType
+	// ComplaintMsgType lists the dealers whose shares were found invalid
+	ComplaintMsgType
+	// JustificationMsgType reveals the shares that were complained about
+	JustificationMsgType
+	// OutputMsgType holds the result of the ceremony as computed by an operator
+	OutputMsgType
+)
+
+// Message is a message of a ceremony, it is encoded and signed into a network.DKGMessage
+type Message struct {
+	Type       MsgType
+	CeremonyID string
+
+	Init          *Init          `json:",omitempty"`
+	Deal          *Deal          `json:",omitempty"`
+	Complaint     *Complaint     `json:",omitempty"`
+	Justification *Justification `json:",omitempty"`
+	Output        *Output        `json:",omitempty"`
+}
+
+// Init starts a ceremony, the index of an operator (and the id of its share) is its position in the list + 1
+type Init struct {
+	// Operators are the public keys (base64 encoded PEM) of the participating operators
+	Operators []string
+	// Threshold is the amount of shares that are needed to sign
+	Threshold uint64
+	// Reshare is set when the ceremony reshares an existing validator, its current committee deals instead of the operators
+	Reshare *Reshare `json:",omitempty"`
+}
+
+// Reshare is the current committee of a validator that is reshared to the operators of a ceremony,
+// the index of an operator of the committee is the id of its current share
+type Reshare struct {
+	// ValidatorPubKey is the public key of the validator, which is kept by the new shares
+	ValidatorPubKey []byte
+	// Operators are the public keys (base64 encoded PEM) of the operators of the current committee
+	Operators []string
+	// Threshold is the amount of current shares that are needed to sign
+	Threshold uint64
+	// SharePubKeys are the public keys of the current shares, in the order of the operators
+	SharePubKeys [][]byte
+}
+
+// Deal is sent by each operator (as a dealer) in the first phase of the ceremony
+type Deal struct {
+	// Commitments are the public keys of the coefficients of the dealer's secret polynomial
+	Commitments [][]byte
+	// Shares are the shares that were dealt to the other operators (by index),
+	// each share is encrypted with the operator key of its recipient
+	Shares map[uint64]string
+	// Handover is the signing history of the dealer's current share, only when resharing
+	Handover *Handover `json:",omitempty"`
+}
+
+// Handover is the signing history of a share of the current committee of a validator,
+// it is handed over to the new committee so duties continue without a gap
+type Handover struct {
+	// Decided are the highest decided messages of the validator, one per role
+	Decided []*proto.SignedMessage `json:"decided,omitempty"`
+	// HighestSigned is the slashing protection data of the share
+	HighestSigned *beacon.HighestSigned `json:"highestSigned,omitempty"`
+}
+
+// Complaint is sent by each operator once the deal phase is over
+type Complaint struct {
+	// Dealers are the indexes of the dealers that dealt an invalid share to the sender
+	Dealers []uint64
+}
+
+// Justification is sent by a dealer that was complained about
+type Justification struct {
+	// Shares are the (hex) shares that were dealt to the complaining operators, by index
+	Shares map[uint64]string
+}
+
+// Output is the result of the ceremony as computed by an operator
+type Output struct {
+	// ValidatorPubKey is the group public key
+	ValidatorPubKey []byte
+	// SharePubKeys are the public keys of the shares of all operators, in the order of the operators
+	SharePubKeys [][]byte
+	// Qualified are the indexes of the dealers whose polynomials make the group key
+	Qualified []uint64
+	// EncryptedShare is the share of the sender, encrypted with its own operator key
+	EncryptedShare string
+	// Signature is a signature on the ceremony id with the share of the sender
+	Signature []byte
+}
+
+// signMessage encodes the given message and signs it with the operator key
+func signMessage(operatorKey *rsa.PrivateKey, operatorPubKey string, msg *Message) (*network.DKGMessage, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal message")
+	}
+	signature, err := rsaencryption.SignMessage(operatorKey, data)
+	if err != nil {
+		return nil, err
+	}
+	return &network.DKGMessage{
+		Data:      data,
+		Signer:    operatorPubKey,
+		Signature: signature,
+	}, nil
+}
+
+// verifyMessage verifies the signature of the given network message and decodes it
+func verifyMessage(netMsg *network.DKGMessage) (*Message, error) {
+	if netMsg == nil {
+		return nil, errors.New("message is nil")
+	}
+	pk, err := rsaencryption.ConvertEncodedPemToPublicKey(netMsg.Signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signer")
+	}
+	if err := rsaencryption.VerifySignature(pk, netMsg.Data, netMsg.Signature); err != nil {
+		return nil, err
+	}
+	msg := &Message{}
+	if err := json.Unmarshal(netMsg.Data, msg); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal message")
+	}
+	if len(msg.CeremonyID) == 0 {
+		return nil, errors.New("missing ceremony id")
+	}
+	if !msg.hasPayload() {
+		return nil, errors.Errorf("missing payload of message type %d", msg.Type)
+	}
+	return msg, nil
+}
+
+// hasPayload returns true if the payload of the message type is present
+func (msg *Message) hasPayload() bool {
+	switch msg.Type {
+	case InitMsgType:
+		return msg.Init != nil
+	case DealMsgType:
+		return msg.Deal != nil
+	case ComplaintMsgType:
+		return msg.Complaint != nil
+	case JustificationMsgType:
+		return msg.Justification != nil
+	case OutputMsgType:
+		return msg.Output != nil
+	default:
+		return false
+	}
+}
+
+// validate checks the parameters of the ceremony
+func (i *Init) validate() error {
+	if i.Threshold < 2 || i.Threshold > uint64(len(i.Operators)) {
+		return errors.Errorf("invalid threshold %d for %d operators", i.Threshold, len(i.Operators))
+	}
+	if err := validateOperators(i.Operators); err != nil {
+		return err
+	}
+	if i.Reshare != nil {
+		return errors.Wrap(i.Reshare.validate(), "invalid current committee")
+	}
+	return nil
+}
+
+// participants returns the operators of the ceremony and of the current committee when resharing
+func (i *Init) participants() []string {
+	if i.Reshare == nil {
+		return i.Operators
+	}
+	participants := append([]string{}, i.Operators...)
+	for _, op := range i.Reshare.Operators {
+		if !contains(participants, op) {
+			participants = append(participants, op)
+		}
+	}
+	return participants
+}
+
+// validate checks the current committee
+func (r *Reshare) validate() error {
+	if r.Threshold < 1 || r.Threshold > uint64(len(r.Operators)) {
+		return errors.Errorf("invalid threshold %d for %d operators", r.Threshold, len(r.Operators))
+	}
+	if len(r.SharePubKeys) != len(r.Operators) {
+		return errors.Errorf("%d share public keys for %d operators", len(r.SharePubKeys), len(r.Operators))
+	}
+	pk := &bls.PublicKey{}
+	if err := pk.Deserialize(r.ValidatorPubKey); err != nil {
+		return errors.Wrap(err, "invalid validator public key")
+	}
+	for i, raw := range r.SharePubKeys {
+		if err := pk.Deserialize(raw); err != nil {
+			return errors.Wrapf(err, "invalid share public key of operator %d", i+1)
+		}
+	}
+	return validateOperators(r.Operators)
+}
+
+// validateOperators checks that the given operators public keys are valid and unique
+func validateOperators(operators []string) error {
+	seen := make(map[string]bool)
+	for _, op := range operators {
+		if seen[op] {
+			return errors.Errorf("operator %s appears more than once", op)
+		}
+		seen[op] = true
+		if _, err := rsaencryption.ConvertEncodedPemToPublicKey(op); err != nil {
+			return errors.Wrapf(err, "invalid public key of operator %s", op)
+		}
+	}
+	return nil
+}
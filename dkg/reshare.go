@@ -0,0 +1,120 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// OperatorShare is the share of a validator that is held by the operator, as needed for resharing the validator
+type OperatorShare struct {
+	// Committee is the current committee of the validator
+	Committee *Reshare
+	// SecretKey is the operator's current share
+	SecretKey *bls.SecretKey
+	// Handover is the signing history of the share
+	Handover *Handover
+}
+
+// ShareStore provides the shares of the operator's validators, it is needed for resharing validators
+type ShareStore interface {
+	// GetOperatorShare returns the operator's share of the given validator
+	GetOperatorShare(validatorPubKey []byte) (*OperatorShare, bool, error)
+	// SaveHandover saves the signing history of a validator for the given share of its new committee,
+	// so the share won't sign below the history of the previous committee
+	SaveHandover(validatorPubKey []byte, sharePubKey []byte, handover *Handover) error
+}
+
+// mergeHandovers merges the signing history of the given dealers, the highest values are taken.
+// decided messages must be signed by a quorum of the current committee
+func mergeHandovers(reshare *Reshare, handovers []*Handover) *Handover {
+	merged := &Handover{HighestSigned: &beacon.HighestSigned{}}
+	decided := make(map[string]*proto.SignedMessage)
+	var lambdas []string
+	for _, h := range handovers {
+		if h == nil {
+			continue
+		}
+		if h.HighestSigned != nil {
+			if h.HighestSigned.SourceEpoch > merged.HighestSigned.SourceEpoch {
+				merged.HighestSigned.SourceEpoch = h.HighestSigned.SourceEpoch
+			}
+			if h.HighestSigned.TargetEpoch > merged.HighestSigned.TargetEpoch {
+				merged.HighestSigned.TargetEpoch = h.HighestSigned.TargetEpoch
+			}
+			if h.HighestSigned.Slot > merged.HighestSigned.Slot {
+				merged.HighestSigned.Slot = h.HighestSigned.Slot
+			}
+		}
+		for _, msg := range h.Decided {
+			if verifyDecided(reshare, msg) != nil {
+				continue
+			}
+			lambda := string(msg.Message.Lambda)
+			existing, found := decided[lambda]
+			if !found {
+				lambdas = append(lambdas, lambda)
+			}
+			if !found || existing.Message.SeqNumber < msg.Message.SeqNumber {
+				decided[lambda] = msg
+			}
+		}
+	}
+	for _, lambda := range lambdas {
+		merged.Decided = append(merged.Decided, decided[lambda])
+	}
+	return merged
+}
+
+// verifyDecided checks that the given decided message of the validator is signed by a quorum of the current committee
+func verifyDecided(reshare *Reshare, msg *proto.SignedMessage) error {
+	if msg == nil || msg.Message == nil {
+		return errors.New("missing message")
+	}
+	pk, role := format.IdentifierUnformat(string(msg.Message.Lambda))
+	if len(role) == 0 || pk != hex.EncodeToString(reshare.ValidatorPubKey) {
+		return errors.New("message is not of the reshared validator")
+	}
+	if msg.Message.Type != proto.RoundState_Commit {
+		return errors.New("message is not a commit message")
+	}
+	quorum := int(math.Ceil(float64(len(reshare.Operators)) * 2 / 3))
+	if len(msg.SignerIds) < quorum {
+		return errors.Errorf("message has %d signers, quorum is %d", len(msg.SignerIds), quorum)
+	}
+	var pks []*bls.PublicKey
+	seen := make(map[uint64]bool)
+	for _, id := range msg.SignerIds {
+		if id == 0 || id > uint64(len(reshare.SharePubKeys)) || seen[id] {
+			return errors.Errorf("invalid signer %d", id)
+		}
+		seen[id] = true
+		pk := &bls.PublicKey{}
+		if err := pk.Deserialize(reshare.SharePubKeys[id-1]); err != nil {
+			return errors.Wrapf(err, "invalid share public key of signer %d", id)
+		}
+		pks = append(pks, pk)
+	}
+	valid, err := msg.VerifyAggregatedSig(pks)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// matchSharePubKey returns an error if the given secret key is not the share of the given public key
+func matchSharePubKey(sk *bls.SecretKey, pk []byte) error {
+	if sk == nil || !bytes.Equal(sk.GetPublicKey().Serialize(), pk) {
+		return errors.New("share does not match the current committee")
+	}
+	return nil
+}
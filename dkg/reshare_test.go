@@ -0,0 +1,194 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+type testCommittee struct {
+	sk      *bls.SecretKey
+	reshare *Reshare
+	shares  map[uint64]*bls.SecretKey
+}
+
+// newTestCommittee splits a new validator key between the given operators
+func newTestCommittee(t *testing.T, ops []*testOperator, t2 uint64) *testCommittee {
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	shares, err := threshold.Create(sk.Serialize(), t2, uint64(len(ops)))
+	require.NoError(t, err)
+	reshare := &Reshare{ValidatorPubKey: sk.GetPublicKey().Serialize(), Threshold: t2}
+	for i, op := range ops {
+		reshare.Operators = append(reshare.Operators, op.pk)
+		reshare.SharePubKeys = append(reshare.SharePubKeys, shares[uint64(i+1)].GetPublicKey().Serialize())
+	}
+	return &testCommittee{sk: sk, reshare: reshare, shares: shares}
+}
+
+// operatorShares returns the current shares of the committee operators, with the given handovers (by index)
+func (c *testCommittee) operatorShares(handovers map[uint64]*Handover) map[string]*OperatorShare {
+	shares := make(map[string]*OperatorShare)
+	for i, op := range c.reshare.Operators {
+		index := uint64(i + 1)
+		shares[op] = &OperatorShare{Committee: c.reshare, SecretKey: c.shares[index], Handover: handovers[index]}
+	}
+	return shares
+}
+
+// decided returns a decided message of the validator, signed by the given operators of the committee
+func (c *testCommittee) decided(t *testing.T, seq uint64, signers ...uint64) *proto.SignedMessage {
+	msg := &proto.Message{
+		Type:      proto.RoundState_Commit,
+		Round:     1,
+		Lambda:    []byte(format.IdentifierFormat(c.reshare.ValidatorPubKey, beacon.RoleTypeAttester.String())),
+		SeqNumber: seq,
+		Value:     []byte("value"),
+	}
+	var agg *bls.Sign
+	for _, signer := range signers {
+		sig, err := msg.Sign(c.shares[signer])
+		require.NoError(t, err)
+		if agg == nil {
+			agg = sig
+		} else {
+			agg.Add(sig)
+		}
+	}
+	return &proto.SignedMessage{Message: msg, Signature: agg.Serialize(), SignerIds: signers}
+}
+
+func TestReshare(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 5)
+	// the validator moves from the first 4 operators to the last 3, with a lower threshold
+	committee := newTestCommittee(t, ops[:4], 3)
+	newOps := ops[2:]
+	init := &Init{Operators: testInit(newOps, 2).Operators, Threshold: 2, Reshare: committee.reshare}
+
+	handovers := map[uint64]*Handover{
+		1: {
+			Decided:       []*proto.SignedMessage{committee.decided(t, 10, 1, 2, 3)},
+			HighestSigned: &beacon.HighestSigned{SourceEpoch: 1, TargetEpoch: 2, Slot: 40},
+		},
+		2: {
+			Decided:       []*proto.SignedMessage{committee.decided(t, 12, 1, 2, 4)},
+			HighestSigned: &beacon.HighestSigned{SourceEpoch: 3, TargetEpoch: 4, Slot: 20},
+		},
+		// not signed by a quorum
+		3: {Decided: []*proto.SignedMessage{committee.decided(t, 20, 1, 3)}},
+	}
+	now := time.Now()
+	cers, deals := startTestInit(t, init, ops, committee.operatorShares(handovers), now)
+	require.Len(t, deals, 4)
+
+	require.Empty(t, runTestCeremonies(cers, deals, now, nil))
+	for _, cer := range cers {
+		require.Equal(t, donePhase, cer.phase)
+		require.Equal(t, cers[0].result, cer.result)
+	}
+	result := cers[0].result
+	require.Equal(t, committee.sk.GetPublicKey().SerializeToHexStr(), result.ValidatorPubKey)
+	require.Equal(t, []uint64{1, 2, 3, 4}, result.Qualified)
+	require.Len(t, result.Shares, 3)
+	for i, share := range result.Shares {
+		require.Equal(t, newOps[i].pk, share.OperatorPubKey)
+	}
+
+	// the new shares recover the original validator key
+	require.True(t, committee.sk.IsEqual(recoverSecret(t, newOps, result, 1, 2)))
+	require.True(t, committee.sk.IsEqual(recoverSecret(t, newOps, result, 2, 3)))
+
+	require.Len(t, result.Handover.Decided, 1)
+	require.Equal(t, uint64(12), result.Handover.Decided[0].Message.SeqNumber)
+	require.Equal(t, &beacon.HighestSigned{SourceEpoch: 3, TargetEpoch: 4, Slot: 40}, result.Handover.HighestSigned)
+}
+
+func TestReshare_InvalidDealer(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 5)
+	committee := newTestCommittee(t, ops[:4], 3)
+	// operator 1 only deals, it is not part of the new committee
+	init := &Init{Operators: testInit(ops[1:], 3).Operators, Threshold: 3, Reshare: committee.reshare}
+
+	// dealer 1 deals a polynomial whose secret is not its current share
+	otherSecret := func(m *testMsg, to uint64) *Message {
+		if m.from != 1 || m.msg.Type != DealMsgType {
+			return m.msg
+		}
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		commitments := append([][]byte{sk.GetPublicKey().Serialize()}, m.msg.Deal.Commitments[1:]...)
+		deal := &Deal{Commitments: commitments, Shares: m.msg.Deal.Shares}
+		return &Message{Type: DealMsgType, CeremonyID: m.msg.CeremonyID, Deal: deal}
+	}
+
+	now := time.Now()
+	cers, deals := startTestInit(t, init, ops, committee.operatorShares(nil), now)
+	require.Empty(t, runTestCeremonies(cers, deals, now, otherSecret))
+	require.Equal(t, dealPhase, cers[1].phase)
+
+	errs := runTestCeremonies(cers, nil, now.Add(testTimeout), otherSecret)
+	for _, cer := range cers[1:] {
+		require.NoError(t, errs[cer])
+		require.Equal(t, donePhase, cer.phase)
+		require.Equal(t, []uint64{2, 3, 4}, cer.result.Qualified)
+		require.Equal(t, cers[1].result, cer.result)
+	}
+	require.True(t, committee.sk.IsEqual(recoverSecret(t, ops[1:], cers[1].result, 1, 2, 4)))
+	// dealer 1 qualifies its own deal, so it doesn't agree with the new committee
+	require.EqualError(t, errs[cers[0]], "output of operator 1 does not match: different share public keys")
+}
+
+func TestReshare_NotEnoughDealers(t *testing.T) {
+	threshold.Init()
+	ops := newTestOperators(t, 4)
+	committee := newTestCommittee(t, ops, 3)
+	init := &Init{Operators: testInit(ops, 3).Operators, Threshold: 3, Reshare: committee.reshare}
+
+	now := time.Now()
+	cers, deals := startTestInit(t, init, ops, committee.operatorShares(nil), now)
+	// operators 1 and 2 are offline
+	cers, deals = cers[2:], deals[2:]
+	require.Empty(t, runTestCeremonies(cers, deals, now, nil))
+	require.Empty(t, runTestCeremonies(cers, nil, now.Add(testTimeout), nil))
+	errs := runTestCeremonies(cers, nil, now.Add(2*testTimeout), nil)
+	for _, cer := range cers {
+		require.EqualError(t, errs[cer], "not enough qualified dealers: [3 4]")
+	}
+}
+
+func TestNewCeremony_Reshare(t *testing.T) {
+	ops := newTestOperators(t, 4)
+	committee := newTestCommittee(t, ops[:3], 2)
+	shares := committee.operatorShares(nil)
+	init := &Init{Operators: testInit(ops[1:], 2).Operators, Threshold: 2, Reshare: committee.reshare}
+
+	_, err := newCeremony("id", init, ops[0].sk, ops[0].pk, nil, testTimeout)
+	require.EqualError(t, err, "missing current share")
+	_, err = newCeremony("id", init, ops[0].sk, ops[0].pk, shares[ops[1].pk], testTimeout)
+	require.EqualError(t, err, "share does not match the current committee")
+
+	// a dealer that is not a new operator
+	cer, err := newCeremony("id", init, ops[0].sk, ops[0].pk, shares[ops[0].pk], testTimeout)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), cer.index)
+	require.Equal(t, uint64(1), cer.dealerIndex)
+	// a new operator that doesn't deal
+	cer, err = newCeremony("id", init, ops[3].sk, ops[3].pk, nil, testTimeout)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), cer.index)
+	require.Equal(t, uint64(0), cer.dealerIndex)
+
+	invalid := *committee.reshare
+	invalid.Threshold = 4
+	init.Reshare = &invalid
+	_, err = newCeremony("id", init, ops[0].sk, ops[0].pk, shares[ops[0].pk], testTimeout)
+	require.EqualError(t, err, "invalid current committee: invalid threshold 4 for 3 operators")
+}
@@ -0,0 +1,51 @@
+package dkg
+
+import "time"
+
+// Status is the status of a ceremony
+type Status string
+
+const (
+	// StatusRunning means that the ceremony is in progress
+	StatusRunning Status = "running"
+	// StatusCompleted means that all operators computed the same result
+	StatusCompleted Status = "completed"
+	// StatusFailed means that the ceremony was aborted, see the error
+	StatusFailed Status = "failed"
+)
+
+// CeremonyState is the state of a ceremony, as seen by the node
+type CeremonyState struct {
+	ID        string    `json:"id"`
+	Operators []string  `json:"operators"`
+	Threshold uint64    `json:"threshold"`
+	Reshare   *Reshare  `json:"reshare,omitempty"`
+	Status    Status    `json:"status"`
+	Phase     string    `json:"phase,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Result    *Result   `json:"result,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// Result is the outcome of a completed ceremony, it holds what is needed to register the validator
+type Result struct {
+	// ValidatorPubKey is the (hex) group public key
+	ValidatorPubKey string `json:"validatorPubKey"`
+	// Qualified are the indexes of the dealers whose polynomials make the group key
+	Qualified []uint64       `json:"qualified"`
+	Shares    []*ShareResult `json:"shares"`
+	// Handover is the signing history of the validator when resharing, it is saved by the new operators
+	Handover *Handover `json:"handover,omitempty"`
+}
+
+// ShareResult is the share of a single operator
+type ShareResult struct {
+	// Index is the index of the operator, which is also the id of its share
+	Index          uint64 `json:"index"`
+	OperatorPubKey string `json:"operatorPubKey"`
+	// SharePubKey is the (hex) public key of the share
+	SharePubKey string `json:"sharePubKey"`
+	// EncryptedShare is the (hex) share, encrypted with the operator key (base64)
+	EncryptedShare string `json:"encryptedShare"`
+}
@@ -0,0 +1,52 @@
+package dkg
+
+import (
+	"encoding/json"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+)
+
+var ceremoniesPrefix = []byte("dkg-ceremonies/")
+
+// Storage persists the state of finished ceremonies
+type Storage interface {
+	// SaveCeremony saves the given ceremony state
+	SaveCeremony(state *CeremonyState) error
+	// GetCeremony returns the state of the given ceremony
+	GetCeremony(id string) (*CeremonyState, bool, error)
+}
+
+type storage struct {
+	db basedb.IDb
+}
+
+// NewStorage creates a new instance of Storage
+func NewStorage(db basedb.IDb) Storage {
+	return &storage{db: db}
+}
+
+// SaveCeremony saves the given ceremony state
+func (s *storage) SaveCeremony(state *CeremonyState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "marshaling error")
+	}
+	return s.db.Set(ceremoniesPrefix, []byte(state.ID), raw)
+}
+
+// GetCeremony returns the state of the given ceremony
+func (s *storage) GetCeremony(id string) (*CeremonyState, bool, error) {
+	obj, found, err := s.db.Get(ceremoniesPrefix, []byte(id))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not read ceremony")
+	}
+	if !found {
+		return nil, false, nil
+	}
+	state := &CeremonyState{}
+	if err := json.Unmarshal(obj.Value, state); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshaling error")
+	}
+	return state, true, nil
+}
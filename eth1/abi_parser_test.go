@@ -3,8 +3,10 @@ package eth1
 import (
 	"encoding/hex"
 	"encoding/json"
+	"github.com/bloxapp/ssv/eth1/abiparser"
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -12,6 +14,20 @@ import (
 	"testing"
 )
 
+var v2RawValidatorAdded = `{
+   "address":"0xd594c1ef4845713e86658cb42227a811625a285b",
+   "topics":[
+      "0x088097840a21a2c763dd9bd97cc2b0b27628bb6a42124a398260fac7f31ff571"
+   ],
+   "data":"0x0000000000000000000000004e409db090a71d14d32adbfbc0a22b1b06dde7de00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000d200000000000000000000000000000000000000000000000000000000000000f4000000000000000000000000000000000000000000000000000000000000000308687eb8b88ff9c39e659c47b7bb76665fabfc4fc02c4246caca49700242fa9260a145969ede608b10c711ef2d57d0da1000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000003600000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000092000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e42555556424e32705863457872656d643254586476527a684e64455679556a494b524768554d6b313164456c6d59556430566d784d654456574b326734616d7772646e6c7854315976636d784b5245566c517939484d7a567056304d3057455533526e464b55566331516d707651575a315458685165677052517a5a364d45453162314933656e52755748553263305633546b684a534668335245464954486c54645664514d334247596c6f30516e63356231465a54554a6d62564e734c33685852307379566e4e336156686b436b4e4663555a4b526d644e55466b334e6c4a5159306f325232646b545763725756525257565646616d6c52546a4670646d4a4b5a6a5257615570435254637262564e7465465a4e4e54417a566d6c7951575a6e646b494b656e426e64544e7a64485a496448705256315a3265484a304e545230526d39444d48526d5745315252584e53553056745456526f566b686f63566f725a544a434f43396b545751325231466f646e45355a58523152517068516b786f536c704655586c704d6b6c7055553032556c6732613031765a476447556d6376656d747454465a5851305649547a457a61465635526b6f78616e67314c304d3562454979553256454e57396a64316834436d4a525355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e4255555642623370566147467a534739486545315953337055627a67725348634b57475630656e4a7457454e594f546474655842706148686a4c32777853456c6c53565677563256334e6b464e4d7a6c5064314a515a3256564d465a33516d51324e485a68627a5a7354544e615157785464565a6c4d677061626c4e305430314a636b4a5457475673596b633062314272524735785a6b4e4e62474a6d6131524e526c685856466f776445314964474a77566b55334e326f3061457078615549335a553133596974774e585578436c6f764e6d5678576a5a6d5257526e4f4449354d7a4e335a55686856574e7a64325a4a516d68594e6c4e61556a4e6c4d6b4a7652554a3262486c6a4e4535454e45466f4e5646615a6a4d7252577078536974356448594b63336869526d354d4e55704c5757686a536c6334596d7443647a4e6f4d3256726555597959324932655545334d336473547a5a68576b6c6152574a34516b453057446c34576a684d534642614e484a59574739476277706f4d564643643149784f555668656d463562306831546d4a6b574770426255396863315669543074744e464a42646b3979613146775a31493453304a344e474d7a637a6b304f466c696454424a526b745162304e49436b4a335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e4255555642636a5a586330396b4d7a4a5a5653745065566f7756565a74556c594b516b68455245744c4d3255314f545270557a56326448524c4d564a694d6c5659643359774e475a4b634764344c314e51576d6c71556d45306546646d63335a7361544d7865486731633273724d6c68364f544a3156516f35546c45344f47526c4c305978656d4a74616e51774d323577576a686153323533636d314c4f585a55524539505a4659344d3152694d554e59547a466862334a3265564d314d4552695a546c536248453253474e44436e567554545261516e6b30534864765a3270425a6a5932595446436330383565477832526a63305545677252544a3051316b305a5659774c314d3456466448626a6834523064495457354754306c31556d524d5554414b656d4d7651307050566a42494b316461534556455a5463794e5538775231417754585630516d4e485a57453152334134636b5a7757486b764d444642646d6c58616a426e4d4464714d4652314d30685a4e30646c53776f765a564e544c3168574f474a55524734344d305a516245353457486479566d6c33637a6c306347787a54464d78655578534e30787854324e5959566c344e48524c59334672565451305546686d656d395565433942436d68335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e42555556426456497a5630686d55316c68576c45304e6a6b78656e52306154594b5a6c4242634578716132394c6379737251533930515764535658644862456858596d35694e6a4a5056553472613074545555353356576c4e4d4652775747644f564856534e47706a6457644b61314e54526c5253524170355745777653585270627a6c465a48453361456852513342455130784356464e59526c4e744d6a4a724e6c4e52626c6c476557733355564e6e646e6f795157396d4f584a3659566442516d566d556b5a5064557335436e465754303072627a686e526e467763586c51526e524a527939435653394662316c324d30464e5531413555574a4354585258536b4976635464325153745a4d5546725a454a6959554e756147466b4b3146555747774b5931566b537a526162485a314e566446576b784c6443394f4d6c5531524751776146683452584275526c6f334c3031534e56526e52566c324e466c336155704865574e795254464b5747565355324d724d3231445751704b656b567a596a4a50575442545a453833596a424d635764714d326856613052746345645653324e6f516c5179614777304e574a35616b3476616c5a6a555731726232396c5955677a53437432523249764e7a6856436b56335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000014000000000000000000000000000000000000000000000000000000000000001a00000000000000000000000000000000000000000000000000000000000000030adb6d42245eaf4b00909679642964d6d5c12c4c550eaffcee499a12ea731c5f101f43a3880b9363daf873ae455fa7aa6000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000030b6de3081ad9a8becd37676827afb46386eeaa4cd7ebf8711a37505d3c5d3a7a3c1e167e3031e98094ed5262ec65ff205000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000030ad4754bd8ca755db23a0701d0dd5488403f9092912b09bcef95b8f70b380b528effd395fb3f06f92c515acf618f2cfa900000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003097fceae9c1eeaeb5f9c8ebf875b7bc8248c514fe0c847cb2a15e662595ec6e214ebe3351f9b79629185008be0a1d1f5000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000240000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000005c000000000000000000000000000000000000000000000000000000000000001a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000001584d6a4e563955484666326f37644a76584d4c6867517866486430443945764a4f324e714c6c7753706a4c534b39497076663065514c49645a7a396e2f454941647a544734726c4f344a614332336270634946665036422b742f387a3379552b45425238785a35654235424c316f506730376672544d582f3951325a48305a7a6966716e535a372b672f396243483678675a495a634f5574687a30595141476752542f636c4d466b6162687a6d6a377172794972592f4577424a7a5164335363554d53586b4b4f65466d42496e78494241485238506e69495161597559734e314f4e48353571764b5833433452554a4175502b3675584b3949746d737353716b4249726851786a6f76696f6c4d51776b646b515038396a4c6d635835467062506973355771563856682f516b74376f4c734170306d5851546f67456d47566657426d4d736853464563384170446c6f5968344d547245413d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158575633707254546c593632746152366b42774f32773431544950613474796e574761596663444c672b46644b414c3061686d396136314a784276643676714c78634a7072346d472f61635053446e67657462624761425074494a423871556c4e6144796c744b7051675947526c394e657746736d69546570706c785769644d6a523445643663344154627a495a346c74486a6658683868582b772f7a7850704f4b5648344d7334414a6d50595a6835434c7057426e65554d436f4c412f6849556b71586a586d4a6c2b316d456a7270314a64526c6977762b37586f467379565570744839617767714167416d45415077454c4f575454526b6536482f4e2b774f334d526c6c4663726d476f555a73756d567a38452f523947744b32452f6864573356616934506c686a727552717a3965696d4f66564c764469774b505370546f5479675548717a72745a5047486e2b58716f64496b673d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158466a2b55417371307536524d74632f2b6f61446e5136386568427252745744424e37716b694c6e46526744634a6c454c426d6f6e37482f5056754a4279666775565639624c3968366b4b645844556955575873736a543048306c424e3454785475737a464d5149317158336851576f474735594a644b75596b3268362b3862572f77526c6c667033734544534b2f6e4a4936676c316e427a6a71414361794c505044466d47442b746e46767a6870765152476736334d54475969346c336939744d706f564e574573586249715773716d6f61383747695831354e59435a75397947476c66387567644d5a4b54324a66306345476c6b7957745856676433716b6b6e4d756d504c34746d2b305349636a3177434a456654737478757a614b546d44434e64315a4d72792b6d70457744414f684a6c4e36444b433348486e524d6a57672f4b376f30536d44654b504949644942344e7078673d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158475331774963466545787a5369587a3471384f4e6833734e765577542b493055517a70684773386a6638484b524d36615047617170384c4c65365568737941577659336373485863656144624356536178652f3556785061302f31574f34394a456c4c3346386f4975305649323769572b514d7a7735715944615a4b427476595351663379346f3036686436346c6d5a7a6c3855512b7570616161725746724f464e312b5572716565547130672b713747444b44536a614a7a524a5952566546572b7763456f5063662b515343314a5a6c5a653370314e643472524a7852304a41307477477978634f4f6a4e576b494479565866624a34766b6e72646e7539524b695a5056514d344a6a69796762684b302b516d43744a463436304935304750745247534a58756a4b3163786f6c56366c73536a557a4e51386e7141426838726278693651356b585478774155526d6b44456f2b52773d3d0000000000000000",
+   "blockNumber":"0x5b5dc0",
+   "transactionHash":"0x39fc924907817a759b41abd98353d3f94b9b1c159a796ad2f5339cbd2ed24dbd",
+   "transactionIndex":"0x0",
+   "blockHash":"0x021be90e25602cddc56386db2b690d427c05c9de288ca7c39f389157ba08c903",
+   "logIndex":"0x2",
+   "removed":false
+}`
+
 func TestParseOperatorAddedEvent(t *testing.T) {
 	OldRawOperatorAdded := `{
   "address": "0x9573c41f0ed8b72f3bd6a9ba6e3e15426a0aa65b",
@@ -85,19 +101,6 @@ func TestParseValidatorAddedEvent(t *testing.T) {
   "logIndex": "0x2",
   "removed": false
 }`
-	rawValidatorAdded := `{
-   "address":"0xd594c1ef4845713e86658cb42227a811625a285b",
-   "topics":[
-      "0x088097840a21a2c763dd9bd97cc2b0b27628bb6a42124a398260fac7f31ff571"
-   ],
-   "data":"0x0000000000000000000000004e409db090a71d14d32adbfbc0a22b1b06dde7de00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000d200000000000000000000000000000000000000000000000000000000000000f4000000000000000000000000000000000000000000000000000000000000000308687eb8b88ff9c39e659c47b7bb76665fabfc4fc02c4246caca49700242fa9260a145969ede608b10c711ef2d57d0da1000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000003600000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000092000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e42555556424e32705863457872656d643254586476527a684e64455679556a494b524768554d6b313164456c6d59556430566d784d654456574b326734616d7772646e6c7854315976636d784b5245566c517939484d7a567056304d3057455533526e464b55566331516d707651575a315458685165677052517a5a364d45453162314933656e52755748553263305633546b684a534668335245464954486c54645664514d334247596c6f30516e63356231465a54554a6d62564e734c33685852307379566e4e336156686b436b4e4663555a4b526d644e55466b334e6c4a5159306f325232646b545763725756525257565646616d6c52546a4670646d4a4b5a6a5257615570435254637262564e7465465a4e4e54417a566d6c7951575a6e646b494b656e426e64544e7a64485a496448705256315a3265484a304e545230526d39444d48526d5745315252584e53553056745456526f566b686f63566f725a544a434f43396b545751325231466f646e45355a58523152517068516b786f536c704655586c704d6b6c7055553032556c6732613031765a476447556d6376656d747454465a5851305649547a457a61465635526b6f78616e67314c304d3562454979553256454e57396a64316834436d4a525355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e4255555642623370566147467a534739486545315953337055627a67725348634b57475630656e4a7457454e594f546474655842706148686a4c32777853456c6c53565677563256334e6b464e4d7a6c5064314a515a3256564d465a33516d51324e485a68627a5a7354544e615157785464565a6c4d677061626c4e305430314a636b4a5457475673596b633062314272524735785a6b4e4e62474a6d6131524e526c685856466f776445314964474a77566b55334e326f3061457078615549335a553133596974774e585578436c6f764e6d5678576a5a6d5257526e4f4449354d7a4e335a55686856574e7a64325a4a516d68594e6c4e61556a4e6c4d6b4a7652554a3262486c6a4e4535454e45466f4e5646615a6a4d7252577078536974356448594b63336869526d354d4e55704c5757686a536c6334596d7443647a4e6f4d3256726555597959324932655545334d336473547a5a68576b6c6152574a34516b453057446c34576a684d534642614e484a59574739476277706f4d564643643149784f555668656d463562306831546d4a6b574770426255396863315669543074744e464a42646b3979613146775a31493453304a344e474d7a637a6b304f466c696454424a526b745162304e49436b4a335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e4255555642636a5a586330396b4d7a4a5a5653745065566f7756565a74556c594b516b68455245744c4d3255314f545270557a56326448524c4d564a694d6c5659643359774e475a4b634764344c314e51576d6c71556d45306546646d63335a7361544d7865486731633273724d6c68364f544a3156516f35546c45344f47526c4c305978656d4a74616e51774d323577576a686153323533636d314c4f585a55524539505a4659344d3152694d554e59547a466862334a3265564d314d4552695a546c536248453253474e44436e567554545261516e6b30534864765a3270425a6a5932595446436330383565477832526a63305545677252544a3051316b305a5659774c314d3456466448626a6834523064495457354754306c31556d524d5554414b656d4d7651307050566a42494b316461534556455a5463794e5538775231417754585630516d4e485a57453152334134636b5a7757486b764d444642646d6c58616a426e4d4464714d4652314d30685a4e30646c53776f765a564e544c3168574f474a55524734344d305a516245353457486479566d6c33637a6c306347787a54464d78655578534e30787854324e5959566c344e48524c59334672565451305546686d656d395565433942436d68335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002c0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000002644c5330744c5331435255644a54694253553045675546564354456c4449457446575330744c533074436b314a53554a4a616b464f516d64726357687261556335647a424351564646526b464254304e425554684254556c4a516b4e6e53304e42555556426456497a5630686d55316c68576c45304e6a6b78656e52306154594b5a6c4242634578716132394c6379737251533930515764535658644862456858596d35694e6a4a5056553472613074545555353356576c4e4d4652775747644f564856534e47706a6457644b61314e54526c5253524170355745777653585270627a6c465a48453361456852513342455130784356464e59526c4e744d6a4a724e6c4e52626c6c476557733355564e6e646e6f795157396d4f584a3659566442516d566d556b5a5064557335436e465754303072627a686e526e467763586c51526e524a527939435653394662316c324d30464e5531413555574a4354585258536b4976635464325153745a4d5546725a454a6959554e756147466b4b3146555747774b5931566b537a526162485a314e566446576b784c6443394f4d6c5531524751776146683452584275526c6f334c3031534e56526e52566c324e466c336155704865574e795254464b5747565355324d724d3231445751704b656b567a596a4a50575442545a453833596a424d635764714d326856613052746345645653324e6f516c5179614777304e574a35616b3476616c5a6a555731726232396c5955677a53437432523249764e7a6856436b56335355524255554643436930744c5330745255354549464a545153425156554a4d53554d675330565a4c5330744c53304b000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000014000000000000000000000000000000000000000000000000000000000000001a00000000000000000000000000000000000000000000000000000000000000030adb6d42245eaf4b00909679642964d6d5c12c4c550eaffcee499a12ea731c5f101f43a3880b9363daf873ae455fa7aa6000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000030b6de3081ad9a8becd37676827afb46386eeaa4cd7ebf8711a37505d3c5d3a7a3c1e167e3031e98094ed5262ec65ff205000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000030ad4754bd8ca755db23a0701d0dd5488403f9092912b09bcef95b8f70b380b528effd395fb3f06f92c515acf618f2cfa900000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003097fceae9c1eeaeb5f9c8ebf875b7bc8248c514fe0c847cb2a15e662595ec6e214ebe3351f9b79629185008be0a1d1f5000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000240000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000005c000000000000000000000000000000000000000000000000000000000000001a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000001584d6a4e563955484666326f37644a76584d4c6867517866486430443945764a4f324e714c6c7753706a4c534b39497076663065514c49645a7a396e2f454941647a544734726c4f344a614332336270634946665036422b742f387a3379552b45425238785a35654235424c316f506730376672544d582f3951325a48305a7a6966716e535a372b672f396243483678675a495a634f5574687a30595141476752542f636c4d466b6162687a6d6a377172794972592f4577424a7a5164335363554d53586b4b4f65466d42496e78494241485238506e69495161597559734e314f4e48353571764b5833433452554a4175502b3675584b3949746d737353716b4249726851786a6f76696f6c4d51776b646b515038396a4c6d635835467062506973355771563856682f516b74376f4c734170306d5851546f67456d47566657426d4d736853464563384170446c6f5968344d547245413d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158575633707254546c593632746152366b42774f32773431544950613474796e574761596663444c672b46644b414c3061686d396136314a784276643676714c78634a7072346d472f61635053446e67657462624761425074494a423871556c4e6144796c744b7051675947526c394e657746736d69546570706c785769644d6a523445643663344154627a495a346c74486a6658683868582b772f7a7850704f4b5648344d7334414a6d50595a6835434c7057426e65554d436f4c412f6849556b71586a586d4a6c2b316d456a7270314a64526c6977762b37586f467379565570744839617767714167416d45415077454c4f575454526b6536482f4e2b774f334d526c6c4663726d476f555a73756d567a38452f523947744b32452f6864573356616934506c686a727552717a3965696d4f66564c764469774b505370546f5479675548717a72745a5047486e2b58716f64496b673d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158466a2b55417371307536524d74632f2b6f61446e5136386568427252745744424e37716b694c6e46526744634a6c454c426d6f6e37482f5056754a4279666775565639624c3968366b4b645844556955575873736a543048306c424e3454785475737a464d5149317158336851576f474735594a644b75596b3268362b3862572f77526c6c667033734544534b2f6e4a4936676c316e427a6a71414361794c505044466d47442b746e46767a6870765152476736334d54475969346c336939744d706f564e574573586249715773716d6f61383747695831354e59435a75397947476c66387567644d5a4b54324a66306345476c6b7957745856676433716b6b6e4d756d504c34746d2b305349636a3177434a456654737478757a614b546d44434e64315a4d72792b6d70457744414f684a6c4e36444b433348486e524d6a57672f4b376f30536d44654b504949644942344e7078673d3d000000000000000000000000000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000158475331774963466545787a5369587a3471384f4e6833734e765577542b493055517a70684773386a6638484b524d36615047617170384c4c65365568737941577659336373485863656144624356536178652f3556785061302f31574f34394a456c4c3346386f4975305649323769572b514d7a7735715944615a4b427476595351663379346f3036686436346c6d5a7a6c3855512b7570616161725746724f464e312b5572716565547130672b713747444b44536a614a7a524a5952566546572b7763456f5063662b515343314a5a6c5a653370314e643472524a7852304a41307477477978634f4f6a4e576b494479565866624a34766b6e72646e7539524b695a5056514d344a6a69796762684b302b516d43744a463436304935304750745247534a58756a4b3163786f6c56366c73536a557a4e51386e7141426838726278693651356b585478774155526d6b44456f2b52773d3d0000000000000000",
-   "blockNumber":"0x5b5dc0",
-   "transactionHash":"0x39fc924907817a759b41abd98353d3f94b9b1c159a796ad2f5339cbd2ed24dbd",
-   "transactionIndex":"0x0",
-   "blockHash":"0x021be90e25602cddc56386db2b690d427c05c9de288ca7c39f389157ba08c903",
-   "logIndex":"0x2",
-   "removed":false
-}`
 
 	t.Run("legacy validator added", func(t *testing.T) {
 		vLogValidatorAdded, contractAbi := unmarshalLog(t, legacyRawValidatorAdded, Legacy)
@@ -112,7 +115,7 @@ func TestParseValidatorAddedEvent(t *testing.T) {
 	})
 
 	t.Run("v2 validator added", func(t *testing.T) {
-		vLogValidatorAdded, contractAbi := unmarshalLog(t, rawValidatorAdded, V2)
+		vLogValidatorAdded, contractAbi := unmarshalLog(t, v2RawValidatorAdded, V2)
 		abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
 		parsed, isEventBelongsToOperator, unpackErr, err := abiParser.ParseValidatorAddedEvent(nil, vLogValidatorAdded.Data, contractAbi)
 		require.NoError(t, err)
@@ -133,6 +136,75 @@ func TestParseValidatorAddedEvent(t *testing.T) {
 	})
 }
 
+func TestParseValidatorUpdatedEvent(t *testing.T) {
+	// ValidatorUpdated has the same inputs as ValidatorAdded
+	vLogValidatorAdded, contractAbi := unmarshalLog(t, v2RawValidatorAdded, V2)
+	abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+	parsed, isEventBelongsToOperator, unpackErr, err := abiParser.ParseValidatorUpdatedEvent(nil, vLogValidatorAdded.Data, contractAbi)
+	require.NoError(t, err)
+	require.False(t, isEventBelongsToOperator)
+	require.False(t, unpackErr)
+	require.NotNil(t, parsed)
+	require.Equal(t, "8687eb8b88ff9c39e659c47b7bb76665fabfc4fc02c4246caca49700242fa9260a145969ede608b10c711ef2d57d0da1", hex.EncodeToString(parsed.PublicKey))
+	require.Equal(t, 4, len(parsed.OperatorPublicKeys))
+	require.Equal(t, 4, len(parsed.SharesPublicKeys))
+}
+
+func TestParseDeletedEvents(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	abiParser := NewParser(logger, V2)
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+	ownerTopic := common.BytesToHash(owner.Bytes())
+
+	t.Run("validator deleted", func(t *testing.T) {
+		pk, err := hex.DecodeString("8687eb8b88ff9c39e659c47b7bb76665fabfc4fc02c4246caca49700242fa9260a145969ede608b10c711ef2d57d0da1")
+		require.NoError(t, err)
+		data, err := contractAbi.Events["ValidatorDeleted"].Inputs.NonIndexed().Pack(owner, pk)
+		require.NoError(t, err)
+		parsed, unpackErr, err := abiParser.ParseValidatorDeletedEvent(data, contractAbi)
+		require.NoError(t, err)
+		require.False(t, unpackErr)
+		require.Equal(t, pk, parsed.PublicKey)
+		require.Equal(t, owner, parsed.OwnerAddress)
+	})
+
+	t.Run("operator deleted", func(t *testing.T) {
+		stringType, err := abi.NewType("string", "", nil)
+		require.NoError(t, err)
+		encodedPubKey, err := abi.Arguments{{Type: stringType}}.Pack("operator-pubkey")
+		require.NoError(t, err)
+		data, err := contractAbi.Events["OperatorDeleted"].Inputs.NonIndexed().Pack(encodedPubKey)
+		require.NoError(t, err)
+		topics := []common.Hash{contractAbi.Events["OperatorDeleted"].ID, ownerTopic}
+		parsed, isEventBelongsToOperator, unpackErr, err := abiParser.ParseOperatorDeletedEvent("operator-pubkey", data, topics, contractAbi)
+		require.NoError(t, err)
+		require.False(t, unpackErr)
+		require.True(t, isEventBelongsToOperator)
+		require.Equal(t, "operator-pubkey", string(parsed.PublicKey))
+		require.Equal(t, owner, parsed.OwnerAddress)
+	})
+
+	t.Run("account liquidated", func(t *testing.T) {
+		topics := []common.Hash{contractAbi.Events["AccountLiquidated"].ID, ownerTopic}
+		parsed, unpackErr, err := abiParser.ParseAccountLiquidatedEvent(topics)
+		require.NoError(t, err)
+		require.False(t, unpackErr)
+		require.Equal(t, owner, parsed.OwnerAddress)
+
+		_, unpackErr, err = abiParser.ParseAccountLiquidatedEvent(topics[:1])
+		require.Error(t, err)
+		require.True(t, unpackErr)
+	})
+
+	t.Run("legacy abi", func(t *testing.T) {
+		_, unpackErr, err := NewParser(logger, Legacy).ParseValidatorDeletedEvent(nil, contractAbi)
+		require.EqualError(t, err, "ValidatorDeleted event is not supported by legacy abi")
+		require.True(t, unpackErr)
+	})
+}
+
 func unmarshalLog(t *testing.T, rawOperatorAdded string, abiVersion Version) (*types.Log, abi.ABI) {
 	var vLogOperatorAdded types.Log
 	err := json.Unmarshal([]byte(rawOperatorAdded), &vLogOperatorAdded)
@@ -142,3 +214,38 @@ func unmarshalLog(t *testing.T, rawOperatorAdded string, abiVersion Version) (*t
 	require.NotNil(t, contractAbi)
 	return &vLogOperatorAdded, contractAbi
 }
+
+func TestParseEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	abiParser := NewParser(logger, V2)
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+
+	t.Run("account liquidated", func(t *testing.T) {
+		vLog := types.Log{Topics: []common.Hash{contractAbi.Events["AccountLiquidated"].ID, common.BytesToHash(owner.Bytes())}}
+		eventName, e, unpackErr, err := abiParser.ParseEvent(vLog, contractAbi, "", nil)
+		require.NoError(t, err)
+		require.False(t, unpackErr)
+		require.Equal(t, "AccountLiquidated", eventName)
+		require.Equal(t, abiparser.AccountLiquidatedEvent{OwnerAddress: owner}, e.Data)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		vLog := types.Log{Topics: []common.Hash{contractAbi.Events["AccountLiquidated"].ID}}
+		eventName, e, unpackErr, err := abiParser.ParseEvent(vLog, contractAbi, "", nil)
+		require.Error(t, err)
+		require.True(t, unpackErr)
+		require.Equal(t, "AccountLiquidated", eventName)
+		require.NotNil(t, e)
+		require.Nil(t, e.Data)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		vLog := types.Log{Topics: []common.Hash{common.HexToHash("0x1")}}
+		eventName, e, _, err := abiParser.ParseEvent(vLog, contractAbi, "", nil)
+		require.NoError(t, err)
+		require.Empty(t, eventName)
+		require.Nil(t, e)
+	})
+}
@@ -90,6 +90,44 @@ func (adapter LegacyAdapter) ParseValidatorAddedEvent(
 	}, isOperatorEvent, unpackErr, err
 }
 
+// ParseValidatorUpdatedEvent is not supported by the legacy contract
+func (adapter LegacyAdapter) ParseValidatorUpdatedEvent(
+	logger *zap.Logger,
+	operatorPrivateKey *rsa.PrivateKey,
+	data []byte,
+	contractAbi abi.ABI,
+) (*ValidatorUpdatedEvent, bool, bool, error) {
+	return nil, false, true, errors.New("ValidatorUpdated event is not supported by legacy abi")
+}
+
+// ParseValidatorDeletedEvent is not supported by the legacy contract
+func (adapter LegacyAdapter) ParseValidatorDeletedEvent(
+	logger *zap.Logger,
+	data []byte,
+	contractAbi abi.ABI,
+) (*ValidatorDeletedEvent, bool, error) {
+	return nil, true, errors.New("ValidatorDeleted event is not supported by legacy abi")
+}
+
+// ParseOperatorDeletedEvent is not supported by the legacy contract
+func (adapter LegacyAdapter) ParseOperatorDeletedEvent(
+	logger *zap.Logger,
+	operatorPubKey string,
+	data []byte,
+	topics []common.Hash,
+	contractAbi abi.ABI,
+) (*OperatorDeletedEvent, bool, bool, error) {
+	return nil, false, true, errors.New("OperatorDeleted event is not supported by legacy abi")
+}
+
+// ParseAccountLiquidatedEvent is not supported by the legacy contract
+func (adapter LegacyAdapter) ParseAccountLiquidatedEvent(
+	logger *zap.Logger,
+	topics []common.Hash,
+) (*AccountLiquidatedEvent, bool, error) {
+	return nil, true, errors.New("AccountLiquidated event is not supported by legacy abi")
+}
+
 // LegacyAbi parsing events from legacy abi contract
 type LegacyAbi struct {
 }
@@ -21,6 +21,21 @@ type ValidatorAddedEvent struct {
 	EncryptedKeys      [][]byte
 }
 
+// ValidatorUpdatedEvent struct represents event received by the smart contract
+type ValidatorUpdatedEvent struct {
+	PublicKey          []byte
+	OwnerAddress       common.Address
+	OperatorPublicKeys [][]byte
+	SharesPublicKeys   [][]byte
+	EncryptedKeys      [][]byte
+}
+
+// ValidatorDeletedEvent struct represents event received by the smart contract
+type ValidatorDeletedEvent struct {
+	OwnerAddress common.Address
+	PublicKey    []byte
+}
+
 // OperatorAddedEvent struct represents event received by the smart contract
 type OperatorAddedEvent struct {
 	Name         string
@@ -28,6 +43,17 @@ type OperatorAddedEvent struct {
 	PublicKey    []byte
 }
 
+// OperatorDeletedEvent struct represents event received by the smart contract
+type OperatorDeletedEvent struct {
+	OwnerAddress common.Address
+	PublicKey    []byte
+}
+
+// AccountLiquidatedEvent struct represents event received by the smart contract
+type AccountLiquidatedEvent struct {
+	OwnerAddress common.Address
+}
+
 // V2Abi parsing events from v2 abi contract
 type V2Abi struct {
 }
@@ -77,42 +103,138 @@ func (v2 *V2Abi) ParseValidatorAddedEvent(
 		return nil, false, true, errors.Wrap(err, "Failed to unpack ValidatorAdded event")
 	}
 
+	isOperatorEvent, unpackErr, err := readValidatorShares(operatorPrivateKey, validatorAddedEvent.OperatorPublicKeys, validatorAddedEvent.EncryptedKeys)
+	if err != nil {
+		return nil, false, unpackErr, err
+	}
+
+	return &validatorAddedEvent, isOperatorEvent, false, nil
+}
+
+// ParseValidatorUpdatedEvent parses ValidatorUpdatedEvent
+func (v2 *V2Abi) ParseValidatorUpdatedEvent(
+	logger *zap.Logger,
+	operatorPrivateKey *rsa.PrivateKey,
+	data []byte,
+	contractAbi abi.ABI,
+) (*ValidatorUpdatedEvent, bool, bool, error) {
+	var validatorUpdatedEvent ValidatorUpdatedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorUpdatedEvent, "ValidatorUpdated", data)
+	if err != nil {
+		return nil, false, true, errors.Wrap(err, "Failed to unpack ValidatorUpdated event")
+	}
+
+	isOperatorEvent, unpackErr, err := readValidatorShares(operatorPrivateKey, validatorUpdatedEvent.OperatorPublicKeys, validatorUpdatedEvent.EncryptedKeys)
+	if err != nil {
+		return nil, false, unpackErr, err
+	}
+
+	return &validatorUpdatedEvent, isOperatorEvent, false, nil
+}
+
+// ParseValidatorDeletedEvent parses ValidatorDeletedEvent
+func (v2 *V2Abi) ParseValidatorDeletedEvent(
+	logger *zap.Logger,
+	data []byte,
+	contractAbi abi.ABI,
+) (*ValidatorDeletedEvent, bool, error) {
+	var validatorDeletedEvent ValidatorDeletedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorDeletedEvent, "ValidatorDeleted", data)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "Failed to unpack ValidatorDeleted event")
+	}
+	return &validatorDeletedEvent, false, nil
+}
+
+// ParseOperatorDeletedEvent parses OperatorDeletedEvent
+func (v2 *V2Abi) ParseOperatorDeletedEvent(
+	logger *zap.Logger,
+	operatorPubKey string,
+	data []byte,
+	topics []common.Hash,
+	contractAbi abi.ABI,
+) (*OperatorDeletedEvent, bool, bool, error) {
+	var operatorDeletedEvent OperatorDeletedEvent
+	err := contractAbi.UnpackIntoInterface(&operatorDeletedEvent, "OperatorDeleted", data)
+	if err != nil {
+		return nil, false, true, errors.Wrap(err, "failed to unpack OperatorDeleted event")
+	}
+	outAbi, err := getOutAbi()
+	if err != nil {
+		return nil, false, false, err
+	}
+	pubKey, err := readOperatorPubKey(operatorDeletedEvent.PublicKey, outAbi)
+	if err != nil {
+		return nil, false, true, errors.Wrap(err, "failed to read OperatorPublicKey")
+	}
+	operatorDeletedEvent.PublicKey = []byte(pubKey)
+
+	if len(topics) > 1 {
+		operatorDeletedEvent.OwnerAddress = common.HexToAddress(topics[1].Hex())
+	} else {
+		logger.Error("operator event missing topics. no owner address provided.")
+	}
+	isOperatorEvent := strings.EqualFold(pubKey, operatorPubKey)
+	return &operatorDeletedEvent, isOperatorEvent, false, nil
+}
+
+// ParseAccountLiquidatedEvent parses AccountLiquidatedEvent
+func (v2 *V2Abi) ParseAccountLiquidatedEvent(
+	logger *zap.Logger,
+	topics []common.Hash,
+) (*AccountLiquidatedEvent, bool, error) {
+	// the owner address is the only (indexed) field of the event
+	if len(topics) < 2 {
+		return nil, true, errors.New("account liquidated event missing topics. no owner address provided")
+	}
+	return &AccountLiquidatedEvent{
+		OwnerAddress: common.HexToAddress(topics[1].Hex()),
+	}, false, nil
+}
+
+// readValidatorShares reads the operators public keys of a validator event (in place),
+// in case one of the shares belongs to the given operator, its private key will be decrypted
+func readValidatorShares(
+	operatorPrivateKey *rsa.PrivateKey,
+	operatorPublicKeys [][]byte,
+	encryptedKeys [][]byte,
+) (bool, bool, error) {
 	var isOperatorEvent bool
-	for i, operatorPublicKey := range validatorAddedEvent.OperatorPublicKeys {
+	for i, operatorPublicKey := range operatorPublicKeys {
 		outAbi, err := getOutAbi()
 		if err != nil {
-			return nil, false, false, errors.Wrap(err, "failed to define ABI")
+			return false, false, errors.Wrap(err, "failed to define ABI")
 		}
 		operatorPublicKey, err := readOperatorPubKey(operatorPublicKey, outAbi)
 		if err != nil {
-			return nil, false, true, errors.Wrap(err, "failed to read OperatorPublicKey")
+			return false, true, errors.Wrap(err, "failed to read OperatorPublicKey")
 		}
 
-		validatorAddedEvent.OperatorPublicKeys[i] = []byte(operatorPublicKey) // set for further use in code
+		operatorPublicKeys[i] = []byte(operatorPublicKey) // set for further use in code
 		if operatorPrivateKey == nil {
 			continue
 		}
 		nodeOperatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
 		if err != nil {
-			return nil, false, false, errors.Wrap(err, "failed to extract public key")
+			return false, false, errors.Wrap(err, "failed to extract public key")
 		}
 		if strings.EqualFold(operatorPublicKey, nodeOperatorPubKey) {
-			out, err := outAbi.Unpack("method", validatorAddedEvent.EncryptedKeys[i])
+			out, err := outAbi.Unpack("method", encryptedKeys[i])
 			if err != nil {
-				return nil, false, true, errors.Wrap(err, "failed to unpack EncryptedKey")
+				return false, true, errors.Wrap(err, "failed to unpack EncryptedKey")
 			}
 
 			if encryptedSharePrivateKey, ok := out[0].(string); ok {
 				decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, encryptedSharePrivateKey)
 				decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
 				if err != nil {
-					return nil, false, false, errors.Wrap(err, "failed to decrypt share private key")
+					return false, false, errors.Wrap(err, "failed to decrypt share private key")
 				}
-				validatorAddedEvent.EncryptedKeys[i] = []byte(decryptedSharePrivateKey)
+				encryptedKeys[i] = []byte(decryptedSharePrivateKey)
 				isOperatorEvent = true
 			}
 		}
 	}
 
-	return &validatorAddedEvent, isOperatorEvent, false, nil
+	return isOperatorEvent, false, nil
 }
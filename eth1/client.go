@@ -2,6 +2,7 @@ package eth1
 
 import (
 	"crypto/rsa"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"math/big"
@@ -10,18 +11,29 @@ import (
 
 // Options configurations related to eth1
 type Options struct {
-	ETH1Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"ETH1 node WebSocket address"`
-	ETH1SyncOffset        string        `yaml:"ETH1SyncOffset" env:"ETH_1_SYNC_OFFSET" env-description:"block number to start the sync from"`
+	ETH1Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-description:"comma separated list of ETH1 node addresses (WebSocket or HTTP), the first one is the primary and the others are used for failover (required unless ETH1LogsFile is used)"`
+	ETH1LogsFile          string        `yaml:"ETH1LogsFile" env:"ETH_1_LOGS_FILE" env-description:"contract logs file (export-registry-events) to sync from instead of an eth1 node"`
+	ETH1SyncOffset        string        `yaml:"ETH1SyncOffset" env:"ETH_1_SYNC_OFFSET" env-description:"block number to start the sync from, defaults to the sync offset of the network profile"`
 	ETH1ConnectionTimeout time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"eth1 node connection timeout"`
-	RegistryContractAddr  string        `yaml:"RegistryContractAddr" env:"REGISTRY_CONTRACT_ADDR_KEY" env-default:"0x9573C41F0Ed8B72f3bD6A9bA6E3e15426A0aa65B" env-description:"registry contract address"`
+	ETH1PollingInterval   time.Duration `yaml:"ETH1PollingInterval" env:"ETH_1_POLLING_INTERVAL" env-default:"12s" env-description:"interval of polling HTTP nodes for new blocks"`
+	ETH1FollowDistance    uint64        `yaml:"ETH1FollowDistance" env:"ETH_1_FOLLOW_DISTANCE" env-default:"8" env-description:"number of confirmations (blocks) to wait for before applying contract events"`
+	RegistryContractAddr  string        `yaml:"RegistryContractAddr" env:"REGISTRY_CONTRACT_ADDR_KEY" env-description:"registry contract address, defaults to the contract of the network profile"`
 	RegistryContractABI   string        `yaml:"RegistryContractABI" env:"REGISTRY_CONTRACT_ABI" env-description:"registry contract abi json file"`
 	CleanRegistryData     bool          `yaml:"CleanRegistryData" env:"CLEAN_REGISTRY_DATA" env-default:"false" env-description:"cleans registry contract data (validator shares) and forces re-sync"`
-	AbiVersion            Version       `yaml:"AbiVersion" env:"ABI_VERSION" env-default:"0" env-description:"smart contract abi version (format)"`
+	AbiVersion            string        `yaml:"AbiVersion" env:"ABI_VERSION" env-description:"smart contract abi version (format): 0 (legacy) or 1 (v2), defaults to the abi version of the network profile"`
+}
+
+// ContractVersion returns the configured abi version, legacy if it was not configured
+func (o Options) ContractVersion() (Version, error) {
+	if len(o.AbiVersion) == 0 {
+		return Legacy, nil
+	}
+	return ParseVersion(o.AbiVersion)
 }
 
 // Event represents an eth1 event log in the system
 type Event struct {
-	// Log is the raw event log
+	// Log is the raw event log, Log.Removed is set if the event was orphaned by a reorg and should be rolled back
 	Log types.Log
 	// Data is the parsed event
 	Data interface{}
@@ -35,6 +47,8 @@ type SyncEndedEvent struct {
 	Success bool
 	// Logs is the actual logs that we got from eth1
 	Logs []types.Log
+	// ToBlock is the last block that was synced
+	ToBlock uint64
 }
 
 // ShareEncryptionKeyProvider is a function that returns the operator private key
@@ -45,4 +59,8 @@ type Client interface {
 	EventsFeed() *event.Feed
 	Start() error
 	Sync(fromBlock *big.Int) error
+	// BlockHash returns the hash of the canonical block with the given number
+	BlockHash(number uint64) (common.Hash, error)
+	// Rollback notifies observers that the given logs were orphaned, the events are fired in reverse order with Log.Removed set
+	Rollback(logs []types.Log) error
 }
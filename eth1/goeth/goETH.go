@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bloxapp/ssv/eth1"
@@ -14,8 +15,10 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
@@ -24,18 +27,29 @@ import (
 const (
 	healthCheckTimeout        = 10 * time.Second
 	blocksInBatch      uint64 = 100000
+	// defaultPollingInterval is the interval of polling http nodes for new blocks (~ block time)
+	defaultPollingInterval = 12 * time.Second
+	// reorgTrackingDepth is the number of blocks (below head) that applied blocks are tracked in order to detect reorgs
+	reorgTrackingDepth uint64 = 64
 )
 
 // ClientOptions are the options for the client
 type ClientOptions struct {
-	Ctx                        context.Context
-	Logger                     *zap.Logger
+	Ctx    context.Context
+	Logger *zap.Logger
+	// NodeAddr is a comma separated list of eth1 nodes (WebSocket or HTTP), the first one is the primary
 	NodeAddr                   string
 	RegistryContractAddr       string
 	ContractABI                string
 	ConnectionTimeout          time.Duration
 	ShareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
 	OperatorPubKey             string
+	FollowDistance             uint64
+	// PollingInterval is the interval of polling HTTP nodes for new blocks
+	PollingInterval time.Duration
+	// SyncedBlocksStorage is used to persist the blocks that streamed events were applied from (optional),
+	// so orphaned blocks could be rolled back after a restart
+	SyncedBlocksStorage eth1.SyncedBlocksStorage
 
 	AbiVersion eth1.Version
 }
@@ -43,19 +57,38 @@ type ClientOptions struct {
 // eth1Client is the internal implementation of Client
 type eth1Client struct {
 	ctx    context.Context
-	conn   *ethclient.Client
 	logger *zap.Logger
 
+	// conn and rpcClient are the clients of the current node (nodeAddrs[nodeIndex])
+	conn      *ethclient.Client
+	rpcClient *rpc.Client
+	nodeIndex int
+	connLock  sync.RWMutex
+	dialLock  sync.Mutex
+
 	shareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
 	operatorPubKey             string
 
-	nodeAddr             string
+	nodeAddrs            []string
 	registryContractAddr string
 	contractABI          string
 	connectionTimeout    time.Duration
+	followDistance       uint64
+	pollingInterval      time.Duration
+	// batchSize is the current amount of blocks that are fetched in a single logs request,
+	// it adapts to the limits of the node (accessed atomically)
+	batchSize uint64
 
 	eventsFeed *event.Feed
 
+	// lastBlock is the last (confirmed) block that was processed
+	lastBlock uint64
+	// appliedBlocks are the recent blocks that events were fired from, used to detect reorgs
+	appliedBlocks map[uint64]*eth1.SyncedBlock
+	blocksLock    sync.Mutex
+	// syncedBlocksStorage persists the blocks that streamed events were applied from
+	syncedBlocksStorage eth1.SyncedBlocksStorage
+
 	abiVersion eth1.Version
 }
 
@@ -68,20 +101,34 @@ func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 		zap.String("address", opts.RegistryContractAddr))
 	logger.Info("eth1 addresses", zap.String("address", opts.NodeAddr))
 
+	addrs := nodeAddrs(opts.NodeAddr)
+	if len(addrs) == 0 {
+		return nil, errors.New("missing eth1 node address")
+	}
+	pollingInterval := opts.PollingInterval
+	if pollingInterval == 0 {
+		pollingInterval = defaultPollingInterval
+	}
+
 	ec := eth1Client{
 		ctx:                        opts.Ctx,
 		logger:                     logger,
 		shareEncryptionKeyProvider: opts.ShareEncryptionKeyProvider,
 		operatorPubKey:             opts.OperatorPubKey,
-		nodeAddr:                   opts.NodeAddr,
+		nodeAddrs:                  addrs,
 		registryContractAddr:       opts.RegistryContractAddr,
 		contractABI:                opts.ContractABI,
 		connectionTimeout:          opts.ConnectionTimeout,
+		followDistance:             opts.FollowDistance,
+		pollingInterval:            pollingInterval,
+		batchSize:                  blocksInBatch,
 		eventsFeed:                 new(event.Feed),
+		appliedBlocks:              make(map[uint64]*eth1.SyncedBlock),
+		syncedBlocksStorage:        opts.SyncedBlocksStorage,
 		abiVersion:                 opts.AbiVersion,
 	}
 
-	if err := ec.connect(); err != nil {
+	if err := ec.connect(0); err != nil {
 		logger.Error("failed to connect to the Ethereum client", zap.Error(err))
 		return nil, err
 	}
@@ -112,14 +159,98 @@ func (ec *eth1Client) Sync(fromBlock *big.Int) error {
 	return err
 }
 
+// BlockHash returns the hash of the canonical block with the given number
+func (ec *eth1Client) BlockHash(number uint64) (common.Hash, error) {
+	// the hash is taken from the node rather than computed out of the header,
+	// as the header might contain fields that are not supported by this client
+	var block struct {
+		Hash common.Hash `json:"hash"`
+	}
+	err := ec.withFailover(func(conn *ethclient.Client, rpcClient *rpc.Client) error {
+		return rpcClient.CallContext(ec.ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false)
+	})
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to get block")
+	}
+	return block.Hash, nil
+}
+
+// Rollback fires the given orphaned logs as removed events, in reverse order.
+// data that was removed (or replaced) by the orphaned events is restored by firing the latest events of the affected
+// validators and operators, out of the contract events that precede the orphaned blocks
+func (ec *eth1Client) Rollback(logs []types.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse ABI interface")
+	}
+	var orphaned []eth1.Event
+	for i := len(logs) - 1; i >= 0; i-- {
+		vLog := logs[i]
+		vLog.Removed = true
+		e, err := ec.parseEvent(vLog, contractAbi)
+		if err != nil {
+			ec.logger.Error("failed to rollback event", zap.Error(err),
+				zap.String("txHash", vLog.TxHash.Hex()), zap.Uint64("blockNumber", vLog.BlockNumber))
+			continue
+		}
+		if e != nil {
+			orphaned = append(orphaned, *e)
+			ec.fireEvent(vLog, e.Data, e.IsOperatorEvent)
+		}
+	}
+	if !eth1.RestoreRequired(orphaned) {
+		return nil
+	}
+	return ec.restore(orphaned, logs[0].BlockNumber, contractAbi)
+}
+
+// restore fires the events that restore the data that was removed by the given orphaned events,
+// the contract events are fetched from the beginning of the chain up to the given fork block (the lowest orphaned block)
+func (ec *eth1Client) restore(orphaned []eth1.Event, forkBlock uint64, contractAbi abi.ABI) error {
+	var history []eth1.Event
+	if forkBlock > 0 {
+		ec.logger.Debug("fetching contract events in order to restore data of orphaned events",
+			zap.Uint64("toBlock", forkBlock-1))
+		logs, _, err := ec.fetchRange(0, forkBlock-1, func(fromBlock, toBlock *big.Int) ([]types.Log, int, error) {
+			logs, err := ec.fetchEvents(fromBlock, toBlock)
+			return logs, len(logs), err
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch events for restore")
+		}
+		for _, vLog := range logs {
+			e, err := ec.parseEvent(vLog, contractAbi)
+			if err != nil {
+				ec.logger.Warn("failed to parse event for restore", zap.Error(err), zap.String("txHash", vLog.TxHash.Hex()))
+				continue
+			}
+			if e != nil {
+				history = append(history, *e)
+			}
+		}
+	}
+	for _, e := range eth1.RestoringEvents(orphaned, history) {
+		ec.logger.Debug("restoring data of orphaned event", zap.String("txHash", e.Log.TxHash.Hex()),
+			zap.Uint64("blockNumber", e.Log.BlockNumber))
+		e := e
+		// the events belong to blocks that were already applied, therefore they are not tracked
+		_ = ec.eventsFeed.Send(&e)
+	}
+	return nil
+}
+
 // HealthCheck provides health status of eth1 node
 func (ec *eth1Client) HealthCheck() []string {
-	if ec.conn == nil {
+	conn, _ := ec.client()
+	if conn == nil {
 		return []string{"not connected to eth1 node"}
 	}
 	ctx, cancel := context.WithTimeout(ec.ctx, healthCheckTimeout)
 	defer cancel()
-	sp, err := ec.conn.SyncProgress(ctx)
+	sp, err := conn.SyncProgress(ctx)
 	if err != nil {
 		reportNodeStatus(statusUnknown)
 		return []string{"could not get eth1 node sync progress"}
@@ -134,48 +265,140 @@ func (ec *eth1Client) HealthCheck() []string {
 	return []string{}
 }
 
-// connect connects to eth1 client
-func (ec *eth1Client) connect() error {
-	// Create an IPC based RPC connection to a remote node
-	ec.logger.Info("dialing eth1 node...")
+// client returns the clients of the current eth1 node
+func (ec *eth1Client) client() (*ethclient.Client, *rpc.Client) {
+	ec.connLock.RLock()
+	defer ec.connLock.RUnlock()
+
+	return ec.conn, ec.rpcClient
+}
+
+// connect connects to the first available eth1 node, starting from the node in the given index
+func (ec *eth1Client) connect(start int) error {
+	var err error
+	for i := 0; i < len(ec.nodeAddrs); i++ {
+		if err = ec.dial((start + i) % len(ec.nodeAddrs)); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// dial connects to the eth1 node in the given index and replaces the current clients
+func (ec *eth1Client) dial(index int) error {
+	logger := ec.logger.With(zap.String("nodeAddr", ec.nodeAddrs[index]))
+	logger.Info("dialing eth1 node...")
 	ctx, cancel := context.WithTimeout(context.Background(), ec.connectionTimeout)
 	defer cancel()
-	conn, err := ethclient.DialContext(ctx, ec.nodeAddr)
+	rpcClient, err := rpc.DialContext(ctx, ec.nodeAddrs[index])
 	if err != nil {
-		ec.logger.Error("could not connect to the eth1 client", zap.Error(err))
+		logger.Error("could not connect to the eth1 client", zap.Error(err))
 		return err
 	}
-	ec.logger.Info("successfully connected to eth1 goETH")
-	ec.conn = conn
+	logger.Info("successfully connected to eth1 goETH")
+
+	ec.connLock.Lock()
+	prev := ec.rpcClient
+	ec.rpcClient = rpcClient
+	ec.conn = ethclient.NewClient(rpcClient)
+	ec.nodeIndex = index
+	ec.connLock.Unlock()
+	// closing the previous client stops its subscriptions and pending calls, which will fail over to the new client
+	if prev != nil {
+		prev.Close()
+	}
 	return nil
 }
 
-// reconnect tries to reconnect multiple times with an exponent interval
-func (ec *eth1Client) reconnect() {
+// failover connects to the next available eth1 node,
+// nothing is done if the failed client was already replaced (e.g. by a concurrent failover)
+func (ec *eth1Client) failover(failed *rpc.Client) error {
+	ec.dialLock.Lock()
+	defer ec.dialLock.Unlock()
+
+	ec.connLock.RLock()
+	current, index := ec.rpcClient, ec.nodeIndex
+	ec.connLock.RUnlock()
+	if current != failed {
+		return nil
+	}
+	return ec.connect(index + 1)
+}
+
+// withFailover calls the given function with the clients of the current node, and fails over to the next nodes on errors.
+// range limit errors are returned as is, as they are handled by reducing the range of the request
+func (ec *eth1Client) withFailover(call func(conn *ethclient.Client, rpcClient *rpc.Client) error) error {
+	var err error
+	for i := 0; i < len(ec.nodeAddrs); i++ {
+		conn, rpcClient := ec.client()
+		if conn == nil {
+			return errors.New("not connected to eth1 node")
+		}
+		if err = call(conn, rpcClient); err == nil || isRangeLimitError(err) || ec.ctx.Err() != nil {
+			return err
+		}
+		if len(ec.nodeAddrs) == 1 {
+			break
+		}
+		ec.logger.Warn("eth1 node request failed, failing over to the next node", zap.Error(err))
+		if connErr := ec.failover(rpcClient); connErr != nil {
+			ec.logger.Warn("could not fail over to another eth1 node", zap.Error(connErr))
+			break
+		}
+	}
+	return err
+}
+
+// reconnect tries to reconnect (to the next available node) multiple times with an exponent interval,
+// once connected the events stream is resumed
+func (ec *eth1Client) reconnect(failed *rpc.Client) {
 	limit := 64 * time.Second
 	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
 		ec.logger.Info("reconnecting to eth1 node")
-		if err := ec.connect(); err != nil {
-			// continue until reaching to limit, and then panic as eth1 connection is required
-			if lastTick >= limit {
-				ec.logger.Panic("failed to reconnect to eth1 node", zap.Error(err))
-			} else {
-				ec.logger.Warn("could not reconnect to eth1 node, still trying", zap.Error(err))
+		err := ec.failover(failed)
+		if err == nil {
+			if err = ec.streamSmartContractEvents(); err == nil {
+				return true, false
 			}
-			return false, false
+			// the current node could not stream, the next attempt will move on to another node
+			_, failed = ec.client()
+		}
+		// continue until reaching to limit, and then panic as eth1 connection is required
+		if lastTick >= limit {
+			ec.logger.Panic("failed to reconnect to eth1 node", zap.Error(err))
+		} else {
+			ec.logger.Warn("could not reconnect to eth1 node, still trying", zap.Error(err))
 		}
-		return true, false
+		return false, false
 	}, 1*time.Second, limit+(1*time.Second))
 	ec.logger.Debug("managed to reconnect to eth1 node")
+}
+
+// restream resumes the events stream once the stream over the given client has stopped,
+// the node is reconnected unless it was already replaced by a failover
+func (ec *eth1Client) restream(rpcClient *rpc.Client, err error) {
+	if ec.ctx.Err() != nil {
+		return
+	}
+	if _, current := ec.client(); current == rpcClient {
+		ec.logger.Warn("eth1 events stream stopped", zap.Error(err))
+		ec.reconnect(rpcClient)
+		return
+	}
+	ec.logger.Debug("eth1 node was replaced, restarting events stream")
 	if err := ec.streamSmartContractEvents(); err != nil {
-		// TODO: panic?
-		ec.logger.Error("failed to stream events after reconnection", zap.Error(err))
+		ec.logger.Warn("failed to stream events from the new eth1 node", zap.Error(err))
+		_, current := ec.client()
+		ec.reconnect(current)
 	}
 }
 
 // fireEvent notifies observers about some contract event
 func (ec *eth1Client) fireEvent(log types.Log, data interface{}, isOperatorEvent bool) {
 	e := eth1.Event{Log: log, Data: data, IsOperatorEvent: isOperatorEvent}
+	if !log.Removed && log.BlockHash != (common.Hash{}) {
+		ec.trackAppliedLog(log)
+	}
 	_ = ec.eventsFeed.Send(&e)
 	// TODO: add trace
 	//ec.logger.Debug("events was sent to subscribers", zap.Int("num of subscribers", n))
@@ -190,53 +413,190 @@ func (ec *eth1Client) streamSmartContractEvents() error {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
 
-	sub, logs, err := ec.subscribeToLogs()
+	conn, rpcClient := ec.client()
+	if conn == nil {
+		return errors.New("not connected to eth1 node")
+	}
+	// http nodes don't support subscriptions, therefore new blocks are polled
+	if isHTTPAddr(ec.currentNodeAddr()) {
+		go func() {
+			err := ec.pollHeads(rpcClient, contractAbi)
+			ec.restream(rpcClient, err)
+		}()
+		return nil
+	}
+
+	sub, heads, err := ec.subscribeToHeads(conn)
 	if err != nil {
-		return errors.Wrap(err, "Failed to subscribe to logs")
+		return errors.Wrap(err, "Failed to subscribe to heads")
 	}
 
 	go func() {
-		if err := ec.listenToSubscription(logs, sub, contractAbi); err != nil {
-			ec.reconnect()
-		}
+		err := ec.listenToSubscription(heads, sub, contractAbi)
+		ec.restream(rpcClient, err)
 	}()
 
 	return nil
 }
 
-func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log, error) {
-	contractAddress := common.HexToAddress(ec.registryContractAddr)
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{contractAddress},
-	}
-	logs := make(chan types.Log)
-	sub, err := ec.conn.SubscribeFilterLogs(ec.ctx, query, logs)
+// currentNodeAddr returns the address of the current node
+func (ec *eth1Client) currentNodeAddr() string {
+	ec.connLock.RLock()
+	defer ec.connLock.RUnlock()
+
+	return ec.nodeAddrs[ec.nodeIndex]
+}
+
+// subscribeToHeads subscribes to new blocks, contract events are fetched once blocks are confirmed (followDistance)
+func (ec *eth1Client) subscribeToHeads(conn *ethclient.Client) (ethereum.Subscription, chan *types.Header, error) {
+	heads := make(chan *types.Header)
+	sub, err := conn.SubscribeNewHead(ec.ctx, heads)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Failed to subscribe to logs")
+		return nil, nil, errors.Wrap(err, "Failed to subscribe to heads")
 	}
-	ec.logger.Debug("subscribed to results of the streaming filter query")
+	ec.logger.Debug("subscribed to new heads")
 
-	return sub, logs, nil
+	return sub, heads, nil
 }
 
-// listenToSubscription listen to new event logs from the contract
-func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Subscription, contractAbi abi.ABI) error {
+// listenToSubscription listen to new heads and process event logs from the contract of confirmed blocks
+func (ec *eth1Client) listenToSubscription(heads chan *types.Header, sub ethereum.Subscription, contractAbi abi.ABI) error {
 	for {
 		select {
 		case err := <-sub.Err():
-			ec.logger.Warn("failed to read logs from subscription", zap.Error(err))
+			if err == nil {
+				// the error channel is closed once the client is closed
+				err = errors.New("subscription was closed")
+			}
+			ec.logger.Warn("failed to read heads from subscription", zap.Error(err))
 			return err
-		case vLog := <-logs:
-			ec.logger.Debug("received contract event from stream")
-			_, err := ec.handleEvent(vLog, contractAbi)
-			if err != nil {
-				ec.logger.Error("Failed to handle event", zap.Error(err))
+		case head := <-heads:
+			if err := ec.processConfirmedBlocks(head.Number.Uint64(), contractAbi); err != nil {
+				ec.logger.Error("Failed to process confirmed blocks", zap.Error(err))
 				continue
 			}
 		}
 	}
 }
 
+// processConfirmedBlocks rolls back orphaned blocks and handles the events of blocks that were confirmed since the last processed block
+func (ec *eth1Client) processConfirmedBlocks(head uint64, contractAbi abi.ABI) error {
+	if err := ec.rollbackOrphanedBlocks(); err != nil {
+		return errors.Wrap(err, "failed to rollback orphaned blocks")
+	}
+	confirmed := confirmedBlock(head, ec.followDistance)
+	ec.blocksLock.Lock()
+	fromBlock := ec.lastBlock + 1
+	if ec.lastBlock == 0 {
+		// nothing was processed yet (no sync), starting from the current confirmed block
+		fromBlock = confirmed
+	}
+	ec.blocksLock.Unlock()
+	if confirmed < fromBlock {
+		return nil
+	}
+	ec.logger.Debug("received confirmed blocks from stream",
+		zap.Uint64("fromBlock", fromBlock), zap.Uint64("toBlock", confirmed))
+	logs, _, err := ec.fetchAndProcessRange(fromBlock, confirmed, contractAbi)
+	if ec.syncedBlocksStorage != nil && len(logs) > 0 {
+		if err := eth1.SaveSyncedBlocks(ec.syncedBlocksStorage, logs); err != nil {
+			ec.logger.Warn("failed to save synced blocks", zap.Error(err))
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get events")
+	}
+	ec.setLastBlock(confirmed, head)
+	return nil
+}
+
+// rollbackOrphanedBlocks checks the tracked blocks against the canonical chain,
+// the events of orphaned blocks are rolled back and the blocks will be processed again
+func (ec *eth1Client) rollbackOrphanedBlocks() error {
+	ec.blocksLock.Lock()
+	blocks := make([]*eth1.SyncedBlock, 0, len(ec.appliedBlocks))
+	for _, block := range ec.appliedBlocks {
+		blocks = append(blocks, block)
+	}
+	ec.blocksLock.Unlock()
+	eth1.SortSyncedBlocks(blocks)
+
+	var orphaned []*eth1.SyncedBlock
+	for i := len(blocks) - 1; i >= 0; i-- {
+		hash, err := ec.BlockHash(blocks[i].Number)
+		if err != nil {
+			return err
+		}
+		if hash == blocks[i].Hash {
+			break
+		}
+		ec.logger.Warn("detected eth1 reorg, rolling back orphaned block",
+			zap.Uint64("blockNumber", blocks[i].Number), zap.String("orphanedHash", blocks[i].Hash.Hex()),
+			zap.String("canonicalHash", hash.Hex()))
+		orphaned = append(orphaned, blocks[i])
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+	var logs []types.Log
+	for i := len(orphaned) - 1; i >= 0; i-- {
+		logs = append(logs, orphaned[i].Logs...)
+	}
+	if err := ec.Rollback(logs); err != nil {
+		return err
+	}
+	ec.blocksLock.Lock()
+	defer ec.blocksLock.Unlock()
+	for _, block := range orphaned {
+		delete(ec.appliedBlocks, block.Number)
+		if ec.syncedBlocksStorage != nil {
+			if err := ec.syncedBlocksStorage.DeleteSyncedBlock(block.Number); err != nil {
+				ec.logger.Warn("failed to delete orphaned block", zap.Error(err), zap.Uint64("blockNumber", block.Number))
+			}
+		}
+	}
+	// the orphaned blocks will be processed again from the canonical chain
+	if lowest := orphaned[len(orphaned)-1].Number; lowest > 0 && ec.lastBlock >= lowest {
+		ec.lastBlock = lowest - 1
+	}
+	return nil
+}
+
+// trackAppliedLog tracks the block of the given log, so it could be rolled back in case of a reorg
+func (ec *eth1Client) trackAppliedLog(vLog types.Log) {
+	ec.blocksLock.Lock()
+	defer ec.blocksLock.Unlock()
+
+	block, ok := ec.appliedBlocks[vLog.BlockNumber]
+	if !ok || block.Hash != vLog.BlockHash {
+		block = &eth1.SyncedBlock{Number: vLog.BlockNumber, Hash: vLog.BlockHash}
+		ec.appliedBlocks[vLog.BlockNumber] = block
+	}
+	block.Logs = append(block.Logs, vLog)
+}
+
+// setLastBlock updates the last processed block and stops tracking blocks that are too old to be reorganized
+func (ec *eth1Client) setLastBlock(lastBlock, head uint64) {
+	ec.blocksLock.Lock()
+	defer ec.blocksLock.Unlock()
+
+	ec.lastBlock = lastBlock
+	oldest := confirmedBlock(head, reorgTrackingDepth)
+	for number := range ec.appliedBlocks {
+		if number < oldest {
+			delete(ec.appliedBlocks, number)
+		}
+	}
+}
+
+// confirmedBlock returns the latest block that has the given amount of confirmations
+func confirmedBlock(head, followDistance uint64) uint64 {
+	if head < followDistance {
+		return 0
+	}
+	return head - followDistance
+}
+
 // syncSmartContractsEvents sync events history of the given contract
 func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 	ec.logger.Debug("syncing smart contract events", zap.Uint64("fromBlock", fromBlock.Uint64()))
@@ -245,79 +605,36 @@ func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+	var currentBlock uint64
+	err = ec.withFailover(func(conn *ethclient.Client, rpcClient *rpc.Client) error {
+		currentBlock, err = conn.BlockNumber(ec.ctx)
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to get current block")
 	}
-	var logs []types.Log
-	var nSuccess int
-	for {
-		var toBlock *big.Int
-		if currentBlock-fromBlock.Uint64() > blocksInBatch {
-			toBlock = big.NewInt(int64(fromBlock.Uint64() + blocksInBatch))
-		} else { // no more batches are required -> setting toBlock to nil
-			toBlock = nil
-		}
-		_logs, _nSuccess, err := ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
-		if err != nil {
-			// in case request exceeded limit, try again with less blocks
-			// will stop after log(blocksInBatch) tries
-			if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-				return errors.Wrap(err, "failed to get events")
-			}
-			currentBatchSize := int64(blocksInBatch)
-		retryLoop:
-			for currentBatchSize > 1 {
-				currentBatchSize /= 2
-				ec.logger.Debug("using a lower batch size", zap.Int64("currentBatchSize", currentBatchSize))
-				toBlock = big.NewInt(int64(fromBlock.Uint64()) + currentBatchSize)
-				_logs, _nSuccess, err = ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
-				if err != nil {
-					if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-						return errors.Wrap(err, "failed to get events")
-					}
-					// limit exceeded
-					continue retryLoop
-				}
-				// done
-				break retryLoop
-			}
-		}
-		nSuccess += _nSuccess
-		logs = append(logs, _logs...)
-		if toBlock == nil { // finished
-			break
-		}
-		fromBlock = toBlock
+	// events are synced only from blocks that were confirmed
+	confirmed := confirmedBlock(currentBlock, ec.followDistance)
+	logs, nSuccess, err := ec.fetchAndProcessRange(fromBlock.Uint64(), confirmed, contractAbi)
+	if err != nil {
+		return errors.Wrap(err, "failed to get events")
 	}
+	ec.setLastBlock(confirmed, currentBlock)
 	ec.logger.Debug("finished syncing registry contract",
-		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess),
+		zap.Uint64("confirmedBlock", confirmed))
 	// publishing SyncEndedEvent so other components could track the sync
-	ec.fireEvent(types.Log{}, eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs)}, false)
+	ec.fireEvent(types.Log{}, eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs), ToBlock: confirmed}, false)
 
 	return nil
 }
 
 func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contractAbi abi.ABI) ([]types.Log, int, error) {
-	logger := ec.logger.With(zap.Int64("fromBlock", fromBlock.Int64()))
-	contractAddress := common.HexToAddress(ec.registryContractAddr)
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{contractAddress},
-		FromBlock: fromBlock,
-	}
-	if toBlock != nil {
-		query.ToBlock = toBlock
-		logger = logger.With(zap.Int64("toBlock", toBlock.Int64()))
-	}
-	logger.Debug("fetching event logs")
-	logs, err := ec.conn.FilterLogs(ec.ctx, query)
+	logs, err := ec.fetchEvents(fromBlock, toBlock)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to get event logs")
+		return nil, 0, err
 	}
 	nSuccess := len(logs)
-	logger = logger.With(zap.Int("results", len(logs)))
-	logger.Debug("got event logs")
-
 	for _, vLog := range logs {
 		unpackErr, err := ec.handleEvent(vLog, contractAbi)
 		if err != nil {
@@ -328,18 +645,38 @@ func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contrac
 			continue
 		}
 	}
-	logger.Debug("event logs were received and parsed successfully",
-		zap.Int("successCount", nSuccess))
+	ec.logger.Debug("event logs were received and parsed successfully",
+		zap.Int64("fromBlock", fromBlock.Int64()), zap.Int("successCount", nSuccess))
 
 	return logs, nSuccess, nil
 }
 
-func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (bool, error) {
-	eventType, err := contractAbi.EventByID(vLog.Topics[0])
-	if err != nil { // unknown event -> ignored
-		ec.logger.Warn("failed to handle event, unknown event type", zap.Error(err), zap.String("txHash", vLog.TxHash.Hex()))
-		return false, nil
+// fetchEvents fetches the logs of the contract in the given range
+func (ec *eth1Client) fetchEvents(fromBlock, toBlock *big.Int) ([]types.Log, error) {
+	logger := ec.logger.With(zap.Int64("fromBlock", fromBlock.Int64()))
+	contractAddress := common.HexToAddress(ec.registryContractAddr)
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{contractAddress},
+		FromBlock: fromBlock,
+	}
+	if toBlock != nil {
+		query.ToBlock = toBlock
+		logger = logger.With(zap.Int64("toBlock", toBlock.Int64()))
+	}
+	logger.Debug("fetching event logs")
+	var logs []types.Log
+	err := ec.withFailover(func(conn *ethclient.Client, rpcClient *rpc.Client) (err error) {
+		logs, err = conn.FilterLogs(ec.ctx, query)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get event logs")
 	}
+	logger.Debug("got event logs", zap.Int("results", len(logs)))
+	return logs, nil
+}
+
+func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (bool, error) {
 	shareEncryptionKey, found, err := ec.shareEncryptionKeyProvider()
 	if !found {
 		return false, errors.New("failed to find operator private key")
@@ -349,24 +686,33 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (bool, er
 	}
 
 	abiParser := eth1.NewParser(ec.logger, ec.abiVersion)
-
-	switch eventName := eventType.Name; eventName {
-	case "OperatorAdded":
-		parsed, isOperatorEvent, unpackErr, err := abiParser.ParseOperatorAddedEvent(ec.operatorPubKey, vLog.Data, vLog.Topics, contractAbi)
-		reportSyncEvent(eventName, isOperatorEvent, err)
-		if err != nil {
-			return unpackErr, errors.Wrap(err, "failed to parse OperatorAdded event")
-		}
-		ec.fireEvent(vLog, *parsed, isOperatorEvent)
-	case "ValidatorAdded":
-		parsed, isOperatorEvent, unpackErr, err := abiParser.ParseValidatorAddedEvent(shareEncryptionKey, vLog.Data, contractAbi)
-		reportSyncEvent(eventName, isOperatorEvent, err)
-		if err != nil {
-			return unpackErr, errors.Wrap(err, "failed to parse ValidatorAdded event")
+	eventName, e, unpackErr, err := abiParser.ParseEvent(vLog, contractAbi, ec.operatorPubKey, shareEncryptionKey)
+	if e == nil {
+		if len(eventName) > 0 {
+			ec.logger.Debug("unknown contract event was received", zap.String("hash", vLog.TxHash.Hex()), zap.String("eventName", eventName))
 		}
-		ec.fireEvent(vLog, *parsed, isOperatorEvent)
-	default:
-		ec.logger.Debug("unknown contract event was received", zap.String("hash", vLog.TxHash.Hex()), zap.String("eventName", eventName))
+		return false, nil
+	}
+	reportSyncEvent(eventName, e.IsOperatorEvent, err)
+	if err != nil {
+		return unpackErr, err
 	}
+	ec.fireEvent(vLog, e.Data, e.IsOperatorEvent)
 	return false, nil
 }
+
+// parseEvent parses the given log without firing it, nil is returned for unknown events
+func (ec *eth1Client) parseEvent(vLog types.Log, contractAbi abi.ABI) (*eth1.Event, error) {
+	shareEncryptionKey, found, err := ec.shareEncryptionKeyProvider()
+	if !found {
+		return nil, errors.New("failed to find operator private key")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operator private key")
+	}
+	_, e, _, err := eth1.NewParser(ec.logger, ec.abiVersion).ParseEvent(vLog, contractAbi, ec.operatorPubKey, shareEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
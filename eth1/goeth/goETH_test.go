@@ -7,6 +7,7 @@ import (
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/stretchr/testify/require"
@@ -88,6 +89,39 @@ func TestEth1Client_handleEvent(t *testing.T) {
 	}
 }
 
+func TestEth1Client_trackAppliedLog(t *testing.T) {
+	ec := newEth1Client(eth1.V2)
+
+	ec.fireEvent(types.Log{BlockNumber: 100, BlockHash: common.HexToHash("0x1"), TxIndex: 0}, struct{}{}, false)
+	ec.fireEvent(types.Log{BlockNumber: 100, BlockHash: common.HexToHash("0x1"), TxIndex: 1}, struct{}{}, false)
+	ec.fireEvent(types.Log{BlockNumber: 150, BlockHash: common.HexToHash("0x2")}, struct{}{}, false)
+	// removed logs and logs without a block (sync ended) are not tracked
+	ec.fireEvent(types.Log{BlockNumber: 160, BlockHash: common.HexToHash("0x3"), Removed: true}, struct{}{}, false)
+	ec.fireEvent(types.Log{}, eth1.SyncEndedEvent{}, false)
+
+	require.Len(t, ec.appliedBlocks, 2)
+	require.Len(t, ec.appliedBlocks[100].Logs, 2)
+	require.Equal(t, common.HexToHash("0x2"), ec.appliedBlocks[150].Hash)
+
+	// a block with a different hash replaces the tracked one
+	ec.fireEvent(types.Log{BlockNumber: 150, BlockHash: common.HexToHash("0x4")}, struct{}{}, false)
+	require.Len(t, ec.appliedBlocks[150].Logs, 1)
+	require.Equal(t, common.HexToHash("0x4"), ec.appliedBlocks[150].Hash)
+
+	// blocks that are too old are not tracked anymore
+	ec.setLastBlock(150-ec.followDistance, 150+reorgTrackingDepth)
+	require.Equal(t, uint64(150), ec.lastBlock)
+	require.Len(t, ec.appliedBlocks, 1)
+	_, ok := ec.appliedBlocks[150]
+	require.True(t, ok)
+}
+
+func TestConfirmedBlock(t *testing.T) {
+	require.Equal(t, uint64(92), confirmedBlock(100, 8))
+	require.Equal(t, uint64(100), confirmedBlock(100, 0))
+	require.Equal(t, uint64(0), confirmedBlock(5, 8))
+}
+
 func newEth1Client(abiVersion eth1.Version) *eth1Client {
 	ec := eth1Client{
 		ctx:    context.TODO(),
@@ -96,8 +130,9 @@ func newEth1Client(abiVersion eth1.Version) *eth1Client {
 		shareEncryptionKeyProvider: func() (*rsa.PrivateKey, bool, error) {
 			return nil, true, nil
 		},
-		eventsFeed: new(event.Feed),
-		abiVersion: abiVersion,
+		eventsFeed:    new(event.Feed),
+		abiVersion:    abiVersion,
+		appliedBlocks: make(map[uint64]*eth1.SyncedBlock),
 	}
 	return &ec
 }
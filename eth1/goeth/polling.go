@@ -0,0 +1,136 @@
+package goeth
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// rangeLimitErrors are (parts of) errors that nodes return when a logs request exceeds their limits
+var rangeLimitErrors = []string{
+	"read limit exceeded",
+	"query returned more than",
+	"exceed maximum block range",
+	"block range is too wide",
+	"block range too large",
+	"response size exceeded",
+	"response size should not greater than",
+}
+
+// isRangeLimitError returns true if the given error indicates that the range of the request is too big
+func isRangeLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, limitErr := range rangeLimitErrors {
+		if strings.Contains(msg, limitErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHTTPAddr returns true if the given node address is an http endpoint
+func isHTTPAddr(addr string) bool {
+	addr = strings.ToLower(addr)
+	return strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://")
+}
+
+// nodeAddrs parses a comma separated list of node addresses
+func nodeAddrs(addr string) []string {
+	var addrs []string
+	for _, a := range strings.Split(addr, ",") {
+		if a = strings.TrimSpace(a); len(a) > 0 {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
+
+// pollHeads polls the given node for new blocks and processes the events of confirmed blocks,
+// it returns once the node fails or was replaced
+func (ec *eth1Client) pollHeads(rpcClient *rpc.Client, contractAbi abi.ABI) error {
+	ec.logger.Debug("polling new heads", zap.Duration("interval", ec.pollingInterval))
+	conn := ethclient.NewClient(rpcClient)
+	ticker := time.NewTicker(ec.pollingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ec.ctx.Done():
+			return ec.ctx.Err()
+		case <-ticker.C:
+		}
+		if _, current := ec.client(); current != rpcClient {
+			return errors.New("eth1 node was replaced")
+		}
+		ctx, cancel := context.WithTimeout(ec.ctx, ec.connectionTimeout)
+		head, err := conn.BlockNumber(ctx)
+		cancel()
+		if err != nil {
+			ec.logger.Warn("failed to poll head", zap.Error(err))
+			return errors.Wrap(err, "failed to get current block")
+		}
+		if err := ec.processConfirmedBlocks(head, contractAbi); err != nil {
+			ec.logger.Error("Failed to process confirmed blocks", zap.Error(err))
+			continue
+		}
+	}
+}
+
+// fetchAndProcessRange fetches and handles the events of the given (inclusive) range in batches
+func (ec *eth1Client) fetchAndProcessRange(fromBlock, toBlock uint64, contractAbi abi.ABI) ([]types.Log, int, error) {
+	return ec.fetchRange(fromBlock, toBlock, func(fromBlock, toBlock *big.Int) ([]types.Log, int, error) {
+		return ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
+	})
+}
+
+// fetchRange calls the given fetch function over the given (inclusive) range in batches.
+// the batch size adapts to the limits of the node: it shrinks once the node rejects a range and grows back after successes
+func (ec *eth1Client) fetchRange(fromBlock, toBlock uint64, fetch func(fromBlock, toBlock *big.Int) ([]types.Log, int, error)) ([]types.Log, int, error) {
+	var logs []types.Log
+	var nSuccess int
+	for fromBlock <= toBlock {
+		batchSize := atomic.LoadUint64(&ec.batchSize)
+		if batchSize == 0 {
+			batchSize = blocksInBatch
+		}
+		to := toBlock
+		if toBlock-fromBlock >= batchSize {
+			to = fromBlock + batchSize - 1
+		}
+		_logs, _nSuccess, err := fetch(new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(to))
+		if err != nil {
+			// in case request exceeded limit, try again with less blocks
+			if !isRangeLimitError(err) || batchSize == 1 {
+				return logs, nSuccess, err
+			}
+			atomic.StoreUint64(&ec.batchSize, batchSize/2)
+			ec.logger.Debug("using a lower batch size", zap.Uint64("batchSize", batchSize/2))
+			continue
+		}
+		if to-fromBlock+1 == batchSize && batchSize < blocksInBatch {
+			grown := batchSize * 2
+			if grown > blocksInBatch {
+				grown = blocksInBatch
+			}
+			atomic.StoreUint64(&ec.batchSize, grown)
+		}
+		nSuccess += _nSuccess
+		logs = append(logs, _logs...)
+		if to == toBlock {
+			break
+		}
+		fromBlock = to + 1
+	}
+	return logs, nSuccess, nil
+}
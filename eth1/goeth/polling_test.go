@@ -0,0 +1,229 @@
+package goeth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testEthService is a minimal eth json-rpc api, logs requests are limited to maxRange blocks
+type testEthService struct {
+	head     uint64
+	maxRange uint64
+
+	lock   sync.Mutex
+	ranges [][2]uint64
+	// logs are returned by GetLogs, hashes are the hashes of the canonical blocks
+	logs   []types.Log
+	hashes map[uint64]common.Hash
+}
+
+type testFilterQuery struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+}
+
+func (s *testEthService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(atomic.LoadUint64(&s.head))
+}
+
+func (s *testEthService) GetLogs(query testFilterQuery) ([]types.Log, error) {
+	if s.maxRange > 0 && uint64(query.ToBlock-query.FromBlock)+1 > s.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ranges = append(s.ranges, [2]uint64{uint64(query.FromBlock), uint64(query.ToBlock)})
+	logs := []types.Log{}
+	for _, l := range s.logs {
+		if l.BlockNumber >= uint64(query.FromBlock) && l.BlockNumber <= uint64(query.ToBlock) {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (s *testEthService) GetBlockByNumber(number hexutil.Uint64, full bool) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return map[string]interface{}{"hash": s.hashes[uint64(number)]}
+}
+
+// testSyncedBlocksStorage is an in-memory eth1.SyncedBlocksStorage
+type testSyncedBlocksStorage struct {
+	blocks map[uint64]*eth1.SyncedBlock
+}
+
+func (s *testSyncedBlocksStorage) SaveSyncedBlock(block *eth1.SyncedBlock) error {
+	s.blocks[block.Number] = block
+	return nil
+}
+
+func (s *testSyncedBlocksStorage) GetSyncedBlocks() ([]*eth1.SyncedBlock, error) {
+	var blocks []*eth1.SyncedBlock
+	for _, block := range s.blocks {
+		blocks = append(blocks, block)
+	}
+	eth1.SortSyncedBlocks(blocks)
+	return blocks, nil
+}
+
+func (s *testSyncedBlocksStorage) DeleteSyncedBlock(number uint64) error {
+	delete(s.blocks, number)
+	return nil
+}
+
+func newTestEthNode(t *testing.T, service *testEthService) *httptest.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	return httptest.NewServer(server)
+}
+
+func newTestHTTPClient(t *testing.T, ctx context.Context, nodeAddr string) *eth1Client {
+	c, err := NewEth1Client(ClientOptions{
+		Ctx:                  ctx,
+		Logger:               zap.L(),
+		NodeAddr:             nodeAddr,
+		RegistryContractAddr: "0x9573C41F0Ed8B72f3bD6A9bA6E3e15426A0aa65B",
+		ContractABI:          eth1.ContractABI(eth1.V2),
+		ConnectionTimeout:    time.Second,
+		FollowDistance:       8,
+		PollingInterval:      10 * time.Millisecond,
+		ShareEncryptionKeyProvider: func() (*rsa.PrivateKey, bool, error) {
+			return nil, true, nil
+		},
+		AbiVersion: eth1.V2,
+	})
+	require.NoError(t, err)
+	return c.(*eth1Client)
+}
+
+func contractAbi(t *testing.T) abi.ABI {
+	contractAbi, err := abi.JSON(strings.NewReader(eth1.ContractABI(eth1.V2)))
+	require.NoError(t, err)
+	return contractAbi
+}
+
+func TestIsRangeLimitError(t *testing.T) {
+	require.False(t, isRangeLimitError(nil))
+	require.False(t, isRangeLimitError(errors.New("connection refused")))
+	require.True(t, isRangeLimitError(errors.New("websocket: read limit exceeded")))
+	require.True(t, isRangeLimitError(errors.Wrap(errors.New("query returned more than 10000 results"), "failed to get event logs")))
+	require.True(t, isRangeLimitError(errors.New("exceed maximum block range: 5000")))
+}
+
+func TestNodeAddrs(t *testing.T) {
+	require.Nil(t, nodeAddrs(""))
+	require.Equal(t, []string{"ws://a"}, nodeAddrs("ws://a"))
+	require.Equal(t, []string{"ws://a", "http://b"}, nodeAddrs(" ws://a, http://b ,"))
+	require.True(t, isHTTPAddr("HTTPS://b"))
+	require.False(t, isHTTPAddr("wss://b"))
+}
+
+func TestEth1Client_fetchAndProcessRange(t *testing.T) {
+	service := &testEthService{maxRange: 1000}
+	node := newTestEthNode(t, service)
+	defer node.Close()
+	ec := newTestHTTPClient(t, context.Background(), node.URL)
+
+	logs, nSuccess, err := ec.fetchAndProcessRange(0, 9999, contractAbi(t))
+	require.NoError(t, err)
+	require.Len(t, logs, 0)
+	require.Equal(t, 0, nSuccess)
+
+	// the requested ranges are contiguous and cover the whole range
+	next := uint64(0)
+	for _, r := range service.ranges {
+		require.Equal(t, next, r[0])
+		require.LessOrEqual(t, r[1]-r[0]+1, uint64(1000))
+		next = r[1] + 1
+	}
+	require.Equal(t, uint64(10000), next)
+
+	// the batch size grows back once the node accepts bigger ranges
+	service.maxRange = 0
+	_, _, err = ec.fetchAndProcessRange(10000, 20000, contractAbi(t))
+	require.NoError(t, err)
+	require.Greater(t, atomic.LoadUint64(&ec.batchSize), uint64(1000))
+}
+
+func TestEth1Client_SyncedBlocksStorage(t *testing.T) {
+	var vLog types.Log
+	require.NoError(t, json.Unmarshal([]byte(v2RawValidatorAdded), &vLog))
+	vLog.BlockNumber = 92
+	vLog.BlockHash = common.HexToHash("0x92")
+	service := &testEthService{head: 100, logs: []types.Log{vLog}, hashes: map[uint64]common.Hash{92: vLog.BlockHash}}
+	node := newTestEthNode(t, service)
+	defer node.Close()
+	ec := newTestHTTPClient(t, context.Background(), node.URL)
+	storage := &testSyncedBlocksStorage{blocks: make(map[uint64]*eth1.SyncedBlock)}
+	ec.syncedBlocksStorage = storage
+	ec.setLastBlock(90, 98)
+
+	// the blocks of streamed events are persisted
+	require.NoError(t, ec.processConfirmedBlocks(100, contractAbi(t)))
+	require.Equal(t, uint64(92), ec.lastBlock)
+	require.Len(t, storage.blocks, 1)
+	require.Equal(t, vLog.BlockHash, storage.blocks[92].Hash)
+
+	// orphaned blocks are removed from storage
+	service.lock.Lock()
+	service.logs = nil
+	service.hashes[92] = common.HexToHash("0x93")
+	service.lock.Unlock()
+	require.NoError(t, ec.rollbackOrphanedBlocks())
+	require.Equal(t, uint64(91), ec.lastBlock)
+	require.Len(t, storage.blocks, 0)
+}
+
+func TestEth1Client_Failover(t *testing.T) {
+	down := newTestEthNode(t, &testEthService{head: 100})
+	down.Close()
+	service := &testEthService{head: 100}
+	node := newTestEthNode(t, service)
+	defer node.Close()
+
+	ec := newTestHTTPClient(t, context.Background(), down.URL+","+node.URL)
+	require.Equal(t, 0, ec.nodeIndex)
+
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, 1, ec.nodeIndex)
+	require.Equal(t, uint64(92), ec.lastBlock)
+	require.Len(t, service.ranges, 1)
+}
+
+func TestEth1Client_Polling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := &testEthService{head: 100}
+	node := newTestEthNode(t, service)
+	defer node.Close()
+
+	ec := newTestHTTPClient(t, ctx, node.URL)
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.NoError(t, ec.Start())
+
+	atomic.StoreUint64(&service.head, 120)
+	require.Eventually(t, func() bool {
+		ec.blocksLock.Lock()
+		defer ec.blocksLock.Unlock()
+		return ec.lastBlock == 112
+	}, 2*time.Second, 10*time.Millisecond)
+}
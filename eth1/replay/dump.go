@@ -0,0 +1,77 @@
+package replay
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// LogsDump is the content of an exported contract logs file
+type LogsDump struct {
+	// Contract is the address of the registry contract
+	Contract string `json:"contract"`
+	// FromBlock is the first block of the export
+	FromBlock uint64 `json:"fromBlock"`
+	// ToBlock is the last block of the export, blocks up to this one are considered as known
+	ToBlock uint64 `json:"toBlock"`
+	// Logs are the raw contract logs, sorted by block
+	Logs []types.Log `json:"logs"`
+}
+
+// ReadLogsDump reads a logs dump from the given file
+func ReadLogsDump(path string) (*LogsDump, error) {
+	raw, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read logs file")
+	}
+	dump := LogsDump{}
+	if err := json.Unmarshal(raw, &dump); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal logs file")
+	}
+	return &dump, nil
+}
+
+// WriteLogsDump writes the given logs dump into a file
+func WriteLogsDump(path string, dump *LogsDump) error {
+	raw, err := json.Marshal(dump)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal logs dump")
+	}
+	if err := ioutil.WriteFile(filepath.Clean(path), raw, 0600); err != nil {
+		return errors.Wrap(err, "failed to write logs file")
+	}
+	return nil
+}
+
+// ExportLogs syncs the contract logs from the given block with the given client, and returns them as a logs dump
+func ExportLogs(client eth1.Client, contract string, fromBlock *big.Int) (*LogsDump, error) {
+	cn := make(chan *eth1.Event)
+	sub := client.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	syncEnded := make(chan eth1.SyncEndedEvent, 1)
+	go func() {
+		for e := range cn {
+			if ended, ok := e.Data.(eth1.SyncEndedEvent); ok {
+				syncEnded <- ended
+				return
+			}
+		}
+	}()
+	if err := client.Sync(fromBlock); err != nil {
+		return nil, errors.Wrap(err, "failed to sync contract events")
+	}
+	ended := <-syncEnded
+
+	return &LogsDump{
+		Contract:  contract,
+		FromBlock: fromBlock.Uint64(),
+		ToBlock:   ended.ToBlock,
+		Logs:      ended.Logs,
+	}, nil
+}
@@ -0,0 +1,218 @@
+package replay
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"go.uber.org/zap"
+)
+
+// ClientOptions are the options for the client
+type ClientOptions struct {
+	Logger                     *zap.Logger
+	LogsFile                   string
+	RegistryContractAddr       string
+	ContractABI                string
+	ShareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
+	OperatorPubKey             string
+
+	AbiVersion eth1.Version
+}
+
+// eth1Client is an implementation of eth1.Client that replays contract logs from a file
+// rather than reading them from an eth1 node, hence it doesn't stream new events
+type eth1Client struct {
+	logger *zap.Logger
+
+	shareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
+	operatorPubKey             string
+
+	dump        *LogsDump
+	blockHashes map[uint64]common.Hash
+	contractAbi abi.ABI
+
+	eventsFeed *event.Feed
+
+	abiVersion eth1.Version
+}
+
+// NewEth1Client creates a new instance
+func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
+	logger := opts.Logger.With(zap.String("component", "eth1Replay"),
+		zap.String("file", opts.LogsFile))
+
+	dump, err := ReadLogsDump(opts.LogsFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.RegistryContractAddr) > 0 && !strings.EqualFold(dump.Contract, opts.RegistryContractAddr) {
+		return nil, errors.Errorf("logs file was exported from another contract (%s)", dump.Contract)
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(opts.ContractABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ABI interface")
+	}
+	blockHashes := make(map[uint64]common.Hash)
+	for _, l := range dump.Logs {
+		blockHashes[l.BlockNumber] = l.BlockHash
+	}
+	logger.Info("loaded contract logs from file", zap.Int("logs", len(dump.Logs)),
+		zap.Uint64("fromBlock", dump.FromBlock), zap.Uint64("toBlock", dump.ToBlock))
+
+	return &eth1Client{
+		logger:                     logger,
+		shareEncryptionKeyProvider: opts.ShareEncryptionKeyProvider,
+		operatorPubKey:             opts.OperatorPubKey,
+		dump:                       dump,
+		blockHashes:                blockHashes,
+		contractAbi:                contractAbi,
+		eventsFeed:                 new(event.Feed),
+		abiVersion:                 opts.AbiVersion,
+	}, nil
+}
+
+// EventsFeed returns the contract events feed
+func (ec *eth1Client) EventsFeed() *event.Feed {
+	return ec.eventsFeed
+}
+
+// Start does nothing as new events are not available without an eth1 node
+func (ec *eth1Client) Start() error {
+	ec.logger.Info("contract events are replayed from file, new events won't be streamed")
+	return nil
+}
+
+// Sync replays the logs from the given block
+func (ec *eth1Client) Sync(fromBlock *big.Int) error {
+	ec.logger.Debug("replaying contract logs", zap.Uint64("fromBlock", fromBlock.Uint64()))
+	if fromBlock.Uint64() < ec.dump.FromBlock {
+		ec.logger.Warn("logs file starts after the sync offset, events might be missing",
+			zap.Uint64("syncOffset", fromBlock.Uint64()))
+	}
+	var logs []types.Log
+	var nSuccess int
+	for _, vLog := range ec.dump.Logs {
+		if vLog.BlockNumber < fromBlock.Uint64() {
+			continue
+		}
+		logs = append(logs, vLog)
+		nSuccess++
+		unpackErr, err := ec.handleEvent(vLog)
+		if err != nil {
+			if !unpackErr {
+				nSuccess--
+			}
+			ec.logger.Error("Failed to handle event during sync", zap.Error(err))
+		}
+	}
+	ec.logger.Debug("finished replaying contract logs",
+		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+	// publishing SyncEndedEvent so other components could track the sync
+	ec.fireEvent(types.Log{}, eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs), ToBlock: ec.dump.ToBlock}, false)
+	return nil
+}
+
+// BlockHash returns the hash of the given block as appears in the logs file,
+// blocks without logs have an empty hash while blocks that were not exported are unknown
+func (ec *eth1Client) BlockHash(number uint64) (common.Hash, error) {
+	if number > ec.dump.ToBlock {
+		return common.Hash{}, errors.Errorf("block %d was not exported, last exported block is %d", number, ec.dump.ToBlock)
+	}
+	return ec.blockHashes[number], nil
+}
+
+// Rollback fires the given orphaned logs as removed events, in reverse order.
+// data that was removed (or replaced) by the orphaned events is restored by firing the latest events of the affected
+// validators and operators, out of the logs (of the file) that precede the orphaned blocks
+func (ec *eth1Client) Rollback(logs []types.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	var orphaned []eth1.Event
+	for i := len(logs) - 1; i >= 0; i-- {
+		vLog := logs[i]
+		vLog.Removed = true
+		e, err := ec.parseEvent(vLog)
+		if err != nil {
+			ec.logger.Error("failed to rollback event", zap.Error(err),
+				zap.String("txHash", vLog.TxHash.Hex()), zap.Uint64("blockNumber", vLog.BlockNumber))
+			continue
+		}
+		if e != nil {
+			orphaned = append(orphaned, *e)
+			ec.fireEvent(vLog, e.Data, e.IsOperatorEvent)
+		}
+	}
+	if !eth1.RestoreRequired(orphaned) {
+		return nil
+	}
+	var history []eth1.Event
+	for _, vLog := range ec.dump.Logs {
+		if vLog.BlockNumber >= logs[0].BlockNumber {
+			break
+		}
+		e, err := ec.parseEvent(vLog)
+		if err != nil {
+			ec.logger.Warn("failed to parse event for restore", zap.Error(err), zap.String("txHash", vLog.TxHash.Hex()))
+			continue
+		}
+		if e != nil {
+			history = append(history, *e)
+		}
+	}
+	for _, e := range eth1.RestoringEvents(orphaned, history) {
+		ec.fireEvent(e.Log, e.Data, e.IsOperatorEvent)
+	}
+	return nil
+}
+
+func (ec *eth1Client) handleEvent(vLog types.Log) (bool, error) {
+	shareEncryptionKey, found, err := ec.shareEncryptionKeyProvider()
+	if !found {
+		return false, errors.New("failed to find operator private key")
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get operator private key")
+	}
+	abiParser := eth1.NewParser(ec.logger, ec.abiVersion)
+	eventName, e, unpackErr, err := abiParser.ParseEvent(vLog, ec.contractAbi, ec.operatorPubKey, shareEncryptionKey)
+	if e == nil {
+		if len(eventName) > 0 {
+			ec.logger.Debug("unknown contract event was received", zap.String("hash", vLog.TxHash.Hex()), zap.String("eventName", eventName))
+		}
+		return false, nil
+	}
+	if err != nil {
+		return unpackErr, err
+	}
+	ec.fireEvent(vLog, e.Data, e.IsOperatorEvent)
+	return false, nil
+}
+
+// parseEvent parses the given log without firing it, nil is returned for unknown events
+func (ec *eth1Client) parseEvent(vLog types.Log) (*eth1.Event, error) {
+	shareEncryptionKey, found, err := ec.shareEncryptionKeyProvider()
+	if !found {
+		return nil, errors.New("failed to find operator private key")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operator private key")
+	}
+	_, e, _, err := eth1.NewParser(ec.logger, ec.abiVersion).ParseEvent(vLog, ec.contractAbi, ec.operatorPubKey, shareEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// fireEvent notifies observers about some contract event
+func (ec *eth1Client) fireEvent(log types.Log, data interface{}, isOperatorEvent bool) {
+	e := eth1.Event{Log: log, Data: data, IsOperatorEvent: isOperatorEvent}
+	_ = ec.eventsFeed.Send(&e)
+}
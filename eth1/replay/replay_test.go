@@ -0,0 +1,225 @@
+package replay
+
+import (
+	"crypto/rsa"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testContract = "0x687fb596F3892904F879118e2113e1EEe8746C2E"
+
+func TestReplay_Sync(t *testing.T) {
+	dump := newTestDump(t)
+	ec := newTestClient(t, dump)
+
+	events, done := collectEvents(ec)
+	require.NoError(t, ec.Sync(big.NewInt(12)))
+	<-done
+
+	require.Len(t, *events, 3)
+	deleted, ok := (*events)[0].Data.(abiparser.ValidatorDeletedEvent)
+	require.True(t, ok)
+	require.Equal(t, []byte{1, 2, 3}, deleted.PublicKey)
+	_, ok = (*events)[1].Data.(abiparser.AccountLiquidatedEvent)
+	require.True(t, ok)
+	syncEnded, ok := (*events)[2].Data.(eth1.SyncEndedEvent)
+	require.True(t, ok)
+	require.True(t, syncEnded.Success)
+	require.Len(t, syncEnded.Logs, 2)
+	require.Equal(t, uint64(20), syncEnded.ToBlock)
+}
+
+func TestReplay_BlockHash(t *testing.T) {
+	dump := newTestDump(t)
+	ec := newTestClient(t, dump)
+
+	hash, err := ec.BlockHash(12)
+	require.NoError(t, err)
+	require.Equal(t, dump.Logs[1].BlockHash, hash)
+
+	// blocks without logs
+	hash, err = ec.BlockHash(13)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, hash)
+
+	_, err = ec.BlockHash(21)
+	require.EqualError(t, err, "block 21 was not exported, last exported block is 20")
+}
+
+func TestReplay_Rollback(t *testing.T) {
+	dump := newTestDump(t)
+	ec := newTestClient(t, dump)
+
+	cn := make(chan *eth1.Event)
+	sub := ec.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+	go func() {
+		require.NoError(t, ec.Rollback(dump.Logs[1:]))
+	}()
+	for _, expected := range []uint64{15, 12} {
+		e := <-cn
+		require.True(t, e.Log.Removed)
+		require.Equal(t, expected, e.Log.BlockNumber)
+	}
+}
+
+func TestReplay_RollbackRestore(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(eth1.ContractABI(eth1.V2)))
+	require.NoError(t, err)
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+	added, err := contractAbi.Events["ValidatorAdded"].Inputs.NonIndexed().Pack(owner, []byte{1, 2, 3},
+		[][]byte{}, [][]byte{}, [][]byte{})
+	require.NoError(t, err)
+	deleted, err := contractAbi.Events["ValidatorDeleted"].Inputs.NonIndexed().Pack(owner, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	dump := newTestDump(t)
+	dump.FromBlock = 5
+	dump.Logs = append([]types.Log{{
+		BlockNumber: 5,
+		BlockHash:   common.HexToHash("0x5"),
+		Topics:      []common.Hash{contractAbi.Events["ValidatorAdded"].ID},
+		Data:        added,
+	}}, dump.Logs...)
+	ec := newTestClient(t, dump)
+
+	cn := make(chan *eth1.Event)
+	sub := ec.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+	orphaned := types.Log{
+		BlockNumber: 11,
+		BlockHash:   common.HexToHash("0x11"),
+		Topics:      []common.Hash{contractAbi.Events["ValidatorDeleted"].ID},
+		Data:        deleted,
+	}
+	go func() {
+		require.NoError(t, ec.Rollback([]types.Log{orphaned}))
+	}()
+	e := <-cn
+	require.True(t, e.Log.Removed)
+	require.Equal(t, uint64(11), e.Log.BlockNumber)
+	// the validator is restored out of the logs that precede the orphaned block
+	e = <-cn
+	require.False(t, e.Log.Removed)
+	require.Equal(t, uint64(5), e.Log.BlockNumber)
+	restored, ok := e.Data.(abiparser.ValidatorAddedEvent)
+	require.True(t, ok)
+	require.Equal(t, []byte{1, 2, 3}, restored.PublicKey)
+}
+
+func TestExportLogs(t *testing.T) {
+	dump := newTestDump(t)
+	ec := newTestClient(t, dump)
+
+	exported, err := ExportLogs(ec, testContract, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, testContract, exported.Contract)
+	require.Equal(t, uint64(10), exported.FromBlock)
+	require.Equal(t, uint64(20), exported.ToBlock)
+	require.Len(t, exported.Logs, 3)
+
+	path := filepath.Join(t.TempDir(), "logs.json")
+	require.NoError(t, WriteLogsDump(path, exported))
+	read, err := ReadLogsDump(path)
+	require.NoError(t, err)
+	require.Equal(t, exported, read)
+}
+
+func TestNewEth1Client_OtherContract(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.json")
+	require.NoError(t, WriteLogsDump(path, newTestDump(t)))
+	_, err := NewEth1Client(ClientOptions{
+		Logger:               zap.L(),
+		LogsFile:             path,
+		RegistryContractAddr: "0x9573C41F0Ed8B72f3bD6A9bA6E3e15426A0aa65B",
+		ContractABI:          eth1.ContractABI(eth1.V2),
+		AbiVersion:           eth1.V2,
+	})
+	require.EqualError(t, err, "logs file was exported from another contract (0x687fb596F3892904F879118e2113e1EEe8746C2E)")
+}
+
+func collectEvents(ec eth1.Client) (*[]*eth1.Event, chan struct{}) {
+	var events []*eth1.Event
+	done := make(chan struct{})
+	cn := make(chan *eth1.Event)
+	sub := ec.EventsFeed().Subscribe(cn)
+	var once sync.Once
+	go func() {
+		defer sub.Unsubscribe()
+		for e := range cn {
+			events = append(events, e)
+			if _, ok := e.Data.(eth1.SyncEndedEvent); ok {
+				once.Do(func() {
+					close(done)
+				})
+				return
+			}
+		}
+	}()
+	return &events, done
+}
+
+func newTestClient(t *testing.T, dump *LogsDump) eth1.Client {
+	path := filepath.Join(t.TempDir(), "logs.json")
+	require.NoError(t, WriteLogsDump(path, dump))
+	ec, err := NewEth1Client(ClientOptions{
+		Logger:               zap.L(),
+		LogsFile:             path,
+		RegistryContractAddr: strings.ToLower(testContract),
+		ContractABI:          eth1.ContractABI(eth1.V2),
+		ShareEncryptionKeyProvider: func() (*rsa.PrivateKey, bool, error) {
+			return nil, true, nil
+		},
+		AbiVersion: eth1.V2,
+	})
+	require.NoError(t, err)
+	return ec
+}
+
+func newTestDump(t *testing.T) *LogsDump {
+	contractAbi, err := abi.JSON(strings.NewReader(eth1.ContractABI(eth1.V2)))
+	require.NoError(t, err)
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+
+	validatorDeleted := func(pk []byte) []byte {
+		data, err := contractAbi.Events["ValidatorDeleted"].Inputs.NonIndexed().Pack(owner, pk)
+		require.NoError(t, err)
+		return data
+	}
+	return &LogsDump{
+		Contract:  testContract,
+		FromBlock: 10,
+		ToBlock:   20,
+		Logs: []types.Log{
+			{
+				BlockNumber: 10,
+				BlockHash:   common.HexToHash("0x10"),
+				Topics:      []common.Hash{contractAbi.Events["ValidatorDeleted"].ID},
+				Data:        validatorDeleted([]byte{0, 0, 0}),
+			},
+			{
+				BlockNumber: 12,
+				BlockHash:   common.HexToHash("0x12"),
+				Topics:      []common.Hash{contractAbi.Events["ValidatorDeleted"].ID},
+				Data:        validatorDeleted([]byte{1, 2, 3}),
+			},
+			{
+				BlockNumber: 15,
+				BlockHash:   common.HexToHash("0x15"),
+				Topics:      []common.Hash{contractAbi.Events["AccountLiquidated"].ID, common.BytesToHash(owner.Bytes())},
+				Data:        []byte{},
+			},
+		},
+	}
+}
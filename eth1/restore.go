@@ -0,0 +1,107 @@
+package eth1
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RestoreRequired returns true if some of the given orphaned events removed (or replaced) registry data,
+// which can't be rolled back without the events that precede the orphaned blocks
+func RestoreRequired(orphaned []Event) bool {
+	for _, e := range orphaned {
+		switch e.Data.(type) {
+		case abiparser.ValidatorUpdatedEvent, abiparser.ValidatorDeletedEvent,
+			abiparser.OperatorDeletedEvent, abiparser.AccountLiquidatedEvent:
+			return true
+		}
+	}
+	return false
+}
+
+// RestoringEvents returns the events that restore the registry data that was removed (or replaced) by the given orphaned events.
+// the data is computed out of the canonical events that precede the orphaned blocks (history):
+// the latest ValidatorAdded / ValidatorUpdated event (as ValidatorAdded) of every affected validator that is still registered,
+// and the OperatorAdded event of every affected operator that is still registered
+func RestoringEvents(orphaned []Event, history []Event) []Event {
+	validators := make(map[string]bool)
+	operators := make(map[string]bool)
+	owners := make(map[common.Address]bool)
+	for _, e := range orphaned {
+		switch ev := e.Data.(type) {
+		case abiparser.ValidatorUpdatedEvent:
+			validators[hex.EncodeToString(ev.PublicKey)] = true
+		case abiparser.ValidatorDeletedEvent:
+			validators[hex.EncodeToString(ev.PublicKey)] = true
+		case abiparser.OperatorDeletedEvent:
+			operators[string(ev.PublicKey)] = true
+		case abiparser.AccountLiquidatedEvent:
+			owners[ev.OwnerAddress] = true
+		}
+	}
+	if len(validators) == 0 && len(operators) == 0 && len(owners) == 0 {
+		return nil
+	}
+
+	// the indices (in history) of the latest event of every registered validator / operator
+	validatorEvents := make(map[string]int)
+	operatorEvents := make(map[string]int)
+	for i, e := range history {
+		switch ev := e.Data.(type) {
+		case abiparser.ValidatorAddedEvent:
+			if validators[hex.EncodeToString(ev.PublicKey)] || owners[ev.OwnerAddress] {
+				validatorEvents[hex.EncodeToString(ev.PublicKey)] = i
+			}
+		case abiparser.ValidatorUpdatedEvent:
+			if validators[hex.EncodeToString(ev.PublicKey)] || owners[ev.OwnerAddress] {
+				validatorEvents[hex.EncodeToString(ev.PublicKey)] = i
+			}
+		case abiparser.ValidatorDeletedEvent:
+			delete(validatorEvents, hex.EncodeToString(ev.PublicKey))
+		case abiparser.AccountLiquidatedEvent:
+			for pk, j := range validatorEvents {
+				if validatorOwner(history[j]) == ev.OwnerAddress {
+					delete(validatorEvents, pk)
+				}
+			}
+		case abiparser.OperatorAddedEvent:
+			if operators[string(ev.PublicKey)] {
+				operatorEvents[string(ev.PublicKey)] = i
+			}
+		case abiparser.OperatorDeletedEvent:
+			delete(operatorEvents, string(ev.PublicKey))
+		}
+	}
+
+	indices := make([]int, 0, len(validatorEvents)+len(operatorEvents))
+	for _, i := range validatorEvents {
+		indices = append(indices, i)
+	}
+	for _, i := range operatorEvents {
+		indices = append(indices, i)
+	}
+	// events are restored in the order they were emitted
+	sort.Ints(indices)
+	events := make([]Event, 0, len(indices))
+	for _, i := range indices {
+		e := history[i]
+		if ev, ok := e.Data.(abiparser.ValidatorUpdatedEvent); ok {
+			e.Data = abiparser.ValidatorAddedEvent(ev)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// validatorOwner returns the owner address of the given ValidatorAdded / ValidatorUpdated event
+func validatorOwner(e Event) common.Address {
+	switch ev := e.Data.(type) {
+	case abiparser.ValidatorAddedEvent:
+		return ev.OwnerAddress
+	case abiparser.ValidatorUpdatedEvent:
+		return ev.OwnerAddress
+	}
+	return common.Address{}
+}
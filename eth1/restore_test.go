@@ -0,0 +1,91 @@
+package eth1
+
+import (
+	"testing"
+
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreRequired(t *testing.T) {
+	require.False(t, RestoreRequired(nil))
+	require.False(t, RestoreRequired([]Event{
+		{Data: abiparser.ValidatorAddedEvent{PublicKey: []byte{1}}},
+		{Data: abiparser.OperatorAddedEvent{PublicKey: []byte{1}}},
+	}))
+	require.True(t, RestoreRequired([]Event{{Data: abiparser.ValidatorUpdatedEvent{PublicKey: []byte{1}}}}))
+	require.True(t, RestoreRequired([]Event{{Data: abiparser.ValidatorDeletedEvent{PublicKey: []byte{1}}}}))
+	require.True(t, RestoreRequired([]Event{{Data: abiparser.OperatorDeletedEvent{PublicKey: []byte{1}}}}))
+	require.True(t, RestoreRequired([]Event{{Data: abiparser.AccountLiquidatedEvent{}}}))
+}
+
+func TestRestoringEvents(t *testing.T) {
+	owner := common.HexToAddress("0x1")
+	otherOwner := common.HexToAddress("0x2")
+	history := []Event{
+		{Log: types.Log{BlockNumber: 1}, Data: abiparser.OperatorAddedEvent{PublicKey: []byte("op1"), OwnerAddress: owner}},
+		{Log: types.Log{BlockNumber: 1, Index: 1}, Data: abiparser.OperatorAddedEvent{PublicKey: []byte("op2"), OwnerAddress: owner}},
+		{Log: types.Log{BlockNumber: 2}, Data: abiparser.ValidatorAddedEvent{PublicKey: []byte{1}, OwnerAddress: owner}},
+		{Log: types.Log{BlockNumber: 3}, Data: abiparser.ValidatorAddedEvent{PublicKey: []byte{2}, OwnerAddress: owner}},
+		{Log: types.Log{BlockNumber: 4}, Data: abiparser.ValidatorUpdatedEvent{PublicKey: []byte{1}, OwnerAddress: owner,
+			SharesPublicKeys: [][]byte{{1}}}, IsOperatorEvent: true},
+		{Log: types.Log{BlockNumber: 5}, Data: abiparser.ValidatorAddedEvent{PublicKey: []byte{3}, OwnerAddress: otherOwner}},
+		{Log: types.Log{BlockNumber: 6}, Data: abiparser.ValidatorDeletedEvent{PublicKey: []byte{2}, OwnerAddress: owner}},
+		{Log: types.Log{BlockNumber: 7}, Data: abiparser.OperatorDeletedEvent{PublicKey: []byte("op2"), OwnerAddress: owner}},
+	}
+
+	t.Run("no removals", func(t *testing.T) {
+		orphaned := []Event{{Data: abiparser.ValidatorAddedEvent{PublicKey: []byte{4}, OwnerAddress: owner}}}
+		require.Len(t, RestoringEvents(orphaned, history), 0)
+	})
+
+	t.Run("updated validator", func(t *testing.T) {
+		orphaned := []Event{{Data: abiparser.ValidatorUpdatedEvent{PublicKey: []byte{1}, OwnerAddress: owner}}}
+		events := RestoringEvents(orphaned, history)
+		require.Len(t, events, 1)
+		require.Equal(t, uint64(4), events[0].Log.BlockNumber)
+		require.True(t, events[0].IsOperatorEvent)
+		// the latest state is restored as ValidatorAdded
+		ev, ok := events[0].Data.(abiparser.ValidatorAddedEvent)
+		require.True(t, ok)
+		require.Equal(t, [][]byte{{1}}, ev.SharesPublicKeys)
+	})
+
+	t.Run("deleted validators", func(t *testing.T) {
+		orphaned := []Event{
+			{Data: abiparser.ValidatorDeletedEvent{PublicKey: []byte{3}, OwnerAddress: otherOwner}},
+			// was deleted before the orphaned blocks
+			{Data: abiparser.ValidatorDeletedEvent{PublicKey: []byte{2}, OwnerAddress: owner}},
+		}
+		events := RestoringEvents(orphaned, history)
+		require.Len(t, events, 1)
+		require.Equal(t, uint64(5), events[0].Log.BlockNumber)
+	})
+
+	t.Run("deleted operators", func(t *testing.T) {
+		orphaned := []Event{
+			{Data: abiparser.OperatorDeletedEvent{PublicKey: []byte("op1"), OwnerAddress: owner}},
+			{Data: abiparser.OperatorDeletedEvent{PublicKey: []byte("op2"), OwnerAddress: owner}},
+		}
+		events := RestoringEvents(orphaned, history)
+		require.Len(t, events, 1)
+		ev, ok := events[0].Data.(abiparser.OperatorAddedEvent)
+		require.True(t, ok)
+		require.Equal(t, []byte("op1"), ev.PublicKey)
+	})
+
+	t.Run("liquidated account", func(t *testing.T) {
+		orphaned := []Event{{Data: abiparser.AccountLiquidatedEvent{OwnerAddress: owner}}}
+		events := RestoringEvents(orphaned, history)
+		require.Len(t, events, 1)
+		require.Equal(t, uint64(4), events[0].Log.BlockNumber)
+	})
+
+	t.Run("liquidated before the orphaned blocks", func(t *testing.T) {
+		orphaned := []Event{{Data: abiparser.AccountLiquidatedEvent{OwnerAddress: otherOwner}}}
+		liquidated := append(history, Event{Log: types.Log{BlockNumber: 8}, Data: abiparser.AccountLiquidatedEvent{OwnerAddress: otherOwner}})
+		require.Len(t, RestoringEvents(orphaned, liquidated), 0)
+	})
+}
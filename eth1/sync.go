@@ -2,17 +2,19 @@ package eth1
 
 import (
 	"github.com/bloxapp/ssv/utils/tasks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 )
 
 const (
-	// prod contract genesis
-	defaultSyncOffset string = "4e706f"
-	// stage contract genesis -> 49e08f
+	// syncedBlocksLimit is the amount of (latest) synced blocks that are kept in order to detect reorgs
+	syncedBlocksLimit = 128
 )
 
 // SyncOffset is the type of variable used for passing around the offset
@@ -27,11 +29,25 @@ type SyncOffsetStorage interface {
 	SaveSyncOffset(offset *SyncOffset) error
 	// GetSyncOffset returns the sync offset
 	GetSyncOffset() (*SyncOffset, bool, error)
+	SyncedBlocksStorage
 }
 
-// DefaultSyncOffset returns the default value (block number of the first event from the contract)
-func DefaultSyncOffset() *SyncOffset {
-	return HexStringToSyncOffset(defaultSyncOffset)
+// SyncedBlocksStorage represents the storage of the blocks that contract events were applied from
+type SyncedBlocksStorage interface {
+	// SaveSyncedBlock saves a block that contract events were applied from
+	SaveSyncedBlock(block *SyncedBlock) error
+	// GetSyncedBlocks returns the saved blocks, sorted by block number
+	GetSyncedBlocks() ([]*SyncedBlock, error)
+	// DeleteSyncedBlock removes the block with the given number
+	DeleteSyncedBlock(number uint64) error
+}
+
+// SyncedBlock represents a block that contract events were applied from,
+// the hash is used to detect whether the block was orphaned by a reorg
+type SyncedBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Logs   []types.Log `json:"logs"`
 }
 
 // HexStringToSyncOffset converts an hex string to SyncOffset
@@ -78,6 +94,12 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 		}
 	}()
 	syncOffset = determineSyncOffset(logger, storage, syncOffset)
+	syncOffset, err := rollbackOrphanedBlocks(logger, client, storage, syncOffset)
+	if err != nil {
+		return errors.Wrap(err, "failed to rollback orphaned blocks")
+	}
+	// waiting for rolled back events to be processed before applying new events
+	q.Wait()
 	if err := client.Sync(syncOffset); err != nil {
 		return errors.Wrap(err, "failed to sync contract events")
 	}
@@ -91,7 +113,101 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 		return errors.New("failed to handle all events from sync")
 	}
 
-	return upgradeSyncOffset(logger, storage, syncOffset, syncEndedEvent)
+	if err := upgradeSyncOffset(logger, storage, syncOffset, syncEndedEvent); err != nil {
+		return err
+	}
+	return SaveSyncedBlocks(storage, syncEndedEvent.Logs)
+}
+
+// rollbackOrphanedBlocks looks for synced blocks that are no longer part of the canonical chain,
+// their events are rolled back (from the newest to the oldest) and the sync offset is set to the lowest orphaned block
+func rollbackOrphanedBlocks(logger *zap.Logger, client Client, storage SyncOffsetStorage, syncOffset *SyncOffset) (*SyncOffset, error) {
+	blocks, err := storage.GetSyncedBlocks()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get synced blocks")
+	}
+	var orphaned []*SyncedBlock
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		hash, err := client.BlockHash(block.Number)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get block hash")
+		}
+		if hash == block.Hash {
+			// blocks are sorted, hence the rest of the blocks are part of the canonical chain
+			break
+		}
+		logger.Warn("detected eth1 reorg, rolling back orphaned block",
+			zap.Uint64("blockNumber", block.Number), zap.String("orphanedHash", block.Hash.Hex()),
+			zap.String("canonicalHash", hash.Hex()))
+		orphaned = append(orphaned, block)
+	}
+	if len(orphaned) == 0 {
+		return syncOffset, nil
+	}
+	// orphaned blocks are ordered from the newest, logs are collected from the oldest as they are rolled back in reverse order
+	var orphanedLogs []types.Log
+	for i := len(orphaned) - 1; i >= 0; i-- {
+		orphanedLogs = append(orphanedLogs, orphaned[i].Logs...)
+	}
+	if err := client.Rollback(orphanedLogs); err != nil {
+		return nil, errors.Wrap(err, "could not rollback orphaned logs")
+	}
+	for _, block := range orphaned {
+		if err := storage.DeleteSyncedBlock(block.Number); err != nil {
+			return nil, errors.Wrap(err, "could not delete orphaned block")
+		}
+	}
+	// the sync should start from the lowest orphaned block, to apply the events of the canonical chain
+	if lowest := orphaned[len(orphaned)-1].Number; lowest < syncOffset.Uint64() {
+		syncOffset = new(SyncOffset).SetUint64(lowest)
+	}
+	if err := storage.SaveSyncOffset(syncOffset); err != nil {
+		return nil, errors.Wrap(err, "could not save sync offset")
+	}
+	return syncOffset, nil
+}
+
+// SaveSyncedBlocks saves the hashes and logs of the blocks of the given (sorted) logs,
+// only the latest blocks (syncedBlocksLimit) are kept as older blocks are not expected to be orphaned
+func SaveSyncedBlocks(storage SyncedBlocksStorage, logs []types.Log) error {
+	var block *SyncedBlock
+	save := func() error {
+		if block == nil {
+			return nil
+		}
+		return storage.SaveSyncedBlock(block)
+	}
+	for _, l := range logs {
+		if block == nil || block.Number != l.BlockNumber {
+			if err := save(); err != nil {
+				return errors.Wrap(err, "could not save synced block")
+			}
+			block = &SyncedBlock{Number: l.BlockNumber, Hash: l.BlockHash}
+		}
+		block.Logs = append(block.Logs, l)
+	}
+	if err := save(); err != nil {
+		return errors.Wrap(err, "could not save synced block")
+	}
+
+	blocks, err := storage.GetSyncedBlocks()
+	if err != nil {
+		return errors.Wrap(err, "could not get synced blocks")
+	}
+	for i := 0; i < len(blocks)-syncedBlocksLimit; i++ {
+		if err := storage.DeleteSyncedBlock(blocks[i].Number); err != nil {
+			return errors.Wrap(err, "could not delete synced block")
+		}
+	}
+	return nil
+}
+
+// SortSyncedBlocks sorts the given blocks by block number
+func SortSyncedBlocks(blocks []*SyncedBlock) {
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Number < blocks[j].Number
+	})
 }
 
 // upgradeSyncOffset updates the sync offset after a sync
@@ -113,8 +229,8 @@ func upgradeSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffset
 
 // determineSyncOffset decides what is the value of sync offset by using one of (by priority):
 //   1. last saved sync offset
-//   2. provided value (from config)
-//   3. default sync offset (the genesis block of the contract)
+//   2. provided value (from config or the network profile)
+//   3. the first block
 func determineSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffset *SyncOffset) *SyncOffset {
 	syncOffsetFromStorage, found, err := storage.GetSyncOffset()
 	if err != nil {
@@ -125,13 +241,13 @@ func determineSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffs
 			zap.Uint64("syncOffset", syncOffsetFromStorage.Uint64()))
 		return syncOffsetFromStorage
 	}
-	if syncOffset != nil { // if provided sync offset is nil - use default sync offset
+	if syncOffset != nil { // if provided sync offset is nil - sync from the first block
 		logger.Debug("using provided sync offset",
 			zap.Uint64("syncOffset", syncOffset.Uint64()))
 		return syncOffset
 	}
-	syncOffset = DefaultSyncOffset()
-	logger.Debug("using default sync offset",
+	syncOffset = new(SyncOffset)
+	logger.Debug("using first block as sync offset",
 		zap.Uint64("syncOffset", syncOffset.Uint64()))
 	return syncOffset
 }
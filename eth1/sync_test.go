@@ -1,11 +1,13 @@
 package eth1
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"sync"
 	"testing"
 	"time"
 )
@@ -13,7 +15,7 @@ import (
 func TestSyncEth1(t *testing.T) {
 	logger, eth1Client, storage := setupStorageWithEth1ClientMock()
 
-	rawOffset := DefaultSyncOffset().Uint64()
+	rawOffset := testSyncOffset().Uint64()
 	rawOffset += 10
 	go func() {
 		// wait 5 ms and start to push events
@@ -35,7 +37,7 @@ func TestSyncEth1Error(t *testing.T) {
 	logger, eth1Client, storage := setupStorageWithEth1ClientMock()
 	eth1Client.SyncResponse = errors.New("eth1-sync-test")
 	go func() {
-		logs := []types.Log{{}, {BlockNumber: DefaultSyncOffset().Uint64()}}
+		logs := []types.Log{{}, {BlockNumber: testSyncOffset().Uint64()}}
 		eth1Client.Feed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eth1Client.Feed.Send(&Event{Data: struct{}{}, Log: logs[1]})
 		eth1Client.Feed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: false}})
@@ -52,7 +54,7 @@ func TestSyncEth1HandlerError(t *testing.T) {
 	logger, eth1Client, storage := setupStorageWithEth1ClientMock()
 	go func() {
 		<-time.After(time.Millisecond * 25)
-		logs := []types.Log{{BlockNumber: DefaultSyncOffset().Uint64() - 1}, {BlockNumber: DefaultSyncOffset().Uint64()}}
+		logs := []types.Log{{BlockNumber: testSyncOffset().Uint64() - 1}, {BlockNumber: testSyncOffset().Uint64()}}
 		eth1Client.Feed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eth1Client.Feed.Send(&Event{Data: struct{}{}, Log: logs[1]})
 		eth1Client.Feed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: false}})
@@ -63,18 +65,94 @@ func TestSyncEth1HandlerError(t *testing.T) {
 	require.EqualError(t, err, "failed to handle all events from sync")
 }
 
+func TestSyncEth1Reorg(t *testing.T) {
+	logger, eth1Client, storage := setupStorageWithEth1ClientMock()
+
+	rawOffset := testSyncOffset().Uint64() + 10
+	orphanedLogs := []types.Log{
+		{BlockNumber: rawOffset - 2, BlockHash: common.HexToHash("0x1"), TxIndex: 0},
+		{BlockNumber: rawOffset - 2, BlockHash: common.HexToHash("0x1"), TxIndex: 1},
+		{BlockNumber: rawOffset, BlockHash: common.HexToHash("0x2")},
+	}
+	// the oldest block is part of the canonical chain
+	require.NoError(t, storage.SaveSyncedBlock(&SyncedBlock{Number: rawOffset - 5, Hash: common.HexToHash("0x5")}))
+	require.NoError(t, storage.SaveSyncedBlock(&SyncedBlock{Number: rawOffset - 2, Hash: common.HexToHash("0x1"), Logs: orphanedLogs[:2]}))
+	require.NoError(t, storage.SaveSyncedBlock(&SyncedBlock{Number: rawOffset, Hash: common.HexToHash("0x2"), Logs: orphanedLogs[2:]}))
+	require.NoError(t, storage.SaveSyncOffset(new(SyncOffset).SetUint64(rawOffset)))
+	eth1Client.BlockHashes = map[uint64]common.Hash{
+		rawOffset - 5: common.HexToHash("0x5"),
+		rawOffset - 2: common.HexToHash("0x3"),
+		rawOffset:     common.HexToHash("0x4"),
+	}
+
+	var removedLock sync.Mutex
+	var removed []types.Log
+	go func() {
+		<-time.After(time.Millisecond * 25)
+		logs := []types.Log{{BlockNumber: rawOffset - 1, BlockHash: common.HexToHash("0x6")}}
+		eth1Client.Feed.Send(&Event{Data: struct{}{}, Log: logs[0]})
+		eth1Client.Feed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: true}})
+	}()
+	err := SyncEth1Events(logger, eth1Client, storage, nil, func(e Event) error {
+		if e.Log.Removed {
+			removedLock.Lock()
+			removed = append(removed, e.Log)
+			removedLock.Unlock()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// orphaned logs were rolled back from the newest
+	require.Len(t, eth1Client.RolledBack, 3)
+	require.Equal(t, rawOffset, eth1Client.RolledBack[0].BlockNumber)
+	require.Equal(t, uint(1), eth1Client.RolledBack[1].TxIndex)
+	require.Equal(t, uint(0), eth1Client.RolledBack[2].TxIndex)
+	removedLock.Lock()
+	require.Len(t, removed, 3)
+	removedLock.Unlock()
+
+	// sync offset was set to the lowest orphaned block and upgraded by the new sync
+	syncOffset, _, err := storage.GetSyncOffset()
+	require.NoError(t, err)
+	require.Equal(t, rawOffset-1, syncOffset.Uint64())
+
+	blocks, err := storage.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, rawOffset-5, blocks[0].Number)
+	require.Equal(t, rawOffset-1, blocks[1].Number)
+	require.Equal(t, common.HexToHash("0x6"), blocks[1].Hash)
+}
+
+func TestSaveSyncedBlocks(t *testing.T) {
+	_, _, storage := setupStorageWithEth1ClientMock()
+
+	var logs []types.Log
+	for i := uint64(0); i < syncedBlocksLimit+10; i++ {
+		logs = append(logs, types.Log{BlockNumber: i, TxIndex: 0}, types.Log{BlockNumber: i, TxIndex: 1})
+	}
+	require.NoError(t, SaveSyncedBlocks(storage, logs))
+
+	blocks, err := storage.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, syncedBlocksLimit)
+	require.Equal(t, uint64(10), blocks[0].Number)
+	require.Len(t, blocks[0].Logs, 2)
+}
+
 func TestDetermineSyncOffset(t *testing.T) {
 	logger := zap.L()
 
-	t.Run("default sync offset", func(t *testing.T) {
-		storage := syncStorageMock{[]byte{}}
+	t.Run("first block", func(t *testing.T) {
+		storage := syncStorageMock{syncOffset: []byte{}}
 		so := determineSyncOffset(logger, &storage, nil)
 		require.NotNil(t, so)
-		require.Equal(t, defaultSyncOffset, so.Text(16))
+		require.Equal(t, uint64(0), so.Uint64())
 	})
 
 	t.Run("persisted sync offset", func(t *testing.T) {
-		storage := syncStorageMock{[]byte{}}
+		storage := syncStorageMock{syncOffset: []byte{}}
 		so := new(SyncOffset)
 		persistedSyncOffset := "60e08f"
 		so.SetString(persistedSyncOffset, 16)
@@ -85,7 +163,7 @@ func TestDetermineSyncOffset(t *testing.T) {
 	})
 
 	t.Run("sync offset from config", func(t *testing.T) {
-		storage := syncStorageMock{[]byte{}}
+		storage := syncStorageMock{syncOffset: []byte{}}
 		soConfig := new(SyncOffset)
 		soConfig.SetString("61e08f", 16)
 		so := determineSyncOffset(logger, &storage, soConfig)
@@ -94,15 +172,20 @@ func TestDetermineSyncOffset(t *testing.T) {
 	})
 }
 
+func testSyncOffset() *SyncOffset {
+	return HexStringToSyncOffset("4e706f")
+}
+
 func setupStorageWithEth1ClientMock() (*zap.Logger, *ClientMock, *syncStorageMock) {
 	logger := zap.L()
 	eth1Client := ClientMock{Feed: new(event.Feed), SyncTimeout: 50 * time.Millisecond}
-	storage := syncStorageMock{[]byte{}}
+	storage := syncStorageMock{syncOffset: []byte{}}
 	return logger, &eth1Client, &storage
 }
 
 type syncStorageMock struct {
-	syncOffset []byte
+	syncOffset   []byte
+	syncedBlocks map[uint64]*SyncedBlock
 }
 
 // SaveSyncOffset saves the offset
@@ -120,3 +203,28 @@ func (ssm *syncStorageMock) GetSyncOffset() (*SyncOffset, bool, error) {
 	offset.SetBytes(ssm.syncOffset)
 	return offset, true, nil
 }
+
+// SaveSyncedBlock saves a synced block
+func (ssm *syncStorageMock) SaveSyncedBlock(block *SyncedBlock) error {
+	if ssm.syncedBlocks == nil {
+		ssm.syncedBlocks = make(map[uint64]*SyncedBlock)
+	}
+	ssm.syncedBlocks[block.Number] = block
+	return nil
+}
+
+// GetSyncedBlocks returns the synced blocks
+func (ssm *syncStorageMock) GetSyncedBlocks() ([]*SyncedBlock, error) {
+	var blocks []*SyncedBlock
+	for _, block := range ssm.syncedBlocks {
+		blocks = append(blocks, block)
+	}
+	SortSyncedBlocks(blocks)
+	return blocks, nil
+}
+
+// DeleteSyncedBlock removes a synced block
+func (ssm *syncStorageMock) DeleteSyncedBlock(number uint64) error {
+	delete(ssm.syncedBlocks, number)
+	return nil
+}
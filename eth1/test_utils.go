@@ -1,6 +1,8 @@
 package eth1
 
 import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"math/big"
 	"time"
@@ -12,6 +14,11 @@ type ClientMock struct {
 
 	SyncTimeout  time.Duration
 	SyncResponse error
+
+	// BlockHashes are the hashes of the canonical blocks, blocks that are not listed have an empty hash
+	BlockHashes map[uint64]common.Hash
+	// RolledBack are the logs that were rolled back
+	RolledBack []types.Log
 }
 
 // EventsFeed returns the contract events feed
@@ -29,3 +36,19 @@ func (ec *ClientMock) Sync(fromBlock *big.Int) error {
 	<-time.After(ec.SyncTimeout)
 	return ec.SyncResponse
 }
+
+// BlockHash returns the mocked hash of the given block
+func (ec *ClientMock) BlockHash(number uint64) (common.Hash, error) {
+	return ec.BlockHashes[number], nil
+}
+
+// Rollback mocking rollback of orphaned logs
+func (ec *ClientMock) Rollback(logs []types.Log) error {
+	for i := len(logs) - 1; i >= 0; i-- {
+		l := logs[i]
+		l.Removed = true
+		ec.RolledBack = append(ec.RolledBack, l)
+		ec.Feed.Send(&Event{Log: l, Data: struct{}{}})
+	}
+	return nil
+}
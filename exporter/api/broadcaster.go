@@ -14,6 +14,7 @@ type Broadcaster interface {
 	Broadcast(msg Message) error
 	Register(conn broadcasted) bool
 	Deregister(conn broadcasted) bool
+	Subscribe(id string, sub *Subscription) bool
 }
 
 type broadcasted interface {
@@ -21,17 +22,23 @@ type broadcasted interface {
 	Send([]byte)
 }
 
+// subscriber is a registered connection and its subscription, nil subscription means all messages
+type subscriber struct {
+	conn broadcasted
+	sub  *Subscription
+}
+
 type broadcaster struct {
 	logger      *zap.Logger
 	mut         sync.Mutex
-	connections map[string]broadcasted
+	connections map[string]*subscriber
 }
 
 func newBroadcaster(logger *zap.Logger) Broadcaster {
 	return &broadcaster{
 		logger:      logger.With(zap.String("component", "exporter/api/broadcaster")),
 		mut:         sync.Mutex{},
-		connections: map[string]broadcasted{},
+		connections: map[string]*subscriber{},
 	}
 }
 
@@ -57,7 +64,7 @@ func (b *broadcaster) FromFeed(msgFeed *event.Feed) error {
 	}
 }
 
-// Broadcast broadcasts a message to all available connections
+// Broadcast broadcasts a message to all available connections that are subscribed to it
 func (b *broadcaster) Broadcast(msg Message) error {
 	data, err := json.Marshal(&msg)
 	if err != nil {
@@ -67,13 +74,15 @@ func (b *broadcaster) Broadcast(msg Message) error {
 	defer b.logger.Debug("message was broadcast-ed", zap.Any("msg", msg))
 
 	// lock is applied only when reading from the connections map
-	// therefore a new temp slice is created to hold all current connections and avoid concurrency issues
+	// therefore a new temp slice is created to hold all current (subscribed) connections and avoid concurrency issues
 	b.mut.Lock()
 	b.logger.Debug("broadcasting message", zap.Int("total connections", len(b.connections)),
 		zap.Any("msg", msg))
 	var conns []broadcasted
-	for _, c := range b.connections {
-		conns = append(conns, c)
+	for _, s := range b.connections {
+		if s.sub.Match(msg) {
+			conns = append(conns, s.conn)
+		}
 	}
 	b.mut.Unlock()
 	// send to all connections
@@ -91,7 +100,7 @@ func (b *broadcaster) Register(conn broadcasted) bool {
 
 	id := conn.ID()
 	if _, ok := b.connections[id]; !ok {
-		b.connections[id] = conn
+		b.connections[id] = &subscriber{conn: conn}
 		return true
 	}
 	return false
@@ -109,3 +118,16 @@ func (b *broadcaster) Deregister(conn broadcasted) bool {
 	}
 	return false
 }
+
+// Subscribe replaces the subscription of the given (registered) connection
+func (b *broadcaster) Subscribe(id string, sub *Subscription) bool {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	s, ok := b.connections[id]
+	if !ok {
+		return false
+	}
+	s.sub = sub
+	return true
+}
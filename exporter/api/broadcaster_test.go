@@ -13,7 +13,7 @@ import (
 
 func TestConn_Send_FullQueue(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	c := newConn(context.Background(), logger, nil, "test", 0, false)
+	c := newConn(context.Background(), logger, nil, "test", 0, maxMessageSize, false)
 
 	for i := 0; i < chanSize+2; i++ {
 		c.Send([]byte(fmt.Sprintf("test-%d", i)))
@@ -51,6 +51,32 @@ func TestBroadcaster(t *testing.T) {
 	require.Equal(t, bm2.Size(), 1)
 }
 
+func TestBroadcaster_Subscribe(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	b := newBroadcaster(logger)
+
+	bm1 := newBroadcastedMock("1")
+	bm2 := newBroadcastedMock("2")
+	require.True(t, b.Register(bm1))
+	require.True(t, b.Register(bm2))
+	require.False(t, b.Subscribe("3", &Subscription{}))
+
+	sub := &Subscription{Types: []MessageType{TypeOperator}}
+	require.NoError(t, sub.init())
+	require.True(t, b.Subscribe(bm2.ID(), sub))
+
+	require.NoError(t, b.Broadcast(Message{Type: TypeValidator}))
+	require.NoError(t, b.Broadcast(Message{Type: TypeOperator}))
+	require.Equal(t, 2, bm1.Size())
+	require.Equal(t, 1, bm2.Size())
+
+	// changing the subscription
+	require.True(t, b.Subscribe(bm2.ID(), nil))
+	require.NoError(t, b.Broadcast(Message{Type: TypeValidator}))
+	require.Equal(t, 3, bm1.Size())
+	require.Equal(t, 2, bm2.Size())
+}
+
 type broadcastedMock struct {
 	mut  sync.Mutex
 	msgs [][]byte
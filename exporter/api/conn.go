@@ -23,6 +23,9 @@ var (
 	// maxMessageSize max msg size allowed from peer.
 	maxMessageSize = int64(1024)
 
+	// maxStreamMessageSize max msg size allowed from stream peer, subscriptions might contain many validators.
+	maxStreamMessageSize = int64(1 << 20)
+
 	chanSize = 256
 
 	newline = []byte{'\n'}
@@ -55,6 +58,7 @@ type conn struct {
 	ws     *websocket.Conn
 
 	writeTimeout time.Duration
+	readLimit    int64
 
 	read chan []byte
 	send chan []byte
@@ -64,13 +68,14 @@ type conn struct {
 	withPing bool
 }
 
-func newConn(ctx context.Context, logger *zap.Logger, ws *websocket.Conn, id string, writeTimeout time.Duration, withPing bool) Conn {
+func newConn(ctx context.Context, logger *zap.Logger, ws *websocket.Conn, id string, writeTimeout time.Duration, readLimit int64, withPing bool) Conn {
 	return &conn{
 		ctx:          ctx,
 		logger:       logger.With(zap.String("who", "WSConn")),
 		id:           id,
 		ws:           ws,
 		writeTimeout: writeTimeout,
+		readLimit:    readLimit,
 		read:         make(chan []byte, chanSize),
 		send:         make(chan []byte, chanSize),
 		writeLock:    &sync.Mutex{},
@@ -93,9 +98,14 @@ func (c *conn) Close() error {
 	return c.ws.Close()
 }
 
-// ReadNext reads the next message
+// ReadNext reads the next message, returns nil once the connection context is done
 func (c *conn) ReadNext() []byte {
-	return <-c.read
+	select {
+	case <-c.ctx.Done():
+		return nil
+	case msg := <-c.read:
+		return msg
+	}
 }
 
 // Send sends the given message
@@ -155,7 +165,7 @@ func (c *conn) ReadLoop() {
 	defer func() {
 		_ = c.ws.Close()
 	}()
-	c.ws.SetReadLimit(maxMessageSize)
+	c.ws.SetReadLimit(c.readLimit)
 	// ping helps to keep the connection alive from our POV
 	if c.withPing {
 		// set deadline so ping messages won't exceed timeout
@@ -183,7 +193,11 @@ func (c *conn) ReadLoop() {
 		}
 		if mt == websocket.TextMessage {
 			msg = bytes.TrimSpace(bytes.Replace(msg, newline, space, -1))
-			c.read <- msg
+			select {
+			case <-c.ctx.Done():
+				return
+			case c.read <- msg:
+			}
 		}
 	}
 }
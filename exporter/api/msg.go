@@ -13,6 +13,8 @@ type Message struct {
 	Filter MessageFilter `json:"filter"`
 	// Values holds the results, optional as it's relevant for response
 	Data interface{} `json:"data,omitempty"`
+	// Scope is used for matching stream subscriptions, it is not sent
+	Scope *MessageScope `json:"-"`
 }
 
 // MessageFilter is a criteria for query in request messages and projection in responses
@@ -51,6 +53,10 @@ const (
 	RoleAggregator DutyRole = "AGGREGATOR"
 	// RoleProposer is an enum for proposer role
 	RoleProposer DutyRole = "PROPOSER"
+	// RoleSyncCommittee is an enum for sync committee role
+	RoleSyncCommittee DutyRole = "SYNC_COMMITTEE"
+	// RoleSyncCommitteeContribution is an enum for sync committee contribution role
+	RoleSyncCommitteeContribution DutyRole = "SYNC_COMMITTEE_CONTRIBUTION"
 )
 
 // ValidatorsMessage represents message for validators response
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/bloxapp/ssv/utils/tasks"
 	"github.com/gorilla/websocket"
 	"github.com/prysmaticlabs/prysm/async/event"
@@ -19,6 +21,7 @@ type WebSocketServer interface {
 	Start(addr string) error
 	BroadcastFeed() *event.Feed
 	UseQueryHandler(handler QueryMessageHandler)
+	UseHTTPHandler(pattern string, handler http.Handler)
 }
 
 // wsServer is an implementation of WebSocketServer
@@ -55,6 +58,11 @@ func (ws *wsServer) UseQueryHandler(handler QueryMessageHandler) {
 	ws.handler = handler
 }
 
+// UseHTTPHandler registers a plain http end point, which is served alongside the websocket end points
+func (ws *wsServer) UseHTTPHandler(pattern string, handler http.Handler) {
+	ws.router.Handle(pattern, handler)
+}
+
 // Start starts the websocket server and the broadcaster
 func (ws *wsServer) Start(addr string) error {
 	ws.RegisterHandler("/query", ws.handleQuery)
@@ -110,6 +118,7 @@ func (ws *wsServer) handleQuery(conn *websocket.Conn) {
 	cid := ConnectionID(conn)
 	logger := ws.logger.With(zap.String("cid", cid))
 	logger.Debug("handles query requests")
+	conn.SetReadLimit(maxMessageSize)
 
 	for {
 		if ws.ctx.Err() != nil {
@@ -149,7 +158,7 @@ func (ws *wsServer) handleStream(wsc *websocket.Conn) {
 	defer logger.Debug("stream handler done")
 
 	ctx, cancel := context.WithCancel(ws.ctx)
-	c := newConn(ctx, logger, wsc, cid, sendTimeout, ws.withPing)
+	c := newConn(ctx, logger, wsc, cid, sendTimeout, maxStreamMessageSize, ws.withPing)
 	defer cancel()
 
 	if !ws.broadcaster.Register(c) {
@@ -159,6 +168,35 @@ func (ws *wsServer) handleStream(wsc *websocket.Conn) {
 	defer ws.broadcaster.Deregister(c)
 
 	go c.ReadLoop()
+	go ws.handleSubscriptions(logger, c)
 
 	c.WriteLoop()
 }
+
+// handleSubscriptions reads subscription messages of a stream connection,
+// each message replaces the current subscription and is acknowledged with the accepted subscription
+func (ws *wsServer) handleSubscriptions(logger *zap.Logger, c Conn) {
+	for {
+		raw := c.ReadNext()
+		if raw == nil {
+			return
+		}
+		res := Message{Type: TypeSubscribe}
+		sub, err := parseSubscription(raw)
+		if err != nil {
+			logger.Debug("invalid subscription", zap.Error(err))
+			res = Message{Type: TypeError, Data: []string{fmt.Sprintf("bad request - %s", err.Error())}}
+		} else if !ws.broadcaster.Subscribe(c.ID(), sub) {
+			return
+		} else {
+			logger.Debug("subscription was updated", zap.ByteString("subscription", raw))
+			res.Data = sub
+		}
+		data, err := json.Marshal(&res)
+		if err != nil {
+			logger.Error("could not marshal subscription response", zap.Error(err))
+			continue
+		}
+		c.Send(data)
+	}
+}
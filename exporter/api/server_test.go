@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"github.com/bloxapp/ssv/exporter/storage"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 	"math/rand"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -116,6 +119,111 @@ func TestHandleStream(t *testing.T) {
 	}
 }
 
+func TestHandleStream_Subscription(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, logger, nil, mux, false).(*wsServer)
+	addr := fmt.Sprintf(":%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(addr))
+	}()
+	// sleep so setup will be finished
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: addr, Path: "/stream"}
+	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = c.Close()
+	}()
+	read := func() Message {
+		require.NoError(t, c.SetReadDeadline(time.Now().Add(time.Second)))
+		var msg Message
+		require.NoError(t, c.ReadJSON(&msg))
+		return msg
+	}
+
+	require.NoError(t, c.WriteJSON(Message{Type: TypeSubscribe, Data: Subscription{Types: []MessageType{"xxx"}}}))
+	require.Equal(t, TypeError, read().Type)
+
+	require.NoError(t, c.WriteJSON(Message{Type: TypeSubscribe, Data: Subscription{Validators: []string{"pubkey3"}}}))
+	require.Equal(t, TypeSubscribe, read().Type)
+
+	ws.out.Send(newTestMessage())
+	msg := newTestMessage()
+	msg.Data = []storage.ValidatorInformation{
+		{PublicKey: "pubkey3"},
+	}
+	ws.out.Send(msg)
+	// only the subscribed validator is received
+	res := read()
+	require.Equal(t, TypeValidator, res.Type)
+	require.Contains(t, fmt.Sprintf("%v", res.Data), "pubkey3")
+
+	// subscription can be changed on the same connection
+	require.NoError(t, c.WriteJSON(Message{Type: TypeSubscribe, Data: Subscription{Types: []MessageType{TypeOperator}}}))
+	require.Equal(t, TypeSubscribe, read().Type)
+	ws.out.Send(newTestMessage())
+	msg = newTestMessage()
+	msg.Type = TypeOperator
+	msg.Data = []registrystorage.OperatorInformation{
+		{PublicKey: "pubkey-operator"},
+	}
+	ws.out.Send(msg)
+	require.Equal(t, TypeOperator, read().Type)
+}
+
+func TestReadLimit(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, logger, func(nm *NetworkMessage) {}, mux, false).(*wsServer)
+	addr := fmt.Sprintf(":%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(addr))
+	}()
+	// sleep so setup will be finished
+	time.Sleep(100 * time.Millisecond)
+
+	dial := func(path string) *websocket.Conn {
+		u := url.URL{Scheme: "ws", Host: addr, Path: path}
+		c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		require.NoError(t, err)
+		require.NoError(t, c.SetReadDeadline(time.Now().Add(time.Second)))
+		return c
+	}
+	// subscription of many validators, larger than the limit of queries
+	var validators []string
+	for i := 0; i < 100; i++ {
+		validators = append(validators, fmt.Sprintf("%096d", i))
+	}
+
+	t.Run("stream", func(t *testing.T) {
+		c := dial("/stream")
+		defer func() {
+			_ = c.Close()
+		}()
+		require.NoError(t, c.WriteJSON(Message{Type: TypeSubscribe, Data: Subscription{Validators: validators}}))
+		var msg Message
+		require.NoError(t, c.ReadJSON(&msg))
+		require.Equal(t, TypeSubscribe, msg.Type)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		c := dial("/query")
+		defer func() {
+			_ = c.Close()
+		}()
+		require.NoError(t, c.WriteJSON(Message{Type: TypeValidator, Filter: MessageFilter{PublicKey: strings.Join(validators, ",")}}))
+		var msg Message
+		err := c.ReadJSON(&msg)
+		require.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig))
+	})
+}
+
 func newTestMessage() Message {
 	return Message{
 		Type:   TypeValidator,
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bloxapp/ssv/exporter/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// TypeSubscribe is an enum for subscription messages, sent by stream clients to filter the messages they receive
+const TypeSubscribe MessageType = "subscribe"
+
+// Subscription is a filter of stream messages, empty fields are not filtered.
+// types and roles must match, while the message must relate to one of the given validators, operator or owner
+type Subscription struct {
+	// Types are the types of the requested messages
+	Types []MessageType `json:"types,omitempty"`
+	// Roles are the duty roles of the requested decided messages
+	Roles []DutyRole `json:"roles,omitempty"`
+	// Validators are the public keys (hex) of the requested validators
+	Validators []string `json:"validators,omitempty"`
+	// Operator is the public key of the requested operator
+	Operator string `json:"operator,omitempty"`
+	// OwnerAddress is the address of the requested owner
+	OwnerAddress string `json:"ownerAddress,omitempty"`
+
+	validators map[string]bool
+}
+
+// MessageScope holds the owner and operators of a stream message,
+// used for matching subscriptions of data that doesn't contain those
+type MessageScope struct {
+	OwnerAddress string
+	Operators    []string
+}
+
+// subscriptionMessage is the message that is sent by stream clients in order to (re)subscribe
+type subscriptionMessage struct {
+	Type MessageType   `json:"type"`
+	Data *Subscription `json:"data"`
+}
+
+// parseSubscription parses and validates the given subscription message
+func parseSubscription(raw []byte) (*Subscription, error) {
+	var msg subscriptionMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, errors.Wrap(err, "could not parse message")
+	}
+	if msg.Type != TypeSubscribe {
+		return nil, errors.Errorf("unknown message type '%s'", msg.Type)
+	}
+	sub := msg.Data
+	if sub == nil {
+		sub = &Subscription{}
+	}
+	if err := sub.init(); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// init validates the subscription and prepares it for matching
+func (s *Subscription) init() error {
+	for _, t := range s.Types {
+		switch t {
+		case TypeValidator, TypeOperator, TypeDecided:
+		default:
+			return errors.Errorf("unknown type '%s'", t)
+		}
+	}
+	for _, r := range s.Roles {
+		switch r {
+		case RoleAttester, RoleAggregator, RoleProposer, RoleSyncCommittee, RoleSyncCommitteeContribution:
+		default:
+			return errors.Errorf("unknown role '%s'", r)
+		}
+	}
+	if len(s.OwnerAddress) > 0 && !common.IsHexAddress(s.OwnerAddress) {
+		return errors.Errorf("invalid owner address '%s'", s.OwnerAddress)
+	}
+	s.validators = make(map[string]bool, len(s.Validators))
+	for _, pk := range s.Validators {
+		s.validators[normalizeValidatorPubKey(pk)] = true
+	}
+	return nil
+}
+
+// Match returns true if the given message should be sent to the subscriber
+func (s *Subscription) Match(msg Message) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.Types) > 0 && !containsType(s.Types, msg.Type) {
+		return false
+	}
+	if msg.Type == TypeDecided && len(s.Roles) > 0 && !containsRole(s.Roles, msg.Filter.Role) {
+		return false
+	}
+	if len(s.validators) == 0 && len(s.Operator) == 0 && len(s.OwnerAddress) == 0 {
+		return true
+	}
+	if msg.Scope != nil {
+		if s.matchOwner(msg.Scope.OwnerAddress) {
+			return true
+		}
+		for _, op := range msg.Scope.Operators {
+			if s.matchOperator(op) {
+				return true
+			}
+		}
+	}
+	switch data := msg.Data.(type) {
+	case []storage.ValidatorInformation:
+		for _, vi := range data {
+			if s.matchValidator(vi.PublicKey) {
+				return true
+			}
+			for _, op := range vi.Operators {
+				if s.matchOperator(op.PublicKey) {
+					return true
+				}
+			}
+		}
+	case []registrystorage.OperatorInformation:
+		for _, oi := range data {
+			if s.matchOperator(oi.PublicKey) || s.matchOwner(oi.OwnerAddress.String()) {
+				return true
+			}
+		}
+	default:
+		if msg.Type == TypeDecided {
+			return s.matchValidator(msg.Filter.PublicKey)
+		}
+	}
+	return false
+}
+
+func (s *Subscription) matchValidator(pk string) bool {
+	return len(pk) > 0 && s.validators[normalizeValidatorPubKey(pk)]
+}
+
+func (s *Subscription) matchOperator(pk string) bool {
+	return len(s.Operator) > 0 && s.Operator == pk
+}
+
+func (s *Subscription) matchOwner(address string) bool {
+	return len(s.OwnerAddress) > 0 && strings.EqualFold(s.OwnerAddress, address)
+}
+
+func normalizeValidatorPubKey(pk string) string {
+	return strings.TrimPrefix(strings.ToLower(pk), "0x")
+}
+
+func containsType(types []MessageType, t MessageType) bool {
+	for _, item := range types {
+		if item == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRole(roles []DutyRole, r DutyRole) bool {
+	for _, item := range roles {
+		if item == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/bloxapp/ssv/exporter/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSubscription(t *testing.T) {
+	sub, err := parseSubscription([]byte(`{"type":"subscribe","data":{"types":["decided"],"roles":["ATTESTER"],"validators":["0xAABB"]}}`))
+	require.NoError(t, err)
+	require.Equal(t, []MessageType{TypeDecided}, sub.Types)
+	require.True(t, sub.validators["aabb"])
+
+	sub, err = parseSubscription([]byte(`{"type":"subscribe"}`))
+	require.NoError(t, err)
+	require.True(t, sub.Match(Message{Type: TypeOperator}))
+
+	_, err = parseSubscription([]byte(`{"type":"validator"}`))
+	require.EqualError(t, err, "unknown message type 'validator'")
+	_, err = parseSubscription([]byte(`{"type":"subscribe","data":{"types":["xxx"]}}`))
+	require.EqualError(t, err, "unknown type 'xxx'")
+	_, err = parseSubscription([]byte(`{"type":"subscribe","data":{"roles":["xxx"]}}`))
+	require.EqualError(t, err, "unknown role 'xxx'")
+	_, err = parseSubscription([]byte(`{"type":"subscribe","data":{"ownerAddress":"xxx"}}`))
+	require.EqualError(t, err, "invalid owner address 'xxx'")
+	_, err = parseSubscription([]byte(`xxx`))
+	require.Error(t, err)
+}
+
+func TestSubscription_Match(t *testing.T) {
+	owner := "0x67Ce5c69260bd819B4e0AD13f4b873074D479811"
+	validatorMsg := Message{
+		Type: TypeValidator,
+		Data: []storage.ValidatorInformation{{
+			PublicKey: "aabb",
+			Operators: []storage.OperatorNodeLink{{ID: 1, PublicKey: "op1"}},
+		}},
+		Scope: &MessageScope{OwnerAddress: owner},
+	}
+	operatorMsg := Message{
+		Type: TypeOperator,
+		Data: []registrystorage.OperatorInformation{{PublicKey: "op2", OwnerAddress: common.HexToAddress(owner)}},
+	}
+	decidedMsg := Message{
+		Type:   TypeDecided,
+		Filter: MessageFilter{PublicKey: "ccdd", Role: RoleAttester},
+		Scope:  &MessageScope{Operators: []string{"op1", "op3"}},
+	}
+
+	tests := []struct {
+		name     string
+		sub      *Subscription
+		expected []bool // validator, operator, decided
+	}{
+		{"nil", nil, []bool{true, true, true}},
+		{"empty", &Subscription{}, []bool{true, true, true}},
+		{"types", &Subscription{Types: []MessageType{TypeOperator, TypeDecided}}, []bool{false, true, true}},
+		{"roles", &Subscription{Roles: []DutyRole{RoleProposer}}, []bool{true, true, false}},
+		{"validators", &Subscription{Validators: []string{"AABB", "ccdd"}}, []bool{true, false, true}},
+		{"operator of validator", &Subscription{Operator: "op1"}, []bool{true, false, true}},
+		{"operator", &Subscription{Operator: "op2"}, []bool{false, true, false}},
+		{"owner", &Subscription{OwnerAddress: "0x67ce5c69260bd819b4e0ad13f4b873074d479811"}, []bool{true, true, false}},
+		{"types and validators", &Subscription{Types: []MessageType{TypeDecided}, Validators: []string{"aabb"}}, []bool{false, false, false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.sub != nil {
+				require.NoError(t, test.sub.init())
+			}
+			require.Equal(t, test.expected[0], test.sub.Match(validatorMsg))
+			require.Equal(t, test.expected[1], test.sub.Match(operatorMsg))
+			require.Equal(t, test.expected[2], test.sub.Match(decidedMsg))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/utils/format"
+	"go.uber.org/zap"
+)
+
+// compactionLoop prunes the decided history (if retention is configured) and reclaims the space of the db in an interval
+func (exp *exporter) compactionLoop() {
+	ticker := time.NewTicker(compactionInterval)
+	defer ticker.Stop()
+	for {
+		exp.compact()
+		select {
+		case <-exp.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// compact prunes the decided history that exceeded the retention, and then runs the db garbage collection
+func (exp *exporter) compact() {
+	if exp.decidedRetention > 0 {
+		exp.pruneDecided()
+	}
+	if err := exp.db.CollectGarbage(); err != nil {
+		exp.logger.Warn("could not collect db garbage", zap.Error(err))
+	}
+}
+
+// pruneDecided removes the decided messages of all the validators, except of the last sequences to retain.
+// the exporter syncs and stores only attester decided messages, therefore other roles have nothing to prune
+func (exp *exporter) pruneDecided() {
+	shares, err := exp.validatorStorage.GetAllValidatorShares()
+	if err != nil {
+		exp.logger.Warn("could not get validators shares for pruning", zap.Error(err))
+		return
+	}
+	removed := 0
+	for _, share := range shares {
+		identifier := format.IdentifierFormat(share.PublicKey.Serialize(), beacon.RoleTypeAttester.String())
+		n, err := exp.ibftStorage.PruneDecided([]byte(identifier), exp.decidedRetention)
+		removed += n
+		if err != nil {
+			exp.logger.Warn("could not prune decided history", zap.Error(err),
+				zap.String("pubKey", share.PublicKey.SerializeToHexStr()))
+		}
+	}
+	exp.logger.Debug("decided history was pruned", zap.Int("removed", removed), zap.Uint64("retain", exp.decidedRetention))
+}
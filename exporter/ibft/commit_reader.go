@@ -103,7 +103,7 @@ func (cr *commitReader) onCommitMessage(msg *proto.SignedMessage) error {
 	}
 	if updated != nil {
 		logger.Debug("decided message was updated")
-		go cr.out.Send(newDecidedAPIMsg(updated, pkHex))
+		go cr.out.Send(newDecidedAPIMsg(updated, share))
 	}
 	return nil
 }
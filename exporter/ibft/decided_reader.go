@@ -178,7 +178,7 @@ func (r *decidedReader) handleNewDecidedMessage(msg *proto.SignedMessage) (bool,
 	}
 	logger.Debug("decided saved")
 	ibft.ReportDecided(r.validatorShare.PublicKey.SerializeToHexStr(), msg)
-	go r.out.Send(newDecidedAPIMsg(msg, r.validatorShare.PublicKey.SerializeToHexStr()))
+	go r.out.Send(newDecidedAPIMsg(msg, r.validatorShare))
 	return true, r.checkHighestDecided(msg)
 }
 
@@ -267,14 +267,19 @@ func validateMsg(msg *proto.SignedMessage, identifier []byte) error {
 	return p.Run(msg)
 }
 
-func newDecidedAPIMsg(msg *proto.SignedMessage, pk string) api.Message {
+func newDecidedAPIMsg(msg *proto.SignedMessage, share *storage.Share) api.Message {
+	operators := make([]string, len(share.Operators))
+	for i, op := range share.Operators {
+		operators[i] = string(op)
+	}
 	return api.Message{
 		Type: api.TypeDecided,
 		Filter: api.MessageFilter{
-			PublicKey: pk,
+			PublicKey: share.PublicKey.SerializeToHexStr(),
 			From:      int64(msg.Message.SeqNumber), To: int64(msg.Message.SeqNumber),
 			Role: api.RoleAttester},
-		Data: []*proto.SignedMessage{msg},
+		Data:  []*proto.SignedMessage{msg},
+		Scope: &api.MessageScope{OwnerAddress: share.OwnerAddress, Operators: operators},
 	}
 }
 
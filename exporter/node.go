@@ -3,7 +3,6 @@ package exporter
 import (
 	"context"
 	"fmt"
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/exporter/api"
@@ -30,6 +29,7 @@ const (
 	readerQueuesInterval         = 10 * time.Millisecond
 	metaDataReaderQueuesInterval = 5 * time.Second
 	metaDataBatchSize            = 25
+	compactionInterval           = time.Hour
 )
 
 // Exporter represents the main interface of this package
@@ -43,7 +43,7 @@ type Options struct {
 	Ctx context.Context
 
 	Logger     *zap.Logger
-	ETHNetwork *core.Network
+	ETHNetwork *beacon.Network
 
 	Eth1Client eth1.Client
 	Beacon     beacon.Beacon
@@ -57,6 +57,8 @@ type Options struct {
 	IbftSyncEnabled                 bool
 	CleanRegistryData               bool
 	ValidatorMetaDataUpdateInterval time.Duration
+	// DecidedRetention is the number of the last decided sequences to keep per validator, 0 keeps the full history
+	DecidedRetention uint64
 
 	UseMainTopic bool
 
@@ -67,6 +69,7 @@ type Options struct {
 // exporter is the internal implementation of Exporter interface
 type exporter struct {
 	ctx              context.Context
+	db               basedb.IDb
 	storage          storage.Storage
 	validatorStorage validatorstorage.ICollection
 	ibftStorage      collections.Iibft
@@ -85,6 +88,7 @@ type exporter struct {
 	wsAPIPort                       int
 	ibftSyncEnabled                 bool
 	validatorMetaDataUpdateInterval time.Duration
+	decidedRetention                uint64
 
 	decidedReadersQueue  tasks.Queue
 	networkReadersQueue  tasks.Queue
@@ -112,6 +116,7 @@ func New(opts Options) Exporter {
 	}
 	e := exporter{
 		ctx:                  opts.Ctx,
+		db:                   opts.DB,
 		storage:              storage.NewExporterStorage(opts.DB, opts.Logger),
 		ibftStorage:          &ibftStorage,
 		validatorStorage:     validatorStorage,
@@ -139,6 +144,7 @@ func New(opts Options) Exporter {
 		wsAPIPort:                       opts.WsAPIPort,
 		ibftSyncEnabled:                 opts.IbftSyncEnabled,
 		validatorMetaDataUpdateInterval: opts.ValidatorMetaDataUpdateInterval,
+		decidedRetention:                opts.DecidedRetention,
 		useMainTopic:                    opts.UseMainTopic,
 
 		numOfInstances: opts.NumOfInstances,
@@ -174,6 +180,7 @@ func (exp *exporter) Start() error {
 		exp.logger.Error("failed to warmup validators metadata", zap.Error(err))
 	}
 	go exp.continuouslyUpdateValidatorMetaData()
+	go exp.compactionLoop()
 
 	go exp.decidedReadersQueue.Start()
 	go exp.networkReadersQueue.Start()
@@ -183,6 +190,7 @@ func (exp *exporter) Start() error {
 	}
 
 	exp.ws.UseQueryHandler(exp.handleQueryRequests)
+	exp.registerRestAPI()
 
 	go exp.triggerAllValidators()
 
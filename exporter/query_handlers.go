@@ -6,6 +6,7 @@ import (
 
 	"github.com/bloxapp/ssv/exporter/api"
 	"github.com/bloxapp/ssv/exporter/storage"
+	"github.com/bloxapp/ssv/ibft/proto"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/collections"
 	"github.com/bloxapp/ssv/utils/format"
@@ -14,21 +15,81 @@ import (
 
 const (
 	unknownError = "unknown error"
+	// validatorNotFound is the message of a missing validator in the REST api
+	validatorNotFound = "validator not found"
 )
 
+// queryError is an error of a query, msg is the description that is returned to the client
+type queryError struct {
+	msg string
+	// notFoundMsg is set if the requested item doesn't exist, the REST api returns it instead of msg
+	notFoundMsg string
+}
+
+func (e *queryError) Error() string {
+	return e.msg
+}
+
+// queryOperators returns the operators that match the given filter
+func queryOperators(logger *zap.Logger, storage registrystorage.OperatorsCollection, filter api.MessageFilter) ([]registrystorage.OperatorInformation, *queryError) {
+	operators, err := getOperators(storage, filter)
+	if err != nil {
+		logger.Error("could not get operators", zap.Error(err))
+		return nil, &queryError{msg: "internal error - could not get operators"}
+	}
+	return operators, nil
+}
+
+// queryValidators returns the validators that match the given filter
+func queryValidators(logger *zap.Logger, s storage.ValidatorsCollection, filter api.MessageFilter) ([]storage.ValidatorInformation, *queryError) {
+	validators, err := getValidators(s, filter)
+	if err == errValidatorNotFound {
+		logger.Warn("validator not found")
+		return nil, &queryError{msg: "internal error - could not get validators", notFoundMsg: validatorNotFound}
+	}
+	if err != nil {
+		logger.Warn("failed to get validators", zap.Error(err))
+		return nil, &queryError{msg: "internal error - could not get validators"}
+	}
+	return validators, nil
+}
+
+// queryDecided returns the decided messages of the validator and role of the given filter, in the sequences range of the filter
+func queryDecided(logger *zap.Logger, validatorStorage storage.ValidatorsCollection, ibftStorage collections.Iibft, filter api.MessageFilter) ([]*proto.SignedMessage, *queryError) {
+	v, found, err := validatorStorage.GetValidatorInformation(filter.PublicKey)
+	if err != nil {
+		logger.Warn("failed to get validators", zap.Error(err))
+		return nil, &queryError{msg: "internal error - could not get validator"}
+	}
+	if !found {
+		logger.Warn("validator not found")
+		return nil, &queryError{msg: "internal error - could not find validator", notFoundMsg: validatorNotFound}
+	}
+	pkRaw, err := hex.DecodeString(v.PublicKey)
+	if err != nil {
+		logger.Warn("failed to decode validator public key", zap.Error(err))
+		return nil, &queryError{msg: "internal error - could not read validator key"}
+	}
+	identifier := format.IdentifierFormat(pkRaw, string(filter.Role))
+	msgs, err := ibftStorage.GetDecidedInRange([]byte(identifier), uint64(filter.From), uint64(filter.To))
+	if err != nil {
+		logger.Warn("failed to get decided messages", zap.Error(err))
+		return nil, &queryError{msg: "internal error - could not get decided messages"}
+	}
+	return msgs, nil
+}
+
 func handleOperatorsQuery(logger *zap.Logger, storage registrystorage.OperatorsCollection, nm *api.NetworkMessage) {
 	logger.Debug("handles operators request",
 		zap.Int64("from", nm.Msg.Filter.From),
 		zap.Int64("to", nm.Msg.Filter.To),
 		zap.String("pk", nm.Msg.Filter.PublicKey))
-	operators, err := getOperators(storage, nm.Msg.Filter)
 	res := api.Message{
 		Type:   nm.Msg.Type,
 		Filter: nm.Msg.Filter,
 	}
-	if err != nil {
-		logger.Error("could not get operators", zap.Error(err))
-		res.Data = []string{"internal error - could not get operators"}
+	if operators, err := queryOperators(logger, storage, nm.Msg.Filter); err != nil {
+		res.Data = []string{err.msg}
 	} else {
 		res.Data = operators
 	}
@@ -44,10 +105,8 @@ func handleValidatorsQuery(logger *zap.Logger, s storage.ValidatorsCollection, n
 		Type:   nm.Msg.Type,
 		Filter: nm.Msg.Filter,
 	}
-	validators, err := getValidators(s, nm.Msg.Filter)
-	if err != nil {
-		logger.Warn("failed to get validators", zap.Error(err))
-		res.Data = []string{"internal error - could not get validators"}
+	if validators, err := queryValidators(logger, s, nm.Msg.Filter); err != nil {
+		res.Data = []string{err.msg}
 	} else {
 		res.Data = validators
 	}
@@ -64,28 +123,10 @@ func handleDecidedQuery(logger *zap.Logger, validatorStorage storage.ValidatorsC
 		Type:   nm.Msg.Type,
 		Filter: nm.Msg.Filter,
 	}
-	v, found, err := validatorStorage.GetValidatorInformation(nm.Msg.Filter.PublicKey)
-	if err != nil {
-		logger.Warn("failed to get validators", zap.Error(err))
-		res.Data = []string{"internal error - could not get validator"}
-	} else if !found {
-		logger.Warn("validator not found")
-		res.Data = []string{"internal error - could not find validator"}
+	if msgs, err := queryDecided(logger, validatorStorage, ibftStorage, nm.Msg.Filter); err != nil {
+		res.Data = []string{err.msg}
 	} else {
-		pkRaw, err := hex.DecodeString(v.PublicKey)
-		if err != nil {
-			logger.Warn("failed to decode validator public key", zap.Error(err))
-			res.Data = []string{"internal error - could not read validator key"}
-		} else {
-			identifier := format.IdentifierFormat(pkRaw, string(nm.Msg.Filter.Role))
-			msgs, err := ibftStorage.GetDecidedInRange([]byte(identifier), uint64(nm.Msg.Filter.From), uint64(nm.Msg.Filter.To))
-			if err != nil {
-				logger.Warn("failed to get decided messages", zap.Error(err))
-				res.Data = []string{"internal error - could not get decided messages"}
-			} else {
-				res.Data = msgs
-			}
-		}
+		res.Data = msgs
 	}
 	nm.Msg = res
 }
@@ -39,6 +39,9 @@ func getOperators(s registrystorage.OperatorsCollection, filter api.MessageFilte
 	return operators, nil
 }
 
+// errValidatorNotFound is returned when the requested validator doesn't exist
+var errValidatorNotFound = errors.New("could not find validator")
+
 // validatorIndexSorter sorts validators by Index
 type validatorIndexSorter []storage.ValidatorInformation
 
@@ -52,7 +55,7 @@ func getValidators(s storage.ValidatorsCollection, filter api.MessageFilter) ([]
 	if len(filter.PublicKey) > 0 {
 		validator, found, err := s.GetValidatorInformation(filter.PublicKey)
 		if !found {
-			return nil, errors.New("could not find validator")
+			return nil, errValidatorNotFound
 		}
 		if err != nil {
 			return nil, errors.Wrap(err, "could not read validator")
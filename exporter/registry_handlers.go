@@ -2,6 +2,7 @@ package exporter
 
 import (
 	"encoding/hex"
+	"strings"
 
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
@@ -38,11 +39,23 @@ func (exp *exporter) listenToEth1Events(eventsFeed *event.Feed) <-chan error {
 
 // ListenToEth1Events register for eth1 events
 func (exp *exporter) handleEth1Event(e eth1.Event) error {
+	if e.Log.Removed {
+		return exp.handleOrphanedEvent(e)
+	}
 	var err error = nil
-	if validatorAddedEvent, ok := e.Data.(abiparser.ValidatorAddedEvent); ok {
-		err = exp.handleValidatorAddedEvent(validatorAddedEvent)
-	} else if operatorAddedEvent, ok := e.Data.(abiparser.OperatorAddedEvent); ok {
-		err = exp.handleOperatorAddedEvent(operatorAddedEvent)
+	switch ev := e.Data.(type) {
+	case abiparser.ValidatorAddedEvent:
+		err = exp.handleValidatorAddedEvent(ev)
+	case abiparser.ValidatorUpdatedEvent:
+		err = exp.handleValidatorUpdatedEvent(ev)
+	case abiparser.ValidatorDeletedEvent:
+		err = exp.handleValidatorDeletedEvent(ev)
+	case abiparser.OperatorAddedEvent:
+		err = exp.handleOperatorAddedEvent(ev)
+	case abiparser.OperatorDeletedEvent:
+		err = exp.handleOperatorDeletedEvent(ev)
+	case abiparser.AccountLiquidatedEvent:
+		err = exp.handleAccountLiquidatedEvent(ev)
 	}
 	return err
 }
@@ -82,6 +95,7 @@ func (exp *exporter) handleValidatorAddedEvent(event abiparser.ValidatorAddedEve
 			Type:   api.TypeValidator,
 			Filter: api.MessageFilter{From: vi.Index, To: vi.Index},
 			Data:   []storage.ValidatorInformation{*vi},
+			Scope:  &api.MessageScope{OwnerAddress: event.OwnerAddress.String()},
 		})
 		logger.Debug("msg was sent on outbound feed", zap.Int("num of subscribers", n))
 	}()
@@ -121,6 +135,120 @@ func (exp *exporter) handleOperatorAddedEvent(event abiparser.OperatorAddedEvent
 	return nil
 }
 
+// handleValidatorUpdatedEvent updates the share and the operators of the given validator
+func (exp *exporter) handleValidatorUpdatedEvent(event abiparser.ValidatorUpdatedEvent) error {
+	validatorAddedEvent := abiparser.ValidatorAddedEvent(event)
+	pubKeyHex := hex.EncodeToString(event.PublicKey)
+	logger := exp.logger.With(zap.String("eventType", "ValidatorUpdated"), zap.String("pubKey", pubKeyHex))
+	logger.Info("validator updated event")
+	validatorShare, _, err := validator.ShareFromValidatorAddedEvent(validatorAddedEvent, "")
+	if err != nil {
+		return errors.Wrap(err, "could not create a share from ValidatorUpdatedEvent")
+	}
+	// keep the metadata of the existing share
+	existingShare, found, err := exp.validatorStorage.GetValidatorShare(event.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get validator share")
+	}
+	if !found {
+		// unknown validator, handled as a new one
+		return exp.handleValidatorAddedEvent(validatorAddedEvent)
+	}
+	validatorShare.Metadata = existingShare.Metadata
+	if err := exp.validatorStorage.SaveValidatorShare(validatorShare); err != nil {
+		return errors.Wrap(err, "failed to save validator share")
+	}
+	vi, err := toValidatorInformation(validatorAddedEvent)
+	if err != nil {
+		return errors.Wrap(err, "could not create ValidatorInformation")
+	}
+	if err := exp.storage.UpdateValidatorInformation(vi); err != nil {
+		return errors.Wrap(err, "failed to update validator information")
+	}
+	go func() {
+		n := exp.ws.BroadcastFeed().Send(api.Message{
+			Type:   api.TypeValidator,
+			Filter: api.MessageFilter{From: vi.Index, To: vi.Index},
+			Data:   []storage.ValidatorInformation{*vi},
+			Scope:  &api.MessageScope{OwnerAddress: validatorAddedEvent.OwnerAddress.String()},
+		})
+		logger.Debug("msg was sent on outbound feed", zap.Int("num of subscribers", n))
+	}()
+	return nil
+}
+
+// handleOrphanedEvent rolls back an event from a block that was orphaned by an eth1 reorg,
+// added (or updated) validators and operators are removed. removed (or replaced) data is restored afterwards,
+// as the eth1 client fires the preceding events of the affected validators and operators
+func (exp *exporter) handleOrphanedEvent(e eth1.Event) error {
+	logger := exp.logger.With(zap.String("txHash", e.Log.TxHash.Hex()), zap.Uint64("blockNumber", e.Log.BlockNumber))
+	switch ev := e.Data.(type) {
+	case abiparser.ValidatorAddedEvent:
+		logger.Info("rolling back orphaned validator added event", zap.String("pubKey", hex.EncodeToString(ev.PublicKey)))
+		return exp.removeValidator(ev.PublicKey)
+	case abiparser.ValidatorUpdatedEvent:
+		logger.Info("rolling back orphaned validator updated event", zap.String("pubKey", hex.EncodeToString(ev.PublicKey)))
+		return exp.removeValidator(ev.PublicKey)
+	case abiparser.OperatorAddedEvent:
+		logger.Info("rolling back orphaned operator added event", zap.String("pubKey", string(ev.PublicKey)))
+		if err := exp.storage.DeleteOperatorInformation(string(ev.PublicKey)); err != nil {
+			return errors.Wrap(err, "failed to delete operator information")
+		}
+	default:
+		logger.Debug("orphaned event was not rolled back, removed data is restored by the eth1 client")
+	}
+	return nil
+}
+
+// handleValidatorDeletedEvent removes the share and information of the given validator
+func (exp *exporter) handleValidatorDeletedEvent(event abiparser.ValidatorDeletedEvent) error {
+	pubKeyHex := hex.EncodeToString(event.PublicKey)
+	exp.logger.Info("validator deleted event",
+		zap.String("eventType", "ValidatorDeleted"), zap.String("pubKey", pubKeyHex))
+	return exp.removeValidator(event.PublicKey)
+}
+
+// handleOperatorDeletedEvent removes the information of the given operator
+func (exp *exporter) handleOperatorDeletedEvent(event abiparser.OperatorDeletedEvent) error {
+	exp.logger.Info("operator deleted event", zap.String("eventType", "OperatorDeleted"),
+		zap.String("pubKey", string(event.PublicKey)))
+	if err := exp.storage.DeleteOperatorInformation(string(event.PublicKey)); err != nil {
+		return errors.Wrap(err, "failed to delete operator information")
+	}
+	return nil
+}
+
+// handleAccountLiquidatedEvent removes all the validators of the liquidated owner
+func (exp *exporter) handleAccountLiquidatedEvent(event abiparser.AccountLiquidatedEvent) error {
+	logger := exp.logger.With(zap.String("eventType", "AccountLiquidated"),
+		zap.String("ownerAddress", event.OwnerAddress.String()))
+	logger.Info("account liquidated event")
+	shares, err := exp.validatorStorage.GetAllValidatorShares()
+	if err != nil {
+		return errors.Wrap(err, "could not get validators shares")
+	}
+	for _, share := range shares {
+		if !strings.EqualFold(share.OwnerAddress, event.OwnerAddress.String()) {
+			continue
+		}
+		if err := exp.removeValidator(share.PublicKey.Serialize()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeValidator removes the share and information of the given validator
+func (exp *exporter) removeValidator(pubKey []byte) error {
+	if err := exp.validatorStorage.DeleteValidatorShare(pubKey); err != nil {
+		return errors.Wrap(err, "failed to delete validator share")
+	}
+	if err := exp.storage.DeleteValidatorInformation(hex.EncodeToString(pubKey)); err != nil {
+		return errors.Wrap(err, "failed to delete validator information")
+	}
+	return nil
+}
+
 // toValidatorInformation converts raw event to ValidatorInformation
 func toValidatorInformation(validatorAddedEvent abiparser.ValidatorAddedEvent) (*storage.ValidatorInformation, error) {
 	pubKey := &bls.PublicKey{}
@@ -0,0 +1,217 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bloxapp/ssv/exporter/api"
+	"github.com/bloxapp/ssv/exporter/storage"
+	"github.com/bloxapp/ssv/ibft/proto"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// restDefaultPageSize is the amount of items that are returned when the range end (to) was not requested
+	restDefaultPageSize = 100
+	// restMaxPageSize is the max amount of items that can be requested in a single page
+	restMaxPageSize = 1000
+	// restCacheMaxAge is the amount of seconds that responses can be cached (a slot)
+	restCacheMaxAge = 12
+
+	restOperatorsPath  = "/v1/operators"
+	restValidatorsPath = "/v1/validators"
+)
+
+// restRoles are the known roles of decided messages, mapped to whether they can be requested.
+// the exporter syncs and stores only attester decided messages, therefore other roles are not supported
+var restRoles = map[api.DutyRole]bool{
+	api.RoleAttester:                  true,
+	api.RoleAggregator:                false,
+	api.RoleProposer:                  false,
+	api.RoleSyncCommittee:             false,
+	api.RoleSyncCommitteeContribution: false,
+}
+
+// registerRestAPI registers the REST end points, which serve the same data as the query websocket end point:
+//
+//	GET /v1/operators?from=&to=
+//	GET /v1/operators/{publicKey}
+//	GET /v1/validators?from=&to=
+//	GET /v1/validators/{publicKey}
+//	GET /v1/validators/{publicKey}/decided?role=&from=&to=
+func (exp *exporter) registerRestAPI() {
+	exp.ws.UseHTTPHandler(restOperatorsPath, http.HandlerFunc(exp.handleRestOperators))
+	exp.ws.UseHTTPHandler(restOperatorsPath+"/", http.HandlerFunc(exp.handleRestOperators))
+	exp.ws.UseHTTPHandler(restValidatorsPath, http.HandlerFunc(exp.handleRestValidators))
+	exp.ws.UseHTTPHandler(restValidatorsPath+"/", http.HandlerFunc(exp.handleRestValidators))
+}
+
+// handleRestOperators returns a page of operators (by index), or a single operator by its public key
+func (exp *exporter) handleRestOperators(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeRestError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	pk := strings.Trim(strings.TrimPrefix(req.URL.Path, restOperatorsPath), "/")
+	filter := api.MessageFilter{PublicKey: pk}
+	if len(pk) == 0 {
+		var err error
+		if filter, err = parseRestRange(req); err != nil {
+			writeRestError(res, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	operators, qerr := queryOperators(exp.logger, exp.storage, filter)
+	if qerr != nil {
+		writeRestQueryError(res, qerr)
+		return
+	}
+	if operators == nil {
+		operators = []registrystorage.OperatorInformation{}
+	}
+	if len(pk) > 0 && len(operators) == 0 {
+		writeRestError(res, http.StatusNotFound, "operator not found")
+		return
+	}
+	exp.writeRestResponse(res, req, api.Message{Type: api.TypeOperator, Filter: filter, Data: operators}, len(operators))
+}
+
+// handleRestValidators returns a page of validators (by index), a single validator by its public key,
+// or the decided messages of a validator
+func (exp *exporter) handleRestValidators(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeRestError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, restValidatorsPath), "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case len(path) == 0:
+		filter, err := parseRestRange(req)
+		if err != nil {
+			writeRestError(res, http.StatusBadRequest, err.Error())
+			return
+		}
+		exp.writeRestValidators(res, req, filter)
+	case len(parts) == 1:
+		exp.writeRestValidators(res, req, api.MessageFilter{PublicKey: parts[0]})
+	case len(parts) == 2 && parts[1] == "decided":
+		exp.writeRestDecided(res, req, parts[0])
+	default:
+		writeRestError(res, http.StatusNotFound, "not found")
+	}
+}
+
+func (exp *exporter) writeRestValidators(res http.ResponseWriter, req *http.Request, filter api.MessageFilter) {
+	validators, qerr := queryValidators(exp.logger, exp.storage, filter)
+	if qerr != nil {
+		writeRestQueryError(res, qerr)
+		return
+	}
+	if validators == nil {
+		validators = []storage.ValidatorInformation{}
+	}
+	exp.writeRestResponse(res, req, api.Message{Type: api.TypeValidator, Filter: filter, Data: validators}, len(validators))
+}
+
+func (exp *exporter) writeRestDecided(res http.ResponseWriter, req *http.Request, pk string) {
+	filter, err := parseRestRange(req)
+	if err != nil {
+		writeRestError(res, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.PublicKey = pk
+	filter.Role = api.RoleAttester
+	if role := req.URL.Query().Get("role"); len(role) > 0 {
+		filter.Role = api.DutyRole(role)
+	}
+	supported, known := restRoles[filter.Role]
+	if !known {
+		writeRestError(res, http.StatusBadRequest, fmt.Sprintf("unknown role '%s'", filter.Role))
+		return
+	}
+	if !supported {
+		writeRestError(res, http.StatusBadRequest, fmt.Sprintf("role '%s' is not supported, only %s decided messages are stored", filter.Role, api.RoleAttester))
+		return
+	}
+	msgs, qerr := queryDecided(exp.logger, exp.storage, exp.ibftStorage, filter)
+	if qerr != nil {
+		writeRestQueryError(res, qerr)
+		return
+	}
+	if msgs == nil {
+		msgs = []*proto.SignedMessage{}
+	}
+	exp.writeRestResponse(res, req, api.Message{Type: api.TypeDecided, Filter: filter, Data: msgs}, len(msgs))
+}
+
+// writeRestResponse writes the given message, if a full page of a range was returned,
+// a link to the next page is added (Link header)
+func (exp *exporter) writeRestResponse(res http.ResponseWriter, req *http.Request, msg api.Message, count int) {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		exp.logger.Error("could not marshal rest response", zap.Error(err))
+		writeRestError(res, http.StatusInternalServerError, "internal error - could not marshal response")
+		return
+	}
+	if len(msg.Filter.PublicKey) == 0 || msg.Type == api.TypeDecided {
+		if size := msg.Filter.To - msg.Filter.From + 1; int64(count) >= size {
+			query := req.URL.Query()
+			query.Set("from", strconv.FormatInt(msg.Filter.To+1, 10))
+			query.Set("to", strconv.FormatInt(msg.Filter.To+size, 10))
+			res.Header().Set("Link", fmt.Sprintf("<%s?%s>; rel=\"next\"", req.URL.Path, query.Encode()))
+		}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", restCacheMaxAge))
+	if _, err := res.Write(raw); err != nil {
+		exp.logger.Error("could not write rest response", zap.Error(err))
+	}
+}
+
+// writeRestQueryError writes the error of a query with the matching status
+func writeRestQueryError(res http.ResponseWriter, qerr *queryError) {
+	if len(qerr.notFoundMsg) > 0 {
+		writeRestError(res, http.StatusNotFound, qerr.notFoundMsg)
+		return
+	}
+	writeRestError(res, http.StatusInternalServerError, qerr.msg)
+}
+
+// writeRestError writes an error message (as in the websocket api) with the given status
+func writeRestError(res http.ResponseWriter, status int, msg string) {
+	raw, _ := json.Marshal(api.Message{Type: api.TypeError, Data: []string{msg}})
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_, _ = res.Write(raw)
+}
+
+// parseRestRange parses the range (from and to, inclusive) of the given request
+func parseRestRange(req *http.Request) (api.MessageFilter, error) {
+	query := req.URL.Query()
+	filter := api.MessageFilter{}
+	if from := query.Get("from"); len(from) > 0 {
+		val, err := strconv.ParseInt(from, 10, 64)
+		if err != nil || val < 0 {
+			return filter, errors.Errorf("invalid from '%s'", from)
+		}
+		filter.From = val
+	}
+	filter.To = filter.From + restDefaultPageSize - 1
+	if to := query.Get("to"); len(to) > 0 {
+		val, err := strconv.ParseInt(to, 10, 64)
+		if err != nil || val < filter.From {
+			return filter, errors.Errorf("invalid to '%s'", to)
+		}
+		filter.To = val
+	}
+	if filter.To-filter.From+1 > restMaxPageSize {
+		return filter, errors.Errorf("range is too large, max page size is %d", restMaxPageSize)
+	}
+	return filter, nil
+}
@@ -0,0 +1,184 @@
+package exporter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/exporter/api"
+	"github.com/bloxapp/ssv/exporter/storage"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/ibft/sync"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+type restTestResponse struct {
+	Type   api.MessageType   `json:"type"`
+	Filter api.MessageFilter `json:"filter"`
+	Data   json.RawMessage   `json:"data"`
+}
+
+func TestRestAPI_Validators(t *testing.T) {
+	db, l, done := newDBAndLoggerForTest()
+	defer done()
+	s, ibftStorage := newStorageForTest(db, l)
+	exp := &exporter{logger: l, storage: s, ibftStorage: ibftStorage}
+
+	for _, pk := range []string{"01010101", "02020202", "03030303"} {
+		require.NoError(t, s.SaveValidatorInformation(&storage.ValidatorInformation{
+			PublicKey: pk,
+			Operators: getMockOperatorLinks(),
+		}))
+	}
+
+	t.Run("full page", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators?from=0&to=1")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "</v1/validators?from=2&to=3>; rel=\"next\"", rr.Header().Get("Link"))
+		res, validators := restTestValidators(t, rr)
+		require.Equal(t, api.TypeValidator, res.Type)
+		require.Equal(t, int64(1), res.Filter.To)
+		require.Len(t, validators, 2)
+		require.Equal(t, "01010101", validators[0].PublicKey)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Empty(t, rr.Header().Get("Link"))
+		res, validators := restTestValidators(t, rr)
+		require.Equal(t, int64(restDefaultPageSize-1), res.Filter.To)
+		require.Len(t, validators, 3)
+	})
+
+	t.Run("by public key", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators/03030303")
+		require.Equal(t, http.StatusOK, rr.Code)
+		_, validators := restTestValidators(t, rr)
+		require.Len(t, validators, 1)
+		require.Equal(t, int64(2), validators[0].Index)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators/xxx")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		requireRestError(t, rr, "validator not found")
+	})
+
+	t.Run("bad range", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators?from=5&to=1")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		requireRestError(t, rr, "invalid to '1'")
+
+		rr = restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators?to=5000")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("bad method", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodPost, "/v1/validators")
+		require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestRestAPI_Operators(t *testing.T) {
+	db, l, done := newDBAndLoggerForTest()
+	defer done()
+	s, ibftStorage := newStorageForTest(db, l)
+	exp := &exporter{logger: l, storage: s, ibftStorage: ibftStorage}
+
+	rr := restTestRequest(t, exp.handleRestOperators, http.MethodGet, "/v1/operators")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var res restTestResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Equal(t, api.TypeOperator, res.Type)
+	require.Equal(t, "[]", string(res.Data))
+
+	rr = restTestRequest(t, exp.handleRestOperators, http.MethodGet, "/v1/operators/01010101")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	requireRestError(t, rr, "operator not found")
+}
+
+func TestRestAPI_Decided(t *testing.T) {
+	db, l, done := newDBAndLoggerForTest()
+	defer done()
+	s, ibftStorage := newStorageForTest(db, l)
+	exp := &exporter{logger: l, storage: s, ibftStorage: ibftStorage}
+	_ = bls.Init(bls.BLS12_381)
+
+	sks, _ := sync.GenerateNodes(4)
+	pk := sks[1].GetPublicKey()
+	identifier := format.IdentifierFormat(pk.Serialize(), beacon.RoleTypeAttester.String())
+	for _, d := range sync.DecidedArr(t, 20, sks, []byte(identifier)) {
+		require.NoError(t, ibftStorage.SaveDecided(d))
+	}
+	require.NoError(t, s.SaveValidatorInformation(&storage.ValidatorInformation{
+		PublicKey: pk.SerializeToHexStr(),
+	}))
+	path := "/v1/validators/" + pk.SerializeToHexStr() + "/decided"
+
+	t.Run("valid range", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, path+"?from=5&to=9")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Header().Get("Link"), "from=10")
+		var res restTestResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		require.Equal(t, api.RoleAttester, res.Filter.Role)
+		var msgs []*proto.SignedMessage
+		require.NoError(t, json.Unmarshal(res.Data, &msgs))
+		require.Len(t, msgs, 5)
+		require.Equal(t, uint64(5), msgs[0].Message.SeqNumber)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, path+"?role=XXX")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		requireRestError(t, rr, "unknown role 'XXX'")
+	})
+
+	t.Run("unsupported role", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, path+"?role=PROPOSER")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		requireRestError(t, rr, "role 'PROPOSER' is not supported, only ATTESTER decided messages are stored")
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, "/v1/validators/xxx/decided")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		requireRestError(t, rr, "validator not found")
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		rr := restTestRequest(t, exp.handleRestValidators, http.MethodGet, path+"/xxx")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func restTestRequest(t *testing.T, handler http.HandlerFunc, method, url string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	return rr
+}
+
+func restTestValidators(t *testing.T, rr *httptest.ResponseRecorder) (restTestResponse, []storage.ValidatorInformation) {
+	var res restTestResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	var validators []storage.ValidatorInformation
+	require.NoError(t, json.Unmarshal(res.Data, &validators))
+	return res, validators
+}
+
+func requireRestError(t *testing.T, rr *httptest.ResponseRecorder, expected string) {
+	var res restTestResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Equal(t, api.TypeError, res.Type)
+	var errs []string
+	require.NoError(t, json.Unmarshal(res.Data, &errs))
+	require.Equal(t, []string{expected}, errs)
+}
@@ -38,6 +38,10 @@ func (s *storage) SaveOperatorInformation(operatorInformation *registrystorage.O
 	return s.operatorStore.SaveOperatorInformation(operatorInformation)
 }
 
+func (s *storage) DeleteOperatorInformation(operatorPubKey string) error {
+	return s.operatorStore.DeleteOperatorInformation(operatorPubKey)
+}
+
 func (s *storage) ListOperators(from int64, to int64) ([]registrystorage.OperatorInformation, error) {
 	return s.operatorStore.ListOperators(from, to)
 }
@@ -59,12 +63,3 @@ func NewExporterStorage(db basedb.IDb, logger *zap.Logger) Storage {
 func (s *storage) CleanRegistryData() error {
 	return s.db.RemoveAllByCollection(storagePrefix())
 }
-
-// nextIndex returns the next index for operator
-func (s *storage) nextIndex(prefix []byte) (int64, error) {
-	n, err := s.db.CountByCollection(append(storagePrefix(), prefix...))
-	if err != nil {
-		return 0, err
-	}
-	return n, err
-}
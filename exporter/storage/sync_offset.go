@@ -1,9 +1,17 @@
 package storage
 
-import "github.com/bloxapp/ssv/eth1"
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+)
 
 var (
-	syncOffsetKey = []byte("syncOffset")
+	syncOffsetKey      = []byte("syncOffset")
+	syncedBlocksPrefix = []byte("syncedBlocks/")
 )
 
 // SaveSyncOffset saves the offset
@@ -24,3 +32,39 @@ func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 	offset.SetBytes(obj.Value)
 	return offset, found, nil
 }
+
+// SaveSyncedBlock saves a block that contract events were applied from
+func (s *storage) SaveSyncedBlock(block *eth1.SyncedBlock) error {
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal synced block")
+	}
+	return s.db.Set(storagePrefix(), syncedBlockKey(block.Number), raw)
+}
+
+// GetSyncedBlocks returns the saved blocks, sorted by block number
+func (s *storage) GetSyncedBlocks() ([]*eth1.SyncedBlock, error) {
+	var blocks []*eth1.SyncedBlock
+	err := s.db.GetAll(append(storagePrefix(), syncedBlocksPrefix...), func(i int, obj basedb.Obj) error {
+		block := &eth1.SyncedBlock{}
+		if err := json.Unmarshal(obj.Value, block); err != nil {
+			return errors.Wrap(err, "could not unmarshal synced block")
+		}
+		blocks = append(blocks, block)
+		return nil
+	})
+	eth1.SortSyncedBlocks(blocks)
+	return blocks, err
+}
+
+// DeleteSyncedBlock removes the block with the given number
+func (s *storage) DeleteSyncedBlock(number uint64) error {
+	return s.db.Delete(storagePrefix(), syncedBlockKey(number))
+}
+
+func syncedBlockKey(number uint64) []byte {
+	key := make([]byte, len(syncedBlocksPrefix)+8)
+	copy(key, syncedBlocksPrefix)
+	binary.BigEndian.PutUint64(key[len(syncedBlocksPrefix):], number)
+	return key
+}
@@ -2,7 +2,10 @@ package storage
 
 import (
 	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"math/big"
@@ -25,6 +28,30 @@ func TestExporterStorage_SaveAndGetSyncOffset(t *testing.T) {
 	require.Zero(t, offset.Cmp(o))
 }
 
+func TestExporterStorage_SyncedBlocks(t *testing.T) {
+	s, done := newStorageForTest()
+	require.NotNil(t, s)
+	defer done()
+
+	logs := []types.Log{{BlockNumber: 20, TxIndex: 1, Topics: []common.Hash{}, TxHash: common.HexToHash("0x3")}}
+	require.NoError(t, s.SaveSyncedBlock(&eth1.SyncedBlock{Number: 20, Hash: common.HexToHash("0x2"), Logs: logs}))
+	require.NoError(t, s.SaveSyncedBlock(&eth1.SyncedBlock{Number: 10, Hash: common.HexToHash("0x1")}))
+
+	blocks, err := s.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(10), blocks[0].Number)
+	require.Equal(t, uint64(20), blocks[1].Number)
+	require.Len(t, blocks[1].Logs, 1)
+	require.Equal(t, common.HexToHash("0x3"), blocks[1].Logs[0].TxHash)
+
+	require.NoError(t, s.DeleteSyncedBlock(20))
+	blocks, err = s.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, uint64(10), blocks[0].Number)
+}
+
 func newStorageForTest() (Storage, func()) {
 	logger := zap.L()
 	db, err := ssvstorage.GetStorageFactory(basedb.Options{
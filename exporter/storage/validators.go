@@ -3,6 +3,7 @@ package storage
 import (
 	"bytes"
 	"encoding/json"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -12,6 +13,12 @@ func validatorsPrefix() []byte {
 	return []byte("validators")
 }
 
+// validatorsIndexPrefix is the collection of validator keys by index, it is under validatorsPrefix
+// so it is removed together with the validators
+func validatorsIndexPrefix() []byte {
+	return []byte("validators_index/")
+}
+
 // ValidatorInformation represents a validator
 type ValidatorInformation struct {
 	Index     int64              `json:"index"`
@@ -23,6 +30,8 @@ type ValidatorInformation struct {
 type ValidatorsCollection interface {
 	GetValidatorInformation(validatorPubKey string) (*ValidatorInformation, bool, error)
 	SaveValidatorInformation(validatorInformation *ValidatorInformation) error
+	UpdateValidatorInformation(validatorInformation *ValidatorInformation) error
+	DeleteValidatorInformation(validatorPubKey string) error
 	ListValidators(from int64, to int64) ([]ValidatorInformation, error)
 }
 
@@ -32,21 +41,22 @@ type OperatorNodeLink struct {
 	PublicKey string `json:"publicKey"`
 }
 
-// ListValidators returns information of all the known validators
-// when 'to' equals zero, all validators will be returned
+// ListValidators returns information of the known validators with an index in the range [from, to], ordered by index
 func (s *storage) ListValidators(from int64, to int64) ([]ValidatorInformation, error) {
 	s.validatorsLock.RLock()
 	defer s.validatorsLock.RUnlock()
 
+	keys, err := registrystorage.IndexedKeys(s.db, indexPrefix(), from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read validators index")
+	}
 	var validators []ValidatorInformation
-	err := s.db.GetAll(append(storagePrefix(), validatorsPrefix()...), func(i int, obj basedb.Obj) error {
+	err = s.db.GetMany(storagePrefix(), keys, func(obj basedb.Obj) error {
 		var vi ValidatorInformation
 		if err := json.Unmarshal(obj.Value, &vi); err != nil {
 			return err
 		}
-		if vi.Index >= from && vi.Index <= to {
-			validators = append(validators, vi)
-		}
+		validators = append(validators, vi)
 		return nil
 	})
 	return validators, err
@@ -91,11 +101,21 @@ func (s *storage) SaveValidatorInformation(validatorInformation *ValidatorInform
 		// TODO: update validator information (i.e. change operator)
 		return nil
 	}
-	validatorInformation.Index, err = s.nextIndex(validatorsPrefix())
+	validatorInformation.Index, err = s.nextIndex()
 	if err != nil {
 		return errors.Wrap(err, "could not calculate next validator index")
 	}
-	err = s.saveValidatorNotSafe(validatorInformation)
+	raw, err := json.Marshal(validatorInformation)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal validator information")
+	}
+	key := validatorKey(validatorInformation.PublicKey)
+	err = s.db.Update(func(txn basedb.Txn) error {
+		if err := txn.Set(storagePrefix(), key, raw); err != nil {
+			return err
+		}
+		return txn.Set(storagePrefix(), validatorIndexKey(validatorInformation.Index), key)
+	})
 	if err != nil {
 		return err
 	}
@@ -104,6 +124,42 @@ func (s *storage) SaveValidatorInformation(validatorInformation *ValidatorInform
 	return nil
 }
 
+// UpdateValidatorInformation updates the operators of an existing validator, the index remains the same
+func (s *storage) UpdateValidatorInformation(validatorInformation *ValidatorInformation) error {
+	s.validatorsLock.Lock()
+	defer s.validatorsLock.Unlock()
+
+	info, found, err := s.getValidatorInformationNotSafe(validatorInformation.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read information from DB")
+	}
+	if !found {
+		return errors.New("validator not found")
+	}
+	validatorInformation.Index = info.Index
+	return s.saveValidatorNotSafe(validatorInformation)
+}
+
+// DeleteValidatorInformation removes the information of the given validator
+func (s *storage) DeleteValidatorInformation(validatorPubKey string) error {
+	s.validatorsLock.Lock()
+	defer s.validatorsLock.Unlock()
+
+	info, found, err := s.getValidatorInformationNotSafe(validatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read information from DB")
+	}
+	if !found {
+		return nil
+	}
+	return s.db.Update(func(txn basedb.Txn) error {
+		if err := txn.Delete(storagePrefix(), validatorKey(validatorPubKey)); err != nil {
+			return err
+		}
+		return txn.Delete(storagePrefix(), validatorIndexKey(info.Index))
+	})
+}
+
 func (s *storage) saveValidatorNotSafe(val *ValidatorInformation) error {
 	raw, err := json.Marshal(val)
 	if err != nil {
@@ -112,9 +168,24 @@ func (s *storage) saveValidatorNotSafe(val *ValidatorInformation) error {
 	return s.db.Set(storagePrefix(), validatorKey(val.PublicKey), raw)
 }
 
+// nextIndex returns the next index for validator, which is the highest existing index + 1
+// counting the objects is not enough as validators might be deleted
+func (s *storage) nextIndex() (int64, error) {
+	return registrystorage.NextIndex(s.db, indexPrefix())
+}
+
+// indexPrefix returns the prefix of the validators index collection
+func indexPrefix() []byte {
+	return append(storagePrefix(), validatorsIndexPrefix()...)
+}
+
 func validatorKey(pubKey string) []byte {
 	return bytes.Join([][]byte{
 		validatorsPrefix(),
 		[]byte(pubKey),
 	}, []byte("/"))
 }
+
+func validatorIndexKey(index int64) []byte {
+	return append(validatorsIndexPrefix(), registrystorage.IndexKey(index)...)
+}
@@ -68,7 +68,7 @@ func TestStorage_SaveAndGetValidatorInformation(t *testing.T) {
 	})
 
 	t.Run("create and get multiple validators", func(t *testing.T) {
-		i, err := s.(*storage).nextIndex(validatorsPrefix())
+		i, err := s.(*storage).nextIndex()
 		require.NoError(t, err)
 
 		vis := []ValidatorInformation{
@@ -98,6 +98,54 @@ func TestStorage_SaveAndGetValidatorInformation(t *testing.T) {
 	})
 }
 
+func TestStorage_UpdateAndDeleteValidatorInformation(t *testing.T) {
+	s, done := newStorageForTest()
+	require.NotNil(t, s)
+	defer done()
+
+	operators := []OperatorNodeLink{
+		{ID: 1, PublicKey: hex.EncodeToString([]byte{1, 1, 1, 1})},
+		{ID: 2, PublicKey: hex.EncodeToString([]byte{2, 2, 2, 2})},
+	}
+	vis := []ValidatorInformation{
+		{PublicKey: "8111b36feb8147d3f82c1a0", Operators: operators},
+		{PublicKey: "8222b36feb8147d3f82c1a0", Operators: operators},
+	}
+	for i := range vis {
+		require.NoError(t, s.SaveValidatorInformation(&vis[i]))
+	}
+
+	t.Run("update validator", func(t *testing.T) {
+		vi := ValidatorInformation{
+			PublicKey: vis[1].PublicKey,
+			Operators: operators[1:],
+		}
+		require.NoError(t, s.UpdateValidatorInformation(&vi))
+		viFromDB, found, err := s.GetValidatorInformation(vi.PublicKey)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, vis[1].Index, viFromDB.Index)
+		require.Equal(t, 1, len(viFromDB.Operators))
+	})
+
+	t.Run("update non-existing validator", func(t *testing.T) {
+		vi := ValidatorInformation{PublicKey: "dummyPK"}
+		require.EqualError(t, s.UpdateValidatorInformation(&vi), "validator not found")
+	})
+
+	t.Run("delete validator", func(t *testing.T) {
+		require.NoError(t, s.DeleteValidatorInformation(vis[0].PublicKey))
+		_, found, err := s.GetValidatorInformation(vis[0].PublicKey)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		// index is not reused after delete
+		vi := ValidatorInformation{PublicKey: "8333b36feb8147d3f82c1a0", Operators: operators}
+		require.NoError(t, s.SaveValidatorInformation(&vi))
+		require.Equal(t, int64(2), vi.Index)
+	})
+}
+
 func TestStorage_ListValidators(t *testing.T) {
 	storage, done := newStorageForTest()
 	require.NotNil(t, storage)
@@ -118,4 +166,17 @@ func TestStorage_ListValidators(t *testing.T) {
 	validators, err := storage.ListValidators(0, 0)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(validators))
+
+	validators, err = storage.ListValidators(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(validators))
+	for i, vi := range validators {
+		require.Equal(t, int64(i+1), vi.Index)
+	}
+
+	require.NoError(t, storage.DeleteValidatorInformation(validators[1].PublicKey))
+	validators, err = storage.ListValidators(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(validators))
+	require.Equal(t, int64(3), validators[1].Index)
 }
@@ -111,6 +111,10 @@ func (s *testStorage) GetHighestDecidedInstance(identifier []byte) (*proto.Signe
 	return s.highestDecided, true, nil
 }
 
+func (s *testStorage) PruneDecided(identifier []byte, retain uint64) (int, error) {
+	return 0, nil
+}
+
 func TestDecidedRequiresSync(t *testing.T) {
 	secretKeys, _ := GenerateNodes(4)
 	tests := []struct {
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"time"
 
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft"
@@ -84,6 +86,11 @@ func (km *testSigner) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (km *testSigner) RemoveShare(pubKey string) error {
+	delete(km.keys, pubKey)
+	return nil
+}
+
 func (km *testSigner) getKey(key *bls.PublicKey) *bls.SecretKey {
 	return km.keys[key.SerializeToHexStr()]
 }
@@ -103,6 +110,34 @@ func (km *testSigner) SignAttestation(data *spec.AttestationData, duty *beacon.D
 	return nil, nil, nil
 }
 
+func (km *testSigner) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (km *testSigner) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testSigner) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (km *testSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testSigner) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testSigner) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	return spec.BLSSignature{}, nil, nil
+}
+
+func (km *testSigner) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
 func db() collections.Iibft {
 	db, err := storage.GetStorageFactory(basedb.Options{
 		Type:   "badger-memory",
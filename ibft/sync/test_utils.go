@@ -141,6 +141,16 @@ func (n *TestNetwork) ReceivedSignatureChan() (<-chan *proto.SignedMessage, func
 	return nil, func() {}
 }
 
+// BroadcastPreConsensusSignature impl
+func (n *TestNetwork) BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error {
+	return nil
+}
+
+// ReceivedPreConsensusSignatureChan impl
+func (n *TestNetwork) ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func()) {
+	return nil, func() {}
+}
+
 // BroadcastDecided impl
 func (n *TestNetwork) BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error {
 	return nil
@@ -262,6 +272,11 @@ func (n *TestNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) er
 	return nil
 }
 
+// UnsubscribeFromValidatorNetwork stops listening to validator network
+func (n *TestNetwork) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	return nil
+}
+
 // AllPeers returns all connected peers for a validator PK
 func (n *TestNetwork) AllPeers(validatorPk []byte) ([]string, error) {
 	return n.peers, nil
@@ -277,6 +292,21 @@ func (n *TestNetwork) SubscribeToMainTopic() error {
 	return nil
 }
 
+// SubscribeToDKGTopic implementation
+func (n *TestNetwork) SubscribeToDKGTopic() error {
+	return nil
+}
+
+// BroadcastDKGMessage impl
+func (n *TestNetwork) BroadcastDKGMessage(msg *network.DKGMessage) error {
+	return nil
+}
+
+// ReceivedDKGMsgChan impl
+func (n *TestNetwork) ReceivedDKGMsgChan() (<-chan *network.DKGMessage, func()) {
+	return nil, func() {}
+}
+
 // NotifyOperatorID implementation
 func (n *TestNetwork) NotifyOperatorID(oid string) {
 }
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// decidedMigrationBatchSize is the amount of decided messages that are migrated in a single transaction
+const decidedMigrationBatchSize = 1000
+
+// This migration is responsible to encode the sequence numbers in the keys of decided messages as big endian
+// (instead of little endian), so decided messages are ordered by sequence number and can be iterated by range.
+// decided keys end with "decided" and the sequence number, they are verified against the sequence number of the stored message
+var migrationDecidedSequenceKeys = Migration{
+	Name: "migration_4_decided_sequence_keys",
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		suffix := []byte("decided")
+		var oldKeys, newKeys [][]byte
+		err := opt.Db.Iterate(nil, basedb.IterateOptions{}, func(i int, obj basedb.Obj) error {
+			n := len(obj.Key)
+			if n < len(suffix)+8 || !bytes.Equal(obj.Key[n-8-len(suffix):n-8], suffix) {
+				return nil
+			}
+			seq := binary.LittleEndian.Uint64(obj.Key[n-8:])
+			msg := proto.SignedMessage{}
+			if err := json.Unmarshal(obj.Value, &msg); err != nil || msg.Message == nil || msg.Message.SeqNumber != seq {
+				return nil
+			}
+			newKey := append([]byte{}, obj.Key[:n-8]...)
+			newKey = append(newKey, make([]byte, 8)...)
+			binary.BigEndian.PutUint64(newKey[n-8:], seq)
+			if bytes.Equal(newKey, obj.Key) {
+				return nil
+			}
+			oldKeys = append(oldKeys, obj.Key)
+			newKeys = append(newKeys, newKey)
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not iterate decided messages")
+		}
+
+		for start := 0; start < len(oldKeys); start += decidedMigrationBatchSize {
+			end := start + decidedMigrationBatchSize
+			if end > len(oldKeys) {
+				end = len(oldKeys)
+			}
+			err := opt.Db.Update(func(txn basedb.Txn) error {
+				for i := start; i < end; i++ {
+					obj, found, err := txn.Get(nil, oldKeys[i])
+					if err != nil {
+						return err
+					}
+					if !found {
+						continue
+					}
+					if err := txn.Set(nil, newKeys[i], obj.Value); err != nil {
+						return err
+					}
+					if err := txn.Delete(nil, oldKeys[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return errors.Wrap(err, "could not migrate decided messages")
+			}
+		}
+		opt.Logger.Debug("decided messages keys were migrated", zap.Int("count", len(oldKeys)))
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
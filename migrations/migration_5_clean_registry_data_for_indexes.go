@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// This migration is responsible to delete the exporter and operator registry data,
+// so it is synced again together with the indexes of operators and validators
+var migrationCleanRegistryDataForIndexes = Migration{
+	Name: "migration_5_clean_registry_data_for_indexes",
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		if err := opt.exporterStorage().CleanRegistryData(); err != nil {
+			return errors.Wrap(err, "could not clean exporter registry data")
+		}
+		if err := opt.nodeStorage().CleanRegistryData(); err != nil {
+			return errors.Wrap(err, "could not clean operator registry data")
+		}
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
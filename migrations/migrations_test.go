@@ -2,12 +2,16 @@ package migrations
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
 	"testing"
 
+	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
 	"github.com/bloxapp/ssv/storage/kv"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -153,3 +157,44 @@ func fakeMigration(name string, returnErr error) Migration {
 		},
 	}
 }
+
+func Test_DecidedSequenceKeys(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	prefix := []byte("attestation")
+	identifier := []byte("identifier_ATTESTER")
+	littleEndianKey := func(seq uint64) []byte {
+		key := append([]byte(fmt.Sprintf("%sdecided", identifier)), make([]byte, 8)...)
+		binary.LittleEndian.PutUint64(key[len(key)-8:], seq)
+		return key
+	}
+	for seq := uint64(0); seq < 300; seq++ {
+		value, err := json.Marshal(&proto.SignedMessage{Message: &proto.Message{Lambda: identifier, SeqNumber: seq}})
+		require.NoError(t, err)
+		require.NoError(t, opt.Db.Set(prefix, littleEndianKey(seq), value))
+	}
+	// other keys are not migrated
+	require.NoError(t, opt.Db.Set(prefix, []byte("highest"), []byte("value")))
+
+	key := []byte(migrationDecidedSequenceKeys.Name)
+	require.NoError(t, migrationDecidedSequenceKeys.Run(ctx, opt, key))
+
+	ibftStorage := collections.NewIbft(opt.Db, zap.L(), "attestation")
+	msgs, err := ibftStorage.GetDecidedInRange(identifier, 250, 299)
+	require.NoError(t, err)
+	require.Len(t, msgs, 50)
+	for i, msg := range msgs {
+		require.Equal(t, uint64(250+i), msg.Message.SeqNumber)
+	}
+	_, found, err := opt.Db.Get(prefix, littleEndianKey(1))
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = opt.Db.Get(prefix, []byte("highest"))
+	require.NoError(t, err)
+	require.True(t, found)
+	_, found, err = opt.Db.Get(migrationsPrefix, key)
+	require.NoError(t, err)
+	require.True(t, found)
+}
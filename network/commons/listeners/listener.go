@@ -16,6 +16,8 @@ type Listener struct {
 	sigCh     chan *proto.SignedMessage
 	decidedCh chan *proto.SignedMessage
 	syncCh    chan *network.SyncChanObj
+	preSigCh  chan *proto.SignedMessage
+	dkgCh     chan *network.DKGMessage
 
 	msgType network.NetworkMsg
 	id      string
@@ -31,6 +33,11 @@ func (l *Listener) SigChan() chan *proto.SignedMessage {
 	return l.sigCh
 }
 
+// PreConsensusSigChan returns the underlying pre-consensus signature channel
+func (l *Listener) PreConsensusSigChan() chan *proto.SignedMessage {
+	return l.preSigCh
+}
+
 // DecidedChan returns the underlying decided channel
 func (l *Listener) DecidedChan() chan *proto.SignedMessage {
 	return l.decidedCh
@@ -41,6 +48,11 @@ func (l *Listener) SyncChan() chan *network.SyncChanObj {
 	return l.syncCh
 }
 
+// DKGChan returns the underlying dkg channel
+func (l *Listener) DKGChan() chan *network.DKGMessage {
+	return l.dkgCh
+}
+
 // NewListener creates a new instance of listener
 func NewListener(msgType network.NetworkMsg) *Listener {
 	switch msgType {
@@ -64,6 +76,16 @@ func NewListener(msgType network.NetworkMsg) *Listener {
 			syncCh:  make(chan *network.SyncChanObj, MsgChanSize),
 			msgType: network.NetworkMsg_SyncType,
 		}
+	case network.NetworkMsg_PreConsensusSignatureType:
+		return &Listener{
+			preSigCh: make(chan *proto.SignedMessage, MsgChanSize),
+			msgType:  network.NetworkMsg_PreConsensusSignatureType,
+		}
+	case network.NetworkMsg_DKGType:
+		return &Listener{
+			dkgCh:   make(chan *network.DKGMessage, MsgChanSize),
+			msgType: network.NetworkMsg_DKGType,
+		}
 	default:
 		return nil
 	}
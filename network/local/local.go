@@ -16,9 +16,11 @@ type Local struct {
 	localPeerID        peer.ID
 	msgC               []chan *proto.SignedMessage
 	sigC               []chan *proto.SignedMessage
+	preSigC            []chan *proto.SignedMessage
 	decidedC           []chan *proto.SignedMessage
 	syncC              []chan *network.SyncChanObj
 	syncPeers          map[string]chan *network.SyncChanObj
+	dkgC               *[]chan *network.DKGMessage
 	createChannelMutex *sync.Mutex
 	streamsMut         *sync.Mutex
 	streams            map[string]network.SyncStream
@@ -29,9 +31,11 @@ func NewLocalNetwork() *Local {
 	return &Local{
 		msgC:               make([]chan *proto.SignedMessage, 0),
 		sigC:               make([]chan *proto.SignedMessage, 0),
+		preSigC:            make([]chan *proto.SignedMessage, 0),
 		decidedC:           make([]chan *proto.SignedMessage, 0),
 		syncC:              make([]chan *network.SyncChanObj, 0),
 		syncPeers:          make(map[string]chan *network.SyncChanObj),
+		dkgC:               &[]chan *network.DKGMessage{},
 		createChannelMutex: &sync.Mutex{},
 		streamsMut:         &sync.Mutex{},
 		streams:            make(map[string]network.SyncStream),
@@ -45,9 +49,11 @@ func (n *Local) CopyWithLocalNodeID(id peer.ID) *Local {
 		localPeerID:        id,
 		msgC:               n.msgC,
 		sigC:               n.sigC,
+		preSigC:            n.preSigC,
 		decidedC:           n.decidedC,
 		syncC:              n.syncC,
 		syncPeers:          n.syncPeers,
+		dkgC:               n.dkgC,
 		createChannelMutex: n.createChannelMutex,
 		streamsMut:         n.streamsMut,
 		streams:            n.streams,
@@ -95,6 +101,27 @@ func (n *Local) BroadcastSignature(topicName []byte, msg *proto.SignedMessage) e
 	return nil
 }
 
+// ReceivedPreConsensusSignatureChan returns the channel with pre-consensus signatures
+func (n *Local) ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func()) {
+	n.createChannelMutex.Lock()
+	defer n.createChannelMutex.Unlock()
+	c := make(chan *proto.SignedMessage)
+	n.preSigC = append(n.preSigC, c)
+	return c, func() {}
+}
+
+// BroadcastPreConsensusSignature broadcasts the given pre-consensus signature for the given lambda
+func (n *Local) BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error {
+	n.createChannelMutex.Lock()
+	go func() {
+		for _, c := range n.preSigC {
+			c <- msg
+		}
+		n.createChannelMutex.Unlock()
+	}()
+	return nil
+}
+
 // BroadcastDecided broadcasts a decided instance with collected signatures
 func (n *Local) BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error {
 	n.createChannelMutex.Lock()
@@ -187,6 +214,11 @@ func (n *Local) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) error {
 	return nil
 }
 
+// UnsubscribeFromValidatorNetwork stops listening to validator's network
+func (n *Local) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	return nil
+}
+
 // AllPeers returns all connected peers for a validator PK
 func (n *Local) AllPeers(validatorPk []byte) ([]string, error) {
 	ret := make([]string, 0)
@@ -211,6 +243,35 @@ func (n *Local) SubscribeToMainTopic() error {
 	return nil
 }
 
+// SubscribeToDKGTopic implementation
+func (n *Local) SubscribeToDKGTopic() error {
+	return nil
+}
+
+// ReceivedDKGMsgChan returns the channel for dkg messages
+func (n *Local) ReceivedDKGMsgChan() (<-chan *network.DKGMessage, func()) {
+	n.createChannelMutex.Lock()
+	defer n.createChannelMutex.Unlock()
+	c := make(chan *network.DKGMessage)
+	*n.dkgC = append(*n.dkgC, c)
+	return c, func() {}
+}
+
+// BroadcastDKGMessage broadcasts the given message to all nodes, including the sender.
+// messages are sent concurrently as the receivers might broadcast while handling a message
+func (n *Local) BroadcastDKGMessage(msg *network.DKGMessage) error {
+	n.createChannelMutex.Lock()
+	channels := make([]chan *network.DKGMessage, len(*n.dkgC))
+	copy(channels, *n.dkgC)
+	n.createChannelMutex.Unlock()
+	for _, c := range channels {
+		go func(c chan *network.DKGMessage) {
+			c <- msg
+		}(c)
+	}
+	return nil
+}
+
 // NotifyOperatorID implementation
 func (n *Local) NotifyOperatorID(oid string) {
 }
@@ -61,6 +61,47 @@ func TestSigMessageIndex(t *testing.T) {
 	})
 }
 
+func TestPreConsensusSigRoundIndexKey(t *testing.T) {
+	require.EqualValues(t, "pre_sig_lambda_01020304_seqNumber_2", PreConsensusSigRoundIndexKey([]byte{1, 2, 3, 4}, 2))
+}
+
+func TestPreConsensusSigMessageIndex(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		require.EqualValues(t, []string{"pre_sig_lambda_01020304_seqNumber_2"}, preConsensusSigMessageIndex()(&network.Message{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					Lambda:    []byte{1, 2, 3, 4},
+					SeqNumber: 2,
+				},
+			},
+			Type: network.NetworkMsg_PreConsensusSignatureType,
+		}))
+	})
+
+	t.Run("invalid - no lambda", func(t *testing.T) {
+		require.EqualValues(t, []string{}, preConsensusSigMessageIndex()(&network.Message{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					SeqNumber: 2,
+				},
+			},
+			Type: network.NetworkMsg_PreConsensusSignatureType,
+		}))
+	})
+
+	t.Run("invalid - wrong type", func(t *testing.T) {
+		require.EqualValues(t, []string{}, preConsensusSigMessageIndex()(&network.Message{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					Lambda:    []byte{1, 2, 3, 4},
+					SeqNumber: 2,
+				},
+			},
+			Type: network.NetworkMsg_SignatureType,
+		}))
+	})
+}
+
 func TestSyncIndexKey(t *testing.T) {
 	require.EqualValues(t, "sync_lambda_01020304", SyncIndexKey([]byte{1, 2, 3, 4}))
 }
@@ -26,6 +26,7 @@ type MessageQueue struct {
 	indexFuncs  []IndexFunc
 	queue       *cache.Cache
 	allMessages *cache.Cache
+	subscribers map[string][]chan struct{}
 }
 
 // New is the constructor of MessageQueue
@@ -34,9 +35,11 @@ func New() *MessageQueue {
 		msgMutex:    sync.RWMutex{},
 		queue:       cache.New(time.Minute*10, time.Minute*11),
 		allMessages: cache.New(time.Minute*10, time.Minute*11),
+		subscribers: make(map[string][]chan struct{}),
 		indexFuncs: []IndexFunc{
 			iBFTMessageIndex(),
 			sigMessageIndex(),
+			preConsensusSigMessageIndex(),
 			decidedMessageIndex(),
 			syncMessageIndex(),
 		},
@@ -79,6 +82,53 @@ func (q *MessageQueue) AddMessage(msg *network.Message) {
 		q.queue.SetDefault(idx, msgs)
 	}
 	q.allMessages.SetDefault(msgContainer.id, msgContainer)
+
+	for _, idx := range indexes {
+		q.notifySubscribers(idx)
+	}
+}
+
+// SubscribeToIndex returns a channel that is notified whenever a new message is added to the given index.
+// notifications are coalesced, a single notification might stand for several new messages.
+// the returned function must be called once the subscriber is done
+func (q *MessageQueue) SubscribeToIndex(index string) (<-chan struct{}, func()) {
+	q.msgMutex.Lock()
+	defer q.msgMutex.Unlock()
+
+	cn := make(chan struct{}, 1)
+	q.subscribers[index] = append(q.subscribers[index], cn)
+	return cn, func() {
+		q.unsubscribe(index, cn)
+	}
+}
+
+// notifySubscribers notifies the subscribers of the given index without blocking,
+// a subscriber that didn't consume its previous notification is not notified again
+func (q *MessageQueue) notifySubscribers(index string) {
+	for _, cn := range q.subscribers[index] {
+		select {
+		case cn <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (q *MessageQueue) unsubscribe(index string, cn chan struct{}) {
+	q.msgMutex.Lock()
+	defer q.msgMutex.Unlock()
+
+	subs := q.subscribers[index]
+	for i, sub := range subs {
+		if sub == cn {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(q.subscribers, index)
+		return
+	}
+	q.subscribers[index] = subs
 }
 
 // MessagesForIndex returns all messages for an index
@@ -106,6 +106,28 @@ func TestMessageQueue_DeleteMessagesWithIds(t *testing.T) {
 	require.Nil(t, msg)
 }
 
+func TestMessageQueue_SubscribeToIndex(t *testing.T) {
+	msgQ := New()
+	idx := SigRoundIndexKey([]byte{1, 2, 3, 4}, 1)
+	cn, done := msgQ.SubscribeToIndex(idx)
+
+	// a message of another index doesn't notify
+	msgQ.AddMessage(newNetMsg([]byte{1, 2, 3, 4}, 1, 1, network.NetworkMsg_IBFTType))
+	require.Len(t, cn, 0)
+
+	// notifications are coalesced
+	msgQ.AddMessage(newNetMsg([]byte{1, 2, 3, 4}, 1, 1, network.NetworkMsg_SignatureType))
+	msgQ.AddMessage(newNetMsg([]byte{1, 2, 3, 4}, 1, 1, network.NetworkMsg_SignatureType))
+	<-cn
+	require.Len(t, cn, 0)
+	require.Equal(t, 2, msgQ.MsgCount(idx))
+
+	done()
+	require.Len(t, msgQ.subscribers, 0)
+	msgQ.AddMessage(newNetMsg([]byte{1, 2, 3, 4}, 1, 1, network.NetworkMsg_SignatureType))
+	require.Len(t, cn, 0)
+}
+
 func newNetMsg(lambda []byte, round, seq uint64, t network.NetworkMsg) *network.Message {
 	return &network.Message{
 		SignedMessage: &proto.SignedMessage{
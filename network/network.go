@@ -11,10 +11,22 @@ import (
 type Message struct {
 	SignedMessage *proto.SignedMessage
 	SyncMessage   *SyncMessage
+	DKGMessage    *DKGMessage
 	Type          NetworkMsg
 	StreamID      string
 }
 
+// DKGMessage is a container for distributed key generation messages,
+// the data is opaque to the network and is signed by the operator key of the sender
+type DKGMessage struct {
+	// Data is the encoded message
+	Data []byte
+	// Signer is the public key (base64 encoded PEM) of the operator that sent the message
+	Signer string
+	// Signature is the signature of the sender on the data
+	Signature []byte
+}
+
 // SyncChanObj is a wrapper object for streaming of sync messages
 type SyncChanObj struct {
 	Msg      *SyncMessage
@@ -53,12 +65,16 @@ type Reader interface {
 	ReceivedMsgChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedSignatureChan returns the channel with signatures
 	ReceivedSignatureChan() (<-chan *proto.SignedMessage, func())
+	// ReceivedPreConsensusSignatureChan returns the channel with pre-consensus signatures
+	ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedDecidedChan returns the channel for decided messages
 	ReceivedDecidedChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedSyncMsgChan returns the channel for sync messages
 	ReceivedSyncMsgChan() (<-chan *SyncChanObj, func())
 	// SubscribeToValidatorNetwork subscribes and listens to validator's network
 	SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) error
+	// UnsubscribeFromValidatorNetwork stops listening to validator's network
+	UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error
 	// AllPeers returns all connected peers for a validator PK
 	AllPeers(validatorPk []byte) ([]string, error)
 	// SubscribeToMainTopic subscribes to main topic
@@ -73,6 +89,8 @@ type Broadcaster interface {
 	Broadcast(topicName []byte, msg *proto.SignedMessage) error
 	// BroadcastSignature broadcasts the given signature for the given lambda
 	BroadcastSignature(topicName []byte, msg *proto.SignedMessage) error
+	// BroadcastPreConsensusSignature broadcasts the given pre-consensus signature for the given lambda
+	BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error
 	// BroadcastDecided broadcasts a decided instance with collected signatures
 	BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error
 	// MaxBatch returns the maximum batch size for network responses
@@ -92,11 +110,23 @@ type Syncer interface {
 	RespondSyncMsg(streamID string, msg *SyncMessage) error
 }
 
+// DKG is the interface for messaging of distributed key generation ceremonies,
+// messages are propagated on a dedicated topic that is shared by all operators
+type DKG interface {
+	// SubscribeToDKGTopic subscribes to the topic of dkg messages
+	SubscribeToDKGTopic() error
+	// BroadcastDKGMessage broadcasts the given message to all operators
+	BroadcastDKGMessage(msg *DKGMessage) error
+	// ReceivedDKGMsgChan returns the channel for dkg messages
+	ReceivedDKGMsgChan() (<-chan *DKGMessage, func())
+}
+
 // Network represents the behavior of the network
 type Network interface {
 	Reader
 	Broadcaster
 	Syncer
+	DKG
 
 	// NotifyOperatorID updates the network regarding new operators joining the network
 	// TODO: find a better way to do this
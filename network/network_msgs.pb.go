@@ -32,6 +32,10 @@ const (
 	NetworkMsg_SignatureType NetworkMsg = 2
 	// SyncType is an SSV iBFT specific message that a node uses to sync up with other nodes
 	NetworkMsg_SyncType NetworkMsg = 3
+	// PreConsensusSignatureType is an SSV node specific message for broadcasting partial signatures that are needed before consensus starts on eth2 duties
+	NetworkMsg_PreConsensusSignatureType NetworkMsg = 4
+	// DKGType is an SSV node specific message for running distributed key generation ceremonies between operators
+	NetworkMsg_DKGType NetworkMsg = 5
 )
 
 var NetworkMsg_name = map[int32]string{
@@ -39,13 +43,17 @@ var NetworkMsg_name = map[int32]string{
 	1: "DecidedType",
 	2: "SignatureType",
 	3: "SyncType",
+	4: "PreConsensusSignatureType",
+	5: "DKGType",
 }
 
 var NetworkMsg_value = map[string]int32{
-	"IBFTType":      0,
-	"DecidedType":   1,
-	"SignatureType": 2,
-	"SyncType":      3,
+	"IBFTType":                  0,
+	"DecidedType":               1,
+	"SignatureType":             2,
+	"SyncType":                  3,
+	"PreConsensusSignatureType": 4,
+	"DKGType":                   5,
 }
 
 func (x NetworkMsg) String() string {
@@ -177,25 +185,26 @@ func init() {
 }
 
 var fileDescriptor_a755f4b722170306 = []byte{
-	// 307 bytes of a gzipped FileDescriptorProto
+	// 329 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0x4d, 0x4f, 0xc2, 0x40,
-	0x10, 0x86, 0x2d, 0x2d, 0x88, 0xc3, 0x87, 0x38, 0x69, 0xcc, 0xc6, 0x83, 0xa9, 0x9e, 0x1a, 0x0e,
-	0x35, 0xc1, 0xab, 0x27, 0x24, 0x20, 0x06, 0x8c, 0x59, 0x38, 0x79, 0x31, 0x0b, 0x9d, 0x14, 0x62,
-	0xba, 0x25, 0xbb, 0x4b, 0x0c, 0xff, 0xd3, 0x1f, 0x64, 0x76, 0xd9, 0xc4, 0x8f, 0xe3, 0xf3, 0xcc,
-	0xdb, 0x99, 0xbe, 0x0b, 0x28, 0xc9, 0x7c, 0x56, 0xea, 0xe3, 0xbd, 0xd4, 0x85, 0xce, 0x76, 0xaa,
-	0x32, 0x15, 0x9e, 0x7a, 0x77, 0x05, 0x3f, 0xf2, 0xf6, 0x2b, 0x80, 0xd6, 0xe2, 0x20, 0xd7, 0x73,
-	0xd2, 0x5a, 0x14, 0x84, 0x0f, 0xd0, 0x5d, 0x6c, 0x0b, 0x49, 0xb9, 0x17, 0x9a, 0x05, 0x49, 0x98,
-	0xb6, 0x06, 0xf1, 0x31, 0x9f, 0xfd, 0x19, 0xf2, 0x7f, 0x59, 0xbc, 0x06, 0x18, 0xab, 0xaa, 0x7c,
-	0x25, 0x52, 0xd3, 0x11, 0xab, 0x25, 0x41, 0x7a, 0xc6, 0x7f, 0x19, 0xbc, 0x84, 0xc6, 0x4e, 0x28,
-	0x51, 0x6a, 0x16, 0x26, 0x61, 0x1a, 0x71, 0x4f, 0xd6, 0xcf, 0x44, 0xb9, 0xca, 0x05, 0x8b, 0x92,
-	0x20, 0x6d, 0x73, 0x4f, 0x78, 0x03, 0xd1, 0xf2, 0xb0, 0x23, 0x56, 0x4f, 0x82, 0xb4, 0x3b, 0xe8,
-	0x64, 0xbe, 0x41, 0x66, 0xff, 0x98, 0xbb, 0x11, 0xc6, 0x50, 0x27, 0xa5, 0x2a, 0xc5, 0x1a, 0xee,
-	0xda, 0x11, 0xfa, 0x33, 0x80, 0x97, 0x63, 0x76, 0xae, 0x0b, 0x6c, 0x43, 0x73, 0x3a, 0x1c, 0x2f,
-	0x6d, 0xbe, 0x77, 0x82, 0xe7, 0xd0, 0x1a, 0xd1, 0x7a, 0x9b, 0x53, 0xee, 0x44, 0x80, 0x17, 0xd0,
-	0xb1, 0x3d, 0x84, 0xd9, 0x2b, 0x72, 0xaa, 0x66, 0xbf, 0xb0, 0x37, 0x1c, 0x85, 0xfd, 0x67, 0x88,
-	0x2c, 0x21, 0x42, 0x77, 0x42, 0xe6, 0x69, 0x5b, 0x6c, 0x48, 0x1b, 0xbf, 0x2d, 0x86, 0xde, 0x84,
-	0xcc, 0x54, 0x6a, 0x23, 0xe4, 0x9a, 0xb8, 0x90, 0x85, 0x5d, 0xc9, 0x20, 0x9e, 0x90, 0x99, 0x09,
-	0x43, 0xda, 0x3c, 0x6e, 0xac, 0xe4, 0xd5, 0x5e, 0xe6, 0xbd, 0xda, 0x10, 0xde, 0x9a, 0x77, 0xbe,
-	0xc6, 0xaa, 0xe1, 0xde, 0xf4, 0xfe, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x6c, 0xbd, 0xf0, 0xb7, 0xae,
-	0x01, 0x00, 0x00,
+	0x10, 0x86, 0x2d, 0x2d, 0x1f, 0x4e, 0x01, 0x71, 0xd2, 0x98, 0x6a, 0xa2, 0xa9, 0x9e, 0x1a, 0x0e,
+	0x35, 0xc1, 0xab, 0x27, 0x20, 0x54, 0x14, 0x0c, 0x29, 0x9c, 0xbc, 0x98, 0x85, 0x4e, 0x0a, 0x31,
+	0xdd, 0x92, 0xdd, 0x25, 0xca, 0xff, 0xf4, 0x07, 0x99, 0x2d, 0x9b, 0x28, 0x1e, 0xdf, 0x67, 0x9e,
+	0xc9, 0xbb, 0xb3, 0x80, 0x9c, 0xd4, 0x67, 0x21, 0x3e, 0xde, 0x73, 0x99, 0xc9, 0x68, 0x2b, 0x0a,
+	0x55, 0x60, 0xdd, 0xb0, 0x2b, 0xf8, 0x85, 0x77, 0xdf, 0x16, 0xb8, 0xf3, 0x3d, 0x5f, 0x4d, 0x49,
+	0x4a, 0x96, 0x11, 0x3e, 0x42, 0x7b, 0xbe, 0xc9, 0x38, 0xa5, 0x06, 0x48, 0xdf, 0x0a, 0xec, 0xd0,
+	0xed, 0x79, 0x07, 0x3f, 0x3a, 0x1a, 0x26, 0xff, 0x5c, 0xbc, 0x01, 0x18, 0x89, 0x22, 0x9f, 0x11,
+	0x89, 0xf1, 0xd0, 0xaf, 0x04, 0x56, 0x78, 0x9a, 0xfc, 0x21, 0x78, 0x01, 0xb5, 0x2d, 0x13, 0x2c,
+	0x97, 0xbe, 0x1d, 0xd8, 0xa1, 0x93, 0x98, 0xa4, 0xf9, 0x84, 0xe5, 0xcb, 0x94, 0xf9, 0x4e, 0x60,
+	0x85, 0xcd, 0xc4, 0x24, 0xbc, 0x05, 0x67, 0xb1, 0xdf, 0x92, 0x5f, 0x0d, 0xac, 0xb0, 0xdd, 0x6b,
+	0x45, 0xe6, 0x82, 0x48, 0xbf, 0x38, 0x29, 0x47, 0xe8, 0x41, 0x95, 0x84, 0x28, 0x84, 0x5f, 0x2b,
+	0xdb, 0x0e, 0xa1, 0xfb, 0x05, 0xf0, 0x7a, 0x70, 0xa7, 0x32, 0xc3, 0x26, 0x34, 0xc6, 0xfd, 0xd1,
+	0x42, 0xfb, 0x9d, 0x13, 0x3c, 0x03, 0x77, 0x48, 0xab, 0x4d, 0x4a, 0x69, 0x09, 0x2c, 0x3c, 0x87,
+	0x96, 0xbe, 0x83, 0xa9, 0x9d, 0xa0, 0x12, 0x55, 0xf4, 0x86, 0xee, 0x28, 0x93, 0x8d, 0xd7, 0x70,
+	0x39, 0x13, 0x34, 0x28, 0xb8, 0x24, 0x2e, 0x77, 0xf2, 0x58, 0x76, 0xd0, 0x85, 0xfa, 0xf0, 0x25,
+	0x2e, 0x43, 0xb5, 0xfb, 0x0c, 0x8e, 0xde, 0x44, 0x84, 0x76, 0x4c, 0xea, 0x69, 0x93, 0xad, 0x49,
+	0x2a, 0xd3, 0xec, 0x41, 0x27, 0x26, 0x35, 0xe6, 0x52, 0x31, 0xbe, 0xa2, 0x84, 0xf1, 0x4c, 0xd7,
+	0xfb, 0xe0, 0xc5, 0xa4, 0x26, 0x4c, 0x91, 0x54, 0x83, 0xb5, 0x86, 0x49, 0xb1, 0xe3, 0x69, 0xa7,
+	0xd2, 0x87, 0xb7, 0xc6, 0xbd, 0x39, 0x79, 0x59, 0x2b, 0xff, 0xff, 0xe1, 0x27, 0x00, 0x00, 0xff,
+	0xff, 0x5e, 0xd6, 0x06, 0x89, 0xda, 0x01, 0x00, 0x00,
 }
@@ -6,13 +6,14 @@ import (
 	"github.com/bloxapp/ssv/network/forks"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"strings"
 	"time"
 )
 
 // Config - describe the config options for p2p network
 type Config struct {
 	// yaml/env arguments
-	Enr              string        `yaml:"Enr" env:"ENR_KEY" env-description:"enr used in discovery" env-default:""`
+	Enr              string        `yaml:"Enr" env:"ENR_KEY" env-description:"enr (comma separated) used in discovery, defaults to the bootnodes of the network profile" env-default:""`
 	DiscoveryType    string        `yaml:"DiscoveryType" env:"DISCOVERY_TYPE_KEY" env-description:"Method to use in discovery" env-default:"discv5"`
 	TCPPort          int           `yaml:"TcpPort" env:"TCP_PORT" env-default:"13000"`
 	UDPPort          int           `yaml:"UdpPort" env:"UDP_PORT" env-default:"12000"`
@@ -32,6 +33,10 @@ type Config struct {
 	ExporterPeerID string `yaml:"ExporterPeerID" env:"EXPORTER_PEER_ID"  env-default:"16Uiu2HAkvaBh2xjstjs1koEx3jpBn5Hsnz7Bv8pE4SuwFySkiAuf"  env-description:"peer id of exporter"`
 
 	Fork forks.Fork
+	// Bootnodes are the ENRs of the network bootnodes, used unless Enr is configured
+	Bootnodes []string
+	// ForkVersion is the genesis fork version of the network, nodes of other networks are ignored
+	ForkVersion []byte
 
 	// objects / instances
 	HostID        peer.ID
@@ -81,21 +86,12 @@ func (nt NodeType) String() string {
 	return "unknown"
 }
 
-// TransformEnr converts defaults enr value and convert it to slice
+// TransformEnr converts the given (comma separated) enr value to slice
 func TransformEnr(enr string) []string {
 	if len(enr) == 0 {
-		// stage enr
-		//enr = "enr:-LK4QHVq6HEA2KVnAw593SRMqUOvMGlkP8Jb-qHn4yPLHx--cStvWc38Or2xLcWgDPynVxXPT9NWIEXRzrBUsLmcFkUBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhDbUHcyJc2VjcDI1NmsxoQO8KQz5L1UEXzEr-CXFFq1th0eG6gopbdul2OQVMuxfMoN0Y3CCE4iDdWRwgg-g"
-
-		// prod enr
-		//internal ip
-		//enr = "enr:-LK4QPbCB0Mw_8ji7D02OwXmqSRZe9wTmitle_cQnECIl-5GBPH9PH__eUpdeiI_t122inm62uTgO9CptbGNLKNId7gBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhArsBGGJc2VjcDI1NmsxoQO8KQz5L1UEXzEr-CXFFq1th0eG6gopbdul2OQVMuxfMoN0Y3CCE4iDdWRwgg-g"
-		//external ip
-		enr = "enr:-LK4QMmL9hLJ1csDN4rQoSjlJGE2SvsXOETfcLH8uAVrxlHaELF0u3NeKCTY2eO_X1zy5eEKcHruyaAsGNiyyG4QWUQBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhCLdu_SJc2VjcDI1NmsxoQO8KQz5L1UEXzEr-CXFFq1th0eG6gopbdul2OQVMuxfMoN0Y3CCE4iDdWRwgg-g"
-	}
-	return []string{
-		enr,
+		return nil
 	}
+	return strings.Split(enr, ",")
 }
 
 //
@@ -1,8 +1,10 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -132,11 +134,12 @@ func (n *p2pNetwork) createExtendedLocalNode(ipAddr net.IP) (*enode.LocalNode, e
 		return nil, errors.Wrap(err, "could not create node type entry")
 	}
 
-	// TODO: add fork entry once applicable
-	//localNode, err = addForkEntry(localNode, s.genesisTime, s.genesisValidatorsRoot)
-	//if err != nil {
-	//	return nil, errors.Wrap(err, "could not add eth2 fork version entry to enr")
-	//}
+	if len(n.cfg.ForkVersion) > 0 {
+		localNode, err = addForkVersionEntry(localNode, n.cfg.ForkVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not add fork version entry to enr")
+		}
+	}
 
 	// update local node to use provided host address
 	if n.cfg.HostAddress != "" {
@@ -275,11 +278,20 @@ func (n *p2pNetwork) tryNode(node *enode.Node) {
 }
 
 // isRelevantNode checks whether the given node if relevant by ENR entries.
-// a node is relevant if it fullfils one of the following:
+// nodes of other networks (by fork version) are irrelevant, otherwise a node is relevant if it fullfils one of the following:
 // - it shares a committee with the current node
 // - it is an exporter or bootnode (TODO: bootnode)
 func (n *p2pNetwork) isRelevantNode(node *enode.Node) bool {
 	where := zap.String("where", "discovery:isRelevantNode")
+	forkVersion, err := extractForkVersionEntry(node.Record())
+	if err != nil {
+		n.trace("WARNING: could not extract fork version entry", where, zap.Error(err))
+	}
+	// nodes without fork version entry are accepted, as older versions don't publish it
+	if len(forkVersion) > 0 && len(n.cfg.ForkVersion) > 0 && !bytes.Equal(forkVersion, n.cfg.ForkVersion) {
+		n.trace("node belongs to another network, skipping", where, zap.String("forkVersion", hex.EncodeToString(forkVersion)))
+		return false
+	}
 	oid, err := extractOperatorIDEntry(node.Record())
 	if err != nil {
 		n.trace("WARNING: could not extract operator id entry", where, zap.Error(err))
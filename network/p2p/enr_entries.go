@@ -69,3 +69,27 @@ func extractOperatorIDEntry(record *enr.Record) (*OperatorIDEntry, error) {
 	}
 	return oid, nil
 }
+
+// ForkVersionEntry holds the genesis fork version of the network
+type ForkVersionEntry []byte
+
+// ENRKey implements enr.Entry, returns the entry key
+func (fve ForkVersionEntry) ENRKey() string { return "forkv" }
+
+// addForkVersionEntry adds fork-version entry ('forkv') to the node
+func addForkVersionEntry(node *enode.LocalNode, forkVersion []byte) (*enode.LocalNode, error) {
+	node.Set(ForkVersionEntry(forkVersion))
+	return node, nil
+}
+
+// extractForkVersionEntry extracts the value of fork-version entry ('forkv')
+func extractForkVersionEntry(record *enr.Record) (ForkVersionEntry, error) {
+	var fve ForkVersionEntry
+	if err := record.Load(&fve); err != nil {
+		if enr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return fve, nil
+}
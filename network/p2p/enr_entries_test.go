@@ -60,3 +60,23 @@ func genPublicKey() *bls.PublicKey {
 
 	return sk.GetPublicKey()
 }
+
+func Test_ENR_ForkVersionEntry(t *testing.T) {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pk := convertFromInterfacePrivKey(priv)
+	ip, err := ipAddr()
+	require.NoError(t, err)
+	node, err := createLocalNode(pk, ip, 12000, 13000)
+	require.NoError(t, err)
+
+	forkVersion, err := extractForkVersionEntry(node.Node().Record())
+	require.NoError(t, err)
+	require.Nil(t, forkVersion)
+	node, err = addForkVersionEntry(node, []byte{0, 0, 0x10, 0x20})
+	require.NoError(t, err)
+
+	forkVersion, err = extractForkVersionEntry(node.Node().Record())
+	require.NoError(t, err)
+	require.Equal(t, ForkVersionEntry{0, 0, 0x10, 0x20}, forkVersion)
+}
@@ -10,6 +10,10 @@ import (
 // propagateSignedMsg takes an incoming message (from validator's topic)
 // and propagates it to the corresponding internal listeners
 func (n *p2pNetwork) propagateSignedMsg(cm *network.Message) {
+	if cm != nil && cm.Type == network.NetworkMsg_DKGType && cm.DKGMessage != nil {
+		go propagateDKGMessage(n.listeners.GetListeners(cm.Type), cm.DKGMessage)
+		return
+	}
 	if cm == nil || cm.SignedMessage == nil {
 		n.logger.Debug("could not propagate nil message")
 		return
@@ -24,6 +28,8 @@ func (n *p2pNetwork) propagateSignedMsg(cm *network.Message) {
 		go propagateSigMessage(lss, cm.SignedMessage)
 	case network.NetworkMsg_DecidedType:
 		go propagateDecidedMessage(lss, cm.SignedMessage)
+	case network.NetworkMsg_PreConsensusSignatureType:
+		go propagatePreConsensusSigMessage(lss, cm.SignedMessage)
 	default:
 		n.logger.Error("received unsupported message", zap.Int32("msg type", int32(cm.Type)))
 	}
@@ -47,6 +53,24 @@ func propagateSigMessage(listeners []*listeners.Listener, msg *proto.SignedMessa
 	}
 }
 
+func propagatePreConsensusSigMessage(listeners []*listeners.Listener, msg *proto.SignedMessage) {
+	for _, ls := range listeners {
+		cn := ls.PreConsensusSigChan()
+		if cn != nil {
+			cn <- msg
+		}
+	}
+}
+
+func propagateDKGMessage(listeners []*listeners.Listener, msg *network.DKGMessage) {
+	for _, ls := range listeners {
+		cn := ls.DKGChan()
+		if cn != nil {
+			cn <- msg
+		}
+	}
+}
+
 func propagateDecidedMessage(listeners []*listeners.Listener, msg *proto.SignedMessage) {
 	for _, ls := range listeners {
 		cn := ls.DecidedChan()
@@ -5,6 +5,7 @@ import (
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/bloxapp/ssv/network"
 	"github.com/bloxapp/ssv/network/commons/listeners"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 	"sync"
 	"testing"
@@ -78,3 +79,22 @@ func TestListeners(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestListeners_DKG(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	n := p2pNetwork{
+		logger: logger,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n.listeners = listeners.NewListenersContainer(ctx, logger)
+
+	dkgCn, dkgCnDone := n.ReceivedDKGMsgChan()
+	defer dkgCnDone()
+
+	msg := &network.DKGMessage{Data: []byte{1, 2, 3}, Signer: "operator", Signature: []byte{4}}
+	// dkg messages have no signed message
+	n.propagateSignedMsg(&network.Message{Type: network.NetworkMsg_DKGType, DKGMessage: msg})
+	require.Equal(t, msg, <-dkgCn)
+}
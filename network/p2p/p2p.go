@@ -104,7 +104,11 @@ func New(ctx context.Context, logger *zap.Logger, cfg *Config) (network.Network,
 		},
 	}
 
-	n.cfg.BootnodesENRs = filterInvalidENRs(n.logger, TransformEnr(n.cfg.Enr))
+	bootnodes := n.cfg.Bootnodes
+	if len(n.cfg.Enr) > 0 {
+		bootnodes = TransformEnr(n.cfg.Enr)
+	}
+	n.cfg.BootnodesENRs = filterInvalidENRs(n.logger, bootnodes)
 	if len(n.cfg.BootnodesENRs) == 0 {
 		n.logger.Warn("missing valid bootnode ENR")
 	}
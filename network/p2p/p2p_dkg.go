@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/commons/listeners"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// dkgTopicName is the name of the topic that is used for dkg ceremonies
+const dkgTopicName = "dkg"
+
+// SubscribeToDKGTopic subscribes to the topic of dkg messages
+func (n *p2pNetwork) SubscribeToDKGTopic() error {
+	topic, err := n.getDKGTopic()
+	if err != nil {
+		return err
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe on dkg topic")
+	}
+	go n.listen(n.ctx, sub)
+
+	return nil
+}
+
+// BroadcastDKGMessage broadcasts the given message to all operators
+func (n *p2pNetwork) BroadcastDKGMessage(msg *network.DKGMessage) error {
+	msgBytes, err := n.fork.EncodeNetworkMsg(&network.Message{
+		DKGMessage: msg,
+		Type:       network.NetworkMsg_DKGType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+	topic, err := n.getDKGTopic()
+	if err != nil {
+		return errors.Wrap(err, "failed to get topic")
+	}
+
+	n.logger.Debug("Broadcasting dkg message", zap.String("signer", msg.Signer), zap.Any("peers", topic.ListPeers()))
+	return topic.Publish(n.ctx, msgBytes)
+}
+
+// ReceivedDKGMsgChan returns the channel for dkg messages
+func (n *p2pNetwork) ReceivedDKGMsgChan() (<-chan *network.DKGMessage, func()) {
+	ls := listeners.NewListener(network.NetworkMsg_DKGType)
+
+	return ls.DKGChan(), n.listeners.Register(ls)
+}
+
+// getDKGTopic returns the dkg topic, the topic is joined on first use
+func (n *p2pNetwork) getDKGTopic() (*pubsub.Topic, error) {
+	n.psTopicsLock.Lock()
+	defer n.psTopicsLock.Unlock()
+
+	if _, ok := n.cfg.Topics[dkgTopicName]; !ok {
+		if err := n.joinTopic(dkgTopicName); err != nil {
+			return nil, errors.Wrap(err, "failed to join dkg topic")
+		}
+	}
+	return n.cfg.Topics[dkgTopicName], nil
+}
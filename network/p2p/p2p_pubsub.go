@@ -54,9 +54,17 @@ func (n *p2pNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) err
 			// close topic and mark it as not subscribed
 			n.psTopicsLock.Lock()
 			defer n.psTopicsLock.Unlock()
+			canceled := ctx.Err() != nil
+			if _, subscribed := n.psSubs[pubKey]; canceled && subscribed {
+				// the subscription was canceled and the topic is still in use (e.g. subscribed again)
+				return
+			}
 			if err := n.closeTopic(topicName); err != nil {
 				n.logger.Error("failed to close topic", zap.String("topic", topicName), zap.Error(err))
 			}
+			if canceled {
+				return
+			}
 			// make sure the context is canceled once listen was done from some reason
 			if cancel, ok := n.psSubs[pubKey]; ok {
 				defer cancel()
@@ -70,6 +78,21 @@ func (n *p2pNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) err
 	return nil
 }
 
+// UnsubscribeFromValidatorNetwork cancels the subscription to the validator's topic,
+// the topic will be closed once the listening routine is done
+func (n *p2pNetwork) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	n.psTopicsLock.Lock()
+	defer n.psTopicsLock.Unlock()
+
+	pubKey := validatorPk.SerializeToHexStr()
+	if cancel, ok := n.psSubs[pubKey]; ok {
+		cancel()
+		delete(n.psSubs, pubKey)
+		n.logger.Debug("unsubscribed from topic", zap.String("pubKey", pubKey))
+	}
+	return nil
+}
+
 // AllPeers returns all connected peers for a validator PK (except for the validator itself)
 func (n *p2pNetwork) AllPeers(validatorPk []byte) ([]string, error) {
 	topic, err := n.getTopic(validatorPk)
@@ -0,0 +1,181 @@
+package networkconfig
+
+import (
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/network/p2p"
+	"github.com/pkg/errors"
+)
+
+// Profile bundles the configuration of a network the node can run on
+type Profile struct {
+	Name string `yaml:"Name"`
+	// BaseNetwork is the known eth2 network the profile is derived from, it is used by the key manager
+	// (e.g. far future protection), therefore its genesis must not be later than the genesis of the profile
+	BaseNetwork        core.Network `yaml:"BaseNetwork"`
+	GenesisForkVersion string       `yaml:"GenesisForkVersion"`
+	MinGenesisTime     uint64       `yaml:"MinGenesisTime"`
+	// GenesisValidatorsRoot identifies the chain in slashing protection interchange files
+	GenesisValidatorsRoot string       `yaml:"GenesisValidatorsRoot"`
+	RegistryContractAddr  string       `yaml:"RegistryContractAddr"`
+	AbiVersion            eth1.Version `yaml:"AbiVersion"`
+	// SyncOffset is the (hex) block number of the first event of the registry contract
+	SyncOffset string   `yaml:"SyncOffset"`
+	Bootnodes  []string `yaml:"Bootnodes"`
+}
+
+var (
+	// Mainnet is the profile of the eth2 main network
+	Mainnet = Profile{
+		Name:                  string(core.MainNetwork),
+		BaseNetwork:           core.MainNetwork,
+		GenesisForkVersion:    "00000000",
+		MinGenesisTime:        1606824023,
+		GenesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+	}
+	// Prater is the profile of the prater test network
+	Prater = Profile{
+		Name:                  string(core.PraterNetwork),
+		BaseNetwork:           core.PraterNetwork,
+		GenesisForkVersion:    "00001020",
+		MinGenesisTime:        1616508000,
+		GenesisValidatorsRoot: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eee6eb2bb",
+		RegistryContractAddr:  "0x9573C41F0Ed8B72f3bD6A9bA6E3e15426A0aa65B",
+		AbiVersion:            eth1.Legacy,
+		SyncOffset:            "4e706f",
+		Bootnodes: []string{
+			"enr:-LK4QMmL9hLJ1csDN4rQoSjlJGE2SvsXOETfcLH8uAVrxlHaELF0u3NeKCTY2eO_X1zy5eEKcHruyaAsGNiyyG4QWUQBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhCLdu_SJc2VjcDI1NmsxoQO8KQz5L1UEXzEr-CXFFq1th0eG6gopbdul2OQVMuxfMoN0Y3CCE4iDdWRwgg-g",
+		},
+	}
+
+	profiles     = map[string]Profile{}
+	profilesLock sync.RWMutex
+)
+
+func init() {
+	_ = Register(Mainnet)
+	_ = Register(Prater)
+}
+
+// Register adds the given profile to the registry, profiles names must be unique
+func Register(p Profile) error {
+	if err := p.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid network profile %s", p.Name)
+	}
+	profilesLock.Lock()
+	defer profilesLock.Unlock()
+
+	name := strings.ToLower(p.Name)
+	if _, exist := profiles[name]; exist {
+		return errors.Errorf("network profile %s already exist", p.Name)
+	}
+	profiles[name] = p
+	return nil
+}
+
+// Get returns the profile of the given network
+func Get(name string) (Profile, error) {
+	profilesLock.RLock()
+	defer profilesLock.RUnlock()
+
+	p, exist := profiles[strings.ToLower(name)]
+	if !exist {
+		return Profile{}, errors.Errorf("unknown network profile %s", name)
+	}
+	return p, nil
+}
+
+// Load registers the given custom profiles and returns the profile of the given network
+func Load(name string, custom []Profile) (Profile, error) {
+	for _, p := range custom {
+		if err := Register(p); err != nil {
+			return Profile{}, err
+		}
+	}
+	return Get(name)
+}
+
+// Validate checks that the profile is consistent
+func (p Profile) Validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("missing name")
+	}
+	switch p.BaseNetwork {
+	case core.MainNetwork, core.PraterNetwork, core.PyrmontNetwork:
+	default:
+		return errors.Errorf("unknown base network %s", p.BaseNetwork)
+	}
+	if p.MinGenesisTime > 0 && p.MinGenesisTime < p.BaseNetwork.MinGenesisTime() {
+		return errors.New("genesis time is earlier than the genesis of the base network")
+	}
+	if _, err := p.forkVersion(); err != nil {
+		return err
+	}
+	if len(p.GenesisValidatorsRoot) > 0 {
+		root, err := hex.DecodeString(strings.TrimPrefix(p.GenesisValidatorsRoot, "0x"))
+		if err != nil || len(root) != 32 {
+			return errors.Errorf("invalid genesis validators root %s", p.GenesisValidatorsRoot)
+		}
+	}
+	if len(p.SyncOffset) > 0 {
+		if _, ok := new(eth1.SyncOffset).SetString(p.SyncOffset, 16); !ok {
+			return errors.New("invalid sync offset")
+		}
+	}
+	return nil
+}
+
+// ETHNetwork returns the beacon network of the profile
+func (p Profile) ETHNetwork() beacon.Network {
+	forkVersion, _ := p.forkVersion()
+	return beacon.NewNetwork(p.BaseNetwork, p.MinGenesisTime, forkVersion)
+}
+
+// Apply sets the values of the profile on the given options (nil options are skipped),
+// values that were explicitly configured take precedence over the profile
+func (p Profile) Apply(eth2Opts *beacon.Options, eth1Opts *eth1.Options, p2pCfg *p2p.Config) error {
+	if eth2Opts != nil {
+		eth2Opts.Network = p.Name
+		eth2Opts.ETHNetwork = p.ETHNetwork()
+	}
+	if eth1Opts != nil {
+		if len(eth1Opts.RegistryContractAddr) == 0 {
+			eth1Opts.RegistryContractAddr = p.RegistryContractAddr
+		}
+		if len(eth1Opts.AbiVersion) == 0 {
+			eth1Opts.AbiVersion = p.AbiVersion.String()
+		}
+		if _, err := eth1Opts.ContractVersion(); err != nil {
+			return err
+		}
+		if len(eth1Opts.ETH1SyncOffset) == 0 {
+			eth1Opts.ETH1SyncOffset = p.SyncOffset
+		}
+		if len(eth1Opts.RegistryContractAddr) == 0 && len(eth1Opts.ETH1LogsFile) == 0 {
+			return errors.Errorf("network %s has no registry contract, RegistryContractAddr must be configured", p.Name)
+		}
+	}
+	if p2pCfg != nil {
+		if len(p2pCfg.Bootnodes) == 0 {
+			p2pCfg.Bootnodes = p.Bootnodes
+		}
+		p2pCfg.ForkVersion = p.ETHNetwork().ForkVersion()
+	}
+	return nil
+}
+
+func (p Profile) forkVersion() ([]byte, error) {
+	if len(p.GenesisForkVersion) == 0 {
+		return nil, nil
+	}
+	forkVersion, err := hex.DecodeString(strings.TrimPrefix(p.GenesisForkVersion, "0x"))
+	if err != nil || len(forkVersion) != 4 {
+		return nil, errors.Errorf("invalid genesis fork version %s", p.GenesisForkVersion)
+	}
+	return forkVersion, nil
+}
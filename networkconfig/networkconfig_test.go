@@ -0,0 +1,134 @@
+package networkconfig
+
+import (
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	p, err := Get("prater")
+	require.NoError(t, err)
+	require.Equal(t, Prater.RegistryContractAddr, p.RegistryContractAddr)
+
+	p, err = Get("Mainnet")
+	require.NoError(t, err)
+	require.Equal(t, core.MainNetwork, p.BaseNetwork)
+
+	_, err = Get("unknown")
+	require.EqualError(t, err, "unknown network profile unknown")
+}
+
+func TestLoad(t *testing.T) {
+	devnet := Profile{
+		Name:                 "devnet-load",
+		BaseNetwork:          core.PraterNetwork,
+		GenesisForkVersion:   "0x01020304",
+		MinGenesisTime:       1636000000,
+		RegistryContractAddr: "0x0000000000000000000000000000000000000001",
+		AbiVersion:           eth1.V2,
+		SyncOffset:           "10",
+	}
+	p, err := Load("devnet-load", []Profile{devnet})
+	require.NoError(t, err)
+	require.Equal(t, devnet, p)
+
+	ethNetwork := p.ETHNetwork()
+	require.Equal(t, uint64(1636000000), ethNetwork.MinGenesisTime())
+	require.Equal(t, []byte{1, 2, 3, 4}, ethNetwork.ForkVersion())
+
+	_, err = Load("devnet-load", []Profile{devnet})
+	require.EqualError(t, err, "network profile devnet-load already exist")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		err     string
+	}{
+		{"valid", Prater, ""},
+		{"missing name", Profile{BaseNetwork: core.PraterNetwork}, "missing name"},
+		{"unknown base network", Profile{Name: "x", BaseNetwork: "x"}, "unknown base network x"},
+		{"early genesis", Profile{Name: "x", BaseNetwork: core.PraterNetwork, MinGenesisTime: 1}, "genesis time is earlier than the genesis of the base network"},
+		{"invalid fork version", Profile{Name: "x", BaseNetwork: core.PraterNetwork, GenesisForkVersion: "0102"}, "invalid genesis fork version 0102"},
+		{"invalid sync offset", Profile{Name: "x", BaseNetwork: core.PraterNetwork, SyncOffset: "xyz"}, "invalid sync offset"},
+		{"invalid genesis validators root", Profile{Name: "x", BaseNetwork: core.PraterNetwork, GenesisValidatorsRoot: "0x0102"}, "invalid genesis validators root 0x0102"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.profile.Validate()
+			if len(test.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestProfile_Apply(t *testing.T) {
+	t.Run("profile values", func(t *testing.T) {
+		eth2Opts := beacon.Options{Network: "PRATER"}
+		eth1Opts := eth1.Options{}
+		p2pCfg := p2p.Config{}
+		require.NoError(t, Prater.Apply(&eth2Opts, &eth1Opts, &p2pCfg))
+
+		require.Equal(t, "prater", eth2Opts.Network)
+		require.Equal(t, Prater.MinGenesisTime, eth2Opts.ETHNetwork.MinGenesisTime())
+		require.Equal(t, Prater.RegistryContractAddr, eth1Opts.RegistryContractAddr)
+		require.Equal(t, Prater.SyncOffset, eth1Opts.ETH1SyncOffset)
+		require.Equal(t, "legacy", eth1Opts.AbiVersion)
+		require.Equal(t, Prater.Bootnodes, p2pCfg.Bootnodes)
+		require.Equal(t, []byte{0, 0, 0x10, 0x20}, p2pCfg.ForkVersion)
+	})
+
+	t.Run("configured values", func(t *testing.T) {
+		eth1Opts := eth1.Options{
+			RegistryContractAddr: "0x0000000000000000000000000000000000000002",
+			ETH1SyncOffset:       "20",
+			AbiVersion:           "1",
+		}
+		p2pCfg := p2p.Config{Bootnodes: []string{"enr:x"}}
+		require.NoError(t, Prater.Apply(nil, &eth1Opts, &p2pCfg))
+
+		require.Equal(t, "0x0000000000000000000000000000000000000002", eth1Opts.RegistryContractAddr)
+		require.Equal(t, "20", eth1Opts.ETH1SyncOffset)
+		abiVersion, err := eth1Opts.ContractVersion()
+		require.NoError(t, err)
+		require.Equal(t, eth1.V2, abiVersion)
+		require.Equal(t, []string{"enr:x"}, p2pCfg.Bootnodes)
+	})
+
+	t.Run("configured legacy abi version", func(t *testing.T) {
+		v2 := Prater
+		v2.AbiVersion = eth1.V2
+		eth1Opts := eth1.Options{AbiVersion: "0"}
+		require.NoError(t, v2.Apply(nil, &eth1Opts, nil))
+		abiVersion, err := eth1Opts.ContractVersion()
+		require.NoError(t, err)
+		require.Equal(t, eth1.Legacy, abiVersion)
+
+		eth1Opts = eth1.Options{}
+		require.NoError(t, v2.Apply(nil, &eth1Opts, nil))
+		abiVersion, err = eth1Opts.ContractVersion()
+		require.NoError(t, err)
+		require.Equal(t, eth1.V2, abiVersion)
+	})
+
+	t.Run("invalid abi version", func(t *testing.T) {
+		err := Prater.Apply(nil, &eth1.Options{AbiVersion: "3"}, nil)
+		require.EqualError(t, err, "unknown abi version 3")
+	})
+
+	t.Run("missing registry contract", func(t *testing.T) {
+		err := Mainnet.Apply(nil, &eth1.Options{}, nil)
+		require.EqualError(t, err, "network mainnet has no registry contract, RegistryContractAddr must be configured")
+		require.NoError(t, Mainnet.Apply(nil, &eth1.Options{ETH1LogsFile: "./logs.json"}, nil))
+	})
+}
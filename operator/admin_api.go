@@ -0,0 +1,342 @@
+package operator
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/validator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultStatusPageSize is the amount of validators returned when no limit was requested
+	defaultStatusPageSize = 100
+	// maxStatusPageSize is the max amount of validators that can be returned in a single page
+	maxStatusPageSize = 1000
+)
+
+// ValidatorsStatusProvider provides the status of the validators managed by the node
+type ValidatorsStatusProvider interface {
+	ValidatorsStatus(filter validator.StatusFilter) ([]*validator.ValidatorStatus, int, error)
+}
+
+// DutyRecordsProvider provides the journal of the duties executed by the node
+type DutyRecordsProvider interface {
+	DutyRecords(pubKey string, fromSlot, toSlot uint64) ([]*collections.DutyRecord, error)
+}
+
+// DKGProvider starts dkg ceremonies and provides their state
+type DKGProvider interface {
+	StartCeremony(operators []string, threshold uint64) (string, error)
+	StartReshare(validatorPubKey string, operators []string, threshold uint64) (string, error)
+	GetCeremony(id string) (*dkg.CeremonyState, bool, error)
+}
+
+// DBBackupProvider writes consistent backups of the node db
+type DBBackupProvider interface {
+	BackupDB(w io.Writer) error
+}
+
+// AdminInfoProvider provides the information that is served by the admin api
+type AdminInfoProvider interface {
+	ValidatorsStatusProvider
+	DutyRecordsProvider
+	DKGProvider
+	DBBackupProvider
+}
+
+// AdminAPI serves an http/json api for node administration
+type AdminAPI interface {
+	// Start starts an http server, listening to /validators, /duties, /dkg, /dkg/reshare and /db/backup requests.
+	// the api is not authenticated, therefore addr must be a loopback address
+	Start(mux *http.ServeMux, addr string) error
+}
+
+// validatorsStatusResponse is the response of /validators requests
+type validatorsStatusResponse struct {
+	Total      int                          `json:"total"`
+	Offset     int                          `json:"offset"`
+	Limit      int                          `json:"limit"`
+	Validators []*validator.ValidatorStatus `json:"validators"`
+}
+
+// dutyRecordsResponse is the response of /duties requests
+type dutyRecordsResponse struct {
+	FromSlot uint64                    `json:"fromSlot"`
+	ToSlot   uint64                    `json:"toSlot"`
+	Duties   []*collections.DutyRecord `json:"duties"`
+}
+
+// startCeremonyRequest is the body of POST /dkg requests
+type startCeremonyRequest struct {
+	Operators []string `json:"operators"`
+	Threshold uint64   `json:"threshold"`
+}
+
+// startReshareRequest is the body of POST /dkg/reshare requests
+type startReshareRequest struct {
+	ValidatorPubKey string   `json:"validatorPubKey"`
+	Operators       []string `json:"operators"`
+	Threshold       uint64   `json:"threshold"`
+}
+
+// startCeremonyResponse is the response of POST /dkg and /dkg/reshare requests
+type startCeremonyResponse struct {
+	ID string `json:"id"`
+}
+
+type adminAPI struct {
+	logger       *zap.Logger
+	provider     AdminInfoProvider
+	enableBackup bool
+}
+
+// NewAdminAPI creates a new instance, db backups are served only if enableBackup is set
+func NewAdminAPI(logger *zap.Logger, provider AdminInfoProvider, enableBackup bool) AdminAPI {
+	return &adminAPI{
+		logger:       logger.With(zap.String("component", "operator/adminAPI")),
+		provider:     provider,
+		enableBackup: enableBackup,
+	}
+}
+
+func (api *adminAPI) Start(mux *http.ServeMux, addr string) error {
+	if err := checkLoopback(addr); err != nil {
+		return err
+	}
+	api.logger.Info("setup admin api", zap.String("addr", addr), zap.Bool("enableBackup", api.enableBackup))
+
+	mux.HandleFunc("/validators", api.handleValidators)
+	mux.HandleFunc("/duties", api.handleDuties)
+	mux.HandleFunc("/dkg", api.handleDKG)
+	mux.HandleFunc("/dkg/reshare", api.handleReshare)
+	mux.HandleFunc("/db/backup", api.handleBackup)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			api.logger.Error("failed to start admin http end-point", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// checkLoopback returns an error if the given address is not a loopback address
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return errors.Wrap(err, "invalid admin api address")
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.Errorf("admin api is not authenticated, it can't listen on a non-loopback host '%s'", host)
+	}
+	return nil
+}
+
+// handleValidators returns the status of the requested validators,
+// supported query params: owner (address), offset and limit
+func (api *adminAPI) handleValidators(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	filter, err := parseStatusFilter(req)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	validators, total, err := api.provider.ValidatorsStatus(filter)
+	if err != nil {
+		api.logger.Error("could not get validators status", zap.Error(err))
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	api.writeJSON(res, validatorsStatusResponse{
+		Total:      total,
+		Offset:     filter.Offset,
+		Limit:      filter.Limit,
+		Validators: validators,
+	})
+}
+
+// handleDuties returns the journal records of executed duties,
+// supported query params: pubkey (of a validator), from and to (slots range, inclusive)
+func (api *adminAPI) handleDuties(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := req.URL.Query()
+	fromSlot, err := parseUintParam(query.Get("from"), 0)
+	if err != nil {
+		http.Error(res, errors.Wrap(err, "invalid from slot").Error(), http.StatusBadRequest)
+		return
+	}
+	toSlot, err := parseUintParam(query.Get("to"), math.MaxUint64)
+	if err != nil {
+		http.Error(res, errors.Wrap(err, "invalid to slot").Error(), http.StatusBadRequest)
+		return
+	}
+	if fromSlot > toSlot {
+		http.Error(res, "invalid slots range", http.StatusBadRequest)
+		return
+	}
+	duties, err := api.provider.DutyRecords(query.Get("pubkey"), fromSlot, toSlot)
+	if err != nil {
+		api.logger.Error("could not get duty records", zap.Error(err))
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	api.writeJSON(res, dutyRecordsResponse{
+		FromSlot: fromSlot,
+		ToSlot:   toSlot,
+		Duties:   duties,
+	})
+}
+
+// handleDKG starts a dkg ceremony (POST, with a startCeremonyRequest body),
+// or returns the state of a ceremony (GET, with the id query param)
+func (api *adminAPI) handleDKG(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		var body startCeremonyRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(res, errors.Wrap(err, "invalid request body").Error(), http.StatusBadRequest)
+			return
+		}
+		id, err := api.provider.StartCeremony(body.Operators, body.Threshold)
+		if err == errDKGDisabled {
+			http.Error(res, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		api.writeJSON(res, startCeremonyResponse{ID: id})
+	case http.MethodGet:
+		id := req.URL.Query().Get("id")
+		if len(id) == 0 {
+			http.Error(res, "missing ceremony id", http.StatusBadRequest)
+			return
+		}
+		state, found, err := api.provider.GetCeremony(id)
+		if err == errDKGDisabled {
+			http.Error(res, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		if err != nil {
+			api.logger.Error("could not get ceremony", zap.Error(err))
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			http.Error(res, "ceremony not found", http.StatusNotFound)
+			return
+		}
+		api.writeJSON(res, state)
+	default:
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleReshare starts a dkg ceremony that reshares a validator of the operator (POST, with a startReshareRequest body),
+// the state of the ceremony is available at /dkg
+func (api *adminAPI) handleReshare(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var body startReshareRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, errors.Wrap(err, "invalid request body").Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := api.provider.StartReshare(body.ValidatorPubKey, body.Operators, body.Threshold)
+	if err == errDKGDisabled {
+		http.Error(res, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	api.writeJSON(res, startCeremonyResponse{ID: id})
+}
+
+// handleBackup streams a backup of the node db, the backup is written while the node is running
+func (api *adminAPI) handleBackup(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !api.enableBackup {
+		http.Error(res, "db backup is disabled", http.StatusForbidden)
+		return
+	}
+	res.Header().Set("Content-Type", "application/octet-stream")
+	// once the backup has started the status can't be changed, an incomplete backup fails the checksum verification
+	if err := api.provider.BackupDB(res); err != nil {
+		api.logger.Error("could not write db backup", zap.Error(err))
+		return
+	}
+	api.logger.Info("db backup was written")
+}
+
+// writeJSON writes the given object as a json response
+func (api *adminAPI) writeJSON(res http.ResponseWriter, obj interface{}) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	if _, err := res.Write(raw); err != nil {
+		api.logger.Error("could not write response", zap.Error(err))
+	}
+}
+
+// parseStatusFilter parses the filter and paging params of the given request
+func parseStatusFilter(req *http.Request) (validator.StatusFilter, error) {
+	query := req.URL.Query()
+	filter := validator.StatusFilter{
+		OwnerAddress: query.Get("owner"),
+		Limit:        defaultStatusPageSize,
+	}
+	if offset := query.Get("offset"); len(offset) > 0 {
+		val, err := strconv.Atoi(offset)
+		if err != nil || val < 0 {
+			return filter, errors.Errorf("invalid offset: %s", offset)
+		}
+		filter.Offset = val
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		val, err := strconv.Atoi(limit)
+		if err != nil || val <= 0 {
+			return filter, errors.Errorf("invalid limit: %s", limit)
+		}
+		filter.Limit = val
+	}
+	if filter.Limit > maxStatusPageSize {
+		filter.Limit = maxStatusPageSize
+	}
+	return filter, nil
+}
+
+// parseUintParam parses the given query param, or returns the default value if it is empty
+func parseUintParam(param string, defaultVal uint64) (uint64, error) {
+	if len(param) == 0 {
+		return defaultVal, nil
+	}
+	return strconv.ParseUint(param, 10, 64)
+}
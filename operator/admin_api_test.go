@@ -0,0 +1,295 @@
+package operator
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/validator"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type adminInfoProviderMock struct {
+	filter   validator.StatusFilter
+	pubKey   string
+	fromSlot uint64
+	toSlot   uint64
+	err      error
+
+	validatorPubKey string
+	operators       []string
+	threshold       uint64
+	ceremonies      map[string]*dkg.CeremonyState
+}
+
+func (m *adminInfoProviderMock) ValidatorsStatus(filter validator.StatusFilter) ([]*validator.ValidatorStatus, int, error) {
+	m.filter = filter
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return []*validator.ValidatorStatus{{PublicKey: "aaaa", OwnerAddress: filter.OwnerAddress, Running: true}}, 3, nil
+}
+
+func (m *adminInfoProviderMock) DutyRecords(pubKey string, fromSlot, toSlot uint64) ([]*collections.DutyRecord, error) {
+	m.pubKey, m.fromSlot, m.toSlot = pubKey, fromSlot, toSlot
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*collections.DutyRecord{{PubKey: pubKey, Role: "ATTESTER", Slot: fromSlot, Status: "submitted"}}, nil
+}
+
+func (m *adminInfoProviderMock) StartCeremony(operators []string, threshold uint64) (string, error) {
+	m.operators, m.threshold = operators, threshold
+	if m.err != nil {
+		return "", m.err
+	}
+	return "1234", nil
+}
+
+func (m *adminInfoProviderMock) StartReshare(validatorPubKey string, operators []string, threshold uint64) (string, error) {
+	m.validatorPubKey = validatorPubKey
+	return m.StartCeremony(operators, threshold)
+}
+
+func (m *adminInfoProviderMock) GetCeremony(id string) (*dkg.CeremonyState, bool, error) {
+	if m.err != nil {
+		return nil, false, m.err
+	}
+	state, found := m.ceremonies[id]
+	return state, found, nil
+}
+
+func (m *adminInfoProviderMock) BackupDB(w io.Writer) error {
+	if m.err != nil {
+		return m.err
+	}
+	_, err := w.Write([]byte("backup"))
+	return err
+}
+
+func TestAdminAPI_handleValidators(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	provider := &adminInfoProviderMock{}
+	api := NewAdminAPI(logger, provider, false).(*adminAPI)
+
+	t.Run("filter and paging", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators?owner=0x1234&offset=2&limit=1", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, validator.StatusFilter{OwnerAddress: "0x1234", Offset: 2, Limit: 1}, provider.filter)
+
+		var res validatorsStatusResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, 3, res.Total)
+		require.Equal(t, 2, res.Offset)
+		require.Equal(t, 1, res.Limit)
+		require.Len(t, res.Validators, 1)
+		require.Equal(t, "aaaa", res.Validators[0].PublicKey)
+		require.Equal(t, "0x1234", res.Validators[0].OwnerAddress)
+	})
+
+	t.Run("default paging", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, validator.StatusFilter{Limit: defaultStatusPageSize}, provider.filter)
+
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators?limit=100000", nil))
+		require.Equal(t, maxStatusPageSize, provider.filter.Limit)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators?offset=-1", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators?limit=x", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodPost, "/validators", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		provider.err = errors.New("test error")
+		defer func() { provider.err = nil }()
+		rec := httptest.NewRecorder()
+		api.handleValidators(rec, httptest.NewRequest(http.MethodGet, "/validators", nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestAdminAPI_handleDuties(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	provider := &adminInfoProviderMock{}
+	api := NewAdminAPI(logger, provider, false).(*adminAPI)
+
+	t.Run("slots range", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleDuties(rec, httptest.NewRequest(http.MethodGet, "/duties?pubkey=aaaa&from=10&to=20", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "aaaa", provider.pubKey)
+		require.Equal(t, uint64(10), provider.fromSlot)
+		require.Equal(t, uint64(20), provider.toSlot)
+
+		var res dutyRecordsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res.Duties, 1)
+		require.Equal(t, "aaaa", res.Duties[0].PubKey)
+		require.Equal(t, uint64(10), res.Duties[0].Slot)
+	})
+
+	t.Run("default range", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleDuties(rec, httptest.NewRequest(http.MethodGet, "/duties", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "", provider.pubKey)
+		require.Equal(t, uint64(0), provider.fromSlot)
+		require.Equal(t, uint64(math.MaxUint64), provider.toSlot)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleDuties(rec, httptest.NewRequest(http.MethodGet, "/duties?from=x", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleDuties(rec, httptest.NewRequest(http.MethodGet, "/duties?from=20&to=10", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestAdminAPI_handleDKG(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	provider := &adminInfoProviderMock{
+		ceremonies: map[string]*dkg.CeremonyState{"1234": {ID: "1234", Threshold: 3, Status: dkg.StatusRunning}},
+	}
+	api := NewAdminAPI(logger, provider, false).(*adminAPI)
+
+	t.Run("start ceremony", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := strings.NewReader(`{"operators":["a","b","c","d"],"threshold":3}`)
+		api.handleDKG(rec, httptest.NewRequest(http.MethodPost, "/dkg", body))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, []string{"a", "b", "c", "d"}, provider.operators)
+		require.Equal(t, uint64(3), provider.threshold)
+
+		var res startCeremonyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, "1234", res.ID)
+	})
+
+	t.Run("get ceremony", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodGet, "/dkg?id=1234", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var res dkg.CeremonyState
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, "1234", res.ID)
+		require.Equal(t, dkg.StatusRunning, res.Status)
+
+		rec = httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodGet, "/dkg?id=5678", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodPost, "/dkg", strings.NewReader("{")))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodGet, "/dkg", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodDelete, "/dkg", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("dkg disabled", func(t *testing.T) {
+		provider.err = errDKGDisabled
+		defer func() { provider.err = nil }()
+		rec := httptest.NewRecorder()
+		api.handleDKG(rec, httptest.NewRequest(http.MethodGet, "/dkg?id=1234", nil))
+		require.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+func TestAdminAPI_handleReshare(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	provider := &adminInfoProviderMock{}
+	api := NewAdminAPI(logger, provider, false).(*adminAPI)
+
+	t.Run("start reshare", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := strings.NewReader(`{"validatorPubKey":"aaaa","operators":["a","b","c"],"threshold":2}`)
+		api.handleReshare(rec, httptest.NewRequest(http.MethodPost, "/dkg/reshare", body))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "aaaa", provider.validatorPubKey)
+		require.Equal(t, []string{"a", "b", "c"}, provider.operators)
+		require.Equal(t, uint64(2), provider.threshold)
+
+		var res startCeremonyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, "1234", res.ID)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.handleReshare(rec, httptest.NewRequest(http.MethodPost, "/dkg/reshare", strings.NewReader("{")))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		api.handleReshare(rec, httptest.NewRequest(http.MethodGet, "/dkg/reshare", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+		provider.err = errors.New("validator share was not found")
+		defer func() { provider.err = nil }()
+		rec = httptest.NewRecorder()
+		api.handleReshare(rec, httptest.NewRequest(http.MethodPost, "/dkg/reshare", strings.NewReader(`{}`)))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestAdminAPI_handleBackup(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	provider := &adminInfoProviderMock{}
+
+	// backups are served only if enabled explicitly
+	rec := httptest.NewRecorder()
+	NewAdminAPI(logger, provider, false).(*adminAPI).handleBackup(rec, httptest.NewRequest(http.MethodGet, "/db/backup", nil))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	api := NewAdminAPI(logger, provider, true).(*adminAPI)
+	rec = httptest.NewRecorder()
+	api.handleBackup(rec, httptest.NewRequest(http.MethodGet, "/db/backup", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t, "backup", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	api.handleBackup(rec, httptest.NewRequest(http.MethodPost, "/db/backup", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdminAPI_Start(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	api := NewAdminAPI(logger, &adminInfoProviderMock{}, false)
+
+	require.EqualError(t, api.Start(http.NewServeMux(), ":15001"), "admin api is not authenticated, it can't listen on a non-loopback host ''")
+	require.EqualError(t, api.Start(http.NewServeMux(), "0.0.0.0:15001"), "admin api is not authenticated, it can't listen on a non-loopback host '0.0.0.0'")
+	require.NoError(t, api.Start(http.NewServeMux(), "127.0.0.1:0"))
+}
@@ -3,7 +3,6 @@ package duties
 import (
 	"context"
 	"encoding/hex"
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/validator"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -31,7 +30,7 @@ type ControllerOptions struct {
 	Logger              *zap.Logger
 	Ctx                 context.Context
 	BeaconClient        beacon.Beacon
-	EthNetwork          core.Network
+	EthNetwork          beacon.Network
 	ValidatorController validator.Controller
 	Executor            DutyExecutor
 	GenesisEpoch        uint64
@@ -42,7 +41,7 @@ type ControllerOptions struct {
 type dutyController struct {
 	logger     *zap.Logger
 	ctx        context.Context
-	ethNetwork core.Network
+	ethNetwork beacon.Network
 	// executor enables to work with a custom execution
 	executor            DutyExecutor
 	fetcher             DutyFetcher
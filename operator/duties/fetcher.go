@@ -5,7 +5,6 @@ import (
 	"fmt"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
@@ -31,6 +30,10 @@ type beaconDutiesClient interface {
 	GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error)
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*eth2apiv1.BeaconCommitteeSubscription) error
+	// GetSyncCommitteeDuties returns the sync committee duties for the passed validators indices
+	GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error)
+	// SubscribeToSyncCommitteeSubnet subscribes sync committee members to subnets (p2p topics)
+	SubscribeToSyncCommitteeSubnet(subscriptions []*eth2apiv1.SyncCommitteeSubscription) error
 }
 
 // DutyFetcher represents the component that manages duties
@@ -39,7 +42,7 @@ type DutyFetcher interface {
 }
 
 // newDutyFetcher creates a new instance
-func newDutyFetcher(logger *zap.Logger, beaconClient beaconDutiesClient, indicesFetcher validatorsIndicesFetcher, network core.Network) DutyFetcher {
+func newDutyFetcher(logger *zap.Logger, beaconClient beaconDutiesClient, indicesFetcher validatorsIndicesFetcher, network beacon.Network) DutyFetcher {
 	df := dutyFetcher{
 		logger:         logger.With(zap.String("component", "operator/dutyFetcher")),
 		ethNetwork:     network,
@@ -53,7 +56,7 @@ func newDutyFetcher(logger *zap.Logger, beaconClient beaconDutiesClient, indices
 // dutyFetcher is internal implementation of DutyFetcher
 type dutyFetcher struct {
 	logger         *zap.Logger
-	ethNetwork     core.Network
+	ethNetwork     beacon.Network
 	beaconClient   beaconDutiesClient
 	indicesFetcher validatorsIndicesFetcher
 
@@ -122,7 +125,16 @@ func (df *dutyFetcher) fetchDuties(slot uint64) ([]*beacon.Duty, error) {
 		esEpoch := df.ethNetwork.EstimatedEpochAtSlot(types.Slot(slot))
 		epoch := spec.Epoch(esEpoch)
 		results, err := df.beaconClient.GetDuties(epoch, indices)
-		return results, err
+		if err != nil {
+			return nil, err
+		}
+		// failing to get sync committee duties should not prevent the execution of other duties
+		syncCommitteeDuties, err := df.beaconClient.GetSyncCommitteeDuties(epoch, indices)
+		if err != nil {
+			df.logger.Warn("failed to get sync committee duties", zap.Error(err))
+			return results, nil
+		}
+		return append(results, syncCommitteeDuties...), nil
 	}
 	df.logger.Debug("no indices, duties won't be fetched")
 	return []*beacon.Duty{}, nil
@@ -132,15 +144,34 @@ func (df *dutyFetcher) fetchDuties(slot uint64) ([]*beacon.Duty, error) {
 func (df *dutyFetcher) processFetchedDuties(fetchedDuties []*beacon.Duty) error {
 	if len(fetchedDuties) > 0 {
 		var subscriptions []*eth2apiv1.BeaconCommitteeSubscription
+		// syncSubscriptions holds a single subscription for each sync committee member
+		syncSubscriptions := map[spec.ValidatorIndex]*eth2apiv1.SyncCommitteeSubscription{}
 		// entries holds all the new duties to add
 		entries := map[spec.Slot]cacheEntry{}
 		for _, duty := range fetchedDuties {
 			df.fillEntry(entries, duty)
-			subscriptions = append(subscriptions, toSubscription(duty))
+			// only attestation duties are related to committee subnets
+			if duty.Type == beacon.RoleTypeAttester {
+				subscriptions = append(subscriptions, toSubscription(duty))
+			}
+			if _, exist := syncSubscriptions[duty.ValidatorIndex]; !exist && duty.Type == beacon.RoleTypeSyncCommittee {
+				syncSubscriptions[duty.ValidatorIndex] = df.toSyncCommitteeSubscription(duty)
+			}
 		}
 		df.populateCache(entries)
-		if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
-			df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+		if len(subscriptions) > 0 {
+			if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
+				df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+			}
+		}
+		if len(syncSubscriptions) > 0 {
+			toSubscribe := make([]*eth2apiv1.SyncCommitteeSubscription, 0, len(syncSubscriptions))
+			for _, subscription := range syncSubscriptions {
+				toSubscribe = append(toSubscribe, subscription)
+			}
+			if err := df.beaconClient.SubscribeToSyncCommitteeSubnet(toSubscribe); err != nil {
+				df.logger.Warn("failed to subscribe sync committee to subnet", zap.Error(err))
+			}
 		}
 	}
 	return nil
@@ -167,7 +198,7 @@ func (df *dutyFetcher) populateCache(entriesToAdd map[spec.Slot]cacheEntry) {
 			for _, newDuty := range e.Duties {
 				exist := false
 				for _, existDuty := range existingEntry.Duties {
-					if newDuty.ValidatorIndex == existDuty.ValidatorIndex {
+					if newDuty.ValidatorIndex == existDuty.ValidatorIndex && newDuty.Type == existDuty.Type {
 						exist = true
 						break // already exist, pass
 					}
@@ -224,6 +255,18 @@ func toSubscription(duty *beacon.Duty) *eth2apiv1.BeaconCommitteeSubscription {
 	}
 }
 
+// toSyncCommitteeSubscription creates a sync committee subscription from the given duty,
+// the subscription lasts until the end of the current sync committee period
+func (df *dutyFetcher) toSyncCommitteeSubscription(duty *beacon.Duty) *eth2apiv1.SyncCommitteeSubscription {
+	epoch := uint64(df.ethNetwork.EstimatedEpochAtSlot(types.Slot(duty.Slot)))
+	untilEpoch := (epoch/beacon.EpochsPerSyncCommitteePeriod + 1) * beacon.EpochsPerSyncCommitteePeriod
+	return &eth2apiv1.SyncCommitteeSubscription{
+		ValidatorIndex:       duty.ValidatorIndex,
+		SyncCommitteeIndices: duty.ValidatorSyncCommitteeIndices,
+		UntilEpoch:           spec.Epoch(untilEpoch),
+	}
+}
+
 type serializedDuty struct {
 	PubKey                  string
 	Type                    string
@@ -17,7 +17,7 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		bcMock := beaconDutiesClientMock{
 			getDutiesErr: expectedErr,
 		}
-		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, core.PraterNetwork)
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, beacon.NewNetwork(core.PraterNetwork, 0, nil))
 		duties, err := dm.GetDuties(893108)
 		require.EqualError(t, err, "failed to get duties from beacon: test duties")
 		require.Len(t, duties, 0)
@@ -32,7 +32,7 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		}
 		bcMock := beaconDutiesClientMock{duties: beaconDuties}
 		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
-			core.NetworkFromString(string(core.PraterNetwork)))
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
 		duties, err := dm.GetDuties(893108)
 		require.NoError(t, err)
 		require.Len(t, duties, 1)
@@ -51,7 +51,7 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		}
 		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
 		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
-			core.NetworkFromString(string(core.PraterNetwork)))
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
 		duties, err := dm.GetDuties(893108)
 		require.NoError(t, err)
 		require.Len(t, duties, 1)
@@ -64,6 +64,103 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		require.Len(t, duties, 1)
 	})
 
+	t.Run("serves attester and proposer duties of the same validator", func(t *testing.T) {
+		fetchedDuties := []*beacon.Duty{
+			{
+				Type:           beacon.RoleTypeProposer,
+				Slot:           893108,
+				PubKey:         spec.BLSPubKey{},
+				ValidatorIndex: 205238,
+			},
+			{
+				Type:           beacon.RoleTypeAttester,
+				Slot:           893108,
+				PubKey:         spec.BLSPubKey{},
+				ValidatorIndex: 205238,
+			},
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 2)
+		require.True(t, bcMock.subscribed)
+	})
+
+	t.Run("don't subscribe to subnets w/o attester duties", func(t *testing.T) {
+		fetchedDuties := []*beacon.Duty{
+			{
+				Type:           beacon.RoleTypeProposer,
+				Slot:           893108,
+				PubKey:         spec.BLSPubKey{},
+				ValidatorIndex: 205238,
+			},
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 1)
+		require.False(t, bcMock.subscribed)
+	})
+
+	t.Run("serves sync committee duties and subscribes once per validator", func(t *testing.T) {
+		fetchedDuties := []*beacon.Duty{
+			{
+				Type:           beacon.RoleTypeAttester,
+				Slot:           893108,
+				PubKey:         spec.BLSPubKey{},
+				ValidatorIndex: 205238,
+			},
+		}
+		var syncDuties []*beacon.Duty
+		for _, slot := range []spec.Slot{893108, 893109} {
+			for _, role := range []beacon.RoleType{beacon.RoleTypeSyncCommittee, beacon.RoleTypeSyncCommitteeContribution} {
+				syncDuties = append(syncDuties, &beacon.Duty{
+					Type:                          role,
+					Slot:                          slot,
+					PubKey:                        spec.BLSPubKey{},
+					ValidatorIndex:                205238,
+					ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{130},
+				})
+			}
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties, syncCommitteeDuties: syncDuties}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 3)
+		duties, err = dm.GetDuties(893109)
+		require.NoError(t, err)
+		require.Len(t, duties, 2)
+
+		require.Len(t, bcMock.syncSubscriptions, 1)
+		require.EqualValues(t, 205238, bcMock.syncSubscriptions[0].ValidatorIndex)
+		require.Equal(t, []spec.CommitteeIndex{130}, bcMock.syncSubscriptions[0].SyncCommitteeIndices)
+		// slot 893108 is in epoch 27909, period 109 ends at epoch 28160
+		require.EqualValues(t, 28160, bcMock.syncSubscriptions[0].UntilEpoch)
+	})
+
+	t.Run("serves other duties when sync committee duties fail", func(t *testing.T) {
+		fetchedDuties := []*beacon.Duty{
+			{
+				Type:           beacon.RoleTypeAttester,
+				Slot:           893108,
+				PubKey:         spec.BLSPubKey{},
+				ValidatorIndex: 205238,
+			},
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties, getSyncDutiesErr: errors.New("test sync duties")}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 1)
+	})
+
 	t.Run("handles no indices", func(t *testing.T) {
 		fetchedDuties := []*beacon.Duty{
 			{
@@ -73,7 +170,7 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		}
 		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
 		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{}},
-			core.PraterNetwork)
+			beacon.NewNetwork(core.PraterNetwork, 0, nil))
 		duties, err := dm.GetDuties(893108)
 		require.NoError(t, err)
 		require.Len(t, duties, 0)
@@ -83,7 +180,7 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 func TestDutyFetcher_AddMissingSlots(t *testing.T) {
 	df := dutyFetcher{
 		logger:     zap.L(),
-		ethNetwork: core.PraterNetwork,
+		ethNetwork: beacon.NewNetwork(core.PraterNetwork, 0, nil),
 	}
 	tests := []struct {
 		name string
@@ -122,6 +219,10 @@ type beaconDutiesClientMock struct {
 	getDutiesErr      error
 	subToCommitteeErr error
 	subscribed        bool
+
+	syncCommitteeDuties []*beacon.Duty
+	getSyncDutiesErr    error
+	syncSubscriptions   []*eth2apiv1.SyncCommitteeSubscription
 }
 
 // GetDuties returns duties for the passed validators indices
@@ -134,3 +235,14 @@ func (bc *beaconDutiesClientMock) SubscribeToCommitteeSubnet(subscription []*eth
 	bc.subscribed = true
 	return bc.subToCommitteeErr
 }
+
+// GetSyncCommitteeDuties returns the sync committee duties for the passed validators indices
+func (bc *beaconDutiesClientMock) GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	return bc.syncCommitteeDuties, bc.getSyncDutiesErr
+}
+
+// SubscribeToSyncCommitteeSubnet subscribes sync committee members to subnets (p2p topics)
+func (bc *beaconDutiesClientMock) SubscribeToSyncCommitteeSubnet(subscriptions []*eth2apiv1.SyncCommitteeSubscription) error {
+	bc.syncSubscriptions = append(bc.syncSubscriptions, subscriptions...)
+	return nil
+}
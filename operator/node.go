@@ -2,30 +2,39 @@ package operator
 
 import (
 	"context"
+	"io"
 
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/dkg"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/network"
 	"github.com/bloxapp/ssv/operator/duties"
 	"github.com/bloxapp/ssv/operator/forks"
+	"github.com/bloxapp/ssv/storage/backup"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
 	"github.com/bloxapp/ssv/utils/tasks"
 	"github.com/bloxapp/ssv/validator"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
+var errDKGDisabled = errors.New("dkg is disabled")
+
 // Node represents the behavior of SSV node
 type Node interface {
 	Start() error
 	StartEth1(syncOffset *eth1.SyncOffset) error
+	ValidatorsStatusProvider
+	DutyRecordsProvider
+	DKGProvider
+	DBBackupProvider
 }
 
 // Options contains options to create the node
 type Options struct {
-	ETHNetwork          *core.Network
+	ETHNetwork          *beacon.Network
 	Beacon              beacon.Beacon
 	Network             network.Network
 	Context             context.Context
@@ -34,11 +43,14 @@ type Options struct {
 	DB                  basedb.IDb
 	ValidatorController validator.Controller
 	DutyExec            duties.DutyExecutor
+	// DKGController is optional, dkg ceremonies are not supported if it is nil
+	DKGController dkg.Controller
 	// genesis epoch
 	GenesisEpoch uint64 `yaml:"GenesisEpoch" env:"GENESIS_EPOCH" env-description:"Genesis Epoch SSV node will start"`
 	// max slots for duty to wait
 	DutyLimit        uint64                      `yaml:"DutyLimit" env:"DUTY_LIMIT" env-default:"32" env-description:"max slots to wait for duty to start"`
 	ValidatorOptions validator.ControllerOptions `yaml:"ValidatorOptions"`
+	DKGOptions       dkg.ControllerOptions       `yaml:"DKGOptions"`
 	Fork             forks.Fork
 
 	UseMainTopic bool
@@ -46,15 +58,17 @@ type Options struct {
 
 // operatorNode implements Node interface
 type operatorNode struct {
-	ethNetwork     core.Network
+	ethNetwork     beacon.Network
 	context        context.Context
 	validatorsCtrl validator.Controller
 	logger         *zap.Logger
 	beacon         beacon.Beacon
 	net            network.Network
 	storage        Storage
+	db             basedb.IDb
 	eth1Client     eth1.Client
 	dutyCtrl       duties.DutyController
+	dkgCtrl        dkg.Controller
 	fork           forks.Fork
 
 	useMainTopic bool
@@ -71,6 +85,8 @@ func New(opts Options) Node {
 		net:            opts.Network,
 		eth1Client:     opts.Eth1Client,
 		storage:        NewNodeStorage(opts.DB, opts.Logger),
+		db:             opts.DB,
+		dkgCtrl:        opts.DKGController,
 
 		dutyCtrl: duties.NewDutyController(&duties.ControllerOptions{
 			Logger:              opts.Logger,
@@ -115,8 +131,15 @@ func (n *operatorNode) Start() error {
 		}
 	}
 	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
+	go n.validatorsCtrl.PruneDutyJournalLoop()
+	go n.validatorsCtrl.CompactionLoop()
 	n.dutyCtrl.Start()
 	go n.listenForCurrentSlot()
+	if n.dkgCtrl != nil {
+		if err := n.dkgCtrl.Start(); err != nil {
+			n.logger.Error("failed to start dkg controller", zap.Error(err))
+		}
+	}
 
 	return nil
 }
@@ -131,6 +154,14 @@ func (n *operatorNode) listenForCurrentSlot() {
 func (n *operatorNode) StartEth1(syncOffset *eth1.SyncOffset) error {
 	n.logger.Info("starting operator node syncing with eth1")
 
+	// validators that were added up to the last synced block are re-synced rather than new
+	lastSyncedBlock, found, err := n.storage.GetSyncOffset()
+	if err != nil {
+		return errors.Wrap(err, "failed to get last synced block")
+	}
+	if found {
+		n.validatorsCtrl.SetLastSyncedBlock(lastSyncedBlock)
+	}
 	handler := n.validatorsCtrl.Eth1EventHandler()
 	// sync past events
 	if err := eth1.SyncEth1Events(n.logger, n.eth1Client, n.storage, syncOffset, handler); err != nil {
@@ -161,6 +192,45 @@ func (n *operatorNode) StartEth1(syncOffset *eth1.SyncOffset) error {
 	return nil
 }
 
+// ValidatorsStatus returns the status of the validators that are managed by the node
+func (n *operatorNode) ValidatorsStatus(filter validator.StatusFilter) ([]*validator.ValidatorStatus, int, error) {
+	return n.validatorsCtrl.GetValidatorsStatus(filter)
+}
+
+// DutyRecords returns the journal records of the duties that were executed by the node
+func (n *operatorNode) DutyRecords(pubKey string, fromSlot, toSlot uint64) ([]*collections.DutyRecord, error) {
+	return n.validatorsCtrl.GetDutyRecords(pubKey, fromSlot, toSlot)
+}
+
+// StartCeremony starts a dkg ceremony between the given operators
+func (n *operatorNode) StartCeremony(operators []string, threshold uint64) (string, error) {
+	if n.dkgCtrl == nil {
+		return "", errDKGDisabled
+	}
+	return n.dkgCtrl.StartCeremony(operators, threshold)
+}
+
+// StartReshare starts a dkg ceremony that reshares the given validator to a new committee
+func (n *operatorNode) StartReshare(validatorPubKey string, operators []string, threshold uint64) (string, error) {
+	if n.dkgCtrl == nil {
+		return "", errDKGDisabled
+	}
+	return n.dkgCtrl.StartReshare(validatorPubKey, operators, threshold)
+}
+
+// GetCeremony returns the state of the given dkg ceremony
+func (n *operatorNode) GetCeremony(id string) (*dkg.CeremonyState, bool, error) {
+	if n.dkgCtrl == nil {
+		return nil, false, errDKGDisabled
+	}
+	return n.dkgCtrl.GetCeremony(id)
+}
+
+// BackupDB writes a backup of the node db to the given writer
+func (n *operatorNode) BackupDB(w io.Writer) error {
+	return backup.Write(n.db, w)
+}
+
 // HealthCheck returns a list of issues regards the state of the operator node
 func (n *operatorNode) HealthCheck() []string {
 	return metrics.ProcessAgents(n.healthAgents())
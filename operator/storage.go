@@ -3,19 +3,23 @@ package operator
 import (
 	"crypto/rsa"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"math/big"
 
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 var (
-	prefix        = []byte("operator-")
-	syncOffsetKey = []byte("syncOffset")
+	prefix             = []byte("operator-")
+	syncOffsetKey      = []byte("syncOffset")
+	syncedBlocksPrefix = []byte("syncedBlocks/")
 )
 
 // Storage represents the interface for ssv node storage
@@ -26,12 +30,15 @@ type Storage interface {
 
 	GetPrivateKey() (*rsa.PrivateKey, bool, error)
 	SetupPrivateKey(generateIfNone bool, operatorKeyBase64 string) error
+	SetEncryptionKey(key []byte) error
 }
 
 type storage struct {
 	db     basedb.IDb
 	logger *zap.Logger
 
+	encryptionKey []byte
+
 	operatorStore registrystorage.OperatorsCollection
 }
 
@@ -52,6 +59,10 @@ func (s *storage) SaveOperatorInformation(operatorInformation *registrystorage.O
 	return s.operatorStore.SaveOperatorInformation(operatorInformation)
 }
 
+func (s *storage) DeleteOperatorInformation(operatorPubKey string) error {
+	return s.operatorStore.DeleteOperatorInformation(operatorPubKey)
+}
+
 func (s *storage) ListOperators(from int64, to int64) ([]registrystorage.OperatorInformation, error) {
 	return s.operatorStore.ListOperators(from, to)
 }
@@ -66,6 +77,11 @@ func (s *storage) CleanRegistryData() error {
 		return errors.Wrap(err, "could not clean sync offset")
 	}
 
+	err = s.cleanSyncedBlocks()
+	if err != nil {
+		return errors.Wrap(err, "could not clean synced blocks")
+	}
+
 	err = s.cleanOperators()
 	if err != nil {
 		return errors.Wrap(err, "could not clean operators")
@@ -101,16 +117,56 @@ func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 	return offset, found, nil
 }
 
+// SaveSyncedBlock saves a block that contract events were applied from
+func (s *storage) SaveSyncedBlock(block *eth1.SyncedBlock) error {
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal synced block")
+	}
+	return s.db.Set(prefix, syncedBlockKey(block.Number), raw)
+}
+
+// GetSyncedBlocks returns the saved blocks, sorted by block number
+func (s *storage) GetSyncedBlocks() ([]*eth1.SyncedBlock, error) {
+	var blocks []*eth1.SyncedBlock
+	err := s.db.GetAll(append(prefix, syncedBlocksPrefix...), func(i int, obj basedb.Obj) error {
+		block := &eth1.SyncedBlock{}
+		if err := json.Unmarshal(obj.Value, block); err != nil {
+			return errors.Wrap(err, "could not unmarshal synced block")
+		}
+		blocks = append(blocks, block)
+		return nil
+	})
+	eth1.SortSyncedBlocks(blocks)
+	return blocks, err
+}
+
+// DeleteSyncedBlock removes the block with the given number
+func (s *storage) DeleteSyncedBlock(number uint64) error {
+	return s.db.Delete(prefix, syncedBlockKey(number))
+}
+
+func (s *storage) cleanSyncedBlocks() error {
+	return s.db.RemoveAllByCollection(append(prefix, syncedBlocksPrefix...))
+}
+
+func syncedBlockKey(number uint64) []byte {
+	key := make([]byte, len(syncedBlocksPrefix)+8)
+	copy(key, syncedBlocksPrefix)
+	binary.BigEndian.PutUint64(key[len(syncedBlocksPrefix):], number)
+	return key
+}
+
 // GetPrivateKey return rsa private key
 func (s *storage) GetPrivateKey() (*rsa.PrivateKey, bool, error) {
-	obj, found, err := s.db.Get(prefix, []byte("private-key"))
+	operatorKey, found, err := s.getPrivateKeyPem()
 	if err != nil {
 		return nil, false, err
 	}
 	if !found {
 		return nil, found, nil
 	}
-	sk, err := rsaencryption.ConvertPemToPrivateKey(string(obj.Value))
+	sk, err := rsaencryption.ConvertPemToPrivateKey(operatorKey)
 	if err != nil {
 		return nil, false, err
 	}
@@ -172,9 +228,53 @@ func (s *storage) validateKey(generateIfNone bool, operatorKey string) error {
 	return nil
 }
 
+// SetEncryptionKey sets the key that the operator private key is encrypted with,
+// a private key that was saved in plain form is encrypted
+func (s *storage) SetEncryptionKey(key []byte) error {
+	operatorKey, found, err := s.getPrivateKeyPem()
+	if err != nil {
+		return err
+	}
+	s.encryptionKey = key
+	if !found {
+		return nil
+	}
+	return s.savePrivateKey(operatorKey)
+}
+
+// getPrivateKeyPem returns the operator private key (pem), decrypted if needed
+func (s *storage) getPrivateKeyPem() (string, bool, error) {
+	obj, found, err := s.db.Get(prefix, []byte("private-key"))
+	if err != nil {
+		return "", false, err
+	}
+	if !found {
+		return "", found, nil
+	}
+	if !encryption.IsEncrypted(obj.Value) {
+		return string(obj.Value), found, nil
+	}
+	if len(s.encryptionKey) == 0 {
+		return "", false, errors.New("operator private key is encrypted, storage must be unlocked")
+	}
+	raw, err := encryption.Decrypt(s.encryptionKey, obj.Value)
+	if err != nil {
+		return "", false, errors.Wrap(err, "could not decrypt operator private key")
+	}
+	return string(raw), found, nil
+}
+
 // SavePrivateKey save operator private key
 func (s *storage) savePrivateKey(operatorKey string) error {
-	if err := s.db.Set(prefix, []byte("private-key"), []byte(operatorKey)); err != nil {
+	value := []byte(operatorKey)
+	if len(s.encryptionKey) > 0 {
+		encrypted, err := encryption.Encrypt(s.encryptionKey, value)
+		if err != nil {
+			return errors.Wrap(err, "could not encrypt operator private key")
+		}
+		value = encrypted
+	}
+	if err := s.db.Set(prefix, []byte("private-key"), value); err != nil {
 		return err
 	}
 	return nil
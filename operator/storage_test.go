@@ -5,8 +5,10 @@ import (
 	"github.com/bloxapp/ssv/eth1"
 	ssvstorage "github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/encryption"
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -46,6 +48,46 @@ func TestSaveAndGetPrivateKey(t *testing.T) {
 	require.Equal(t, pkPem, operatorPublicKey)
 }
 
+func TestEncryptedPrivateKey(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	operatorStorage := storage{
+		db:     db,
+		logger: zap.L(),
+	}
+	keyByte, err := base64.StdEncoding.DecodeString(skPem)
+	require.NoError(t, err)
+	require.NoError(t, operatorStorage.savePrivateKey(string(keyByte)))
+
+	// the existing key is encrypted once an encryption key is set
+	encryptionKey, err := encryption.Unlock(db, "password")
+	require.NoError(t, err)
+	require.NoError(t, operatorStorage.SetEncryptionKey(encryptionKey))
+	obj, found, err := db.Get(prefix, []byte("private-key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, encryption.IsEncrypted(obj.Value))
+
+	sk, found, err := operatorStorage.GetPrivateKey()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, string(keyByte), string(rsaencryption.PrivateKeyToByte(sk)))
+
+	// a locked storage can't read the key
+	locked := storage{
+		db:     db,
+		logger: zap.L(),
+	}
+	_, _, err = locked.GetPrivateKey()
+	require.EqualError(t, err, "operator private key is encrypted, storage must be unlocked")
+}
+
 func TestSetupPrivateKey(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -189,3 +231,39 @@ func TestStorage_SaveAndGetSyncOffset(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, offset.Cmp(o))
 }
+
+func TestStorage_SyncedBlocks(t *testing.T) {
+	logger := zap.L()
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	s := NewNodeStorage(db, logger)
+
+	require.NoError(t, s.SaveSyncOffset(new(eth1.SyncOffset).SetUint64(300)))
+	require.NoError(t, s.SaveSyncedBlock(&eth1.SyncedBlock{Number: 300, Hash: common.HexToHash("0x2")}))
+	require.NoError(t, s.SaveSyncedBlock(&eth1.SyncedBlock{Number: 1, Hash: common.HexToHash("0x1")}))
+
+	blocks, err := s.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(1), blocks[0].Number)
+	require.Equal(t, common.HexToHash("0x1"), blocks[0].Hash)
+	require.Equal(t, uint64(300), blocks[1].Number)
+
+	require.NoError(t, s.DeleteSyncedBlock(1))
+	blocks, err = s.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, uint64(300), blocks[0].Number)
+
+	require.NoError(t, s.CleanRegistryData())
+	blocks, err = s.GetSyncedBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 0)
+	_, found, err := s.GetSyncOffset()
+	require.NoError(t, err)
+	require.False(t, found)
+}
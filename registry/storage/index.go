@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// IndexKey returns the key of the given index in an index collection,
+// indexes are encoded as big endian so the keys are ordered by index
+func IndexKey(index int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(index))
+	return b
+}
+
+// NextIndex returns the highest index + 1 of the given index collection
+func NextIndex(db basedb.IDb, indexPrefix []byte) (int64, error) {
+	var next int64
+	err := db.Iterate(indexPrefix, basedb.IterateOptions{Reverse: true, Limit: 1}, func(i int, obj basedb.Obj) error {
+		next = int64(binary.BigEndian.Uint64(obj.Key)) + 1
+		return nil
+	})
+	return next, err
+}
+
+// IndexedKeys returns the keys that are held by the given index collection for the indexes in the range [from, to],
+// ordered by index
+func IndexedKeys(db basedb.IDb, indexPrefix []byte, from int64, to int64) ([][]byte, error) {
+	if from < 0 {
+		from = 0
+	}
+	if to < from {
+		return nil, nil
+	}
+	opts := basedb.IterateOptions{Start: IndexKey(from)}
+	if to < math.MaxInt64 {
+		opts.End = IndexKey(to + 1)
+	}
+	var keys [][]byte
+	err := db.Iterate(indexPrefix, opts, func(i int, obj basedb.Obj) error {
+		keys = append(keys, obj.Value)
+		return nil
+	})
+	return keys, err
+}
@@ -14,6 +14,9 @@ import (
 
 var (
 	operatorsPrefix = []byte("operators")
+	// operatorsIndexPrefix is the collection of operator keys by index, it is under operatorsPrefix
+	// so it is removed together with the operators
+	operatorsIndexPrefix = []byte("operators_index/")
 )
 
 // OperatorInformation the public data of an operator
@@ -28,6 +31,7 @@ type OperatorInformation struct {
 type OperatorsCollection interface {
 	GetOperatorInformation(operatorPubKey string) (*OperatorInformation, bool, error)
 	SaveOperatorInformation(operatorInformation *OperatorInformation) error
+	DeleteOperatorInformation(operatorPubKey string) error
 	ListOperators(from int64, to int64) ([]OperatorInformation, error)
 	GetOperatorsPrefix() []byte
 }
@@ -53,24 +57,24 @@ func (s *operatorsStorage) GetOperatorsPrefix() []byte {
 	return operatorsPrefix
 }
 
-// ListOperators returns information of the all known operators
-// when 'to' equals zero, all operators will be returned
+// ListOperators returns information of the known operators with an index in the range [from, to], ordered by index
 func (s *operatorsStorage) ListOperators(from int64, to int64) ([]OperatorInformation, error) {
 	s.operatorsLock.RLock()
 	defer s.operatorsLock.RUnlock()
 
+	keys, err := IndexedKeys(s.db, s.indexPrefix(), from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read operators index")
+	}
 	var operators []OperatorInformation
-	err := s.db.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
+	err = s.db.GetMany(s.prefix, keys, func(obj basedb.Obj) error {
 		var oi OperatorInformation
 		if err := json.Unmarshal(obj.Value, &oi); err != nil {
 			return err
 		}
-		if oi.Index >= from && oi.Index <= to {
-			operators = append(operators, oi)
-		}
+		operators = append(operators, oi)
 		return nil
 	})
-
 	return operators, err
 }
 
@@ -113,7 +117,7 @@ func (s *operatorsStorage) SaveOperatorInformation(operatorInformation *Operator
 		return nil
 	}
 
-	operatorInformation.Index, err = s.nextIndex(operatorsPrefix)
+	operatorInformation.Index, err = s.nextIndex()
 	if err != nil {
 		return errors.Wrap(err, "could not calculate next operator index")
 	}
@@ -121,11 +125,44 @@ func (s *operatorsStorage) SaveOperatorInformation(operatorInformation *Operator
 	if err != nil {
 		return errors.Wrap(err, "could not marshal operator information")
 	}
-	return s.db.Set(s.prefix, operatorKey(operatorInformation.PublicKey), raw)
+	key := operatorKey(operatorInformation.PublicKey)
+	return s.db.Update(func(txn basedb.Txn) error {
+		if err := txn.Set(s.prefix, key, raw); err != nil {
+			return err
+		}
+		return txn.Set(s.prefix, operatorIndexKey(operatorInformation.Index), key)
+	})
 }
 
-func (s *operatorsStorage) nextIndex(prefix []byte) (int64, error) {
-	return s.db.CountByCollection(append(s.prefix, prefix...))
+// DeleteOperatorInformation removes the information of the given operator
+func (s *operatorsStorage) DeleteOperatorInformation(operatorPubKey string) error {
+	s.operatorsLock.Lock()
+	defer s.operatorsLock.Unlock()
+
+	info, found, err := s.getOperatorInformation(operatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read information from DB")
+	}
+	if !found {
+		return nil
+	}
+	return s.db.Update(func(txn basedb.Txn) error {
+		if err := txn.Delete(s.prefix, operatorKey(operatorPubKey)); err != nil {
+			return err
+		}
+		return txn.Delete(s.prefix, operatorIndexKey(info.Index))
+	})
+}
+
+// nextIndex returns the highest existing index + 1,
+// counting the objects is not enough as operators might be deleted
+func (s *operatorsStorage) nextIndex() (int64, error) {
+	return NextIndex(s.db, s.indexPrefix())
+}
+
+// indexPrefix returns the prefix of the operators index collection
+func (s *operatorsStorage) indexPrefix() []byte {
+	return append(append([]byte{}, s.prefix...), operatorsIndexPrefix...)
 }
 
 func operatorKey(pubKey string) []byte {
@@ -134,3 +171,7 @@ func operatorKey(pubKey string) []byte {
 		[]byte(pubKey),
 	}, []byte("/"))
 }
+
+func operatorIndexKey(index int64) []byte {
+	return append(append([]byte{}, operatorsIndexPrefix...), IndexKey(index)...)
+}
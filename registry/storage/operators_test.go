@@ -61,7 +61,7 @@ func TestStorage_SaveAndGetOperatorInformation(t *testing.T) {
 	})
 
 	t.Run("create and get multiple operators", func(t *testing.T) {
-		i, err := storage.(*operatorsStorage).nextIndex(operatorsPrefix)
+		i, err := storage.(*operatorsStorage).nextIndex()
 		require.NoError(t, err)
 
 		ois := []OperatorInformation{
@@ -118,6 +118,46 @@ func TestStorage_ListOperators(t *testing.T) {
 	for _, operator := range operators {
 		require.True(t, strings.Contains(operator.Name, "operator-"))
 	}
+	operators, err = storage.ListOperators(2, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(operators))
+	for i, operator := range operators {
+		require.Equal(t, int64(i+2), operator.Index)
+	}
+}
+
+func TestStorage_DeleteOperatorInformation(t *testing.T) {
+	storage, done := newStorageForTest()
+	require.NotNil(t, storage)
+	defer done()
+
+	var pks []string
+	for i := 0; i < 3; i++ {
+		pk, _, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operator := OperatorInformation{
+			PublicKey: string(pk),
+			Name:      fmt.Sprintf("operator-%d", i+1),
+		}
+		require.NoError(t, storage.SaveOperatorInformation(&operator))
+		pks = append(pks, operator.PublicKey)
+	}
+
+	require.NoError(t, storage.DeleteOperatorInformation(pks[1]))
+	_, found, err := storage.GetOperatorInformation(pks[1])
+	require.NoError(t, err)
+	require.False(t, found)
+
+	t.Run("index is not reused after delete", func(t *testing.T) {
+		pk, _, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operator := OperatorInformation{
+			PublicKey: string(pk),
+			Name:      "operator-4",
+		}
+		require.NoError(t, storage.SaveOperatorInformation(&operator))
+		require.Equal(t, int64(3), operator.Index)
+	})
 }
 
 func newStorageForTest() (OperatorsCollection, func()) {
@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"os"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+)
+
+// header is the beginning of backup files, it holds the version of the format
+const header = "ssv-db-backup-v1\n"
+
+// Write writes a backup of the given db: a header, a consistent snapshot of the db and its checksum (sha256)
+func Write(db basedb.IDb, w io.Writer) error {
+	if _, err := io.WriteString(w, header); err != nil {
+		return errors.Wrap(err, "could not write header")
+	}
+	h := sha256.New()
+	if err := db.Backup(io.MultiWriter(w, h)); err != nil {
+		return errors.Wrap(err, "could not write snapshot")
+	}
+	if _, err := w.Write(h.Sum(nil)); err != nil {
+		return errors.Wrap(err, "could not write checksum")
+	}
+	return nil
+}
+
+// Verify checks the header and the checksum of the given backup file
+func Verify(path string) error {
+	f, snapshot, err := open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return verify(f, snapshot)
+}
+
+// Restore replaces the content of the db with the snapshot of the given backup file,
+// the db is verified against the checksum of the file before its content is replaced
+func Restore(db basedb.IDb, path string) error {
+	f, snapshot, err := open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	checksum, err := readChecksum(f, snapshot)
+	if err != nil {
+		return err
+	}
+	if err := db.Restore(snapshot, checksum); err != nil {
+		return errors.Wrap(err, "could not restore snapshot")
+	}
+	return nil
+}
+
+// open opens the given backup file and checks its header, it returns the file and a reader of the snapshot
+func open(path string) (*os.File, *io.SectionReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not open backup")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, errors.Wrap(err, "could not read backup")
+	}
+	size := info.Size() - int64(len(header)) - sha256.Size
+	if size < 0 {
+		_ = f.Close()
+		return nil, nil, errors.New("backup is too short")
+	}
+	h := make([]byte, len(header))
+	if _, err := io.ReadFull(f, h); err != nil {
+		_ = f.Close()
+		return nil, nil, errors.Wrap(err, "could not read header")
+	}
+	if string(h) != header {
+		_ = f.Close()
+		return nil, nil, errors.New("unknown backup format")
+	}
+	return f, io.NewSectionReader(f, int64(len(header)), size), nil
+}
+
+// verify compares the checksum at the end of the file with the checksum of the snapshot
+func verify(f *os.File, snapshot *io.SectionReader) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, snapshot); err != nil {
+		return errors.Wrap(err, "could not read snapshot")
+	}
+	checksum, err := readChecksum(f, snapshot)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum, h.Sum(nil)) {
+		return errors.New("invalid checksum, backup is corrupted or incomplete")
+	}
+	return nil
+}
+
+// readChecksum reads the checksum at the end of the file, which follows the given snapshot
+func readChecksum(f *os.File, snapshot *io.SectionReader) ([]byte, error) {
+	checksum := make([]byte, sha256.Size)
+	if _, err := f.ReadAt(checksum, int64(len(header))+snapshot.Size()); err != nil {
+		return nil, errors.Wrap(err, "could not read checksum")
+	}
+	return checksum, nil
+}
@@ -0,0 +1,77 @@
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestDb(t *testing.T) basedb.IDb {
+	db, err := kv.New(basedb.Options{Type: "badger-memory", Logger: zap.L()})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	return db
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	db := newTestDb(t)
+	prefix := []byte("prefix")
+	for i := 0; i < 100; i++ {
+		require.NoError(t, db.Set(prefix, []byte{byte(i)}, bytes.Repeat([]byte{byte(i)}, 100)))
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(db, buf))
+	path := filepath.Join(t.TempDir(), "backup")
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+	require.NoError(t, Verify(path))
+
+	restored := newTestDb(t)
+	// existing items are removed by the restore
+	require.NoError(t, restored.Set([]byte("other"), []byte("key"), []byte("value")))
+	require.NoError(t, Restore(restored, path))
+	count, err := restored.CountByCollection(prefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), count)
+	obj, found, err := restored.Get(prefix, []byte{7})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, bytes.Repeat([]byte{7}, 100), obj.Value)
+	_, found, err = restored.Get([]byte("other"), []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestVerify(t *testing.T) {
+	db := newTestDb(t)
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(db, buf))
+	raw := buf.Bytes()
+
+	writeFile := func(raw []byte) string {
+		path := filepath.Join(t.TempDir(), "backup")
+		require.NoError(t, ioutil.WriteFile(path, raw, 0600))
+		return path
+	}
+	corrupted := append([]byte{}, raw...)
+	corrupted[len(header)+1]++
+
+	require.EqualError(t, Verify(writeFile(raw[:len(raw)-10])), "invalid checksum, backup is corrupted or incomplete")
+	require.EqualError(t, Verify(writeFile(corrupted)), "invalid checksum, backup is corrupted or incomplete")
+	require.EqualError(t, Verify(writeFile(raw[:10])), "backup is too short")
+	require.EqualError(t, Verify(writeFile(append([]byte("other"), raw[5:]...))), "unknown backup format")
+
+	// a corrupted backup doesn't change the db
+	restored := newTestDb(t)
+	require.NoError(t, restored.Set([]byte("other"), []byte("key"), []byte("value")))
+	require.Error(t, Restore(restored, writeFile(corrupted)))
+	_, found, err := restored.Get([]byte("other"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+}
@@ -2,6 +2,7 @@ package basedb
 
 import (
 	"context"
+	"io"
 
 	"go.uber.org/zap"
 )
@@ -15,12 +16,25 @@ type Options struct {
 	Ctx       context.Context
 }
 
+// IterateOptions are the options of an ordered iteration over the items of a collection,
+// the keys of the range are relative to the collection prefix
+type IterateOptions struct {
+	// Start is the first key (inclusive) of the range, nil means the beginning of the collection
+	Start []byte
+	// End is the last key (exclusive) of the range, nil means the end of the collection
+	End []byte
+	// Reverse iterates from the end of the range to its start
+	Reverse bool
+	// Limit is the max amount of items to iterate, 0 means no limit
+	Limit int
+}
+
 // Txn interface for badger transaction like functions
 type Txn interface {
 	Set(prefix []byte, key []byte, value []byte) error
 	Get(prefix []byte, key []byte) (Obj, bool, error)
 	Delete(prefix []byte, key []byte) error
-	// TODO: add iterator
+	Iterate(prefix []byte, opts IterateOptions, handler func(int, Obj) error) error
 }
 
 // RegistryStore interface for registry store
@@ -37,9 +51,18 @@ type IDb interface {
 	GetMany(prefix []byte, keys [][]byte, iterator func(Obj) error) error
 	Delete(prefix []byte, key []byte) error
 	GetAll(prefix []byte, handler func(int, Obj) error) error
+	// Iterate iterates the items of the collection in the given range, ordered by key
+	Iterate(prefix []byte, opts IterateOptions, handler func(int, Obj) error) error
 	CountByCollection(prefix []byte) (int64, error)
 	RemoveAllByCollection(prefix []byte) error
 	Update(fn func(Txn) error) error
+	// CollectGarbage reclaims the space of deleted or overridden values
+	CollectGarbage() error
+	// Backup writes a consistent snapshot of the db to the given writer, while the db can still be used
+	Backup(w io.Writer) error
+	// Restore replaces the content of the db with a snapshot that was written by Backup,
+	// the content is kept if the snapshot doesn't match the given checksum (sha256) or can't be loaded
+	Restore(r io.Reader, checksum []byte) error
 	Close()
 }
 
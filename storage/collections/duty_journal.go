@@ -0,0 +1,155 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var dutiesPrefix = []byte("duties/")
+
+// DutyRecord is a journal entry of a single duty execution
+type DutyRecord struct {
+	PubKey string `json:"pubKey"`
+	Role   string `json:"role"`
+	Slot   uint64 `json:"slot"`
+	// SeqNumber is the sequence number of the ibft instance of the duty
+	SeqNumber uint64 `json:"seqNumber"`
+	// Decided is true if the ibft instance decided
+	Decided bool `json:"decided"`
+	// Round is the round that decided
+	Round uint64 `json:"round,omitempty"`
+	// Signers are the ids of the operators that signed the decided message
+	Signers []uint64 `json:"signers,omitempty"`
+	// PartialSignatures are the ids of the operators whose post consensus partial signatures were collected
+	PartialSignatures []uint64 `json:"partialSignatures,omitempty"`
+	// Status is the outcome of the duty (e.g. submitted, failed)
+	Status string `json:"status"`
+	// Error is the reason of failure
+	Error     string    `json:"error,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// DutyJournal is an interface for persisting the duties executed by validators
+type DutyJournal interface {
+	// SaveDuty saves the given duty record, it overrides an existing record of the same validator, slot and role
+	SaveDuty(record *DutyRecord) error
+	// GetDuties returns the records of the given validator in the given slots range (inclusive), sorted by slot.
+	// all validators are returned if pubKey is empty
+	GetDuties(pubKey string, fromSlot, toSlot uint64) ([]*DutyRecord, error)
+	// PruneDuties removes the records of duties that are older than the given slot, it returns the amount of removed records
+	PruneDuties(beforeSlot uint64) (int, error)
+}
+
+// dutyJournal implements DutyJournal
+type dutyJournal struct {
+	db     basedb.IDb
+	logger *zap.Logger
+}
+
+// NewDutyJournal creates a new duty journal
+func NewDutyJournal(db basedb.IDb, logger *zap.Logger) DutyJournal {
+	return &dutyJournal{
+		db:     db,
+		logger: logger.With(zap.String("component", "dutyJournal")),
+	}
+}
+
+// SaveDuty saves the given duty record
+func (dj *dutyJournal) SaveDuty(record *DutyRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "marshaling error")
+	}
+	return dj.db.Set(dutiesPrefix, dutyKey(record), value)
+}
+
+// GetDuties returns the records of the given validator in the given slots range
+func (dj *dutyJournal) GetDuties(pubKey string, fromSlot, toSlot uint64) ([]*DutyRecord, error) {
+	prefix := dutiesPrefix
+	if len(pubKey) > 0 {
+		prefix = validatorDutiesPrefix(pubKey)
+	}
+	records := make([]*DutyRecord, 0)
+	err := dj.db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		record := &DutyRecord{}
+		if err := json.Unmarshal(obj.Value, record); err != nil {
+			return errors.Wrap(err, "un-marshaling error")
+		}
+		if record.Slot >= fromSlot && record.Slot <= toSlot {
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Slot < records[j].Slot
+	})
+	return records, nil
+}
+
+// PruneDuties removes the records of duties that are older than the given slot
+func (dj *dutyJournal) PruneDuties(beforeSlot uint64) (int, error) {
+	var keys [][]byte
+	err := dj.db.GetAll(dutiesPrefix, func(i int, obj basedb.Obj) error {
+		record := &DutyRecord{}
+		if err := json.Unmarshal(obj.Value, record); err != nil {
+			return errors.Wrap(err, "un-marshaling error")
+		}
+		if record.Slot < beforeSlot {
+			keys = append(keys, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	err = dj.db.Update(func(txn basedb.Txn) error {
+		for _, k := range keys {
+			if err := txn.Delete(dutiesPrefix, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete duty records")
+	}
+	dj.logger.Debug("pruned duty records", zap.Int("count", len(keys)), zap.Uint64("beforeSlot", beforeSlot))
+	return len(keys), nil
+}
+
+// validatorDutiesPrefix returns the prefix of all the duties of the given validator
+func validatorDutiesPrefix(pubKey string) []byte {
+	var prefix bytes.Buffer
+	prefix.Write(dutiesPrefix)
+	prefix.WriteString(pubKey)
+	prefix.WriteString("/")
+	return prefix.Bytes()
+}
+
+// dutyKey returns the key of the given record (relative to dutiesPrefix),
+// slot is encoded as big endian so records of a validator are sorted by slot
+func dutyKey(record *DutyRecord) []byte {
+	slot := make([]byte, 8)
+	binary.BigEndian.PutUint64(slot, record.Slot)
+
+	var key bytes.Buffer
+	key.WriteString(record.PubKey)
+	key.WriteString("/")
+	key.Write(slot)
+	key.WriteString(record.Role)
+	return key.Bytes()
+}
@@ -0,0 +1,79 @@
+package collections
+
+import (
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"testing"
+)
+
+func TestDutyJournal(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Path:   "",
+		Logger: logger,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	journal := NewDutyJournal(db, logger)
+	pk1 := "8687eb8b88ff9c39e659c47b7bb76665fabfc4fc02c4246caca49700242fa9260a145969ede608b10c711ef2d57d0da1"
+	pk2 := "b6de3081ad9a8becd37676827afb46386eeaa4cd7ebf8711a37505d3c5d3a7a3c1e167e3031e98094ed5262ec65ff205"
+
+	for _, slot := range []uint64{300, 10, 256} {
+		require.NoError(t, journal.SaveDuty(&DutyRecord{PubKey: pk1, Role: "ATTESTER", Slot: slot, Status: "submitted",
+			Decided: true, Signers: []uint64{1, 2, 3}, PartialSignatures: []uint64{1, 2, 4}}))
+	}
+	require.NoError(t, journal.SaveDuty(&DutyRecord{PubKey: pk1, Role: "PROPOSER", Slot: 256, Status: "failed", Error: "test"}))
+	require.NoError(t, journal.SaveDuty(&DutyRecord{PubKey: pk2, Role: "ATTESTER", Slot: 20, Status: "submitted"}))
+
+	t.Run("get validator duties", func(t *testing.T) {
+		records, err := journal.GetDuties(pk1, 0, 1000)
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		require.Equal(t, uint64(10), records[0].Slot)
+		require.Equal(t, uint64(256), records[1].Slot)
+		require.Equal(t, uint64(256), records[2].Slot)
+		require.Equal(t, uint64(300), records[3].Slot)
+		require.Equal(t, []uint64{1, 2, 3}, records[0].Signers)
+		require.Equal(t, []uint64{1, 2, 4}, records[0].PartialSignatures)
+	})
+
+	t.Run("get duties in range", func(t *testing.T) {
+		records, err := journal.GetDuties(pk1, 20, 256)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		records, err = journal.GetDuties("", 10, 20)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, pk1, records[0].PubKey)
+		require.Equal(t, pk2, records[1].PubKey)
+	})
+
+	t.Run("override duty", func(t *testing.T) {
+		require.NoError(t, journal.SaveDuty(&DutyRecord{PubKey: pk2, Role: "ATTESTER", Slot: 20, Status: "failed"}))
+		records, err := journal.GetDuties(pk2, 0, 1000)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "failed", records[0].Status)
+	})
+
+	t.Run("prune duties", func(t *testing.T) {
+		removed, err := journal.PruneDuties(256)
+		require.NoError(t, err)
+		require.Equal(t, 2, removed)
+		records, err := journal.GetDuties("", 0, 1000)
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		for _, r := range records {
+			require.GreaterOrEqual(t, r.Slot, uint64(256))
+		}
+
+		removed, err = journal.PruneDuties(256)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+	})
+}
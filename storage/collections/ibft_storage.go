@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 	"log"
+	"math"
 	"strings"
 )
 
@@ -31,19 +32,31 @@ type Iibft interface {
 	SaveHighestDecidedInstance(signedMsg *proto.SignedMessage) error
 	// GetHighestDecidedInstance gets a signed message for an ibft instance which is the highest
 	GetHighestDecidedInstance(identifier []byte) (*proto.SignedMessage, bool, error)
+	// PruneDecided removes the decided messages of the given identifier, except of the last sequences to retain
+	PruneDecided(identifier []byte, retain uint64) (int, error)
 }
 
+// pruneBatchSize is the max amount of decided messages that are removed in a single transaction
+const pruneBatchSize = 1000
+
 var (
 	metricsHighestDecided = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:validator:ibft_highest_decided",
 		Help: "The highest decided sequence number",
 	}, []string{"lambda", "pubKey"})
+	metricsPrunedDecided = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:storage:pruned_decided_messages",
+		Help: "Count decided messages that were removed by the retention policy",
+	})
 )
 
 func init() {
 	if err := prometheus.Register(metricsHighestDecided); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsPrunedDecided); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // IbftStorage struct
@@ -130,16 +143,15 @@ func (i *IbftStorage) GetDecided(identifier []byte, seqNumber uint64) (*proto.Si
 
 // GetDecidedInRange returns decided message in the given range
 func (i *IbftStorage) GetDecidedInRange(identifier []byte, from uint64, to uint64) ([]*proto.SignedMessage, error) {
-	prefix := make([]byte, len(i.prefix))
-	copy(prefix, i.prefix)
-	prefix = append(prefix, identifier...)
-
-	var sequences [][]byte
-	for seq := from; seq <= to; seq++ {
-		sequences = append(sequences, i.key("decided", uInt64ToByteSlice(seq)))
-	}
 	msgs := make([]*proto.SignedMessage, 0)
-	err := i.db.GetMany(prefix, sequences, func(obj basedb.Obj) error {
+	if from > to {
+		return msgs, nil
+	}
+	opts := basedb.IterateOptions{Start: uInt64ToByteSlice(from)}
+	if to < math.MaxUint64 {
+		opts.End = uInt64ToByteSlice(to + 1)
+	}
+	err := i.db.Iterate(i.decidedPrefix(identifier), opts, func(j int, obj basedb.Obj) error {
 		msg := proto.SignedMessage{}
 		if err := json.Unmarshal(obj.Value, &msg); err != nil {
 			return errors.Wrap(err, "un-marshaling error")
@@ -194,6 +206,52 @@ func (i *IbftStorage) GetHighestDecidedInstance(identifier []byte) (*proto.Signe
 	return ret, found, nil
 }
 
+// PruneDecided removes the decided messages of the given identifier that are lower than the last `retain` sequences,
+// the highest decided is never removed and 0 keeps the full history. it returns the amount of removed messages
+func (i *IbftStorage) PruneDecided(identifier []byte, retain uint64) (int, error) {
+	if retain == 0 {
+		return 0, nil
+	}
+	highest, found, err := i.GetHighestDecidedInstance(identifier)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get highest decided")
+	}
+	if !found || highest == nil || highest.Message == nil || highest.Message.SeqNumber < retain {
+		return 0, nil
+	}
+	// the messages in [highest - retain + 1, highest] are kept
+	opts := basedb.IterateOptions{End: uInt64ToByteSlice(highest.Message.SeqNumber - retain + 1), Limit: pruneBatchSize}
+	prefix := i.decidedPrefix(identifier)
+	removed := 0
+	for {
+		var keys [][]byte
+		err := i.db.Update(func(txn basedb.Txn) error {
+			keys = keys[:0]
+			err := txn.Iterate(prefix, opts, func(j int, obj basedb.Obj) error {
+				keys = append(keys, obj.Key)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if err := txn.Delete(prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return removed, errors.Wrap(err, "could not remove decided messages")
+		}
+		removed += len(keys)
+		metricsPrunedDecided.Add(float64(len(keys)))
+		if len(keys) < pruneBatchSize {
+			return removed, nil
+		}
+	}
+}
+
 func (i *IbftStorage) save(value []byte, id string, pk []byte, keyParams ...[]byte) error {
 	prefix := append(i.prefix, pk...)
 	key := i.key(id, keyParams...)
@@ -213,6 +271,14 @@ func (i *IbftStorage) get(id string, pk []byte, keyParams ...[]byte) ([]byte, bo
 	return obj.Value, found, nil
 }
 
+// decidedPrefix returns the prefix of the decided messages of the given identifier, ordered by sequence number
+func (i *IbftStorage) decidedPrefix(identifier []byte) []byte {
+	prefix := make([]byte, 0, len(i.prefix)+len(identifier)+len("decided"))
+	prefix = append(prefix, i.prefix...)
+	prefix = append(prefix, identifier...)
+	return append(prefix, "decided"...)
+}
+
 func (i *IbftStorage) key(id string, params ...[]byte) []byte {
 	ret := []byte(id)
 	for _, p := range params {
@@ -221,8 +287,9 @@ func (i *IbftStorage) key(id string, params ...[]byte) []byte {
 	return ret
 }
 
+// uInt64ToByteSlice encodes the given number as big endian, so the keys of sequence numbers are ordered
 func uInt64ToByteSlice(n uint64) []byte {
 	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, n)
+	binary.BigEndian.PutUint64(b, n)
 	return b
 }
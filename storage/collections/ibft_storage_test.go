@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"math"
 	"os"
 	"path"
 	"sync"
@@ -108,6 +109,94 @@ func TestIbftStorage_SaveDecidedMessages(t *testing.T) {
 	require.NoError(t, storage.SaveDecidedMessages(msgs))
 }
 
+func TestIbftStorage_GetDecidedInRange(t *testing.T) {
+	storage := NewIbft(newInMemDb(), zap.L(), "attestation")
+	identifier := []byte{1, 2, 3, 4}
+	var msgs []*proto.SignedMessage
+	for i := uint64(0); i < 300; i++ {
+		msgs = append(msgs, &proto.SignedMessage{
+			Message: &proto.Message{
+				Type:      proto.RoundState_Decided,
+				Round:     1,
+				Lambda:    identifier,
+				SeqNumber: i,
+			},
+			Signature: []byte{1, 2, 3, 4},
+			SignerIds: []uint64{1, 2, 3},
+		})
+	}
+	require.NoError(t, storage.SaveDecidedMessages(msgs))
+	// a decided message of another identifier that shares the prefix
+	require.NoError(t, storage.SaveDecided(&proto.SignedMessage{
+		Message: &proto.Message{Lambda: []byte{1, 2, 3}, SeqNumber: 252},
+	}))
+
+	res, err := storage.GetDecidedInRange(identifier, 250, 260)
+	require.NoError(t, err)
+	require.Len(t, res, 11)
+	for i, msg := range res {
+		require.Equal(t, uint64(250+i), msg.Message.SeqNumber)
+	}
+
+	res, err = storage.GetDecidedInRange(identifier, 290, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, res, 10)
+
+	res, err = storage.GetDecidedInRange(identifier, 10, 9)
+	require.NoError(t, err)
+	require.Len(t, res, 0)
+}
+
+func TestIbftStorage_PruneDecided(t *testing.T) {
+	storage := NewIbft(newInMemDb(), zap.L(), "attestation")
+	identifier := []byte{1, 2, 3, 4}
+	var msgs []*proto.SignedMessage
+	for i := uint64(0); i < 2500; i++ {
+		msgs = append(msgs, &proto.SignedMessage{
+			Message: &proto.Message{
+				Type:      proto.RoundState_Decided,
+				Round:     1,
+				Lambda:    identifier,
+				SeqNumber: i,
+			},
+		})
+	}
+	require.NoError(t, storage.SaveDecidedMessages(msgs))
+
+	// nothing is removed without a highest decided
+	removed, err := storage.PruneDecided(identifier, 100)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	require.NoError(t, storage.SaveHighestDecidedInstance(msgs[2499]))
+	removed, err = storage.PruneDecided(identifier, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+	removed, err = storage.PruneDecided(identifier, 3000)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	removed, err = storage.PruneDecided(identifier, 100)
+	require.NoError(t, err)
+	require.Equal(t, 2400, removed)
+	res, err := storage.GetDecidedInRange(identifier, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, res, 100)
+	require.Equal(t, uint64(2400), res[0].Message.SeqNumber)
+
+	// the highest decided is kept
+	removed, err = storage.PruneDecided(identifier, 1)
+	require.NoError(t, err)
+	require.Equal(t, 99, removed)
+	_, found, err := storage.GetDecided(identifier, 2499)
+	require.NoError(t, err)
+	require.True(t, found)
+	highest, found, err := storage.GetHighestDecidedInstance(identifier)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(2499), highest.Message.SeqNumber)
+}
+
 func TestIbftStorage_SaveDecided(t *testing.T) {
 	storage := NewIbft(newInMemDb(), zap.L(), "attestation")
 	err := storage.SaveDecided(&proto.SignedMessage{
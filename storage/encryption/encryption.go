@@ -0,0 +1,177 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/bloxapp/eth2-key-manager/encryptor"
+	"github.com/pkg/errors"
+)
+
+const (
+	encryptorName    = "aes-256-gcm"
+	encryptorVersion = 1
+	// KeyLength is the length of encryption keys
+	KeyLength = 32
+)
+
+// Options configures the at rest encryption of sensitive data (operator key and share secrets),
+// the password is taken from the password file, or from the key file
+type Options struct {
+	PasswordFile string `yaml:"PasswordFile" env:"ENCRYPTION_PASSWORD_FILE" env-description:"file containing the password that sensitive data (operator key, shares) is encrypted with"`
+	KeyFile      string `yaml:"KeyFile" env:"ENCRYPTION_KEY_FILE" env-description:"file containing a random key that sensitive data (operator key, shares) is encrypted with, used instead of PasswordFile"`
+}
+
+// Enabled returns true if encryption was configured
+func (o Options) Enabled() bool {
+	return len(o.PasswordFile) > 0 || len(o.KeyFile) > 0
+}
+
+// Password reads the configured password
+func (o Options) Password() (string, error) {
+	if len(o.PasswordFile) > 0 && len(o.KeyFile) > 0 {
+		return "", errors.New("only one of PasswordFile and KeyFile can be used")
+	}
+	path := o.PasswordFile
+	if len(path) == 0 {
+		path = o.KeyFile
+	}
+	if len(path) == 0 {
+		return "", errors.New("encryption is not configured")
+	}
+	return ReadPasswordFile(path)
+}
+
+// ReadPasswordFile reads a password (or key) from the given file, surrounding whitespaces are ignored
+func ReadPasswordFile(path string) (string, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read password file")
+	}
+	password := strings.TrimSpace(string(raw))
+	if len(password) == 0 {
+		return "", errors.Errorf("password file %s is empty", path)
+	}
+	return password, nil
+}
+
+// encryptedData is the encrypted form of data
+type encryptedData struct {
+	Function string `json:"function"`
+	Nonce    string `json:"nonce"`
+	Message  string `json:"message"`
+}
+
+// aesEncryptor implements encryptor.Encryptor with AES-GCM, the key is a raw (hex) 32 bytes key such as the master key
+type aesEncryptor struct{}
+
+// NewEncryptor returns an encryptor that works with raw (hex) keys, as opposed to passwords
+func NewEncryptor() encryptor.Encryptor {
+	return &aesEncryptor{}
+}
+
+// Name returns the name of the encryptor
+func (e *aesEncryptor) Name() string {
+	return encryptorName
+}
+
+// Version returns the version of the encryptor
+func (e *aesEncryptor) Version() uint {
+	return encryptorVersion
+}
+
+// Encrypt encrypts the given data with the given (hex) key
+func (e *aesEncryptor) Encrypt(data []byte, key string) (map[string]interface{}, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	return map[string]interface{}{
+		"function": encryptorName,
+		"nonce":    hex.EncodeToString(nonce),
+		"message":  hex.EncodeToString(aead.Seal(nil, nonce, data, nil)),
+	}, nil
+}
+
+// Decrypt decrypts the given data with the given (hex) key
+func (e *aesEncryptor) Decrypt(data map[string]interface{}, key string) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal encrypted data")
+	}
+	var ed encryptedData
+	if err := json.Unmarshal(raw, &ed); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal encrypted data")
+	}
+	if ed.Function != encryptorName {
+		return nil, errors.Errorf("unsupported encryption function %q", ed.Function)
+	}
+	nonce, err := hex.DecodeString(ed.Nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid nonce")
+	}
+	message, err := hex.DecodeString(ed.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid message")
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plain, err := aead.Open(nil, nonce, message, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt data")
+	}
+	return plain, nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	rawKey, err := hex.DecodeString(key)
+	if err != nil || len(rawKey) != KeyLength {
+		return nil, errors.New("invalid encryption key")
+	}
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts the given data with the given key, the result is a json object
+func Encrypt(key []byte, data []byte) ([]byte, error) {
+	encrypted, err := NewEncryptor().Encrypt(data, hex.EncodeToString(key))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encrypted)
+}
+
+// Decrypt decrypts data that was encrypted by Encrypt
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	var encrypted map[string]interface{}
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal encrypted data")
+	}
+	return NewEncryptor().Decrypt(encrypted, hex.EncodeToString(key))
+}
+
+// IsEncrypted returns true if the given data was encrypted by Encrypt
+func IsEncrypted(data []byte) bool {
+	var ed encryptedData
+	if err := json.Unmarshal(data, &ed); err != nil {
+		return false
+	}
+	return ed.Function == encryptorName
+}
@@ -0,0 +1,111 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestKey(t *testing.T) []byte {
+	key := make([]byte, KeyLength)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := newTestKey(t)
+	data := []byte("operator private key")
+
+	encrypted, err := Encrypt(key, data)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, string(encrypted), string(data))
+	require.False(t, IsEncrypted(data))
+
+	decrypted, err := Decrypt(key, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	_, err = Decrypt(newTestKey(t), encrypted)
+	require.EqualError(t, err, "could not decrypt data: cipher: message authentication failed")
+
+	_, err = Decrypt(key[:16], encrypted)
+	require.EqualError(t, err, "invalid encryption key")
+}
+
+func TestOptions_Password(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, ioutil.WriteFile(passwordFile, []byte("  secret\n"), 0600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte("\n"), 0600))
+
+	require.False(t, Options{}.Enabled())
+	_, err = Options{}.Password()
+	require.EqualError(t, err, "encryption is not configured")
+
+	password, err := Options{PasswordFile: passwordFile}.Password()
+	require.NoError(t, err)
+	require.Equal(t, "secret", password)
+
+	_, err = Options{PasswordFile: passwordFile, KeyFile: passwordFile}.Password()
+	require.EqualError(t, err, "only one of PasswordFile and KeyFile can be used")
+
+	_, err = Options{KeyFile: emptyFile}.Password()
+	require.EqualError(t, err, "password file "+emptyFile+" is empty")
+}
+
+func TestUnlock(t *testing.T) {
+	db, err := kv.New(basedb.Options{Type: "badger-memory", Logger: zap.L()})
+	require.NoError(t, err)
+	defer db.Close()
+
+	initialized, err := IsInitialized(db)
+	require.NoError(t, err)
+	require.False(t, initialized)
+
+	key, err := Unlock(db, "password")
+	require.NoError(t, err)
+	require.Len(t, key, KeyLength)
+	initialized, err = IsInitialized(db)
+	require.NoError(t, err)
+	require.True(t, initialized)
+
+	unlocked, err := Unlock(db, "password")
+	require.NoError(t, err)
+	require.Equal(t, key, unlocked)
+
+	_, err = Unlock(db, "wrong")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid password")
+
+	t.Run("change password", func(t *testing.T) {
+		require.Error(t, ChangePassword(db, "wrong", "new password"))
+		require.NoError(t, ChangePassword(db, "password", "new password"))
+
+		_, err := Unlock(db, "password")
+		require.Error(t, err)
+		unlocked, err := Unlock(db, "new password")
+		require.NoError(t, err)
+		require.Equal(t, key, unlocked)
+	})
+}
+
+func TestChangePassword_NotEncrypted(t *testing.T) {
+	db, err := kv.New(basedb.Options{Type: "badger-memory", Logger: zap.L()})
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.EqualError(t, ChangePassword(db, "password", "new password"), "storage is not encrypted")
+}
@@ -0,0 +1,89 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
+)
+
+var (
+	prefix       = []byte("encryption-")
+	masterKeyKey = []byte("master-key")
+)
+
+// IsInitialized returns true if a master key was created in the given db
+func IsInitialized(db basedb.IDb) (bool, error) {
+	_, found, err := db.Get(prefix, masterKeyKey)
+	if err != nil {
+		return false, errors.Wrap(err, "could not read master key")
+	}
+	return found, nil
+}
+
+// Unlock returns the master key that sensitive data in the given db is encrypted with,
+// the master key is kept encrypted with the given password (EIP-2335 keystore) and is created on first use
+func Unlock(db basedb.IDb, password string) ([]byte, error) {
+	obj, found, err := db.Get(prefix, masterKeyKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read master key")
+	}
+	if !found {
+		masterKey := make([]byte, KeyLength)
+		if _, err := rand.Read(masterKey); err != nil {
+			return nil, errors.Wrap(err, "could not generate master key")
+		}
+		if err := saveMasterKey(db, masterKey, password); err != nil {
+			return nil, err
+		}
+		return masterKey, nil
+	}
+	return decryptMasterKey(obj.Value, password)
+}
+
+// ChangePassword re-encrypts the master key with the new password,
+// the data itself is not affected as it is encrypted with the master key
+func ChangePassword(db basedb.IDb, password, newPassword string) error {
+	obj, found, err := db.Get(prefix, masterKeyKey)
+	if err != nil {
+		return errors.Wrap(err, "could not read master key")
+	}
+	if !found {
+		return errors.New("storage is not encrypted")
+	}
+	masterKey, err := decryptMasterKey(obj.Value, password)
+	if err != nil {
+		return err
+	}
+	return saveMasterKey(db, masterKey, newPassword)
+}
+
+func saveMasterKey(db basedb.IDb, masterKey []byte, password string) error {
+	encrypted, err := keystorev4.New().Encrypt(masterKey, password)
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt master key")
+	}
+	raw, err := json.Marshal(encrypted)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal master key")
+	}
+	if err := db.Set(prefix, masterKeyKey, raw); err != nil {
+		return errors.Wrap(err, "could not save master key")
+	}
+	return nil
+}
+
+func decryptMasterKey(raw []byte, password string) ([]byte, error) {
+	var encrypted map[string]interface{}
+	if err := json.Unmarshal(raw, &encrypted); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal master key")
+	}
+	masterKey, err := keystorev4.New().Decrypt(encrypted, password)
+	if err != nil {
+		// keystorev4 fails on the checksum in case of a wrong password
+		return nil, errors.Wrap(err, "invalid password")
+	}
+	return masterKey, nil
+}
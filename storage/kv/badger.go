@@ -2,11 +2,19 @@ package kv
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/dgraph-io/badger/v3"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/async"
 	"go.uber.org/zap"
 )
@@ -14,11 +22,29 @@ import (
 const (
 	// EntryNotFoundError is an error for a storage entry not found
 	EntryNotFoundError = "EntryNotFoundError"
+	// gcDiscardRatio is the ratio of discardable data in a value log file, that triggers its rewrite
+	gcDiscardRatio = 0.5
+	// restoreMaxPendingWrites is the max amount of pending writes while restoring a backup
+	restoreMaxPendingWrites = 256
 )
 
+var (
+	metricsDBSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:storage:db_size",
+		Help: "The size of the db in bytes, by type (lsm or vlog)",
+	}, []string{"type"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsDBSize); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
 // BadgerDb struct
 type BadgerDb struct {
 	db     *badger.DB
+	opt    badger.Options
 	logger *zap.Logger
 }
 
@@ -42,6 +68,7 @@ func New(options basedb.Options) (basedb.IDb, error) {
 	}
 	_db := BadgerDb{
 		db:     db,
+		opt:    opt,
 		logger: options.Logger,
 	}
 
@@ -159,6 +186,13 @@ func (b *BadgerDb) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 	return err
 }
 
+// Iterate iterates the items of the collection in the given range, ordered by key
+func (b *BadgerDb) Iterate(prefix []byte, opts basedb.IterateOptions, handler func(int, basedb.Obj) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		return badgerTxn{txn}.Iterate(prefix, opts, handler)
+	})
+}
+
 // CountByCollection return the object count for all keys under specified prefix(bucket)
 func (b *BadgerDb) CountByCollection(prefix []byte) (int64, error) {
 	var res int64
@@ -187,10 +221,172 @@ func (b *BadgerDb) Close() {
 	}
 }
 
+// CollectGarbage reclaims the space of deleted or overridden values by rewriting the value log files
+func (b *BadgerDb) CollectGarbage() error {
+	defer b.reportSize()
+	for {
+		err := b.db.RunValueLogGC(gcDiscardRatio)
+		if err == nil {
+			continue
+		}
+		// nothing left to rewrite, or not applicable
+		if err == badger.ErrNoRewrite || err == badger.ErrGCInMemoryMode || err == badger.ErrRejected {
+			return nil
+		}
+		return errors.Wrap(err, "failed to run value log gc")
+	}
+}
+
+// Backup writes a snapshot of the db at the current read timestamp to the given writer
+func (b *BadgerDb) Backup(w io.Writer) error {
+	if _, err := b.db.Backup(w, 0); err != nil {
+		return errors.Wrap(err, "failed to backup badger")
+	}
+	return nil
+}
+
+// Restore replaces the content of the db with the given backup, the db must not be in use while it is restored.
+// the backup is staged and verified against the checksum (sha256), then loaded into a new db
+// that replaces the current one only if it was loaded successfully
+func (b *BadgerDb) Restore(r io.Reader, checksum []byte) error {
+	staged, err := b.stage(r, checksum)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = staged.Close()
+		_ = os.Remove(staged.Name())
+	}()
+
+	opt := b.opt
+	if !opt.InMemory {
+		opt = opt.WithDir(b.opt.Dir + ".restore").WithValueDir(b.opt.ValueDir + ".restore")
+		if err := removeDirs(opt); err != nil {
+			return errors.Wrap(err, "failed to clean restore dir")
+		}
+	}
+	db, err := badger.Open(opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open restore db")
+	}
+	if err := db.Load(staged, restoreMaxPendingWrites); err != nil {
+		_ = db.Close()
+		_ = removeDirs(opt)
+		return errors.Wrap(err, "failed to load badger backup")
+	}
+
+	if opt.InMemory {
+		if err := b.db.Close(); err != nil {
+			b.logger.Warn("failed to close replaced db", zap.Error(err))
+		}
+		b.db = db
+		return nil
+	}
+	if err := db.Close(); err != nil {
+		_ = removeDirs(opt)
+		return errors.Wrap(err, "failed to close restore db")
+	}
+	return b.swap(opt)
+}
+
+// stage copies the given backup to a file next to the db (or to the temp dir of an in-memory db)
+// and verifies it against the checksum, the file is created with 0600 permissions
+func (b *BadgerDb) stage(r io.Reader, checksum []byte) (*os.File, error) {
+	dir, pattern := "", "ssv-db-restore-"
+	if !b.opt.InMemory {
+		dir, pattern = filepath.Dir(b.opt.Dir), filepath.Base(b.opt.Dir)+".restore-"
+	}
+	staged, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create staging file")
+	}
+	discard := func() {
+		_ = staged.Close()
+		_ = os.Remove(staged.Name())
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(staged, h), r); err != nil {
+		discard()
+		return nil, errors.Wrap(err, "failed to stage badger backup")
+	}
+	if !bytes.Equal(h.Sum(nil), checksum) {
+		discard()
+		return nil, errors.New("invalid checksum, backup is corrupted or incomplete")
+	}
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		discard()
+		return nil, errors.Wrap(err, "failed to read staged backup")
+	}
+	return staged, nil
+}
+
+// swap replaces the dirs of the db with the dirs of the restored db and reopens it,
+// the current dirs are kept aside until the restored db is in place
+func (b *BadgerDb) swap(restored badger.Options) error {
+	old := b.opt.WithDir(b.opt.Dir + ".old").WithValueDir(b.opt.ValueDir + ".old")
+	if err := removeDirs(old); err != nil {
+		return errors.Wrap(err, "failed to clean old db dir")
+	}
+	if err := b.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close db")
+	}
+	if err := moveDirs(b.opt, old); err != nil {
+		// the db is reopened as is
+		if db, openErr := badger.Open(b.opt); openErr == nil {
+			b.db = db
+		}
+		return errors.Wrap(err, "failed to move db aside")
+	}
+	if err := moveDirs(restored, b.opt); err != nil {
+		if moveErr := moveDirs(old, b.opt); moveErr == nil {
+			if db, openErr := badger.Open(b.opt); openErr == nil {
+				b.db = db
+			}
+		}
+		return errors.Wrap(err, "failed to move restored db")
+	}
+	db, err := badger.Open(b.opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open restored db")
+	}
+	b.db = db
+	if err := removeDirs(old); err != nil {
+		b.logger.Warn("failed to remove old db dir", zap.Error(err))
+	}
+	return nil
+}
+
+// removeDirs removes the dirs of the given db options
+func removeDirs(opt badger.Options) error {
+	if err := os.RemoveAll(opt.Dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(opt.ValueDir)
+}
+
+// moveDirs renames the dirs of a db from one options to another
+func moveDirs(from, to badger.Options) error {
+	if err := os.Rename(from.Dir, to.Dir); err != nil {
+		return err
+	}
+	if from.ValueDir != from.Dir {
+		return os.Rename(from.ValueDir, to.ValueDir)
+	}
+	return nil
+}
+
+// reportSize reports the size of the db
+func (b *BadgerDb) reportSize() (int64, int64) {
+	lsm, vlog := b.db.Size()
+	metricsDBSize.WithLabelValues("lsm").Set(float64(lsm))
+	metricsDBSize.WithLabelValues("vlog").Set(float64(vlog))
+	return lsm, vlog
+}
+
 // report the db size and metrics
 func (b *BadgerDb) report() {
 	logger := b.logger.With(zap.String("who", "BadgerDBReporting"))
-	lsm, vlog := b.db.Size()
+	lsm, vlog := b.reportSize()
 	blockCache := b.db.BlockCacheMetrics()
 	indexCache := b.db.IndexCacheMetrics()
 
@@ -256,3 +452,68 @@ func (t badgerTxn) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, e
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+// Iterate iterates the items of the collection in the given range, ordered by key.
+// keys and values are copied as badger reuses them once the iterator moves
+func (t badgerTxn) Iterate(prefix []byte, opts basedb.IterateOptions, handler func(int, basedb.Obj) error) error {
+	start := append(append([]byte{}, prefix...), opts.Start...)
+	var end []byte
+	if opts.End != nil {
+		end = append(append([]byte{}, prefix...), opts.End...)
+	}
+
+	// the prefix is not set in the iterator options, as reverse seeking might land right after the prefix
+	itOpts := badger.DefaultIteratorOptions
+	itOpts.Reverse = opts.Reverse
+	it := t.txn.NewIterator(itOpts)
+	defer it.Close()
+
+	inRange := func(key []byte) bool {
+		return bytes.Compare(key, start) >= 0 && (end == nil || bytes.Compare(key, end) < 0)
+	}
+	if opts.Reverse {
+		upper := end
+		if upper == nil {
+			upper = prefixUpperBound(prefix)
+		}
+		it.Seek(upper)
+		// reverse seeking lands on the upper bound itself if it exists
+		for it.Valid() && upper != nil && bytes.Compare(it.Item().Key(), upper) >= 0 {
+			it.Next()
+		}
+	} else {
+		it.Seek(start)
+	}
+
+	for i := 0; it.ValidForPrefix(prefix) && inRange(it.Item().Key()); it.Next() {
+		if opts.Limit > 0 && i >= opts.Limit {
+			break
+		}
+		item := it.Item()
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if err := handler(i, basedb.Obj{
+			Key:   bytes.TrimPrefix(item.KeyCopy(nil), prefix),
+			Value: val,
+		}); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// prefixUpperBound returns the smallest key that is greater than all the keys with the given prefix,
+// or nil if there is no such key (i.e. the prefix is empty or made of 0xff bytes)
+func prefixUpperBound(prefix []byte) []byte {
+	upper := append([]byte{}, prefix...)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			return upper[:i+1]
+		}
+	}
+	return nil
+}
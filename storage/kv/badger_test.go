@@ -3,12 +3,15 @@ package kv
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -171,6 +174,92 @@ func TestBadgerDb_SetMany(t *testing.T) {
 	}
 }
 
+func TestBadgerDb_Iterate(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	}
+	db, err := New(options)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// items of neighbouring collections shouldn't be iterated
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set([]byte("prefiw"), []byte("x"), []byte("before")))
+	require.NoError(t, db.Set([]byte("prefiy"), []byte{}, []byte("after")))
+	for i := uint64(0); i < 100; i++ {
+		require.NoError(t, db.Set(prefix, bigEndian(i), bigEndian(i)))
+	}
+
+	iterate := func(t *testing.T, opts basedb.IterateOptions) []uint64 {
+		var res []uint64
+		err := db.Iterate(prefix, opts, func(i int, obj basedb.Obj) error {
+			require.Equal(t, len(res), i)
+			require.True(t, bytes.Equal(obj.Key, obj.Value))
+			res = append(res, binary.BigEndian.Uint64(obj.Key))
+			return nil
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		opts     basedb.IterateOptions
+		expected []uint64
+	}{
+		{"range", basedb.IterateOptions{Start: bigEndian(10), End: bigEndian(14)}, []uint64{10, 11, 12, 13}},
+		{"reverse range", basedb.IterateOptions{Start: bigEndian(10), End: bigEndian(14), Reverse: true}, []uint64{13, 12, 11, 10}},
+		{"limit", basedb.IterateOptions{Start: bigEndian(50), Limit: 3}, []uint64{50, 51, 52}},
+		{"reverse limit", basedb.IterateOptions{Reverse: true, Limit: 3}, []uint64{99, 98, 97}},
+		{"reverse from the start", basedb.IterateOptions{End: bigEndian(2), Reverse: true}, []uint64{1, 0}},
+		{"empty range", basedb.IterateOptions{Start: bigEndian(20), End: bigEndian(20)}, nil},
+		{"out of range", basedb.IterateOptions{Start: bigEndian(200)}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, iterate(t, test.opts))
+		})
+	}
+	require.Len(t, iterate(t, basedb.IterateOptions{}), 100)
+	require.Len(t, iterate(t, basedb.IterateOptions{Reverse: true}), 100)
+
+	t.Run("txn", func(t *testing.T) {
+		err := db.Update(func(txn basedb.Txn) error {
+			var keys [][]byte
+			err := txn.Iterate(prefix, basedb.IterateOptions{End: bigEndian(5)}, func(i int, obj basedb.Obj) error {
+				keys = append(keys, obj.Key)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if err := txn.Delete(prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uint64{5, 6}, iterate(t, basedb.IterateOptions{Limit: 2}))
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		err := db.Iterate(prefix, basedb.IterateOptions{}, func(i int, obj basedb.Obj) error {
+			return fmt.Errorf("stop")
+		})
+		require.EqualError(t, err, "stop")
+	})
+}
+
+func bigEndian(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
 func uInt64ToByteSlice(n uint64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, n)
@@ -200,3 +289,86 @@ func getAllTest(t *testing.T, n int, db basedb.IDb) {
 	require.Equal(t, n, len(visited))
 	require.NoError(t, db.RemoveAllByCollection(prefix))
 }
+
+func TestBadgerDb_CollectGarbage(t *testing.T) {
+	for _, dbType := range []string{"badger-memory", "badger-db"} {
+		t.Run(dbType, func(t *testing.T) {
+			db, err := New(basedb.Options{
+				Type:   dbType,
+				Logger: zaptest.NewLogger(t),
+				Path:   t.TempDir(),
+			})
+			require.NoError(t, err)
+			defer db.Close()
+
+			prefix := []byte("prefix")
+			value := bytes.Repeat([]byte{1}, 1024)
+			for i := uint64(0); i < 100; i++ {
+				require.NoError(t, db.Set(prefix, uInt64ToByteSlice(i), value))
+			}
+			for i := uint64(0); i < 100; i++ {
+				require.NoError(t, db.Delete(prefix, uInt64ToByteSlice(i)))
+			}
+			require.NoError(t, db.CollectGarbage())
+		})
+	}
+}
+
+func TestBadgerDb_Restore(t *testing.T) {
+	for _, dbType := range []string{"badger-memory", "badger-db"} {
+		t.Run(dbType, func(t *testing.T) {
+			dir := t.TempDir()
+			newDb := func(path string) basedb.IDb {
+				db, err := New(basedb.Options{Type: dbType, Logger: zaptest.NewLogger(t), Path: path})
+				require.NoError(t, err)
+				t.Cleanup(db.Close)
+				return db
+			}
+			db := newDb(filepath.Join(dir, "backup"))
+			require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+			buf := &bytes.Buffer{}
+			require.NoError(t, db.Backup(buf))
+			checksum := sha256.Sum256(buf.Bytes())
+
+			path := filepath.Join(dir, "db")
+			restored := newDb(path)
+			require.NoError(t, restored.Set([]byte("other"), []byte("key"), []byte("value")))
+			requireFound := func(prefix []byte, expected bool) {
+				_, found, err := restored.Get(prefix, []byte("key"))
+				require.NoError(t, err)
+				require.Equal(t, expected, found)
+			}
+
+			// the content is kept if the backup doesn't match the checksum
+			require.EqualError(t, restored.Restore(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), checksum[:]),
+				"invalid checksum, backup is corrupted or incomplete")
+			requireFound([]byte("other"), true)
+
+			// the content is kept if the backup can't be loaded
+			invalid := buf.Bytes()[:buf.Len()-1]
+			invalidChecksum := sha256.Sum256(invalid)
+			require.Error(t, restored.Restore(bytes.NewReader(invalid), invalidChecksum[:]))
+			requireFound([]byte("other"), true)
+
+			require.NoError(t, restored.Restore(bytes.NewReader(buf.Bytes()), checksum[:]))
+			requireFound([]byte("other"), false)
+			obj, found, err := restored.Get([]byte("prefix"), []byte("key"))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []byte("value"), obj.Value)
+
+			// the restored db is usable, and nothing is left next to it
+			require.NoError(t, restored.Set([]byte("other"), []byte("key"), []byte("value")))
+			requireFound([]byte("other"), true)
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			if dbType == "badger-db" {
+				require.ElementsMatch(t, []string{"backup", "db"}, names)
+			}
+		})
+	}
+}
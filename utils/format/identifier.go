@@ -12,7 +12,7 @@ func IdentifierFormat(pubKey []byte, roleType string) string {
 }
 
 var (
-	identifierRegexp = regexp.MustCompile("(.+)_(ATTESTER)|(PROPOSER)|(AGGREGATOR)")
+	identifierRegexp = regexp.MustCompile("^(.+)_(ATTESTER|AGGREGATOR|PROPOSER|SYNC_COMMITTEE|SYNC_COMMITTEE_CONTRIBUTION)$")
 )
 
 // IdentifierUnformat return parts of the given lambda
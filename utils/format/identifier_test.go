@@ -9,6 +9,16 @@ func TestIdentifierUnformat(t *testing.T) {
 	pk, role := IdentifierUnformat("xxx_ATTESTER")
 	require.Equal(t, "xxx", pk)
 	require.Equal(t, "ATTESTER", role)
+
+	for _, r := range []string{"AGGREGATOR", "PROPOSER", "SYNC_COMMITTEE", "SYNC_COMMITTEE_CONTRIBUTION"} {
+		pk, role = IdentifierUnformat("xxx_" + r)
+		require.Equal(t, "xxx", pk)
+		require.Equal(t, r, role)
+	}
+
+	pk, role = IdentifierUnformat("xxx_RANDAO")
+	require.Equal(t, "", pk)
+	require.Equal(t, "", role)
 }
 
 func TestIdentifierFormat(t *testing.T) {
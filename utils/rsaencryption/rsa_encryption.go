@@ -1,8 +1,10 @@
 package rsaencryption
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -51,6 +53,34 @@ func DecodeKey(sk *rsa.PrivateKey, hashBase64 string) (string, error) {
 	return string(decryptedKey), nil
 }
 
+// EncodeKey encrypts the given key with the public key, returns the encrypted key as base64 (see DecodeKey)
+func EncodeKey(pk *rsa.PublicKey, key string) (string, error) {
+	encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, pk, []byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt key")
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// SignMessage signs the sha256 hash of the given data with the private key
+func SignMessage(sk *rsa.PrivateKey, data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, sk, crypto.SHA256, hash[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to sign message")
+	}
+	return signature, nil
+}
+
+// VerifySignature verifies a signature that was created by SignMessage
+func VerifySignature(pk *rsa.PublicKey, data []byte, signature []byte) error {
+	hash := sha256.Sum256(data)
+	if err := rsa.VerifyPKCS1v15(pk, crypto.SHA256, hash[:], signature); err != nil {
+		return errors.Wrap(err, "Invalid signature")
+	}
+	return nil
+}
+
 // ConvertPemToPrivateKey return rsa private key from secret key
 func ConvertPemToPrivateKey(skPem string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(skPem))
@@ -95,3 +125,24 @@ func ExtractPublicKey(sk *rsa.PrivateKey) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(pemByte), nil
 }
+
+// ConvertEncodedPemToPublicKey returns the rsa public key of the given base64 encoded public key, as returned by ExtractPublicKey
+func ConvertEncodedPemToPublicKey(pkBase64 string) (*rsa.PublicKey, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(pkBase64)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode public key")
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("Failed to decode public key pem")
+	}
+	parsedPk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse public key")
+	}
+	pk, ok := parsedPk.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Public key is not an rsa key")
+	}
+	return pk, nil
+}
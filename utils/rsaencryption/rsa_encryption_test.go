@@ -42,3 +42,33 @@ func TestPrivateKeyToByte(t *testing.T) {
 	require.NotNil(t, b)
 	require.Greater(t, len(b), 1024)
 }
+
+func TestEncodeKey(t *testing.T) {
+	_, skByte, err := GenerateKeys()
+	require.NoError(t, err)
+	sk, err := ConvertPemToPrivateKey(string(skByte))
+	require.NoError(t, err)
+	pkBase64, err := ExtractPublicKey(sk)
+	require.NoError(t, err)
+	pk, err := ConvertEncodedPemToPublicKey(pkBase64)
+	require.NoError(t, err)
+	require.Equal(t, sk.PublicKey, *pk)
+
+	encrypted, err := EncodeKey(pk, "626d6a13ae5b1458c310700941764f3841f279f9c8de5f4ba94abd01dc082517")
+	require.NoError(t, err)
+	key, err := DecodeKey(sk, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "626d6a13ae5b1458c310700941764f3841f279f9c8de5f4ba94abd01dc082517", key)
+
+	_, err = ConvertEncodedPemToPublicKey("not a key")
+	require.Error(t, err)
+}
+
+func TestSignMessage(t *testing.T) {
+	sk, err := ConvertPemToPrivateKey(testingspace.SkPem)
+	require.NoError(t, err)
+	signature, err := SignMessage(sk, []byte("message"))
+	require.NoError(t, err)
+	require.NoError(t, VerifySignature(&sk.PublicKey, []byte("message"), signature))
+	require.Error(t, VerifySignature(&sk.PublicKey, []byte("other message"), signature))
+}
@@ -3,11 +3,12 @@ package validator
 import (
 	"context"
 	"encoding/hex"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/dkg"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
 	controller2 "github.com/bloxapp/ssv/ibft/controller"
@@ -16,6 +17,7 @@ import (
 	"github.com/bloxapp/ssv/operator/forks"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
 	"github.com/bloxapp/ssv/utils/tasks"
 	validatorstorage "github.com/bloxapp/ssv/validator/storage"
 
@@ -28,6 +30,10 @@ import (
 
 const (
 	metadataBatchSize = 25
+	// dutyJournalPruneInterval is the interval of duty journal pruning
+	dutyJournalPruneInterval = time.Hour
+	// compactionInterval is the interval of decided history pruning and db garbage collection
+	compactionInterval = time.Hour
 )
 
 // ShareEventHandlerFunc is a function that handles event in an extended mode
@@ -41,7 +47,11 @@ type ControllerOptions struct {
 	SignatureCollectionTimeout time.Duration `yaml:"SignatureCollectionTimeout" env:"SIGNATURE_COLLECTION_TIMEOUT" env-default:"5s" env-description:"Timeout for signature collection after consensus"`
 	MetadataUpdateInterval     time.Duration `yaml:"MetadataUpdateInterval" env:"METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"Interval for updating metadata"`
 	HistorySyncRateLimit       time.Duration `yaml:"HistorySyncRateLimit" env:"HISTORY_SYNC_BACKOFF" env-default:"200ms" env-description:"Interval for updating metadata"`
-	ETHNetwork                 *core.Network
+	DutyJournalRetention       time.Duration `yaml:"DutyJournalRetention" env:"DUTY_JOURNAL_RETENTION" env-default:"168h" env-description:"Retention period of executed duties records"`
+	DecidedRetention           uint64        `yaml:"DecidedRetention" env:"DECIDED_RETENTION" env-default:"7200" env-description:"Number of the last decided sequences to keep per validator and role, 0 keeps the full history"`
+	DoppelgangerProtection     bool          `yaml:"DoppelgangerProtection" env:"DOPPELGANGER_PROTECTION" env-description:"Pause duties of newly added validators until they are not seen live on the beacon chain, should be enabled by all the operators of a validator"`
+	DoppelgangerEpochs         uint64        `yaml:"DoppelgangerEpochs" env:"DOPPELGANGER_EPOCHS" env-default:"2" env-description:"Number of consecutive epochs a validator should not be live before it is allowed to execute duties"`
+	ETHNetwork                 *beacon.Network
 	Network                    network.Network
 	Beacon                     beacon.Beacon
 	Shares                     []validatorstorage.ShareOptions `yaml:"Shares"`
@@ -63,7 +73,13 @@ type Controller interface {
 	UpdateValidatorMetaDataLoop()
 	StartNetworkMediators()
 	Eth1EventHandler(handlers ...ShareEventHandlerFunc) eth1.SyncEventHandler
+	SetLastSyncedBlock(block *eth1.SyncOffset)
 	GetAllValidatorShares() ([]*validatorstorage.Share, error)
+	GetValidatorsStatus(filter StatusFilter) ([]*ValidatorStatus, int, error)
+	GetDutyRecords(pubKey string, fromSlot, toSlot uint64) ([]*collections.DutyRecord, error)
+	PruneDutyJournalLoop()
+	CompactionLoop()
+	dkg.ShareStore
 }
 
 // controller implements Controller
@@ -83,6 +99,20 @@ type controller struct {
 	metadataUpdateQueue    tasks.Queue
 	metadataUpdateInterval time.Duration
 
+	dutyJournal          collections.DutyJournal
+	dutyJournalRetention time.Duration
+	ethNetwork           *beacon.Network
+	decidedRetention     uint64
+
+	doppelgangerProtection bool
+	doppelgangerEpochs     uint64
+	// doppelgangerPending holds the public keys of new validators that should be checked before starting
+	doppelgangerPending *sync.Map
+	// lastSyncedBlock is the block that the registry was synced up to when the node started, nil if the db was empty.
+	// validators that were added up to this block are re-synced rather than new to the registry
+	lastSyncedBlock     *eth1.SyncOffset
+	lastSyncedBlockLock sync.RWMutex
+
 	networkMediator controller2.Mediator
 	operatorsIDs    *sync.Map
 	network         network.Network
@@ -103,6 +133,8 @@ func NewController(options ControllerOptions) Controller {
 		options.Network.NotifyOperatorID(oid)
 	}
 
+	dutyJournal := collections.NewDutyJournal(options.DB, options.Logger)
+
 	ctrl := controller{
 		collection:                 collection,
 		storage:                    options.RegistryStorage,
@@ -125,12 +157,22 @@ func NewController(options ControllerOptions) Controller {
 			Fork:                       options.Fork,
 			Signer:                     options.KeyManager,
 			SyncRateLimit:              options.HistorySyncRateLimit,
+			DutyJournal:                dutyJournal,
 			notifyOperatorID:           notifyOperatorID,
 		}),
 
 		metadataUpdateQueue:    tasks.NewExecutionQueue(10 * time.Millisecond),
 		metadataUpdateInterval: options.MetadataUpdateInterval,
 
+		dutyJournal:          dutyJournal,
+		dutyJournalRetention: options.DutyJournalRetention,
+		ethNetwork:           options.ETHNetwork,
+		decidedRetention:     options.DecidedRetention,
+
+		doppelgangerProtection: options.DoppelgangerProtection,
+		doppelgangerEpochs:     options.DoppelgangerEpochs,
+		doppelgangerPending:    &sync.Map{},
+
 		networkMediator: controller2.NewMediator(options.Logger),
 		operatorsIDs:    operatorsIDs,
 	}
@@ -152,7 +194,8 @@ func (c *controller) ListenToEth1Events(feed *event.Feed) {
 	sub := feed.Subscribe(cn)
 	defer sub.Unsubscribe()
 
-	handler := c.Eth1EventHandler(c.handleShare)
+	// ongoing events are new to the registry
+	handler := c.eth1EventHandler(true, c.handleShare)
 
 	for {
 		select {
@@ -166,9 +209,35 @@ func (c *controller) ListenToEth1Events(feed *event.Feed) {
 	}
 }
 
+// SetLastSyncedBlock sets the block that the registry was synced up to when the node started,
+// it should be called before syncing past events
+func (c *controller) SetLastSyncedBlock(block *eth1.SyncOffset) {
+	c.lastSyncedBlockLock.Lock()
+	defer c.lastSyncedBlockLock.Unlock()
+
+	c.lastSyncedBlock = block
+}
+
 // Eth1EventHandler is a factory function for creating eth1 event handler
 func (c *controller) Eth1EventHandler(handlers ...ShareEventHandlerFunc) eth1.SyncEventHandler {
+	return c.eth1EventHandler(false, handlers...)
+}
+
+// isNewToRegistry returns true if the given (past) event was emitted after the last synced block,
+// all the events are new if there is no synced block (e.g. a fresh db) as it is unknown whether the validators were running
+func (c *controller) isNewToRegistry(e eth1.Event) bool {
+	c.lastSyncedBlockLock.RLock()
+	defer c.lastSyncedBlockLock.RUnlock()
+
+	return c.lastSyncedBlock == nil || e.Log.BlockNumber > c.lastSyncedBlock.Uint64()
+}
+
+// eth1EventHandler creates eth1 event handler, ongoing should be true for events that are streamed after the sync
+func (c *controller) eth1EventHandler(ongoing bool, handlers ...ShareEventHandlerFunc) eth1.SyncEventHandler {
 	return func(e eth1.Event) error {
+		if e.Log.Removed {
+			return c.handleOrphanedEvent(e)
+		}
 		switch ev := e.Data.(type) {
 		case abiparser.ValidatorAddedEvent:
 			pubKey := hex.EncodeToString(ev.PublicKey)
@@ -177,7 +246,7 @@ func (c *controller) Eth1EventHandler(handlers ...ShareEventHandlerFunc) eth1.Sy
 				c.logger.Debug("validator was loaded already")
 				return nil
 			}
-			share, err := c.handleValidatorAddedEvent(ev, e.IsOperatorEvent)
+			share, err := c.handleValidatorAddedEvent(ev, e.IsOperatorEvent, ongoing || c.isNewToRegistry(e))
 			if err != nil {
 				c.logger.Error("could not handle ValidatorAdded event", zap.String("pubkey", pubKey), zap.Error(err))
 				return err
@@ -187,12 +256,41 @@ func (c *controller) Eth1EventHandler(handlers ...ShareEventHandlerFunc) eth1.Sy
 					h(share)
 				}
 			}
+		case abiparser.ValidatorUpdatedEvent:
+			pubKey := hex.EncodeToString(ev.PublicKey)
+			share, err := c.handleValidatorUpdatedEvent(ev, e.IsOperatorEvent, ongoing || c.isNewToRegistry(e))
+			if err != nil {
+				c.logger.Error("could not handle ValidatorUpdated event", zap.String("pubkey", pubKey), zap.Error(err))
+				return err
+			}
+			if e.IsOperatorEvent {
+				for _, h := range handlers {
+					h(share)
+				}
+			}
+		case abiparser.ValidatorDeletedEvent:
+			pubKey := hex.EncodeToString(ev.PublicKey)
+			if err := c.handleValidatorDeletedEvent(ev); err != nil {
+				c.logger.Error("could not handle ValidatorDeleted event", zap.String("pubkey", pubKey), zap.Error(err))
+				return err
+			}
 		case abiparser.OperatorAddedEvent:
 			err := c.handleOperatorAddedEvent(ev)
 			if err != nil {
 				c.logger.Error("could not handle OperatorAdded event", zap.Error(err))
 				return err
 			}
+		case abiparser.OperatorDeletedEvent:
+			if err := c.handleOperatorDeletedEvent(ev, e.IsOperatorEvent); err != nil {
+				c.logger.Error("could not handle OperatorDeleted event", zap.Error(err))
+				return err
+			}
+		case abiparser.AccountLiquidatedEvent:
+			if err := c.handleAccountLiquidatedEvent(ev); err != nil {
+				c.logger.Error("could not handle AccountLiquidated event",
+					zap.String("ownerAddress", ev.OwnerAddress.String()), zap.Error(err))
+				return err
+			}
 		default:
 			c.logger.Warn("could not handle unknown event")
 		}
@@ -335,10 +433,12 @@ func (c *controller) GetValidatorsIndices() []spec.ValidatorIndex {
 	return indices
 }
 
-// handleValidatorAddedEvent handles registry contract event for validator added
+// handleValidatorAddedEvent handles registry contract event for validator added,
+// operator validators that are new to the registry are checked by doppelganger protection before starting
 func (c *controller) handleValidatorAddedEvent(
 	validatorAddedEvent abiparser.ValidatorAddedEvent,
 	isOperatorShare bool,
+	isNew bool,
 ) (*validatorstorage.Share, error) {
 	pubKey := hex.EncodeToString(validatorAddedEvent.PublicKey)
 	metricsValidatorStatus.WithLabelValues(pubKey).Set(float64(validatorStatusInactive))
@@ -356,6 +456,9 @@ func (c *controller) handleValidatorAddedEvent(
 			return nil, err
 		}
 		validatorShare = newValShare
+		if isOperatorShare && isNew && c.doppelgangerProtection {
+			c.doppelgangerPending.Store(pubKey, true)
+		}
 		if isOperatorShare {
 			logger := c.logger.With(zap.String("pubKey", pubKey))
 			logger.Debug("ValidatorAdded event was handled successfully")
@@ -378,6 +481,113 @@ func (c *controller) handleOperatorAddedEvent(event abiparser.OperatorAddedEvent
 	return nil
 }
 
+// handleValidatorUpdatedEvent handles registry contract event for validator updated (re-shared to new operators),
+// the existing share is removed and the new one is handled as a new validator
+func (c *controller) handleValidatorUpdatedEvent(
+	validatorUpdatedEvent abiparser.ValidatorUpdatedEvent,
+	isOperatorShare bool,
+	isNew bool,
+) (*validatorstorage.Share, error) {
+	share, found, err := c.collection.GetValidatorShare(validatorUpdatedEvent.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check if validator share exist")
+	}
+	if found {
+		if err := c.onShareRemove(share); err != nil {
+			return nil, errors.WithMessage(err, "could not remove previous share")
+		}
+	}
+	return c.handleValidatorAddedEvent(abiparser.ValidatorAddedEvent(validatorUpdatedEvent), isOperatorShare, isNew)
+}
+
+// handleValidatorDeletedEvent handles registry contract event for validator deleted
+func (c *controller) handleValidatorDeletedEvent(validatorDeletedEvent abiparser.ValidatorDeletedEvent) error {
+	share, found, err := c.collection.GetValidatorShare(validatorDeletedEvent.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not check if validator share exist")
+	}
+	if !found {
+		return nil
+	}
+	if err := c.onShareRemove(share); err != nil {
+		return err
+	}
+	if share.IsOperatorShare(c.operatorPubKey) {
+		c.logger.Debug("ValidatorDeleted event was handled successfully",
+			zap.String("pubKey", share.PublicKey.SerializeToHexStr()))
+	}
+	return nil
+}
+
+// handleOperatorDeletedEvent removes the information of the given operator,
+// in case the event belongs to this operator, all its validators are removed as well
+func (c *controller) handleOperatorDeletedEvent(event abiparser.OperatorDeletedEvent, isOperatorEvent bool) error {
+	if err := c.storage.DeleteOperatorInformation(string(event.PublicKey)); err != nil {
+		return errors.Wrap(err, "could not delete operator information")
+	}
+	if !isOperatorEvent {
+		return nil
+	}
+	shares, err := c.collection.GetOperatorValidatorShares(c.operatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get operator shares")
+	}
+	c.logger.Debug("operator was deleted, removing its shares", zap.Int("shares count", len(shares)))
+	return c.removeShares(shares)
+}
+
+// handleAccountLiquidatedEvent removes all the validators of the liquidated owner
+func (c *controller) handleAccountLiquidatedEvent(event abiparser.AccountLiquidatedEvent) error {
+	shares, err := c.collection.GetAllValidatorShares()
+	if err != nil {
+		return errors.Wrap(err, "could not get validator shares")
+	}
+	var toRemove []*validatorstorage.Share
+	for _, share := range shares {
+		if strings.EqualFold(share.OwnerAddress, event.OwnerAddress.String()) {
+			toRemove = append(toRemove, share)
+		}
+	}
+	c.logger.Debug("account was liquidated, removing its shares",
+		zap.String("ownerAddress", event.OwnerAddress.String()), zap.Int("shares count", len(toRemove)))
+	return c.removeShares(toRemove)
+}
+
+// handleOrphanedEvent rolls back an event from a block that was orphaned by an eth1 reorg.
+// added (or updated) validators and operators are removed, the events of the canonical chain are applied afterwards.
+// removed (or replaced) shares are restored by the eth1 client, which fires the preceding events of the affected validators
+func (c *controller) handleOrphanedEvent(e eth1.Event) error {
+	logger := c.logger.With(zap.String("txHash", e.Log.TxHash.Hex()), zap.Uint64("blockNumber", e.Log.BlockNumber))
+	switch ev := e.Data.(type) {
+	case abiparser.ValidatorAddedEvent:
+		logger.Debug("rolling back orphaned ValidatorAdded event")
+		return c.handleValidatorDeletedEvent(abiparser.ValidatorDeletedEvent{OwnerAddress: ev.OwnerAddress, PublicKey: ev.PublicKey})
+	case abiparser.ValidatorUpdatedEvent:
+		logger.Debug("rolling back orphaned ValidatorUpdated event")
+		return c.handleValidatorDeletedEvent(abiparser.ValidatorDeletedEvent{OwnerAddress: ev.OwnerAddress, PublicKey: ev.PublicKey})
+	case abiparser.OperatorAddedEvent:
+		logger.Debug("rolling back orphaned OperatorAdded event")
+		if err := c.storage.DeleteOperatorInformation(string(ev.PublicKey)); err != nil {
+			return errors.Wrap(err, "could not delete operator information")
+		}
+	default:
+		logger.Debug("orphaned event was not rolled back, removed data is restored by the eth1 client")
+	}
+	return nil
+}
+
+// removeShares removes the given shares, it continues in case of failure and returns the last error
+func (c *controller) removeShares(shares []*validatorstorage.Share) error {
+	var lastErr error
+	for _, share := range shares {
+		if err := c.onShareRemove(share); err != nil {
+			c.logger.Error("could not remove share", zap.String("pubKey", share.PublicKey.SerializeToHexStr()), zap.Error(err))
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
 // onMetadataUpdated is called when validator's metadata was updated
 func (c *controller) onMetadataUpdated(pk string, meta *beacon.ValidatorMetadata) {
 	if meta == nil {
@@ -428,6 +638,37 @@ func (c *controller) onNewShare(share *validatorstorage.Share, shareSecret *bls.
 	return nil
 }
 
+// onShareRemove is called when a validator was deleted, updated or liquidated.
+// it stops the running validator and removes the share from key manager and storage
+func (c *controller) onShareRemove(share *validatorstorage.Share) error {
+	pubKey := share.PublicKey.SerializeToHexStr()
+	logger := c.logger.With(zap.String("pubKey", pubKey))
+
+	if v := c.validatorsMap.RemoveValidator(pubKey); v != nil {
+		if err := v.Stop(); err != nil {
+			return errors.Wrap(err, "could not stop validator")
+		}
+		logger.Debug("validator was stopped")
+	}
+
+	// the share secret was added to key manager only if the validator belongs to operator
+	if share.OperatorReady() {
+		operatorPubKey, err := share.OperatorPubKey()
+		if err != nil {
+			return errors.Wrap(err, "could not get operator share public key")
+		}
+		if err := c.keyManager.RemoveShare(operatorPubKey.SerializeToHexStr()); err != nil {
+			return errors.Wrap(err, "failed to remove share secret from key manager")
+		}
+	}
+
+	if err := c.collection.DeleteValidatorShare(share.PublicKey.Serialize()); err != nil {
+		return errors.Wrap(err, "failed to delete share")
+	}
+	metricsValidatorStatus.DeleteLabelValues(pubKey)
+	return nil
+}
+
 // startValidator will start the given validator if applicable
 func (c *controller) startValidator(v *Validator) (bool, error) {
 	ReportValidatorStatus(v.Share.PublicKey.SerializeToHexStr(), v.Share.Metadata, c.logger)
@@ -437,6 +678,12 @@ func (c *controller) startValidator(v *Validator) (bool, error) {
 	if v.Share.Metadata.Index == 0 {
 		return false, errors.New("could not start validator: index not found")
 	}
+	if c.doppelgangerProtection {
+		if _, pending := c.doppelgangerPending.LoadAndDelete(v.Share.PublicKey.SerializeToHexStr()); pending {
+			v.setDoppelgangerState(DoppelgangerStateChecking)
+			go c.detectDoppelganger(v)
+		}
+	}
 	if err := v.Start(); err != nil {
 		metricsValidatorStatus.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(validatorStatusError))
 		return false, errors.Wrap(err, "could not start validator")
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
+	"go.uber.org/zap"
+)
+
+// CompactionLoop prunes the decided history of the validators and reclaims the space of the db in an interval
+func (c *controller) CompactionLoop() {
+	ticker := time.NewTicker(compactionInterval)
+	defer ticker.Stop()
+	for {
+		c.compact()
+		select {
+		case <-c.context.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// compact prunes the decided history that exceeded the retention, and then runs the db garbage collection
+func (c *controller) compact() {
+	if c.decidedRetention > 0 {
+		c.pruneDecided()
+	}
+	if err := c.validatorsMap.optsTemplate.DB.CollectGarbage(); err != nil {
+		c.logger.Warn("could not collect db garbage", zap.Error(err))
+	}
+}
+
+// pruneDecided removes the decided messages of all the validators and roles, except of the last sequences to retain
+func (c *controller) pruneDecided() {
+	shares, err := c.collection.GetAllValidatorShares()
+	if err != nil {
+		c.logger.Warn("could not get validators shares for pruning", zap.Error(err))
+		return
+	}
+	removed := 0
+	for _, share := range shares {
+		pk := share.PublicKey.Serialize()
+		for _, role := range statusRoles {
+			ibftStorage := collections.NewIbft(c.validatorsMap.optsTemplate.DB, c.logger, role.String())
+			identifier := []byte(format.IdentifierFormat(pk, role.String()))
+			n, err := ibftStorage.PruneDecided(identifier, c.decidedRetention)
+			removed += n
+			if err != nil {
+				c.logger.Warn("could not prune decided history", zap.Error(err),
+					zap.String("pubKey", share.PublicKey.SerializeToHexStr()), zap.String("role", role.String()))
+			}
+		}
+	}
+	c.logger.Debug("decided history was pruned", zap.Int("removed", removed), zap.Uint64("retain", c.decidedRetention))
+}
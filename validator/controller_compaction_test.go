@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestController_Compact(t *testing.T) {
+	threshold.Init()
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctr := setupController(logger, map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: logger})
+	ctr.validatorsMap.optsTemplate = &Options{DB: db}
+	ctr.decidedRetention = 10
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	require.NoError(t, ctr.collection.SaveValidatorShare(&validatorstorage.Share{
+		NodeID:    1,
+		PublicKey: sk.GetPublicKey(),
+		Committee: map[uint64]*proto.Node{},
+	}))
+
+	identifiers := make(map[beacon.RoleType][]byte)
+	for _, role := range []beacon.RoleType{beacon.RoleTypeAttester, beacon.RoleTypeProposer} {
+		identifier := []byte(format.IdentifierFormat(sk.GetPublicKey().Serialize(), role.String()))
+		identifiers[role] = identifier
+		ibftStorage := collections.NewIbft(db, logger, role.String())
+		var msgs []*proto.SignedMessage
+		for seq := uint64(0); seq < 50; seq++ {
+			msgs = append(msgs, &proto.SignedMessage{Message: &proto.Message{Lambda: identifier, SeqNumber: seq}})
+		}
+		require.NoError(t, ibftStorage.SaveDecidedMessages(msgs))
+		if role == beacon.RoleTypeAttester {
+			require.NoError(t, ibftStorage.SaveHighestDecidedInstance(msgs[49]))
+		}
+	}
+
+	ctr.compact()
+
+	attesterStorage := collections.NewIbft(db, logger, beacon.RoleTypeAttester.String())
+	msgs, err := attesterStorage.GetDecidedInRange(identifiers[beacon.RoleTypeAttester], 0, 100)
+	require.NoError(t, err)
+	require.Len(t, msgs, 10)
+	require.Equal(t, uint64(40), msgs[0].Message.SeqNumber)
+	// no highest decided, nothing is pruned
+	proposerStorage := collections.NewIbft(db, logger, beacon.RoleTypeProposer.String())
+	msgs, err = proposerStorage.GetDecidedInRange(identifiers[beacon.RoleTypeProposer], 0, 100)
+	require.NoError(t, err)
+	require.Len(t, msgs, 50)
+}
@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// GetDutyRecords returns the journal records of the duties that were executed by the given validator
+// in the given slots range, records of all validators are returned if pubKey is empty
+func (c *controller) GetDutyRecords(pubKey string, fromSlot, toSlot uint64) ([]*collections.DutyRecord, error) {
+	records, err := c.dutyJournal.GetDuties(pubKey, fromSlot, toSlot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get duty records")
+	}
+	return records, nil
+}
+
+// PruneDutyJournalLoop removes records of duties that exceeded the retention period in an interval
+func (c *controller) PruneDutyJournalLoop() {
+	if c.dutyJournalRetention == 0 {
+		c.logger.Debug("duty journal pruning is disabled")
+		return
+	}
+	for {
+		c.pruneDutyJournal()
+		time.Sleep(dutyJournalPruneInterval)
+	}
+}
+
+// pruneDutyJournal removes records of duties that exceeded the retention period
+func (c *controller) pruneDutyJournal() {
+	beforeSlot := uint64(c.ethNetwork.EstimatedSlotAtTime(time.Now().Add(-c.dutyJournalRetention).Unix()))
+	removed, err := c.dutyJournal.PruneDuties(beforeSlot)
+	if err != nil {
+		c.logger.Warn("could not prune duty journal", zap.Error(err))
+		return
+	}
+	c.logger.Debug("duty journal was pruned", zap.Int("removed", removed), zap.Uint64("beforeSlot", beforeSlot))
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"encoding/hex"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/pkg/errors"
+)
+
+// GetOperatorShare returns the operator's share of the given validator, together with its current committee
+// and signing history, so the validator can be reshared to a new committee
+func (c *controller) GetOperatorShare(validatorPubKey []byte) (*dkg.OperatorShare, bool, error) {
+	share, found, err := c.collection.GetValidatorShare(validatorPubKey)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not get validator share")
+	}
+	if !found {
+		return nil, false, nil
+	}
+	if len(share.Operators) != share.CommitteeSize() {
+		return nil, false, errors.New("share operators are missing")
+	}
+	committee := &dkg.Reshare{
+		ValidatorPubKey: share.PublicKey.Serialize(),
+		Threshold:       uint64(share.ThresholdSize()),
+	}
+	for i, op := range share.Operators {
+		node, found := share.Committee[uint64(i+1)]
+		if !found {
+			return nil, false, errors.Errorf("committee member %d is missing", i+1)
+		}
+		committee.Operators = append(committee.Operators, string(op))
+		committee.SharePubKeys = append(committee.SharePubKeys, node.Pk)
+	}
+	node, found := share.Committee[share.NodeID]
+	if !found {
+		return nil, false, errors.New("could not find operator id in committee map")
+	}
+
+	handover, ok := c.keyManager.(beacon.ShareHandover)
+	if !ok {
+		return nil, false, errors.New("key manager does not support share handover")
+	}
+	sk, err := handover.ExportShare(node.Pk)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not export share")
+	}
+	highestSigned, err := handover.HighestSigned(node.Pk)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not get highest signed")
+	}
+	operatorShare := &dkg.OperatorShare{
+		Committee: committee,
+		SecretKey: sk,
+		Handover:  &dkg.Handover{HighestSigned: highestSigned},
+	}
+	for _, role := range statusRoles {
+		ibftStorage := collections.NewIbft(c.validatorsMap.optsTemplate.DB, c.logger, role.String())
+		identifier := []byte(format.IdentifierFormat(validatorPubKey, role.String()))
+		highest, found, err := ibftStorage.GetHighestDecidedInstance(identifier)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to get highest decided of %s", role.String())
+		}
+		if found && highest != nil && highest.Message != nil {
+			operatorShare.Handover.Decided = append(operatorShare.Handover.Decided, highest)
+		}
+	}
+	return operatorShare, true, nil
+}
+
+// SaveHandover raises the highest signed data of the given share and the highest decided instances of the validator,
+// so the new committee continues from where the previous one stopped
+func (c *controller) SaveHandover(validatorPubKey []byte, sharePubKey []byte, handover *dkg.Handover) error {
+	if handover == nil {
+		return nil
+	}
+	if handover.HighestSigned != nil {
+		shareHandover, ok := c.keyManager.(beacon.ShareHandover)
+		if !ok {
+			return errors.New("key manager does not support share handover")
+		}
+		if err := shareHandover.RaiseHighestSigned(sharePubKey, handover.HighestSigned); err != nil {
+			return errors.Wrap(err, "could not raise highest signed")
+		}
+	}
+	for _, msg := range handover.Decided {
+		if msg == nil || msg.Message == nil {
+			continue
+		}
+		pk, role := format.IdentifierUnformat(string(msg.Message.Lambda))
+		if len(role) == 0 || pk != hex.EncodeToString(validatorPubKey) {
+			return errors.New("decided message is not of the validator")
+		}
+		ibftStorage := collections.NewIbft(c.validatorsMap.optsTemplate.DB, c.logger, role)
+		highest, found, err := ibftStorage.GetHighestDecidedInstance(msg.Message.Lambda)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get highest decided of %s", role)
+		}
+		if found && highest != nil && highest.Message != nil && highest.Message.SeqNumber >= msg.Message.SeqNumber {
+			continue
+		}
+		if err := ibftStorage.SaveDecided(msg); err != nil {
+			return errors.Wrapf(err, "could not save decided of %s", role)
+		}
+		if err := ibftStorage.SaveHighestDecidedInstance(msg); err != nil {
+			return errors.Wrapf(err, "could not save highest decided of %s", role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShareStore(t *testing.T) {
+	threshold.Init()
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	km, err := ekm.NewETHKeyManagerSigner(db, nil, beacon.NewNetwork(core.PraterNetwork, 0, nil), nil)
+	require.NoError(t, err)
+	ctr := setupController(logger, map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: logger})
+	ctr.validatorsMap.optsTemplate = &Options{DB: db}
+	ctr.keyManager = km
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	shares, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+	share := &validatorstorage.Share{
+		NodeID:    2,
+		PublicKey: sk.GetPublicKey(),
+		Committee: make(map[uint64]*proto.Node),
+	}
+	for i := uint64(1); i <= 4; i++ {
+		share.Committee[i] = &proto.Node{IbftId: i, Pk: shares[i].GetPublicKey().Serialize()}
+		share.Operators = append(share.Operators, []byte{byte('a' + i)})
+	}
+	require.NoError(t, ctr.collection.SaveValidatorShare(share))
+	require.NoError(t, km.AddShare(shares[2]))
+
+	pk := sk.GetPublicKey().Serialize()
+	decided := func(role beacon.RoleType, seq uint64) *proto.SignedMessage {
+		return &proto.SignedMessage{Message: &proto.Message{
+			Type:      proto.RoundState_Commit,
+			Lambda:    []byte(format.IdentifierFormat(pk, role.String())),
+			SeqNumber: seq,
+		}}
+	}
+	ibftStorage := collections.NewIbft(db, logger, beacon.RoleTypeAttester.String())
+	require.NoError(t, ibftStorage.SaveHighestDecidedInstance(decided(beacon.RoleTypeAttester, 7)))
+
+	t.Run("get operator share", func(t *testing.T) {
+		operatorShare, found, err := ctr.GetOperatorShare(pk)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.True(t, operatorShare.SecretKey.IsEqual(shares[2]))
+		require.Equal(t, []string{"b", "c", "d", "e"}, operatorShare.Committee.Operators)
+		require.Equal(t, uint64(3), operatorShare.Committee.Threshold)
+		require.Equal(t, pk, operatorShare.Committee.ValidatorPubKey)
+		require.Equal(t, share.Committee[4].Pk, operatorShare.Committee.SharePubKeys[3])
+		require.Equal(t, &beacon.HighestSigned{}, operatorShare.Handover.HighestSigned)
+		require.Len(t, operatorShare.Handover.Decided, 1)
+		require.Equal(t, uint64(7), operatorShare.Handover.Decided[0].Message.SeqNumber)
+
+		other := &bls.SecretKey{}
+		other.SetByCSPRNG()
+		_, found, err = ctr.GetOperatorShare(other.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("save handover", func(t *testing.T) {
+		newShare := &bls.SecretKey{}
+		newShare.SetByCSPRNG()
+		handover := &dkg.Handover{
+			Decided: []*proto.SignedMessage{
+				decided(beacon.RoleTypeAttester, 5),
+				decided(beacon.RoleTypeProposer, 3),
+			},
+			HighestSigned: &beacon.HighestSigned{SourceEpoch: 4, TargetEpoch: 5, Slot: 100},
+		}
+		require.NoError(t, ctr.SaveHandover(pk, newShare.GetPublicKey().Serialize(), handover))
+
+		// lower decided instances don't override the local ones
+		highest, found, err := ibftStorage.GetHighestDecidedInstance(handover.Decided[0].Message.Lambda)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, uint64(7), highest.Message.SeqNumber)
+		proposerStorage := collections.NewIbft(db, logger, beacon.RoleTypeProposer.String())
+		highest, found, err = proposerStorage.GetHighestDecidedInstance(handover.Decided[1].Message.Lambda)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, uint64(3), highest.Message.SeqNumber)
+
+		// the new share keeps the highest signed once it is added
+		require.NoError(t, km.AddShare(newShare))
+		highestSigned, err := km.(beacon.ShareHandover).HighestSigned(newShare.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.Equal(t, handover.HighestSigned, highestSigned)
+
+		other := &bls.SecretKey{}
+		other.SetByCSPRNG()
+		err = ctr.SaveHandover(other.GetPublicKey().Serialize(), newShare.GetPublicKey().Serialize(), handover)
+		require.EqualError(t, err, "decided message is not of the validator")
+	})
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/pkg/errors"
+)
+
+// statusRoles are the roles that are reported in validator status
+var statusRoles = []beacon.RoleType{beacon.RoleTypeAttester, beacon.RoleTypeAggregator, beacon.RoleTypeProposer,
+	beacon.RoleTypeSyncCommittee, beacon.RoleTypeSyncCommitteeContribution}
+
+// StatusFilter is used to filter and page validators status
+type StatusFilter struct {
+	// OwnerAddress filters validators of the given owner, ignored if empty
+	OwnerAddress string
+	// Offset is the number of validators to skip
+	Offset int
+	// Limit is the max number of validators to return, 0 means no limit
+	Limit int
+}
+
+// ValidatorStatus represents the operational status of a validator
+type ValidatorStatus struct {
+	PublicKey    string                    `json:"publicKey"`
+	OwnerAddress string                    `json:"ownerAddress"`
+	NodeID       uint64                    `json:"nodeId"`
+	Running      bool                      `json:"running"`
+	Doppelganger string                    `json:"doppelganger,omitempty"`
+	Metadata     *beacon.ValidatorMetadata `json:"metadata,omitempty"`
+	Decided      map[string]uint64         `json:"decided"`
+	LastDuties   map[string]DutyOutcome    `json:"lastDuties"`
+}
+
+// GetValidatorsStatus returns the status of the validators that match the given filter,
+// together with the total amount of matching validators (regardless of paging)
+func (c *controller) GetValidatorsStatus(filter StatusFilter) ([]*ValidatorStatus, int, error) {
+	shares, err := c.collection.GetAllValidatorShares()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get validator shares")
+	}
+	shares = filterSharesByOwner(shares, filter.OwnerAddress)
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].PublicKey.SerializeToHexStr() < shares[j].PublicKey.SerializeToHexStr()
+	})
+	total := len(shares)
+	shares = pageShares(shares, filter.Offset, filter.Limit)
+
+	res := make([]*ValidatorStatus, 0, len(shares))
+	for _, share := range shares {
+		status, err := c.validatorStatus(share)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, status)
+	}
+	return res, total, nil
+}
+
+// validatorStatus builds the status of the given share's validator
+func (c *controller) validatorStatus(share *validatorstorage.Share) (*ValidatorStatus, error) {
+	pk := share.PublicKey.SerializeToHexStr()
+	status := &ValidatorStatus{
+		PublicKey:    pk,
+		OwnerAddress: share.OwnerAddress,
+		NodeID:       share.NodeID,
+		Metadata:     share.Metadata,
+		Decided:      make(map[string]uint64),
+		LastDuties:   make(map[string]DutyOutcome),
+	}
+
+	for _, role := range statusRoles {
+		ibftStorage := collections.NewIbft(c.validatorsMap.optsTemplate.DB, c.logger, role.String())
+		identifier := []byte(format.IdentifierFormat(share.PublicKey.Serialize(), role.String()))
+		highest, found, err := ibftStorage.GetHighestDecidedInstance(identifier)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get highest decided of %s", role.String())
+		}
+		if found && highest != nil && highest.Message != nil {
+			status.Decided[role.String()] = highest.Message.SeqNumber
+		}
+	}
+
+	if v, ok := c.validatorsMap.GetValidator(pk); ok {
+		status.Running = true
+		status.Doppelganger = v.DoppelgangerState().String()
+		for role, outcome := range v.LastDutyOutcomes() {
+			status.LastDuties[role.String()] = outcome
+		}
+	}
+	return status, nil
+}
+
+// filterSharesByOwner returns the shares of the given owner address, or all shares if the address is empty
+func filterSharesByOwner(shares []*validatorstorage.Share, ownerAddress string) []*validatorstorage.Share {
+	if len(ownerAddress) == 0 {
+		return shares
+	}
+	var res []*validatorstorage.Share
+	for _, share := range shares {
+		if strings.EqualFold(share.OwnerAddress, ownerAddress) {
+			res = append(res, share)
+		}
+	}
+	return res
+}
+
+// pageShares returns the shares of the requested page
+func pageShares(shares []*validatorstorage.Share, offset, limit int) []*validatorstorage.Share {
+	if offset >= len(shares) {
+		return []*validatorstorage.Share{}
+	}
+	if offset > 0 {
+		shares = shares[offset:]
+	}
+	if limit > 0 && limit < len(shares) {
+		shares = shares[:limit]
+	}
+	return shares
+}
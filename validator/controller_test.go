@@ -2,9 +2,26 @@ package validator
 
 import (
 	"context"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network/local"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
 	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"strings"
 	"sync"
 	"testing"
 
@@ -161,3 +178,199 @@ func TestGetIndices(t *testing.T) {
 	logger.Info("result", zap.Any("indices", indices))
 	require.Equal(t, 1, len(indices)) // should return only active indices
 }
+
+func TestRemoveValidatorEvents(t *testing.T) {
+	threshold.Init()
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+	km, err := ekm.NewETHKeyManagerSigner(db, nil, beacon.NewNetwork(core.PraterNetwork, 0, nil), nil)
+	require.NoError(t, err)
+	ctr := setupController(logger, map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: logger})
+	ctr.keyManager = km
+
+	// creates a running validator and persists its share, the share secret is added to key manager
+	newValidator := func(ownerAddress common.Address) *Validator {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		shareSk := &bls.SecretKey{}
+		shareSk.SetByCSPRNG()
+		share := &validatorstorage.Share{
+			NodeID:       1,
+			PublicKey:    sk.GetPublicKey(),
+			Committee:    map[uint64]*proto.Node{1: {IbftId: 1, Pk: shareSk.GetPublicKey().Serialize()}},
+			OwnerAddress: ownerAddress.String(),
+		}
+		require.NoError(t, ctr.collection.SaveValidatorShare(share))
+		require.NoError(t, km.AddShare(shareSk))
+		v := &Validator{Share: share, logger: logger, network: local.NewLocalNetwork()}
+		v.ctx, v.cancel = context.WithCancel(context.Background())
+		ctr.validatorsMap.validatorsMap[share.PublicKey.SerializeToHexStr()] = v
+		return v
+	}
+	requireRemoved := func(v *Validator) {
+		_, found := ctr.GetValidator(v.Share.PublicKey.SerializeToHexStr())
+		require.False(t, found)
+		_, found, err := ctr.collection.GetValidatorShare(v.Share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Error(t, v.ctx.Err())
+		// the share secret was removed from key manager
+		_, err = km.SignIBFTMessage(&proto.Message{Lambda: []byte("lambda")}, v.Share.Committee[1].Pk)
+		require.EqualError(t, err, "could not get signing account: account not found")
+	}
+	handler := ctr.Eth1EventHandler()
+
+	t.Run("validator deleted", func(t *testing.T) {
+		v := newValidator(owner)
+		other := newValidator(owner)
+		err := handler(eth1.Event{Data: abiparser.ValidatorDeletedEvent{
+			OwnerAddress: owner,
+			PublicKey:    v.Share.PublicKey.Serialize(),
+		}})
+		require.NoError(t, err)
+		requireRemoved(v)
+		_, found := ctr.GetValidator(other.Share.PublicKey.SerializeToHexStr())
+		require.True(t, found)
+	})
+
+	t.Run("unknown validator deleted", func(t *testing.T) {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		err := handler(eth1.Event{Data: abiparser.ValidatorDeletedEvent{
+			PublicKey: sk.GetPublicKey().Serialize(),
+		}})
+		require.NoError(t, err)
+	})
+
+	t.Run("account liquidated", func(t *testing.T) {
+		liquidated := common.HexToAddress("0x67Ce5c69260bd819B4e0AD13f4b873074D479811")
+		v1 := newValidator(liquidated)
+		v2 := newValidator(liquidated)
+		other := newValidator(owner)
+		err := handler(eth1.Event{Data: abiparser.AccountLiquidatedEvent{OwnerAddress: liquidated}})
+		require.NoError(t, err)
+		requireRemoved(v1)
+		requireRemoved(v2)
+		_, found := ctr.GetValidator(other.Share.PublicKey.SerializeToHexStr())
+		require.True(t, found)
+	})
+	t.Run("orphaned validator added", func(t *testing.T) {
+		v := newValidator(owner)
+		err := handler(eth1.Event{
+			Log: types.Log{Removed: true},
+			Data: abiparser.ValidatorAddedEvent{
+				OwnerAddress: owner,
+				PublicKey:    v.Share.PublicKey.Serialize(),
+			},
+		})
+		require.NoError(t, err)
+		requireRemoved(v)
+	})
+}
+
+func TestGetValidatorsStatus(t *testing.T) {
+	threshold.Init()
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctr := setupController(logger, map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: logger})
+	ctr.validatorsMap.optsTemplate = &Options{DB: db}
+
+	owner := common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE")
+	other := common.HexToAddress("0x67Ce5c69260bd819B4e0AD13f4b873074D479811")
+	var shares []*validatorstorage.Share
+	for i := 0; i < 5; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		ownerAddress := owner
+		if i%2 == 1 {
+			ownerAddress = other
+		}
+		share := &validatorstorage.Share{
+			NodeID:       1,
+			PublicKey:    sk.GetPublicKey(),
+			OwnerAddress: ownerAddress.String(),
+			Metadata:     &beacon.ValidatorMetadata{Index: spec.ValidatorIndex(i + 1)},
+		}
+		require.NoError(t, ctr.collection.SaveValidatorShare(share))
+		shares = append(shares, share)
+	}
+
+	// first validator is running, decided and executed an attestation duty
+	running := &Validator{Share: shares[0]}
+	running.reportDutyOutcome(&beacon.Duty{Type: beacon.RoleTypeAttester, Slot: 12}, DutyStatusSubmitted, nil)
+	running.reportDutyOutcome(&beacon.Duty{Type: beacon.RoleTypeProposer, Slot: 14}, DutyStatusFailed, errors.New("test error"))
+	ctr.validatorsMap.validatorsMap[shares[0].PublicKey.SerializeToHexStr()] = running
+	ibftStorage := collections.NewIbft(db, logger, beacon.RoleTypeAttester.String())
+	require.NoError(t, ibftStorage.SaveHighestDecidedInstance(&proto.SignedMessage{
+		Message: &proto.Message{
+			Lambda:    []byte(format.IdentifierFormat(shares[0].PublicKey.Serialize(), beacon.RoleTypeAttester.String())),
+			SeqNumber: 7,
+		},
+	}))
+
+	t.Run("all validators", func(t *testing.T) {
+		res, total, err := ctr.GetValidatorsStatus(StatusFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Len(t, res, 5)
+		for _, status := range res {
+			if status.PublicKey != shares[0].PublicKey.SerializeToHexStr() {
+				require.False(t, status.Running)
+				require.Empty(t, status.Decided)
+				require.Empty(t, status.LastDuties)
+				continue
+			}
+			require.True(t, status.Running)
+			require.Equal(t, map[string]uint64{beacon.RoleTypeAttester.String(): 7}, status.Decided)
+			require.Len(t, status.LastDuties, 2)
+			require.Equal(t, DutyStatusSubmitted, status.LastDuties[beacon.RoleTypeAttester.String()].Status)
+			proposal := status.LastDuties[beacon.RoleTypeProposer.String()]
+			require.Equal(t, DutyStatusFailed, proposal.Status)
+			require.Equal(t, uint64(14), proposal.Slot)
+			require.Equal(t, "test error", proposal.Error)
+		}
+	})
+
+	t.Run("filter by owner", func(t *testing.T) {
+		res, total, err := ctr.GetValidatorsStatus(StatusFilter{OwnerAddress: strings.ToLower(other.String())})
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+		require.Len(t, res, 2)
+		for _, status := range res {
+			require.Equal(t, other.String(), status.OwnerAddress)
+		}
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		all, _, err := ctr.GetValidatorsStatus(StatusFilter{})
+		require.NoError(t, err)
+		res, total, err := ctr.GetValidatorsStatus(StatusFilter{Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Len(t, res, 2)
+		require.Equal(t, all[1].PublicKey, res[0].PublicKey)
+		require.Equal(t, all[2].PublicKey, res[1].PublicKey)
+
+		res, total, err = ctr.GetValidatorsStatus(StatusFilter{Offset: 5, Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Len(t, res, 0)
+	})
+}
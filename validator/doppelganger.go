@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"sync/atomic"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DoppelgangerState is the state of doppelganger protection of a validator
+type DoppelgangerState int32
+
+const (
+	// DoppelgangerStateSafe means the validator is allowed to execute duties
+	DoppelgangerStateSafe DoppelgangerState = iota
+	// DoppelgangerStateChecking means the validator is checked for liveness, duties are not allowed
+	DoppelgangerStateChecking
+	// DoppelgangerStateDetected means the validator was seen live elsewhere, duties are not allowed
+	DoppelgangerStateDetected
+)
+
+// String returns the name of the state
+func (s DoppelgangerState) String() string {
+	switch s {
+	case DoppelgangerStateSafe:
+		return "safe"
+	case DoppelgangerStateChecking:
+		return "checking"
+	case DoppelgangerStateDetected:
+		return "detected"
+	default:
+		return "unknown"
+	}
+}
+
+// errDoppelgangerProtection is returned when a duty was not executed due to doppelganger protection
+var errDoppelgangerProtection = errors.New("duties are not allowed by doppelganger protection")
+
+// DoppelgangerState returns the current doppelganger protection state of the validator
+func (v *Validator) DoppelgangerState() DoppelgangerState {
+	return DoppelgangerState(atomic.LoadInt32(&v.doppelgangerState))
+}
+
+func (v *Validator) setDoppelgangerState(state DoppelgangerState) {
+	atomic.StoreInt32(&v.doppelgangerState, int32(state))
+}
+
+// checkDoppelgangerProtection returns an error if the validator is not allowed to execute duties
+func (v *Validator) checkDoppelgangerProtection() error {
+	if state := v.DoppelgangerState(); state != DoppelgangerStateSafe {
+		return errors.WithMessagef(errDoppelgangerProtection, "state is %s", state.String())
+	}
+	return nil
+}
+
+// detectDoppelganger pauses the duties of the given validator until it is not seen live on the beacon chain
+// for the configured amount of consecutive epochs.
+// in case the validator is seen live (i.e. running elsewhere), it stays paused and the counting restarts
+func (c *controller) detectDoppelganger(v *Validator) {
+	pk := v.Share.PublicKey.SerializeToHexStr()
+	logger := c.logger.With(zap.String("pubKey", pk), zap.String("who", "doppelganger"))
+	index := v.Share.Metadata.Index
+
+	logger.Info("starting doppelganger detection, duties are paused",
+		zap.Uint64("index", uint64(index)), zap.Uint64("epochs", c.doppelgangerEpochs))
+
+	// the previous epoch is checked as well, the validator might have been running elsewhere until it was added
+	epoch := uint64(c.ethNetwork.EstimatedCurrentEpoch())
+	if epoch > 0 {
+		epoch--
+	}
+	var safeEpochs uint64
+	for safeEpochs < c.doppelgangerEpochs {
+		// liveness is known only once the epoch ends
+		if !c.waitForEpochEnd(v, epoch) {
+			logger.Debug("doppelganger detection was stopped")
+			return
+		}
+		liveness, err := c.beacon.GetValidatorsLiveness(spec.Epoch(epoch), []spec.ValidatorIndex{index})
+		if err != nil {
+			logger.Warn("could not get validator liveness, retrying", zap.Uint64("epoch", epoch), zap.Error(err))
+			if !c.sleep(v, c.ethNetwork.SlotDurationSec()) {
+				return
+			}
+			continue
+		}
+		if liveness[index] {
+			if v.DoppelgangerState() != DoppelgangerStateDetected {
+				logger.Error("validator is live elsewhere, duties are paused until it stops", zap.Uint64("epoch", epoch))
+				metricsValidatorStatus.WithLabelValues(pk).Set(float64(validatorStatusDoppelganger))
+			}
+			v.setDoppelgangerState(DoppelgangerStateDetected)
+			safeEpochs = 0
+		} else {
+			safeEpochs++
+		}
+		epoch++
+	}
+
+	v.setDoppelgangerState(DoppelgangerStateSafe)
+	ReportValidatorStatus(pk, v.Share.Metadata, c.logger)
+	logger.Info("doppelganger was not detected, duties are allowed")
+}
+
+// waitForEpochEnd waits until the given epoch ends, returns false if the validator was stopped
+func (c *controller) waitForEpochEnd(v *Validator, epoch uint64) bool {
+	nextEpochStart := c.ethNetwork.MinGenesisTime() +
+		(epoch+1)*c.ethNetwork.SlotsPerEpoch()*uint64(c.ethNetwork.SlotDurationSec().Seconds())
+	return c.sleep(v, time.Until(time.Unix(int64(nextEpochStart), 0)))
+}
+
+// sleep waits for the given duration, returns false if the validator was stopped
+func (c *controller) sleep(v *Validator, d time.Duration) bool {
+	if d <= 0 {
+		return v.ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-v.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
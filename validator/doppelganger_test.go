@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/threshold"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testingDoppelgangerController(t *testing.T, liveness map[spec.Epoch]map[spec.ValidatorIndex]bool) *controller {
+	ethNetwork := beacon.NewNetwork(core.PraterNetwork, 0, nil)
+	b := newTestBeacon(t)
+	b.liveness = liveness
+	return &controller{
+		logger:                 zap.L(),
+		beacon:                 b,
+		ethNetwork:             &ethNetwork,
+		doppelgangerProtection: true,
+		doppelgangerEpochs:     1,
+	}
+}
+
+func TestDetectDoppelganger(t *testing.T) {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	index := spec.ValidatorIndex(1)
+	ethNetwork := beacon.NewNetwork(core.PraterNetwork, 0, nil)
+	prevEpoch := spec.Epoch(ethNetwork.EstimatedCurrentEpoch() - 1)
+
+	t.Run("not live", func(t *testing.T) {
+		v := testingValidator(t, false, 0, identifier)
+		defer v.cancel()
+		v.Share.Metadata = &beacon.ValidatorMetadata{Index: index}
+		c := testingDoppelgangerController(t, nil)
+
+		v.setDoppelgangerState(DoppelgangerStateChecking)
+		require.Error(t, v.checkDoppelgangerProtection())
+		c.detectDoppelganger(v)
+		require.Equal(t, DoppelgangerStateSafe, v.DoppelgangerState())
+		require.NoError(t, v.checkDoppelgangerProtection())
+	})
+
+	t.Run("live", func(t *testing.T) {
+		v := testingValidator(t, false, 0, identifier)
+		v.Share.Metadata = &beacon.ValidatorMetadata{Index: index}
+		c := testingDoppelgangerController(t, map[spec.Epoch]map[spec.ValidatorIndex]bool{
+			prevEpoch: {index: true},
+		})
+
+		v.setDoppelgangerState(DoppelgangerStateChecking)
+		done := make(chan struct{})
+		go func() {
+			c.detectDoppelganger(v)
+			close(done)
+		}()
+		require.Eventually(t, func() bool {
+			return v.DoppelgangerState() == DoppelgangerStateDetected
+		}, time.Second*5, time.Millisecond*10)
+		require.Error(t, v.checkDoppelgangerProtection())
+
+		// stopping the validator stops the detection, the validator stays paused
+		v.cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second * 5):
+			require.Fail(t, "detection was not stopped")
+		}
+		require.Equal(t, DoppelgangerStateDetected, v.DoppelgangerState())
+	})
+}
+
+func TestExecuteDuty_DoppelgangerProtection(t *testing.T) {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	v := testingValidator(t, true, 3, identifier)
+	defer v.cancel()
+	ethNetwork := beacon.NewNetwork(core.PraterNetwork, 0, nil)
+	v.ethNetwork = &ethNetwork
+	v.setDoppelgangerState(DoppelgangerStateChecking)
+
+	duty := &beacon.Duty{
+		Type: beacon.RoleTypeAttester,
+		Slot: 12,
+	}
+	v.ExecuteDuty(context.Background(), uint64(duty.Slot), duty)
+
+	outcome := v.LastDutyOutcomes()[beacon.RoleTypeAttester]
+	require.Equal(t, DutyStatusSkipped, outcome.Status)
+	require.Equal(t, "state is checking: duties are not allowed by doppelganger protection", outcome.Error)
+	require.Nil(t, v.beacon.(*testBeacon).LastSubmittedAttestation)
+}
+
+func TestDoppelgangerPending(t *testing.T) {
+	threshold.Init()
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	km, err := ekm.NewETHKeyManagerSigner(db, nil, beacon.NewNetwork(core.PraterNetwork, 0, nil), nil)
+	require.NoError(t, err)
+	ctr := setupController(zap.L(), map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{DB: db, Logger: zap.L()})
+	ctr.keyManager = km
+	ctr.beacon = newTestBeacon(t)
+	ctr.operatorPubKey = "operator"
+	ctr.doppelgangerProtection = true
+	ctr.doppelgangerPending = &sync.Map{}
+
+	// handles a ValidatorAdded event of an operator validator in the given block, returns true if the validator is pending
+	handleValidatorAdded := func(handler eth1.SyncEventHandler, block uint64) bool {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		shareSk := &bls.SecretKey{}
+		shareSk.SetByCSPRNG()
+		require.NoError(t, handler(eth1.Event{
+			Log: types.Log{BlockNumber: block},
+			Data: abiparser.ValidatorAddedEvent{
+				PublicKey:          sk.GetPublicKey().Serialize(),
+				OwnerAddress:       common.HexToAddress("0x4e409dB090a71D14d32AdBFbC0A22B1B06dde7dE"),
+				OperatorPublicKeys: [][]byte{[]byte("operator")},
+				SharesPublicKeys:   [][]byte{shareSk.GetPublicKey().Serialize()},
+				EncryptedKeys:      [][]byte{[]byte(shareSk.SerializeToHexStr())},
+			},
+			IsOperatorEvent: true,
+		}))
+		_, pending := ctr.doppelgangerPending.Load(sk.GetPublicKey().SerializeToHexStr())
+		return pending
+	}
+
+	t.Run("sync with a fresh db", func(t *testing.T) {
+		require.True(t, handleValidatorAdded(ctr.Eth1EventHandler(), 10))
+	})
+
+	t.Run("sync after the last synced block", func(t *testing.T) {
+		ctr.SetLastSyncedBlock(big.NewInt(10))
+		defer ctr.SetLastSyncedBlock(nil)
+		handler := ctr.Eth1EventHandler()
+		require.False(t, handleValidatorAdded(handler, 9))
+		require.False(t, handleValidatorAdded(handler, 10))
+		require.True(t, handleValidatorAdded(handler, 11))
+	})
+
+	t.Run("ongoing events", func(t *testing.T) {
+		require.True(t, handleValidatorAdded(ctr.eth1EventHandler(true), 10))
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"time"
 
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	ibftvalcheck "github.com/bloxapp/ssv/ibft/valcheck"
 	"github.com/bloxapp/ssv/network/msgqueue"
 	"github.com/pkg/errors"
@@ -15,73 +17,111 @@ import (
 	"go.uber.org/zap"
 )
 
-// waitForSignatureCollection waits for inbound signatures, collects them or times out if not.
-func (v *Validator) waitForSignatureCollection(logger *zap.Logger, identifier []byte, seqNumber uint64, sigRoot []byte, signaturesCount int, committiee map[uint64]*proto.Node) (map[uint64][]byte, error) {
-	// Collect signatures from other nodes
-	// TODO - change signature count to min threshold
-	signatures := make(map[uint64][]byte, signaturesCount)
-	signedIndxes := make([]uint64, 0)
-	var err error
+// errNotAggregator is returned when the selection proof of the validator doesn't select it as an aggregator
+var errNotAggregator = errors.New("validator is not an aggregator")
+
+// waitForSignatureCollection waits for inbound signatures of the given index and collects them until a threshold of
+// valid signatures is reached, or times out if not.
+// signatures that arrive after the threshold was reached are still verified (for metrics) until the timeout,
+// the index is purged once collection is done
+func (v *Validator) waitForSignatureCollection(logger *zap.Logger, indexKey string, sigRoot []byte, committiee map[uint64]*proto.Node) (map[uint64][]byte, error) {
+	notifications, unsubscribe := v.msgQueue.SubscribeToIndex(indexKey)
+	done := func() {
+		unsubscribe()
+		// clean queue for messages, we don't need them anymore.
+		v.msgQueue.PurgeIndexedMessages(indexKey)
+	}
+	thresholdSize := v.Share.ThresholdSize()
+	signatures := make(map[uint64][]byte, len(committiee))
 	timer := time.NewTimer(v.signatureCollectionTimeout)
-	// loop through messages until timeout
-SigCollectionLoop:
-	for {
+
+	// messages might have arrived before subscribing
+	v.drainSignatures(logger, indexKey, sigRoot, committiee, signatures)
+	for len(signatures) < thresholdSize {
 		select {
 		case <-timer.C:
-			err = errors.Errorf("timed out waiting for post consensus signatures, received %d", len(signedIndxes))
-			break SigCollectionLoop
-		default:
-			if msg := v.msgQueue.PopMessage(msgqueue.SigRoundIndexKey(identifier, seqNumber)); msg != nil {
-				if len(msg.SignedMessage.SignerIds) == 0 { // no KeyManager, empty sig
-					v.logger.Error("missing KeyManager id", zap.Any("msg", msg.SignedMessage))
-					continue SigCollectionLoop
-				}
-				if len(msg.SignedMessage.Signature) == 0 { // no KeyManager, empty sig
-					v.logger.Error("missing sig", zap.Any("msg", msg.SignedMessage))
-					continue SigCollectionLoop
-				}
-				if _, found := signatures[msg.SignedMessage.SignerIds[0]]; found { // sig already exists
-					continue SigCollectionLoop
-				}
-
-				logger.Info("collected valid signature", zap.Uint64("node_id", msg.SignedMessage.SignerIds[0]), zap.Any("msg", msg))
-
-				// verify sig
-				if err := v.verifyPartialSignature(msg.SignedMessage.Signature, sigRoot, msg.SignedMessage.SignerIds[0], committiee); err != nil {
-					logger.Error("received invalid signature", zap.Error(err))
-					continue SigCollectionLoop
-				}
-				logger.Info("signature verified", zap.Uint64("node_id", msg.SignedMessage.SignerIds[0]))
-
-				signatures[msg.SignedMessage.SignerIds[0]] = msg.SignedMessage.Signature
-				signedIndxes = append(signedIndxes, msg.SignedMessage.SignerIds[0])
-				if len(signedIndxes) >= signaturesCount {
-					timer.Stop()
-					break SigCollectionLoop
-				}
-			} else {
-				time.Sleep(time.Millisecond * 100)
-			}
-		}
-	}
-
-	return signatures, err
+			done()
+			return signatures, errors.Errorf("timed out waiting for signatures, received %d", len(signatures))
+		case <-notifications:
+			v.drainSignatures(logger, indexKey, sigRoot, committiee, signatures)
+		}
+	}
+
+	collected := make(map[uint64][]byte, len(signatures))
+	for id, sig := range signatures {
+		collected[id] = sig
+	}
+	go v.verifyLateSignatures(logger, indexKey, sigRoot, committiee, signatures, notifications, timer, done)
+
+	return collected, nil
+}
+
+// verifyLateSignatures keeps verifying signatures that arrive after collection was done, until all committee members
+// have signed or the collection timer fires
+func (v *Validator) verifyLateSignatures(logger *zap.Logger, indexKey string, sigRoot []byte, committiee map[uint64]*proto.Node, signatures map[uint64][]byte, notifications <-chan struct{}, timer *time.Timer, done func()) {
+	defer done()
+	pk := v.Share.PublicKey.SerializeToHexStr()
+	for len(signatures) < len(committiee) {
+		select {
+		case <-timer.C:
+			return
+		case <-notifications:
+			valid, invalid := v.drainSignatures(logger, indexKey, sigRoot, committiee, signatures)
+			metricsLatePartialSignatures.WithLabelValues(pk, "valid").Add(float64(valid))
+			metricsLatePartialSignatures.WithLabelValues(pk, "invalid").Add(float64(invalid))
+		}
+	}
+	timer.Stop()
+}
+
+// drainSignatures pops all the messages of the given index, verifies their signatures and adds the valid ones to signatures.
+// it returns the number of valid and invalid signatures that were found
+func (v *Validator) drainSignatures(logger *zap.Logger, indexKey string, sigRoot []byte, committiee map[uint64]*proto.Node, signatures map[uint64][]byte) (int, int) {
+	valid, invalid := 0, 0
+	for msg := v.msgQueue.PopMessage(indexKey); msg != nil; msg = v.msgQueue.PopMessage(indexKey) {
+		if len(msg.SignedMessage.SignerIds) == 0 { // no KeyManager, empty sig
+			logger.Error("missing KeyManager id", zap.Any("msg", msg.SignedMessage))
+			invalid++
+			continue
+		}
+		if len(msg.SignedMessage.Signature) == 0 { // no KeyManager, empty sig
+			logger.Error("missing sig", zap.Any("msg", msg.SignedMessage))
+			invalid++
+			continue
+		}
+		signerID := msg.SignedMessage.SignerIds[0]
+		if _, found := signatures[signerID]; found { // sig already exists
+			continue
+		}
+
+		// verify sig
+		if err := v.verifyPartialSignature(msg.SignedMessage.Signature, sigRoot, signerID, committiee); err != nil {
+			logger.Debug("rejected invalid signature", zap.Uint64("node_id", signerID), zap.Error(err))
+			invalid++
+			continue
+		}
+		logger.Info("collected valid signature", zap.Uint64("node_id", signerID), zap.Any("msg", msg))
+
+		signatures[signerID] = msg.SignedMessage.Signature
+		valid++
+	}
+	return valid, invalid
 }
 
 // postConsensusDutyExecution signs the eth2 duty after iBFT came to consensus,
-// waits for others to sign, collect sigs, reconstruct and broadcast the reconstructed signature to the beacon chain
+// waits for others to sign, collect sigs, reconstruct and broadcast the reconstructed signature to the beacon chain.
+// it returns the partial signatures that were collected
 func (v *Validator) postConsensusDutyExecution(
 	ctx context.Context,
 	logger *zap.Logger,
 	seqNumber uint64,
 	decidedValue []byte,
-	signaturesCount int,
 	duty *beacon.Duty,
-) error {
+) (map[uint64][]byte, error) {
 	// sign input value and broadcast
 	sig, root, valueStruct, err := v.signDuty(decidedValue, duty)
 	if err != nil {
-		return errors.Wrap(err, "failed to sign input data")
+		return nil, errors.Wrap(err, "failed to sign input data")
 	}
 
 	identifier := v.ibfts[duty.Type].GetIdentifier()
@@ -94,92 +134,130 @@ func (v *Validator) postConsensusDutyExecution(
 		Signature: sig,
 		SignerIds: []uint64{v.Share.NodeID},
 	}); err != nil {
-		return errors.Wrap(err, "failed to broadcast signature")
+		return nil, errors.Wrap(err, "failed to broadcast signature")
 	}
 	logger.Info("broadcasting partial signature post consensus")
 
-	signatures, err := v.waitForSignatureCollection(logger, identifier, seqNumber, root, signaturesCount, v.Share.Committee)
-
-	// clean queue for messages, we don't need them anymore.
-	v.msgQueue.PurgeIndexedMessages(msgqueue.SigRoundIndexKey(identifier, seqNumber))
-
+	signatures, err := v.waitForSignatureCollection(logger, msgqueue.SigRoundIndexKey(identifier, seqNumber), root, v.Share.Committee)
 	if err != nil {
-		return err
+		return signatures, err
 	}
 	logger.Info("collected enough signature to reconstruct...", zap.Int("signatures", len(signatures)))
 
 	// Reconstruct signatures
 	if err := v.reconstructAndBroadcastSignature(logger, signatures, root, valueStruct, duty); err != nil {
-		return errors.Wrap(err, "failed to reconstruct and broadcast signature")
+		return signatures, errors.Wrap(err, "failed to reconstruct and broadcast signature")
 	}
 	logger.Info("Successfully submitted role!")
-	return nil
+	return signatures, nil
 }
 
-func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon.Duty) (int, []byte, uint64, error) {
+// decideInputValue fetches the input value of the given duty and starts an ibft instance to decide on it,
+// it returns the decided message and the sequence number of the instance
+func (v *Validator) decideInputValue(logger *zap.Logger, duty *beacon.Duty) (*proto.SignedMessage, uint64, error) {
 	var inputByts []byte
 	var err error
 	var valCheckInstance ibftvalcheck.ValueCheck
 
 	if _, ok := v.ibfts[duty.Type]; !ok {
-		return 0, nil, 0, errors.Errorf("no ibft for this role [%s]", duty.Type.String())
+		return nil, 0, errors.Errorf("no ibft for this role [%s]", duty.Type.String())
 	}
 
 	switch duty.Type {
 	case beacon.RoleTypeAttester:
 		attData, err := v.beacon.GetAttestationData(duty.Slot, duty.CommitteeIndex)
 		if err != nil {
-			return 0, nil, 0, errors.Wrap(err, "failed to get attestation data")
+			return nil, 0, errors.Wrap(err, "failed to get attestation data")
 		}
 
 		inputByts, err = attData.MarshalSSZ()
 		if err != nil {
-			return 0, nil, 0, errors.Errorf("failed to marshal on attestation role: %s", duty.Type.String())
-		}
-		valCheckInstance = v.valueCheck.AttestationSlashingProtector()
-	//case beacon.RoleTypeAggregator:
-	//	aggData, err := v.beacon.GetAggregationData(ctx, duty, v.Share.PublicKey, v.Share.ShareKey)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Wrap(err, "failed to get aggregation data")
-	//	}
-	//
-	//	d := &proto.InputValue_AggregationData{
-	//		AggregationData: aggData,
-	//	}
-	//	inputByts, err = json.Marshal(d)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Errorf("failed to marshal on aggregation role: %s", role.String())
-	//	}
-	//	valueCheck = &valcheck.AggregatorValueCheck{}
-	//case beacon.RoleTypeProposer:
-	//	block, err := v.beacon.GetProposalData(ctx, slot, v.Share.ShareKey)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Wrap(err, "failed to get proposal block")
-	//	}
-	//
-	//	d := &proto.InputValue_BeaconBlock{
-	//		BeaconBlock: block,
-	//	}
-	//	inputByts, err = json.Marshal(d)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Errorf("failed to marshal on proposer role: %s", role.String())
-	//	}
-	//	valueCheck = &valcheck.ProposerValueCheck{}
+			return nil, 0, errors.Errorf("failed to marshal on attestation role: %s", duty.Type.String())
+		}
+		pk, err := v.Share.OperatorPubKey()
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "could not find operator pk for slashing protection")
+		}
+		valCheckInstance = v.valueCheck.AttestationSlashingProtector(pk.Serialize())
+	case beacon.RoleTypeAggregator:
+		selectionProof, err := v.preConsensusSignature(logger, duty)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get selection proof")
+		}
+		if !beacon.IsAggregator(duty.CommitteeLength, selectionProof[:]) {
+			return nil, 0, errNotAggregator
+		}
+		// let the beacon node know it should aggregate the attestations of the committee
+		if err := v.beacon.SubscribeToCommitteeSubnet([]*api.BeaconCommitteeSubscription{{
+			ValidatorIndex:   duty.ValidatorIndex,
+			Slot:             duty.Slot,
+			CommitteeIndex:   duty.CommitteeIndex,
+			CommitteesAtSlot: duty.CommitteesAtSlot,
+			IsAggregator:     true,
+		}}); err != nil {
+			logger.Warn("failed to subscribe committee to subnet as aggregator", zap.Error(err))
+		}
+		aggregate, err := v.beacon.GetAggregateAttestation(duty.Slot, duty.CommitteeIndex)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get aggregate attestation")
+		}
+
+		aggregateAndProof := &spec.AggregateAndProof{
+			AggregatorIndex: duty.ValidatorIndex,
+			Aggregate:       aggregate,
+			SelectionProof:  selectionProof,
+		}
+		inputByts, err = aggregateAndProof.MarshalSSZ()
+		if err != nil {
+			return nil, 0, errors.Errorf("failed to marshal on aggregator role: %s", duty.Type.String())
+		}
+		valCheckInstance = v.valueCheck.AggregationValidation()
+	case beacon.RoleTypeProposer:
+		randaoReveal, err := v.preConsensusSignature(logger, duty)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get randao reveal")
+		}
+		block, err := v.beacon.GetBeaconBlock(duty.Slot, randaoReveal)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get proposal block")
+		}
+
+		inputByts, err = beacon.MarshalBeaconBlock(block)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to marshal on proposer role: %s", duty.Type.String())
+		}
+		pk, err := v.Share.OperatorPubKey()
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "could not find operator pk for slashing protection")
+		}
+		valCheckInstance = v.valueCheck.ProposalSlashingProtector(pk.Serialize())
+	case beacon.RoleTypeSyncCommittee:
+		inputByts, err = v.syncCommitteeInputValue(duty)
+		if err != nil {
+			return nil, 0, err
+		}
+		valCheckInstance = v.valueCheck.SyncCommitteeValidation()
+	case beacon.RoleTypeSyncCommitteeContribution:
+		inputByts, err = v.syncCommitteeContributionInputValue(logger, duty)
+		if err != nil {
+			return nil, 0, err
+		}
+		valCheckInstance = v.valueCheck.SyncCommitteeContributionValidation()
 	default:
-		return 0, nil, 0, errors.Errorf("unknown role: %s", duty.Type.String())
+		return nil, 0, errors.Errorf("unknown role: %s", duty.Type.String())
 	}
 
 	// do a value check before instance starts to prevent a dead lock if all SSV instances start
 	// an iBFT instance with values which are invalid which will result in them getting "stuck"
 	// in infinite round changes
 	if err := valCheckInstance.Check(inputByts); err != nil {
-		return 0, nil, 0, errors.Wrap(err, "input value failed pre-consensus check")
+		return nil, 0, errors.Wrap(err, "input value failed pre-consensus check")
 	}
 
 	// calculate next seq
 	seqNumber, err := v.ibfts[duty.Type].NextSeqNumber()
 	if err != nil {
-		return 0, nil, 0, errors.Wrap(err, "failed to calculate next sequence number")
+		return nil, 0, errors.Wrap(err, "failed to calculate next sequence number")
 	}
 
 	result, err := v.ibfts[duty.Type].StartInstance(ibft.ControllerStartInstanceOptions{
@@ -190,16 +268,16 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 		RequireMinPeers: true,
 	})
 	if err != nil {
-		return 0, nil, 0, errors.WithMessage(err, "ibft instance failed")
+		return nil, 0, errors.WithMessage(err, "ibft instance failed")
 	}
 	if result == nil {
-		return 0, nil, seqNumber, errors.Wrap(err, "instance result returned nil")
+		return nil, seqNumber, errors.New("instance result returned nil")
 	}
 	if !result.Decided {
-		return 0, nil, seqNumber, errors.New("instance did not decide")
+		return nil, seqNumber, errors.New("instance did not decide")
 	}
 
-	return len(result.Msg.SignerIds), result.Msg.Message.Value, seqNumber, nil
+	return result.Msg, seqNumber, nil
 }
 
 // ExecuteDuty executes the given duty
@@ -211,26 +289,45 @@ func (v *Validator) ExecuteDuty(ctx context.Context, slot uint64, duty *beacon.D
 
 	metricsCurrentSlot.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(duty.Slot))
 
+	record := v.newDutyRecord(duty)
+	if err := v.checkDoppelgangerProtection(); err != nil {
+		logger.Warn("duty was not executed", zap.Error(err))
+		v.onDutyDone(logger, duty, record, DutyStatusSkipped, err)
+		return
+	}
 	logger.Debug("executing duty...")
-	signaturesCount, decidedValue, seqNumber, err := v.comeToConsensusOnInputValue(logger, duty)
+	decided, seqNumber, err := v.decideInputValue(logger, duty)
+	record.SeqNumber = seqNumber
+	if err == errNotAggregator {
+		logger.Debug("validator was not selected as an aggregator")
+		v.onDutyDone(logger, duty, record, DutyStatusSkipped, nil)
+		return
+	}
 	if err != nil {
 		logger.Error("could not come to consensus", zap.Error(err))
+		v.onDutyDone(logger, duty, record, DutyStatusFailed, errors.WithMessage(err, "could not come to consensus"))
 		return
 	}
+	record.Decided = true
+	record.Round = decided.Message.Round
+	record.Signers = decided.SignerIds
 
 	// Here we ensure at least 2/3 instances got a val so we can sign data and broadcast signatures
-	logger.Info("GOT CONSENSUS", zap.Any("inputValueHex", hex.EncodeToString(decidedValue)))
+	logger.Info("GOT CONSENSUS", zap.Any("inputValueHex", hex.EncodeToString(decided.Message.Value)), zap.Int("signers", len(decided.SignerIds)))
 
 	// Sign, aggregate and broadcast signature
-	if err := v.postConsensusDutyExecution(
+	signatures, err := v.postConsensusDutyExecution(
 		ctx,
 		logger,
 		seqNumber,
-		decidedValue,
-		signaturesCount,
+		decided.Message.Value,
 		duty,
-	); err != nil {
+	)
+	record.PartialSignatures = signerIDs(signatures)
+	if err != nil {
 		logger.Error("could not execute duty", zap.Error(err))
+		v.onDutyDone(logger, duty, record, DutyStatusFailed, errors.WithMessage(err, "could not execute duty"))
 		return
 	}
+	v.onDutyDone(logger, duty, record, DutyStatusSubmitted, nil)
 }
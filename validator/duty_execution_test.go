@@ -2,11 +2,22 @@ package validator
 
 import (
 	"context"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/fixtures"
 	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/collections"
+	"github.com/bloxapp/ssv/utils/format"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"testing"
 	"time"
 )
@@ -74,9 +85,24 @@ func TestConsensusOnInputValue(t *testing.T) {
 			beacon.RoleTypeAttester,
 			refAttestationDataByts,
 			&spec.AttestationData{
-				Slot: 100,
+				Slot:   100,
+				Source: &spec.Checkpoint{Epoch: 3},
+				Target: &spec.Checkpoint{Epoch: 2},
 			},
-			"input value failed pre-consensus check: TEST - failed on slot 100",
+			"input value failed pre-consensus check: source epoch 3 is greater than target epoch 2",
+		},
+		{
+			"slashable value pre-check",
+			false,
+			3,
+			beacon.RoleTypeAttester,
+			refAttestationDataByts,
+			&spec.AttestationData{
+				Slot:   100,
+				Source: &spec.Checkpoint{Epoch: 2},
+				Target: &spec.Checkpoint{Epoch: 3},
+			},
+			"input value failed pre-consensus check: attestation data failed slashing protection: slashable attestation (target epoch 3)",
 		},
 	}
 
@@ -87,6 +113,7 @@ func TestConsensusOnInputValue(t *testing.T) {
 
 			if test.overrideAttestationData != nil {
 				node.beacon.(*testBeacon).refAttestationData = test.overrideAttestationData
+				node.beacon.(*testBeacon).highestTarget = 3
 			}
 
 			duty := &beacon.Duty{
@@ -100,16 +127,16 @@ func TestConsensusOnInputValue(t *testing.T) {
 				ValidatorCommitteeIndex: 0,
 			}
 
-			signaturesCount, decidedByts, _, err := node.comeToConsensusOnInputValue(node.logger, duty)
+			decided, _, err := node.decideInputValue(node.logger, duty)
 			if !test.decided {
 				require.EqualError(t, err, test.expectedError)
 				return
 			}
 			require.NoError(t, err)
-			require.EqualValues(t, 3, signaturesCount)
-			require.NotNil(t, decidedByts)
+			require.EqualValues(t, 3, len(decided.SignerIds))
+			require.NotNil(t, decided.Message.Value)
 
-			require.EqualValues(t, test.expectedAttestationDataByts, decidedByts)
+			require.EqualValues(t, test.expectedAttestationDataByts, decided.Message.Value)
 		})
 	}
 }
@@ -158,7 +185,7 @@ func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 			3,
 			refAttestationDataByts,
 			refAttestationSig,
-			"timed out waiting for post consensus signatures, received 2",
+			"timed out waiting for signatures, received 2",
 		},
 	}
 
@@ -197,7 +224,7 @@ func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			err = validator.postConsensusDutyExecution(context.Background(), validator.logger, 0, test.expectedAttestationDataByts, test.expectedSignaturesCount, duty)
+			_, err = validator.postConsensusDutyExecution(context.Background(), validator.logger, 0, test.expectedAttestationDataByts, duty)
 			if len(test.expectedError) > 0 {
 				require.EqualError(t, err, test.expectedError)
 			} else {
@@ -207,3 +234,329 @@ func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 		})
 	}
 }
+
+func TestProposerDutyExecution(t *testing.T) {
+	t.Run("phase0", func(t *testing.T) {
+		testProposerDutyExecution(t, nil)
+	})
+
+	t.Run("altair", func(t *testing.T) {
+		testProposerDutyExecution(t, &ethspec.VersionedBeaconBlock{
+			Version: ethspec.DataVersionAltair,
+			Altair: &altair.BeaconBlock{
+				ProposerIndex: 1,
+				ParentRoot:    spec.Root{1, 2, 3, 4},
+				StateRoot:     spec.Root{1, 2, 3, 4},
+				Body: &altair.BeaconBlockBody{
+					ETH1Data: &spec.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+					Graffiti: make([]byte, 32),
+					SyncAggregate: &altair.SyncAggregate{
+						SyncCommitteeBits: bitfield.NewBitvector512(),
+					},
+				},
+			},
+		})
+	})
+}
+
+// testProposerDutyExecution runs a proposer duty with the given block of the beacon node (or the default phase0 block)
+func testProposerDutyExecution(t *testing.T, refBlock *ethspec.VersionedBeaconBlock) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	proposerIdentifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeProposer.String()))
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeProposer] = &testIBFT{decided: true, signaturesCount: 3, identifier: proposerIdentifier}
+	ethNetwork := beacon.NewNetwork(core.PraterNetwork, 0, nil)
+	validator.ethNetwork = &ethNetwork
+	if refBlock != nil {
+		validator.beacon.(*testBeacon).refBlock = refBlock
+	}
+	// wait for for listeners to spin up
+	time.Sleep(time.Millisecond * 100)
+
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeProposer,
+		PubKey:         spec.BLSPubKey{},
+		Slot:           64,
+		ValidatorIndex: 1,
+	}
+
+	// other operators sign randao reveal (epoch 2)
+	randaoRoot, err := types.Epoch(2).HashTreeRoot()
+	require.NoError(t, err)
+	broadcastOtherOperatorsSignatures(t, validator.network.BroadcastPreConsensusSignature, validator.preConsensusIdentifier(beacon.RoleTypeProposer), uint64(duty.Slot), randaoRoot[:])
+
+	decided, seqNumber, err := validator.decideInputValue(validator.logger, duty)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, len(decided.SignerIds))
+	decidedByts := decided.Message.Value
+
+	block, err := beacon.UnmarshalBeaconBlock(decidedByts)
+	require.NoError(t, err)
+	require.Equal(t, validator.beacon.(*testBeacon).refBlock.Version, block.Version)
+	slot, err := block.Slot()
+	require.NoError(t, err)
+	require.EqualValues(t, duty.Slot, slot)
+	var randaoReveal spec.BLSSignature
+	if block.Version == ethspec.DataVersionAltair {
+		randaoReveal = block.Altair.Body.RANDAOReveal
+	} else {
+		randaoReveal = block.Phase0.Body.RANDAOReveal
+	}
+	randaoSig := &bls.Sign{}
+	require.NoError(t, randaoSig.Deserialize(randaoReveal[:]))
+	require.True(t, randaoSig.VerifyByte(validator.Share.PublicKey, randaoRoot[:]))
+
+	// other operators sign the decided block
+	root, err := blockRoot(block)
+	require.NoError(t, err)
+	broadcastOtherOperatorsSignatures(t, validator.network.BroadcastSignature, proposerIdentifier, seqNumber, root[:])
+
+	_, err = validator.postConsensusDutyExecution(context.Background(), validator.logger, seqNumber, decidedByts, duty)
+	require.NoError(t, err)
+	submitted := validator.beacon.(*testBeacon).LastSubmittedBlock
+	require.NotNil(t, submitted)
+	require.Equal(t, block.Version, submitted.Version)
+	signature, err := blockSignature(submitted)
+	require.NoError(t, err)
+	blockSig := &bls.Sign{}
+	require.NoError(t, blockSig.Deserialize(signature))
+	require.True(t, blockSig.VerifyByte(validator.Share.PublicKey, root[:]))
+}
+
+func TestAggregatorDutyExecution(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	aggregatorIdentifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAggregator.String()))
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeAggregator] = &testIBFT{decided: true, signaturesCount: 3, identifier: aggregatorIdentifier}
+	// wait for for listeners to spin up
+	time.Sleep(time.Millisecond * 100)
+
+	slotRoot, err := types.Slot(2).HashTreeRoot()
+	require.NoError(t, err)
+
+	t.Run("not an aggregator", func(t *testing.T) {
+		duty := &beacon.Duty{
+			Type:            beacon.RoleTypeAggregator,
+			PubKey:          spec.BLSPubKey{},
+			Slot:            1,
+			ValidatorIndex:  1,
+			CommitteeLength: beacon.TargetAggregatorsPerCommittee << 20,
+		}
+		root, err := types.Slot(1).HashTreeRoot()
+		require.NoError(t, err)
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastPreConsensusSignature, validator.preConsensusIdentifier(beacon.RoleTypeAggregator), uint64(duty.Slot), root[:])
+
+		_, _, err = validator.decideInputValue(validator.logger, duty)
+		require.EqualError(t, err, errNotAggregator.Error())
+	})
+
+	t.Run("aggregate and submit", func(t *testing.T) {
+		duty := &beacon.Duty{
+			Type:            beacon.RoleTypeAggregator,
+			PubKey:          spec.BLSPubKey{},
+			Slot:            2,
+			ValidatorIndex:  1,
+			CommitteeLength: beacon.TargetAggregatorsPerCommittee,
+		}
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastPreConsensusSignature, validator.preConsensusIdentifier(beacon.RoleTypeAggregator), uint64(duty.Slot), slotRoot[:])
+
+		decided, seqNumber, err := validator.decideInputValue(validator.logger, duty)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, len(decided.SignerIds))
+		decidedByts := decided.Message.Value
+
+		aggregateAndProof := &spec.AggregateAndProof{}
+		require.NoError(t, aggregateAndProof.UnmarshalSSZ(decidedByts))
+		require.EqualValues(t, duty.ValidatorIndex, aggregateAndProof.AggregatorIndex)
+		selectionProof := aggregateAndProof.SelectionProof
+		proofSig := &bls.Sign{}
+		require.NoError(t, proofSig.Deserialize(selectionProof[:]))
+		require.True(t, proofSig.VerifyByte(validator.Share.PublicKey, slotRoot[:]))
+
+		// other operators sign the decided aggregate and proof
+		root, err := aggregateAndProof.HashTreeRoot()
+		require.NoError(t, err)
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastSignature, aggregatorIdentifier, seqNumber, root[:])
+
+		_, err = validator.postConsensusDutyExecution(context.Background(), validator.logger, seqNumber, decidedByts, duty)
+		require.NoError(t, err)
+		submitted := validator.beacon.(*testBeacon).LastSubmittedAggregate
+		require.NotNil(t, submitted)
+		signature := submitted.Signature
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(signature[:]))
+		require.True(t, sig.VerifyByte(validator.Share.PublicKey, root[:]))
+	})
+}
+
+func TestSyncCommitteeDutyExecution(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	syncCommitteeIdentifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeSyncCommittee.String()))
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeSyncCommittee] = &testIBFT{decided: true, signaturesCount: 3, identifier: syncCommitteeIdentifier}
+	// wait for for listeners to spin up
+	time.Sleep(time.Millisecond * 100)
+
+	duty := &beacon.Duty{
+		Type:                          beacon.RoleTypeSyncCommittee,
+		PubKey:                        spec.BLSPubKey{},
+		Slot:                          12,
+		ValidatorIndex:                1,
+		ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{5},
+	}
+
+	decided, seqNumber, err := validator.decideInputValue(validator.logger, duty)
+	require.NoError(t, err)
+	decidedByts := decided.Message.Value
+	blockRoot := validator.beacon.(*testBeacon).refSyncBlockRoot
+	require.Equal(t, blockRoot[:], decidedByts)
+
+	// other operators sign the decided block root
+	broadcastOtherOperatorsSignatures(t, validator.network.BroadcastSignature, syncCommitteeIdentifier, seqNumber, blockRoot[:])
+
+	_, err = validator.postConsensusDutyExecution(context.Background(), validator.logger, seqNumber, decidedByts, duty)
+	require.NoError(t, err)
+	submitted := validator.beacon.(*testBeacon).LastSubmittedSyncMessage
+	require.NotNil(t, submitted)
+	require.EqualValues(t, duty.Slot, submitted.Slot)
+	require.EqualValues(t, duty.ValidatorIndex, submitted.ValidatorIndex)
+	require.Equal(t, blockRoot, submitted.BeaconBlockRoot)
+	signature := submitted.Signature
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(signature[:]))
+	require.True(t, sig.VerifyByte(validator.Share.PublicKey, blockRoot[:]))
+}
+
+func TestSyncCommitteeContributionDutyExecution(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	contributionIdentifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeSyncCommitteeContribution.String()))
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeSyncCommitteeContribution] = &testIBFT{decided: true, signaturesCount: 3, identifier: contributionIdentifier}
+	// wait for for listeners to spin up
+	time.Sleep(time.Millisecond * 100)
+
+	// sync committee index 130 belongs to subcommittee 1
+	const subcommitteeIndex = 1
+	selectionRoot := func(slot spec.Slot) []byte {
+		data := &altair.SyncAggregatorSelectionData{Slot: slot, SubcommitteeIndex: subcommitteeIndex}
+		root, err := data.HashTreeRoot()
+		require.NoError(t, err)
+		return root[:]
+	}
+	// find slots in which the validator is (not) selected as an aggregator
+	validatorSk := &bls.SecretKey{}
+	require.NoError(t, validatorSk.Deserialize(fixtures.RefSk))
+	var aggregatorSlot, notAggregatorSlot spec.Slot
+	for slot := spec.Slot(1); aggregatorSlot == 0 || notAggregatorSlot == 0; slot++ {
+		if beacon.IsSyncCommitteeAggregator(validatorSk.SignByte(selectionRoot(slot)).Serialize()) {
+			aggregatorSlot = slot
+		} else {
+			notAggregatorSlot = slot
+		}
+	}
+	newDuty := func(slot spec.Slot) *beacon.Duty {
+		return &beacon.Duty{
+			Type:                          beacon.RoleTypeSyncCommitteeContribution,
+			PubKey:                        spec.BLSPubKey{},
+			Slot:                          slot,
+			ValidatorIndex:                1,
+			ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{130},
+		}
+	}
+
+	t.Run("not an aggregator", func(t *testing.T) {
+		duty := newDuty(notAggregatorSlot)
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastPreConsensusSignature, validator.preConsensusIdentifier(beacon.RoleTypeSyncCommitteeContribution), uint64(duty.Slot), selectionRoot(duty.Slot))
+
+		_, _, err := validator.decideInputValue(validator.logger, duty)
+		require.EqualError(t, err, errNotAggregator.Error())
+	})
+
+	t.Run("contribute and submit", func(t *testing.T) {
+		duty := newDuty(aggregatorSlot)
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastPreConsensusSignature, validator.preConsensusIdentifier(beacon.RoleTypeSyncCommitteeContribution), uint64(duty.Slot), selectionRoot(duty.Slot))
+
+		decided, seqNumber, err := validator.decideInputValue(validator.logger, duty)
+		require.NoError(t, err)
+		decidedByts := decided.Message.Value
+
+		contributionAndProof := &altair.ContributionAndProof{}
+		require.NoError(t, contributionAndProof.UnmarshalSSZ(decidedByts))
+		require.EqualValues(t, duty.ValidatorIndex, contributionAndProof.AggregatorIndex)
+		require.EqualValues(t, subcommitteeIndex, contributionAndProof.Contribution.SubcommitteeIndex)
+		require.Equal(t, validator.beacon.(*testBeacon).refSyncBlockRoot, contributionAndProof.Contribution.BeaconBlockRoot)
+		selectionProof := contributionAndProof.SelectionProof
+		proofSig := &bls.Sign{}
+		require.NoError(t, proofSig.Deserialize(selectionProof[:]))
+		require.True(t, proofSig.VerifyByte(validator.Share.PublicKey, selectionRoot(duty.Slot)))
+
+		// other operators sign the decided contribution and proof
+		root, err := contributionAndProof.HashTreeRoot()
+		require.NoError(t, err)
+		broadcastOtherOperatorsSignatures(t, validator.network.BroadcastSignature, contributionIdentifier, seqNumber, root[:])
+
+		_, err = validator.postConsensusDutyExecution(context.Background(), validator.logger, seqNumber, decidedByts, duty)
+		require.NoError(t, err)
+		submitted := validator.beacon.(*testBeacon).LastSubmittedContribution
+		require.NotNil(t, submitted)
+		signature := submitted.Signature
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(signature[:]))
+		require.True(t, sig.VerifyByte(validator.Share.PublicKey, root[:]))
+	})
+}
+
+// broadcastOtherOperatorsSignatures broadcasts the partial signatures of all operators except the validator's operator
+func broadcastOtherOperatorsSignatures(t *testing.T, broadcast func(topicName []byte, msg *proto.SignedMessage) error, identifier []byte, seqNumber uint64, root []byte) {
+	for i := 1; i < len(refSplitShares); i++ {
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.Deserialize(refSplitShares[i]))
+		require.NoError(t, broadcast(nil, &proto.SignedMessage{
+			Message: &proto.Message{
+				Lambda:    identifier,
+				SeqNumber: seqNumber,
+			},
+			Signature: sk.SignByte(root).Serialize(),
+			SignerIds: []uint64{uint64(i + 1)},
+		}))
+	}
+}
+
+func TestExecuteDuty_Journal(t *testing.T) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	validator := testingValidator(t, false, 0, identifier)
+	ethNetwork := beacon.NewNetwork(core.PraterNetwork, 0, nil)
+	validator.ethNetwork = &ethNetwork
+	validator.dutyJournal = collections.NewDutyJournal(db, zap.L())
+
+	duty := &beacon.Duty{
+		Type: beacon.RoleTypeAttester,
+		Slot: 12,
+	}
+	validator.ExecuteDuty(context.Background(), uint64(duty.Slot), duty)
+
+	records, err := validator.dutyJournal.GetDuties(validator.Share.PublicKey.SerializeToHexStr(), 0, 100)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	record := records[0]
+	require.Equal(t, uint64(12), record.Slot)
+	require.Equal(t, beacon.RoleTypeAttester.String(), record.Role)
+	require.Equal(t, string(DutyStatusFailed), record.Status)
+	require.False(t, record.Decided)
+	require.Equal(t, "could not come to consensus: instance did not decide", record.Error)
+	require.False(t, record.EndTime.Before(record.StartTime))
+
+	outcome := validator.LastDutyOutcomes()[beacon.RoleTypeAttester]
+	require.Equal(t, DutyStatusFailed, outcome.Status)
+	require.Equal(t, record.Error, outcome.Error)
+}
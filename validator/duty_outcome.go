@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/storage/collections"
+	"go.uber.org/zap"
+)
+
+// DutyStatus is the outcome status of an executed duty
+type DutyStatus string
+
+const (
+	// DutyStatusSubmitted means the duty was decided and the reconstructed signature was submitted to the beacon chain
+	DutyStatusSubmitted DutyStatus = "submitted"
+	// DutyStatusFailed means the duty could not be completed
+	DutyStatusFailed DutyStatus = "failed"
+	// DutyStatusSkipped means the duty was not relevant for this validator (e.g. not selected as an aggregator)
+	DutyStatusSkipped DutyStatus = "skipped"
+)
+
+// DutyOutcome holds the result of the last execution of some duty
+type DutyOutcome struct {
+	Slot   uint64     `json:"slot"`
+	Status DutyStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	Time   time.Time  `json:"time"`
+}
+
+// LastDutyOutcomes returns the outcome of the last executed duty of each role
+func (v *Validator) LastDutyOutcomes() map[beacon.RoleType]DutyOutcome {
+	v.dutyOutcomesLock.RLock()
+	defer v.dutyOutcomesLock.RUnlock()
+
+	res := make(map[beacon.RoleType]DutyOutcome, len(v.dutyOutcomes))
+	for role, outcome := range v.dutyOutcomes {
+		res[role] = outcome
+	}
+	return res
+}
+
+// reportDutyOutcome saves the outcome of the given duty
+func (v *Validator) reportDutyOutcome(duty *beacon.Duty, status DutyStatus, err error) {
+	outcome := DutyOutcome{
+		Slot:   uint64(duty.Slot),
+		Status: status,
+		Time:   time.Now(),
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+
+	v.dutyOutcomesLock.Lock()
+	defer v.dutyOutcomesLock.Unlock()
+
+	if v.dutyOutcomes == nil {
+		v.dutyOutcomes = make(map[beacon.RoleType]DutyOutcome)
+	}
+	v.dutyOutcomes[duty.Type] = outcome
+}
+
+// newDutyRecord creates a journal record for the given duty
+func (v *Validator) newDutyRecord(duty *beacon.Duty) *collections.DutyRecord {
+	return &collections.DutyRecord{
+		PubKey:    v.Share.PublicKey.SerializeToHexStr(),
+		Role:      duty.Type.String(),
+		Slot:      uint64(duty.Slot),
+		StartTime: time.Now(),
+	}
+}
+
+// onDutyDone reports the outcome of the given duty and saves its record in the duty journal
+func (v *Validator) onDutyDone(logger *zap.Logger, duty *beacon.Duty, record *collections.DutyRecord, status DutyStatus, err error) {
+	v.reportDutyOutcome(duty, status, err)
+
+	if v.dutyJournal == nil {
+		return
+	}
+	record.Status = string(status)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.EndTime = time.Now()
+	if err := v.dutyJournal.SaveDuty(record); err != nil {
+		logger.Warn("could not save duty record", zap.Error(err))
+	}
+}
+
+// signerIDs returns the sorted ids of the given signatures
+func signerIDs(signatures map[uint64][]byte) []uint64 {
+	ids := make([]uint64, 0, len(signatures))
+	for id := range signatures {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
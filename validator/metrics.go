@@ -17,6 +17,10 @@ var (
 		Name: "ssv:validator:status",
 		Help: "Validator status",
 	}, []string{"pubKey"})
+	metricsLatePartialSignatures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:late_partial_signatures",
+		Help: "Count of partial signatures that arrived after a threshold was reached",
+	}, []string{"pubKey", "status"})
 )
 
 func init() {
@@ -26,6 +30,9 @@ func init() {
 	if err := prometheus.Register(metricsValidatorStatus); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsLatePartialSignatures); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // ReportValidatorStatus reports the current status of validator
@@ -72,4 +79,5 @@ var (
 	validatorStatusNotFound     validatorStatus = 7
 	validatorStatusPending      validatorStatus = 8
 	validatorStatusUnknown      validatorStatus = 9
+	validatorStatusDoppelganger validatorStatus = 10
 )
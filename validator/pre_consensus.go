@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"bytes"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/msgqueue"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// preConsensusSigner signs the relevant object of the given duty with the operator's share,
+// it returns the partial signature and the signed root
+type preConsensusSigner func(v *Validator, duty *beacon.Duty, pk []byte) (spec.BLSSignature, []byte, error)
+
+// preConsensusRound describes a partial signatures round that must complete before consensus starts
+type preConsensusRound struct {
+	// identifierSuffix distinguishes the round from other rounds of the same validator
+	identifierSuffix string
+	sign             preConsensusSigner
+}
+
+// preConsensusRounds holds the partial signatures rounds of roles that require one
+var preConsensusRounds = map[beacon.RoleType]preConsensusRound{
+	beacon.RoleTypeProposer: {
+		identifierSuffix: "RANDAO",
+		sign:             (*Validator).signRandaoReveal,
+	},
+	beacon.RoleTypeAggregator: {
+		identifierSuffix: "SELECTION_PROOF",
+		sign:             (*Validator).signSelectionProof,
+	},
+	beacon.RoleTypeSyncCommitteeContribution: {
+		identifierSuffix: "SYNC_SELECTION_PROOF",
+		sign:             (*Validator).signSyncSelectionProof,
+	},
+}
+
+// preConsensusIdentifier returns the identifier that is used for the pre-consensus partial signatures of the given role
+func (v *Validator) preConsensusIdentifier(role beacon.RoleType) []byte {
+	return []byte(format.IdentifierFormat(v.Share.PublicKey.Serialize(), preConsensusRounds[role].identifierSuffix))
+}
+
+// isPreConsensusIdentifier returns true if the provided identifier belongs to one of the pre-consensus rounds of this validator
+func (v *Validator) isPreConsensusIdentifier(toMatch []byte) bool {
+	for role := range preConsensusRounds {
+		if bytes.Equal(v.preConsensusIdentifier(role), toMatch) {
+			return true
+		}
+	}
+	return false
+}
+
+// preConsensusSignature signs the pre-consensus object of the given duty, waits for other operators to sign
+// and reconstructs the signature of the validator.
+// the duty slot is used as the sequence number of the signatures round, as it is known to all operators in advance
+func (v *Validator) preConsensusSignature(logger *zap.Logger, duty *beacon.Duty) (spec.BLSSignature, error) {
+	round, found := preConsensusRounds[duty.Type]
+	if !found {
+		return spec.BLSSignature{}, errors.Errorf("no pre-consensus round for role %s", duty.Type.String())
+	}
+	pk, err := v.Share.OperatorPubKey()
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not find operator pk for pre-consensus signing")
+	}
+	sig, root, err := round.sign(v, duty, pk.Serialize())
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to sign pre-consensus data")
+	}
+	root = ensureRoot(root)
+
+	identifier := v.preConsensusIdentifier(duty.Type)
+	seqNumber := uint64(duty.Slot)
+	if err := v.network.BroadcastPreConsensusSignature(v.Share.PublicKey.Serialize(), &proto.SignedMessage{
+		Message: &proto.Message{
+			Lambda:    identifier,
+			SeqNumber: seqNumber,
+		},
+		Signature: sig[:],
+		SignerIds: []uint64{v.Share.NodeID},
+	}); err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to broadcast pre-consensus signature")
+	}
+	logger.Info("broadcasting partial signature pre consensus")
+
+	signatures, err := v.waitForSignatureCollection(logger, msgqueue.PreConsensusSigRoundIndexKey(identifier, seqNumber), root, v.Share.Committee)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+
+	signature, err := threshold.ReconstructSignatures(signatures)
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to reconstruct signatures")
+	}
+	if res := signature.VerifyByte(v.Share.PublicKey, root); !res {
+		return spec.BLSSignature{}, errors.New("could not reconstruct a valid signature")
+	}
+	logger.Info("pre-consensus signatures successfully reconstructed", zap.Int("signature count", len(signatures)))
+
+	ret := spec.BLSSignature{}
+	copy(ret[:], signature.Serialize())
+	return ret, nil
+}
+
+func (v *Validator) listenToPreConsensusSignatureMessages() {
+	sigChan, done := v.network.ReceivedPreConsensusSignatureChan()
+	defer done()
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case sigMsg, ok := <-sigChan:
+			if !ok {
+				return
+			}
+			if sigMsg == nil {
+				v.logger.Debug("got nil message")
+				continue
+			}
+
+			if sigMsg.Message != nil && v.isPreConsensusIdentifier(sigMsg.Message.Lambda) {
+				v.logger.Debug("adding pre-consensus sig message to msg queue", getFields(sigMsg)...)
+				v.msgQueue.AddMessage(&network.Message{
+					SignedMessage: sigMsg,
+					Type:          network.NetworkMsg_PreConsensusSignatureType,
+				})
+			}
+		}
+	}
+}
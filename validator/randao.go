@@ -0,0 +1,13 @@
+package validator
+
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// signRandaoReveal signs the epoch of the given duty, the reconstructed signature is used as the randao reveal of the proposed block
+func (v *Validator) signRandaoReveal(duty *beacon.Duty, pk []byte) (spec.BLSSignature, []byte, error) {
+	epoch := v.ethNetwork.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	return v.signer.SignRandaoReveal(spec.Epoch(epoch), pk)
+}
@@ -0,0 +1,11 @@
+package validator
+
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+)
+
+// signSelectionProof signs the slot of the given duty, the reconstructed signature is used as the selection proof of the aggregator
+func (v *Validator) signSelectionProof(duty *beacon.Duty, pk []byte) (spec.BLSSignature, []byte, error) {
+	return v.signer.SignSlot(duty.Slot, pk)
+}
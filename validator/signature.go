@@ -2,6 +2,8 @@ package validator
 
 import (
 	"encoding/base64"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
@@ -62,36 +64,62 @@ func (v *Validator) signDuty(decidedValue []byte, duty *beacon.Duty) ([]byte, []
 		retValueStruct.GetAttestation().AggregationBits = signedAttestation.AggregationBits
 		sig = signedAttestation.Signature[:]
 		root = ensureRoot(r)
-	//case beacon.RoleTypeAggregator:
-	//	s := &proto.InputValue_Aggregation{}
-	//	if err := json.Unmarshal(decidedValue, s); err != nil {
-	//		return nil, nil, nil, errors.Wrap(err, "failed to marshal aggregator")
-	//	}
-	//	signedAggregation, e := v.beacon.SignAggregation(ctx, s.Aggregation.Message, v.Share.ShareKey)
-	//	if e != nil{
-	//		return nil, nil, nil, errors.Wrap(err, "failed to sign attestation")
-	//	}
-	//	retValueStruct.SignedData = s
-	//	retValueStruct.GetAggregation().Signature = signedAggregation.Signature
-	//	retValueStruct.GetAggregation().Message = signedAggregation.Message
-	//	err = e
-	//	sig = signedAggregation.GetSignature()
-	//case beacon.RoleTypeProposer:
-	//	s := &proto.InputValue_Block{}
-	//	if err := json.Unmarshal(decidedValue, s); err != nil {
-	//		return nil, nil, nil, errors.Wrap(err, "failed to marshal aggregator")
-	//	}
-	//
-	//	signedProposal, e := v.beacon.SignProposal(ctx, nil, s.Block.Block, v.Share.ShareKey)
-	//	if e != nil{
-	//		return nil, nil, nil, errors.Wrap(err, "failed to sign attestation")
-	//	}
-	//
-	//	retValueStruct.SignedData = s
-	//	retValueStruct.GetBlock().Signature = signedProposal.Signature
-	//	retValueStruct.GetBlock().Block = signedProposal.Block
-	//	err = e
-	//	sig = signedProposal.GetSignature()
+	case beacon.RoleTypeAggregator:
+		s := &spec.AggregateAndProof{}
+		if err := s.UnmarshalSSZ(decidedValue); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal aggregate and proof")
+		}
+		signedAggregateAndProof, r, err := v.signer.SignAggregateAndProof(s, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedAggregateAndProof{SignedAggregateAndProof: signedAggregateAndProof}
+		sig = copySignature(signedAggregateAndProof.Signature)
+		root = ensureRoot(r)
+	case beacon.RoleTypeProposer:
+		s, err := beacon.UnmarshalBeaconBlock(decidedValue)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		signedBlock, r, err := v.signer.SignBeaconBlock(s, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign beacon block")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedBeaconBlock{SignedBeaconBlock: signedBlock}
+		sig, err = blockSignature(signedBlock)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		root = ensureRoot(r)
+	case beacon.RoleTypeSyncCommittee:
+		if len(decidedValue) != len(spec.Root{}) {
+			return nil, nil, nil, errors.Errorf("invalid block root length: %d", len(decidedValue))
+		}
+		blockRoot := spec.Root{}
+		copy(blockRoot[:], decidedValue)
+		msg, r, err := v.signer.SignSyncCommitteeBlockRoot(duty.Slot, blockRoot, duty.ValidatorIndex, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign sync committee block root")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSyncCommitteeMessage{SyncCommitteeMessage: msg}
+		sig = copySignature(msg.Signature)
+		root = ensureRoot(r)
+	case beacon.RoleTypeSyncCommitteeContribution:
+		s := &altair.ContributionAndProof{}
+		if err := s.UnmarshalSSZ(decidedValue); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal contribution and proof")
+		}
+		signedContribution, r, err := v.signer.SignContributionAndProof(s, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedContributionAndProof{SignedContributionAndProof: signedContribution}
+		sig = copySignature(signedContribution.Signature)
+		root = ensureRoot(r)
 	default:
 		return nil, nil, nil, errors.New("unsupported role, can't sign")
 	}
@@ -123,22 +151,65 @@ func (v *Validator) reconstructAndBroadcastSignature(logger *zap.Logger, signatu
 		if err := v.beacon.SubmitAttestation(inputValue.GetAttestation()); err != nil {
 			return errors.Wrap(err, "failed to broadcast attestation")
 		}
-	//case beacon.RoleTypeAggregator:
-	//	inputValue.GetAggregation().Signature = signature.Serialize()
-	//	if err := v.beacon.SubmitAggregation(ctx, inputValue.GetAggregation()); err != nil {
-	//		return errors.Wrap(err, "failed to broadcast aggregation")
-	//	}
-	//case beacon.RoleTypeProposer:
-	//	inputValue.GetBlock().Signature = signature.Serialize()
-	//	if err := v.beacon.SubmitProposal(ctx, inputValue.GetBlock()); err != nil {
-	//		return errors.Wrap(err, "failed to broadcast proposal")
-	//	}
+	case beacon.RoleTypeAggregator:
+		logger.Debug("submitting aggregate and proof")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSignedAggregateAndProof().Signature = blsSig
+		if err := v.beacon.SubmitSignedAggregateSelectionProof(inputValue.GetSignedAggregateAndProof()); err != nil {
+			return errors.Wrap(err, "failed to broadcast aggregate and proof")
+		}
+	case beacon.RoleTypeProposer:
+		logger.Debug("submitting block proposal")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		if err := beacon.SetBeaconBlockSignature(inputValue.GetSignedBeaconBlock(), blsSig); err != nil {
+			return errors.Wrap(err, "failed to set block signature")
+		}
+		if err := v.beacon.SubmitBeaconBlock(inputValue.GetSignedBeaconBlock()); err != nil {
+			return errors.Wrap(err, "failed to broadcast block proposal")
+		}
+	case beacon.RoleTypeSyncCommittee:
+		logger.Debug("submitting sync committee message")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSyncCommitteeMessage().Signature = blsSig
+		if err := v.beacon.SubmitSyncMessage(inputValue.GetSyncCommitteeMessage()); err != nil {
+			return errors.Wrap(err, "failed to broadcast sync committee message")
+		}
+	case beacon.RoleTypeSyncCommitteeContribution:
+		logger.Debug("submitting contribution and proof")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSignedContributionAndProof().Signature = blsSig
+		if err := v.beacon.SubmitSignedContributionAndProof(inputValue.GetSignedContributionAndProof()); err != nil {
+			return errors.Wrap(err, "failed to broadcast contribution and proof")
+		}
 	default:
 		return errors.New("role is undefined, can't reconstruct signature")
 	}
 	return nil
 }
 
+// copySignature copies the given signature so it won't reference the signed struct (cgo rejects it)
+func copySignature(signature spec.BLSSignature) []byte {
+	sig := make([]byte, len(signature))
+	copy(sig, signature[:])
+	return sig
+}
+
+// blockSignature copies the signature of the given signed block of any version
+func blockSignature(block *ethspec.VersionedSignedBeaconBlock) ([]byte, error) {
+	switch {
+	case block.Version == ethspec.DataVersionPhase0 && block.Phase0 != nil:
+		return copySignature(block.Phase0.Signature), nil
+	case block.Version == ethspec.DataVersionAltair && block.Altair != nil:
+		return copySignature(block.Altair.Signature), nil
+	default:
+		return nil, errors.Errorf("no signed block of version %d", block.Version)
+	}
+}
+
 // ensureRoot ensures that root will have sufficient allocated memory
 // otherwise we get panic from bls:
 // github.com/herumi/bls-eth-go-binary/bls.(*Sign).VerifyByte:738
@@ -14,6 +14,7 @@ type ICollection interface {
 	basedb.RegistryStore
 
 	SaveValidatorShare(share *Share) error
+	DeleteValidatorShare(key []byte) error
 	GetValidatorShare(key []byte) (*Share, bool, error)
 	GetAllValidatorShares() ([]*Share, error)
 	GetOperatorValidatorShares(operatorPubKey string) ([]*Share, error)
@@ -68,6 +69,14 @@ func (s *Collection) saveUnsafe(share *Share) error {
 	return s.db.Set(collectionPrefix(), share.PublicKey.Serialize(), value)
 }
 
+// DeleteValidatorShare removes validator share by key
+func (s *Collection) DeleteValidatorShare(key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(collectionPrefix(), key)
+}
+
 // GetValidatorShare by key
 func (s *Collection) GetValidatorShare(key []byte) (*Share, bool, error) {
 	s.lock.RLock()
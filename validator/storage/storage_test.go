@@ -60,6 +60,14 @@ func TestSaveAndGetValidatorStorage(t *testing.T) {
 	validators, err := collection.GetAllValidatorShares()
 	require.NoError(t, err)
 	require.EqualValues(t, 2, len(validators))
+
+	require.NoError(t, collection.DeleteValidatorShare(validatorShare.PublicKey.Serialize()))
+	_, found, err = collection.GetValidatorShare(validatorShare.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.False(t, found)
+	validators, err = collection.GetAllValidatorShares()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, len(validators))
 }
 
 func generateRandomValidatorShare() (*Share, *bls.SecretKey) {
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// syncSubcommitteeIndex returns the subcommittee of the given sync committee duty.
+// a validator that appears more than once in the sync committee produces a contribution only for its first subcommittee
+func syncSubcommitteeIndex(duty *beacon.Duty) (uint64, error) {
+	if len(duty.ValidatorSyncCommitteeIndices) == 0 {
+		return 0, errors.New("sync committee indices are missing")
+	}
+	return beacon.SyncSubcommitteeIndex(uint64(duty.ValidatorSyncCommitteeIndices[0])), nil
+}
+
+// signSyncSelectionProof signs the slot and subcommittee of the given duty,
+// the reconstructed signature is used as the selection proof of the sync committee aggregator
+func (v *Validator) signSyncSelectionProof(duty *beacon.Duty, pk []byte) (spec.BLSSignature, []byte, error) {
+	subcommitteeIndex, err := syncSubcommitteeIndex(duty)
+	if err != nil {
+		return spec.BLSSignature{}, nil, err
+	}
+	return v.signer.SignSyncCommitteeSelectionProof(duty.Slot, subcommitteeIndex, pk)
+}
+
+// syncCommitteeInputValue returns the block root that should be signed by the sync committee member
+func (v *Validator) syncCommitteeInputValue(duty *beacon.Duty) ([]byte, error) {
+	root, err := v.beacon.GetSyncMessageBlockRoot(duty.Slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sync committee block root")
+	}
+	return root[:], nil
+}
+
+// syncCommitteeContributionInputValue returns the contribution and proof that should be signed by the sync committee aggregator,
+// errNotAggregator is returned if the validator was not selected as an aggregator of its subcommittee
+func (v *Validator) syncCommitteeContributionInputValue(logger *zap.Logger, duty *beacon.Duty) ([]byte, error) {
+	subcommitteeIndex, err := syncSubcommitteeIndex(duty)
+	if err != nil {
+		return nil, err
+	}
+	selectionProof, err := v.preConsensusSignature(logger, duty)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sync committee selection proof")
+	}
+	if !beacon.IsSyncCommitteeAggregator(selectionProof[:]) {
+		return nil, errNotAggregator
+	}
+	root, err := v.beacon.GetSyncMessageBlockRoot(duty.Slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sync committee block root")
+	}
+	contribution, err := v.beacon.GetSyncCommitteeContribution(duty.Slot, subcommitteeIndex, root)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sync committee contribution")
+	}
+
+	contributionAndProof := &altair.ContributionAndProof{
+		AggregatorIndex: duty.ValidatorIndex,
+		Contribution:    contribution,
+		SelectionProof:  selectionProof,
+	}
+	inputByts, err := contributionAndProof.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal contribution and proof")
+	}
+	return inputByts, nil
+}
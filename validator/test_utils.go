@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"context"
 	"encoding/hex"
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	ethspec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/valcheck"
@@ -14,6 +17,9 @@ import (
 	"github.com/bloxapp/ssv/utils/threshold"
 	"github.com/bloxapp/ssv/validator/storage"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"testing"
@@ -106,8 +112,21 @@ func (t *testIBFT) NextSeqNumber() (uint64, error) {
 testBeacon
 */
 type testBeacon struct {
-	refAttestationData       *spec.AttestationData
-	LastSubmittedAttestation *spec.Attestation
+	refAttestationData        *spec.AttestationData
+	LastSubmittedAttestation  *spec.Attestation
+	refBlock                  *ethspec.VersionedBeaconBlock
+	LastSubmittedBlock        *ethspec.VersionedSignedBeaconBlock
+	refAggregate              *spec.Attestation
+	LastSubmittedAggregate    *spec.SignedAggregateAndProof
+	refSyncBlockRoot          spec.Root
+	LastSubmittedSyncMessage  *altair.SyncCommitteeMessage
+	LastSubmittedContribution *altair.SignedContributionAndProof
+	// shareKey is used to sign beacon objects that are computed at runtime (e.g. randao, blocks)
+	shareKey *bls.SecretKey
+	// liveness is returned by GetValidatorsLiveness, per epoch
+	liveness map[spec.Epoch]map[spec.ValidatorIndex]bool
+	// highestTarget is the highest attestation target epoch that was signed, used for slashing protection
+	highestTarget spec.Epoch
 }
 
 func newTestBeacon(t *testing.T) *testBeacon {
@@ -115,6 +134,27 @@ func newTestBeacon(t *testing.T) *testBeacon {
 	ret.refAttestationData = &spec.AttestationData{}
 	err := ret.refAttestationData.UnmarshalSSZ(refAttestationDataByts) // ignore error
 	require.NoError(t, err)
+	ret.refBlock = &ethspec.VersionedBeaconBlock{
+		Version: ethspec.DataVersionPhase0,
+		Phase0: &spec.BeaconBlock{
+			ProposerIndex: 1,
+			ParentRoot:    spec.Root{1, 2, 3, 4},
+			StateRoot:     spec.Root{1, 2, 3, 4},
+			Body: &spec.BeaconBlockBody{
+				ETH1Data: &spec.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				Graffiti: make([]byte, 32),
+			},
+		},
+	}
+	ret.refAggregate = &spec.Attestation{
+		AggregationBits: bitfield.NewBitlist(8),
+		Data:            ret.refAttestationData,
+	}
+	ret.refSyncBlockRoot = spec.Root{1, 2, 3, 4}
+	ret.shareKey = &bls.SecretKey{}
+	require.NoError(t, ret.shareKey.Deserialize(refSplitShares[0]))
 	return ret
 }
 
@@ -129,6 +169,14 @@ func (b *testBeacon) GetDuties(epoch spec.Epoch, validatorIndices []spec.Validat
 	return nil, nil
 }
 
+func (b *testBeacon) GetValidatorsLiveness(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) (map[spec.ValidatorIndex]bool, error) {
+	res := make(map[spec.ValidatorIndex]bool)
+	for _, index := range validatorIndices {
+		res[index] = b.liveness[epoch][index]
+	}
+	return res, nil
+}
+
 func (b *testBeacon) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
 	return nil, nil
 }
@@ -137,6 +185,17 @@ func (b *testBeacon) GetAttestationData(slot spec.Slot, committeeIndex spec.Comm
 	return b.refAttestationData, nil
 }
 
+func (b *testBeacon) IsAttestationSlashable(data *spec.AttestationData, pk []byte) error {
+	if data.Target.Epoch <= b.highestTarget {
+		return errors.Errorf("slashable attestation (target epoch %d)", data.Target.Epoch)
+	}
+	return nil
+}
+
+func (b *testBeacon) IsBeaconBlockSlashable(block *ethspec.VersionedBeaconBlock, pk []byte) error {
+	return nil
+}
+
 func (b *testBeacon) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
 	sig := spec.BLSSignature{}
 	copy(sig[:], refAttestationSplitSigs[0])
@@ -152,14 +211,165 @@ func (b *testBeacon) SubmitAttestation(attestation *spec.Attestation) error {
 	return nil
 }
 
+func (b *testBeacon) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*ethspec.VersionedBeaconBlock, error) {
+	switch b.refBlock.Version {
+	case ethspec.DataVersionAltair:
+		b.refBlock.Altair.Slot = slot
+		b.refBlock.Altair.Body.RANDAOReveal = randaoReveal
+	default:
+		b.refBlock.Phase0.Slot = slot
+		b.refBlock.Phase0.Body.RANDAOReveal = randaoReveal
+	}
+	return b.refBlock, nil
+}
+
+func (b *testBeacon) SignRandaoReveal(epoch spec.Epoch, pk []byte) (spec.BLSSignature, []byte, error) {
+	root, err := types.Epoch(epoch).HashTreeRoot()
+	if err != nil {
+		return spec.BLSSignature{}, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return sig, root[:], nil
+}
+
+func (b *testBeacon) SignBeaconBlock(block *ethspec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*ethspec.VersionedSignedBeaconBlock, []byte, error) {
+	root, err := blockRoot(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	signed, err := beacon.NewSignedBeaconBlock(block, sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signed, root[:], nil
+}
+
+// blockRoot returns the hash tree root of the given block of any version
+func blockRoot(block *ethspec.VersionedBeaconBlock) (spec.Root, error) {
+	if block.Version == ethspec.DataVersionAltair {
+		return block.Altair.HashTreeRoot()
+	}
+	return block.Phase0.HashTreeRoot()
+}
+
+func (b *testBeacon) SubmitBeaconBlock(block *ethspec.VersionedSignedBeaconBlock) error {
+	b.LastSubmittedBlock = block
+	return nil
+}
+
+func (b *testBeacon) SignSlot(slot spec.Slot, pk []byte) (spec.BLSSignature, []byte, error) {
+	root, err := types.Slot(slot).HashTreeRoot()
+	if err != nil {
+		return spec.BLSSignature{}, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return sig, root[:], nil
+}
+
+func (b *testBeacon) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Attestation, error) {
+	return b.refAggregate, nil
+}
+
+func (b *testBeacon) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return &spec.SignedAggregateAndProof{
+		Message:   msg,
+		Signature: sig,
+	}, root[:], nil
+}
+
+func (b *testBeacon) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	b.LastSubmittedAggregate = msg
+	return nil
+}
+
 func (b *testBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
-	panic("implement me")
+	return nil
+}
+
+func (b *testBeacon) GetSyncCommitteeDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	return nil, nil
+}
+
+func (b *testBeacon) GetSyncMessageBlockRoot(slot spec.Slot) (spec.Root, error) {
+	return b.refSyncBlockRoot, nil
+}
+
+func (b *testBeacon) SignSyncCommitteeBlockRoot(slot spec.Slot, root spec.Root, validatorIndex spec.ValidatorIndex, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return &altair.SyncCommitteeMessage{
+		Slot:            slot,
+		BeaconBlockRoot: root,
+		ValidatorIndex:  validatorIndex,
+		Signature:       sig,
+	}, root[:], nil
+}
+
+func (b *testBeacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	b.LastSubmittedSyncMessage = msg
+	return nil
+}
+
+func (b *testBeacon) SignSyncCommitteeSelectionProof(slot spec.Slot, subcommitteeIndex uint64, pk []byte) (spec.BLSSignature, []byte, error) {
+	data := &altair.SyncAggregatorSelectionData{Slot: slot, SubcommitteeIndex: subcommitteeIndex}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return spec.BLSSignature{}, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return sig, root[:], nil
+}
+
+func (b *testBeacon) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	return &altair.SyncCommitteeContribution{
+		Slot:              slot,
+		BeaconBlockRoot:   blockRoot,
+		SubcommitteeIndex: subcommitteeIndex,
+		AggregationBits:   bitfield.NewBitvector128(),
+	}, nil
+}
+
+func (b *testBeacon) SignContributionAndProof(msg *altair.ContributionAndProof, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], b.shareKey.SignByte(root[:]).Serialize())
+	return &altair.SignedContributionAndProof{
+		Message:   msg,
+		Signature: sig,
+	}, root[:], nil
+}
+
+func (b *testBeacon) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	b.LastSubmittedContribution = msg
+	return nil
+}
+
+func (b *testBeacon) SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error {
+	return nil
 }
 
 func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
 	panic("implement me")
 }
 
+func (b *testBeacon) RemoveShare(pubKey string) error {
+	return nil
+}
+
 func (b *testBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	panic("implement me")
 }
@@ -167,6 +377,9 @@ func (b *testBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte,
 func (b *testBeacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	panic("implement")
 }
+func (b *testBeacon) GetDomainByType(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	panic("implement")
+}
 func (b *testBeacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
 	panic("implement")
 }
@@ -175,13 +388,14 @@ func testingValidator(t *testing.T, decided bool, signaturesCount int, identifie
 	threshold.Init()
 
 	ret := &Validator{}
+	ret.ctx, ret.cancel = context.WithCancel(context.Background())
 	ret.beacon = newTestBeacon(t)
 	ret.logger = zap.L()
 	ret.ibfts = make(map[beacon.RoleType]ibft.Controller)
 	ret.ibfts[beacon.RoleTypeAttester] = &testIBFT{decided: decided, signaturesCount: signaturesCount}
 	ret.ibfts[beacon.RoleTypeAttester].(*testIBFT).identifier = identifier
 	require.NoError(t, ret.ibfts[beacon.RoleTypeAttester].Init())
-	ret.valueCheck = valcheck.New()
+	ret.valueCheck = valcheck.New(ret.beacon.(beacon.SlashingProtector))
 	ret.signer = ret.beacon
 
 	// nodes
@@ -221,6 +435,7 @@ func testingValidator(t *testing.T, decided bool, signaturesCount int, identifie
 	ret.signatureCollectionTimeout = time.Second * 2
 
 	go ret.listenToSignatureMessages()
+	go ret.listenToPreConsensusSignatureMessages()
 	return ret
 }
 
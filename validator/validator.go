@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
-	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/valcheck"
 	ibftctrl "github.com/bloxapp/ssv/ibft/controller"
@@ -34,11 +33,12 @@ type Options struct {
 	SignatureCollectionTimeout time.Duration
 	Network                    network.Network
 	Beacon                     beacon.Beacon
-	ETHNetwork                 *core.Network
+	ETHNetwork                 *beacon.Network
 	DB                         basedb.IDb
 	Fork                       forks.Fork
 	Signer                     beacon.Signer
 	SyncRateLimit              time.Duration
+	DutyJournal                collections.DutyJournal
 
 	notifyOperatorID func(string)
 }
@@ -47,9 +47,10 @@ type Options struct {
 // it holds the corresponding ibft controllers to trigger consensus layer (see ExecuteDuty())
 type Validator struct {
 	ctx                        context.Context
+	cancel                     context.CancelFunc
 	logger                     *zap.Logger
 	Share                      *storage.Share
-	ethNetwork                 *core.Network
+	ethNetwork                 *beacon.Network
 	beacon                     beacon.Beacon
 	ibfts                      map[beacon.RoleType]ibft.Controller
 	msgQueue                   *msgqueue.MessageQueue
@@ -59,6 +60,12 @@ type Validator struct {
 	startOnce                  sync.Once
 	fork                       forks.Fork
 	signer                     beacon.Signer
+	dutyJournal                collections.DutyJournal
+
+	dutyOutcomes     map[beacon.RoleType]DutyOutcome
+	dutyOutcomesLock sync.RWMutex
+
+	doppelgangerState int32
 }
 
 // New creates a new validator instance and the corresponding ibft controller
@@ -70,8 +77,10 @@ func New(opt Options) *Validator {
 	msgQueue := msgqueue.New()
 	ibfts := make(map[beacon.RoleType]ibft.Controller)
 	ibfts[beacon.RoleTypeAttester] = setupIbftController(beacon.RoleTypeAttester, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer, opt.SyncRateLimit)
-	//ibfts[beacon.RoleAggregator] = setupIbftController(beacon.RoleAggregator, logger, db, opt.Network, msgQueue, opt.Share) TODO not supported for now
-	//ibfts[beacon.RoleProposer] = setupIbftController(beacon.RoleProposer, logger, db, opt.Network, msgQueue, opt.Share) TODO not supported for now
+	ibfts[beacon.RoleTypeAggregator] = setupIbftController(beacon.RoleTypeAggregator, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer, opt.SyncRateLimit)
+	ibfts[beacon.RoleTypeProposer] = setupIbftController(beacon.RoleTypeProposer, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer, opt.SyncRateLimit)
+	ibfts[beacon.RoleTypeSyncCommittee] = setupIbftController(beacon.RoleTypeSyncCommittee, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer, opt.SyncRateLimit)
+	ibfts[beacon.RoleTypeSyncCommitteeContribution] = setupIbftController(beacon.RoleTypeSyncCommitteeContribution, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer, opt.SyncRateLimit)
 
 	// updating goclient map
 	if opt.Share.HasMetadata() && opt.Share.Metadata.Index > 0 {
@@ -88,8 +97,13 @@ func New(opt Options) *Validator {
 	}
 	logger.Debug("new validator instance was created", zap.Strings("operators ids", opsHashList))
 
+	// values are checked against the slashing protection data of the signer, if it has one
+	slashingProtector, _ := opt.Signer.(beacon.SlashingProtector)
+
+	ctx, cancel := context.WithCancel(opt.Context)
 	return &Validator{
-		ctx:                        opt.Context,
+		ctx:                        ctx,
+		cancel:                     cancel,
 		logger:                     logger,
 		msgQueue:                   msgQueue,
 		Share:                      opt.Share,
@@ -98,10 +112,12 @@ func New(opt Options) *Validator {
 		ibfts:                      ibfts,
 		ethNetwork:                 opt.ETHNetwork,
 		beacon:                     opt.Beacon,
-		valueCheck:                 valcheck.New(),
+		valueCheck:                 valcheck.New(slashingProtector),
 		startOnce:                  sync.Once{},
 		fork:                       opt.Fork,
 		signer:                     opt.Signer,
+		dutyJournal:                opt.DutyJournal,
+		dutyOutcomes:               make(map[beacon.RoleType]DutyOutcome),
 	}
 }
 
@@ -126,27 +142,47 @@ func (v *Validator) Start() error {
 
 	v.startOnce.Do(func() {
 		go v.listenToSignatureMessages()
+		go v.listenToPreConsensusSignatureMessages()
 		v.logger.Debug("validator started")
 	})
 
 	return nil
 }
 
+// Stop stops the validator, it unsubscribes from the validator's topic and stops listening to signature messages.
+// a stopped validator should not be started again
+func (v *Validator) Stop() error {
+	v.cancel()
+	if err := v.network.UnsubscribeFromValidatorNetwork(v.Share.PublicKey); err != nil {
+		return errors.Wrap(err, "failed to unsubscribe topic")
+	}
+	v.logger.Debug("validator stopped")
+	return nil
+}
+
 func (v *Validator) listenToSignatureMessages() {
 	sigChan, done := v.network.ReceivedSignatureChan()
 	defer done()
-	for sigMsg := range sigChan {
-		if sigMsg == nil {
-			v.logger.Debug("got nil message")
-			continue
-		}
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case sigMsg, ok := <-sigChan:
+			if !ok {
+				return
+			}
+			if sigMsg == nil {
+				v.logger.Debug("got nil message")
+				continue
+			}
 
-		if sigMsg.Message != nil && v.oneOfIBFTIdentifiers(sigMsg.Message.Lambda) {
-			v.logger.Debug("adding sig message to msg queue", getFields(sigMsg)...)
-			v.msgQueue.AddMessage(&network.Message{
-				SignedMessage: sigMsg,
-				Type:          network.NetworkMsg_SignatureType,
-			})
+			if sigMsg.Message != nil && v.oneOfIBFTIdentifiers(sigMsg.Message.Lambda) {
+				v.logger.Debug("adding sig message to msg queue", getFields(sigMsg)...)
+				v.msgQueue.AddMessage(&network.Message{
+					SignedMessage: sigMsg,
+					Type:          network.NetworkMsg_SignatureType,
+				})
+			}
 		}
 	}
 }